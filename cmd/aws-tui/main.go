@@ -1,23 +1,110 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+	"github.com/giovannirossini/aws-tui/internal/cli"
+	"github.com/giovannirossini/aws-tui/internal/config"
+	"github.com/giovannirossini/aws-tui/internal/logging"
 	"github.com/giovannirossini/aws-tui/internal/ui"
 )
 
 func main() {
-	m, err := ui.NewModel()
+	readOnly := flag.Bool("read-only", false, "disable all create/delete/restart/stop actions")
+	persistCache := flag.Bool("persist-cache", false, "keep cached responses on disk between runs")
+	timeout := flag.Duration("timeout", aws.DefaultRequestTimeout, "timeout for each AWS request")
+	debug := flag.Bool("debug", false, "write debug logs to the user cache directory")
+	noMouse := flag.Bool("no-mouse", false, "disable mouse support, e.g. to select text with the terminal")
+	theme := flag.String("theme", os.Getenv(ui.ThemeEnvVar), "color theme: "+strings.Join(ui.ThemeNames(), ", "))
+	lockAfter := flag.Duration("lock-after", 0, "return home and clear revealed values after this long without input, e.g. 10m (0 disables)")
+	fullARNs := flag.Bool("full-arns", false, "show ARNs in full instead of by resource name (toggle with A)")
+	fitColumns := flag.Bool("fit-columns", false, "size table columns to their content instead of fixed proportions")
+	counts := flag.Bool("counts", false, "show resource counts next to each service on the home screen (one list call per service)")
+	list := flag.String("list", "", "print a resource list and exit, e.g. s3 or ec2:instances")
+	output := flag.String("output", "json", "output format for --list: json or csv")
+	profile := flag.String("profile", "", "AWS profile to start with and to use for --list (default AWS_PROFILE, then default)")
+	fips := flag.Bool("fips", false, "call the FIPS endpoints of every service")
+	dualStack := flag.Bool("dualstack", false, "call the dual-stack (IPv6) endpoints of every service")
+	flag.Parse()
+
+	if *debug || os.Getenv(logging.EnvVar) == "1" {
+		f, err := logging.Setup()
+		if err != nil {
+			fmt.Printf("Error enabling debug log: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+	}
+
+	aws.SetEndpointOptions(endpointOptions(*fips, *dualStack))
+
+	if *list != "" {
+		aws.SetRequestTimeout(*timeout)
+		if *profile == "" {
+			*profile = defaultProfile()
+		}
+		err := cli.List(context.Background(), cli.Options{Target: *list, Output: *output, Profile: *profile}, os.Stdout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	m, err := ui.NewModel(ui.Options{
+		ReadOnly:       *readOnly,
+		PersistCache:   *persistCache,
+		RequestTimeout: *timeout,
+		LockAfter:      *lockAfter,
+		ResourceCounts: *counts,
+		FullARNs:       *fullARNs,
+		FitColumns:     *fitColumns,
+		Mouse:          !*noMouse,
+		Theme:          *theme,
+		Profile:        *profile,
+	})
 	if err != nil {
 		fmt.Printf("Error initializing: %v\n", err)
 		os.Exit(1)
 	}
 
-	p := tea.NewProgram(m, tea.WithAltScreen())
+	opts := []tea.ProgramOption{tea.WithAltScreen()}
+	if !*noMouse {
+		opts = append(opts, tea.WithMouseCellMotion())
+	}
+	p := tea.NewProgram(m, opts...)
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Alas, there's been an error: %v\n", err)
 		os.Exit(1)
 	}
+
+	if err := m.SaveCache(); err != nil {
+		fmt.Printf("Error saving cache: %v\n", err)
+	}
+}
+
+// endpointOptions combines the endpoint flags with the config file, where
+// either can turn an option on
+func endpointOptions(fips, dualStack bool) aws.EndpointOptions {
+	if path, err := config.DefaultPath(); err == nil {
+		if cfg, err := config.Load(path); err == nil {
+			fips = fips || cfg.FIPS
+			dualStack = dualStack || cfg.DualStack
+		}
+	}
+	return aws.EndpointOptions{FIPS: fips, DualStack: dualStack}
+}
+
+// defaultProfile is the profile the AWS CLI would use
+func defaultProfile() string {
+	if profile := os.Getenv("AWS_PROFILE"); profile != "" {
+		return profile
+	}
+	return "default"
 }
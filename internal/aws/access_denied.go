@@ -0,0 +1,39 @@
+package aws
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/aws/smithy-go"
+)
+
+// accessDeniedCodes are the error codes services use when the caller lacks a permission
+var accessDeniedCodes = map[string]bool{
+	"AccessDenied":                true,
+	"AccessDeniedException":       true,
+	"UnauthorizedOperation":       true,
+	"UnauthorizedAccess":          true,
+	"AuthorizationError":          true,
+	"AuthorizationErrorException": true,
+}
+
+// deniedActionPatterns find the IAM action in the two message formats AWS uses
+var deniedActionPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`not authorized to perform: ([\w-]+:\w+)`),
+	regexp.MustCompile(`allows the ([\w-]+:\w+) action`),
+}
+
+// AccessDenied reports whether err was caused by missing permissions and, when
+// the message names it, the IAM action that was denied
+func AccessDenied(err error) (action string, denied bool) {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) || !accessDeniedCodes[apiErr.ErrorCode()] {
+		return "", false
+	}
+	for _, pattern := range deniedActionPatterns {
+		if match := pattern.FindStringSubmatch(apiErr.ErrorMessage()); match != nil {
+			return match[1], true
+		}
+	}
+	return "", true
+}
@@ -7,8 +7,8 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/acm"
+	"github.com/aws/aws-sdk-go-v2/service/acm/types"
 )
 
 type ACMClient struct {
@@ -16,7 +16,7 @@ type ACMClient struct {
 }
 
 func NewACMClient(ctx context.Context, profile string) (*ACMClient, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	cfg, err := loadConfig(ctx, profile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -98,3 +98,125 @@ func (c *ACMClient) ListCertificates(ctx context.Context) ([]CertificateInfo, er
 
 	return certificates, nil
 }
+
+// RequestCertificate requests a public certificate validated through DNS.
+// The first domain becomes the certificate's domain name and the others its
+// subject alternative names.
+func (c *ACMClient) RequestCertificate(ctx context.Context, domains []string) (string, error) {
+	if len(domains) == 0 {
+		return "", fmt.Errorf("no domain name given")
+	}
+
+	input := &acm.RequestCertificateInput{
+		DomainName:       aws.String(domains[0]),
+		ValidationMethod: types.ValidationMethodDns,
+	}
+	if len(domains) > 1 {
+		input.SubjectAlternativeNames = domains
+	}
+
+	output, err := c.client.RequestCertificate(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("unable to request certificate: %w", err)
+	}
+	return aws.ToString(output.CertificateArn), nil
+}
+
+type ValidationRecord struct {
+	Name  string
+	Type  string
+	Value string
+}
+
+// validationPollInterval and validationPollAttempts bound how long to wait
+// for ACM to publish the validation records of a new certificate
+const (
+	validationPollInterval = 2 * time.Second
+	validationPollAttempts = 10
+)
+
+// GetValidationRecords returns the DNS records that validate a certificate.
+// ACM fills them in shortly after the request, so this waits until every
+// domain has one. Domains that share a record, such as a name and its
+// wildcard, are returned once.
+func (c *ACMClient) GetValidationRecords(ctx context.Context, arn string) ([]ValidationRecord, error) {
+	for attempt := 0; ; attempt++ {
+		desc, err := c.client.DescribeCertificate(ctx, &acm.DescribeCertificateInput{
+			CertificateArn: aws.String(arn),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to describe certificate: %w", err)
+		}
+
+		options := desc.Certificate.DomainValidationOptions
+		seen := make(map[string]bool)
+		var records []ValidationRecord
+		for _, o := range options {
+			if o.ResourceRecord == nil {
+				continue
+			}
+			name := aws.ToString(o.ResourceRecord.Name)
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			records = append(records, ValidationRecord{
+				Name:  name,
+				Type:  string(o.ResourceRecord.Type),
+				Value: aws.ToString(o.ResourceRecord.Value),
+			})
+		}
+
+		ready := len(options) > 0
+		for _, o := range options {
+			ready = ready && o.ResourceRecord != nil
+		}
+		if ready || attempt == validationPollAttempts-1 {
+			return records, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(validationPollInterval):
+		}
+	}
+}
+
+type CertificateDetails struct {
+	ARN                     string
+	DomainName              string
+	Status                  string
+	SubjectAlternativeNames []string
+	InUseBy                 []string
+}
+
+// GetCertificateDetails describes a single certificate, including the
+// resources that use it
+func (c *ACMClient) GetCertificateDetails(ctx context.Context, arn string) (*CertificateDetails, error) {
+	desc, err := c.client.DescribeCertificate(ctx, &acm.DescribeCertificateInput{
+		CertificateArn: aws.String(arn),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to describe certificate: %w", err)
+	}
+
+	cert := desc.Certificate
+	return &CertificateDetails{
+		ARN:                     aws.ToString(cert.CertificateArn),
+		DomainName:              aws.ToString(cert.DomainName),
+		Status:                  string(cert.Status),
+		SubjectAlternativeNames: cert.SubjectAlternativeNames,
+		InUseBy:                 cert.InUseBy,
+	}, nil
+}
+
+func (c *ACMClient) DeleteCertificate(ctx context.Context, arn string) error {
+	_, err := c.client.DeleteCertificate(ctx, &acm.DeleteCertificateInput{
+		CertificateArn: aws.String(arn),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to delete certificate: %w", err)
+	}
+	return nil
+}
@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/apigateway"
 	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
 )
@@ -17,7 +16,7 @@ type APIGatewayClient struct {
 }
 
 func NewAPIGatewayClient(ctx context.Context, profile string) (*APIGatewayClient, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	cfg, err := loadConfig(ctx, profile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -6,8 +6,8 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/backup"
+	"github.com/aws/aws-sdk-go-v2/service/backup/types"
 )
 
 type BackupClient struct {
@@ -15,7 +15,7 @@ type BackupClient struct {
 }
 
 func NewBackupClient(ctx context.Context, profile string) (*BackupClient, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	cfg, err := loadConfig(ctx, profile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -70,11 +70,26 @@ type BackupJobInfo struct {
 	BackupSizeInBytes int64
 	CreationDate      time.Time
 	CompletionDate    time.Time
+	StatusMessage     string
+	RecoveryPointArn  string
+	IamRoleArn        string
 }
 
 func (c *BackupClient) ListBackupJobs(ctx context.Context) ([]BackupJobInfo, error) {
+	return c.listBackupJobs(ctx, &backup.ListBackupJobsInput{})
+}
+
+// ListFailedBackupJobs lists the jobs created since the given time that failed
+func (c *BackupClient) ListFailedBackupJobs(ctx context.Context, since time.Time) ([]BackupJobInfo, error) {
+	return c.listBackupJobs(ctx, &backup.ListBackupJobsInput{
+		ByState:        types.BackupJobStateFailed,
+		ByCreatedAfter: aws.Time(since),
+	})
+}
+
+func (c *BackupClient) listBackupJobs(ctx context.Context, input *backup.ListBackupJobsInput) ([]BackupJobInfo, error) {
 	var jobs []BackupJobInfo
-	paginator := backup.NewListBackupJobsPaginator(c.client, &backup.ListBackupJobsInput{})
+	paginator := backup.NewListBackupJobsPaginator(c.client, input)
 
 	for paginator.HasMorePages() {
 		output, err := paginator.NextPage(ctx)
@@ -94,9 +109,67 @@ func (c *BackupClient) ListBackupJobs(ctx context.Context) ([]BackupJobInfo, err
 				BackupSizeInBytes: aws.ToInt64(j.BackupSizeInBytes),
 				CreationDate:      aws.ToTime(j.CreationDate),
 				CompletionDate:    aws.ToTime(j.CompletionDate),
+				StatusMessage:     aws.ToString(j.StatusMessage),
+				RecoveryPointArn:  aws.ToString(j.RecoveryPointArn),
+				IamRoleArn:        aws.ToString(j.IamRoleArn),
 			})
 		}
 	}
 
 	return jobs, nil
 }
+
+// RecoveryPointDetails describes a recovery point and the metadata a restore
+// job needs, prefilled with the values of the backed up resource
+type RecoveryPointDetails struct {
+	RecoveryPointArn string
+	BackupVaultName  string
+	ResourceArn      string
+	ResourceType     string
+	Status           string
+	IamRoleArn       string
+	RestoreMetadata  map[string]string
+}
+
+func (c *BackupClient) GetRecoveryPointDetails(ctx context.Context, vaultName, recoveryPointArn string) (*RecoveryPointDetails, error) {
+	rp, err := c.client.DescribeRecoveryPoint(ctx, &backup.DescribeRecoveryPointInput{
+		BackupVaultName:  aws.String(vaultName),
+		RecoveryPointArn: aws.String(recoveryPointArn),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to describe recovery point: %w", err)
+	}
+
+	meta, err := c.client.GetRecoveryPointRestoreMetadata(ctx, &backup.GetRecoveryPointRestoreMetadataInput{
+		BackupVaultName:  aws.String(vaultName),
+		RecoveryPointArn: aws.String(recoveryPointArn),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get restore metadata: %w", err)
+	}
+
+	return &RecoveryPointDetails{
+		RecoveryPointArn: recoveryPointArn,
+		BackupVaultName:  vaultName,
+		ResourceArn:      aws.ToString(rp.ResourceArn),
+		ResourceType:     aws.ToString(rp.ResourceType),
+		Status:           string(rp.Status),
+		IamRoleArn:       aws.ToString(rp.IamRoleArn),
+		RestoreMetadata:  meta.RestoreMetadata,
+	}, nil
+}
+
+// StartRestoreJob restores a recovery point into a new resource described by
+// metadata and returns the restore job ID
+func (c *BackupClient) StartRestoreJob(ctx context.Context, recoveryPointArn, iamRoleArn, resourceType string, metadata map[string]string) (string, error) {
+	output, err := c.client.StartRestoreJob(ctx, &backup.StartRestoreJobInput{
+		RecoveryPointArn: aws.String(recoveryPointArn),
+		IamRoleArn:       aws.String(iamRoleArn),
+		ResourceType:     aws.String(resourceType),
+		Metadata:         metadata,
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to start restore job: %w", err)
+	}
+	return aws.ToString(output.RestoreJobId), nil
+}
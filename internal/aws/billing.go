@@ -3,6 +3,7 @@ package aws
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -16,7 +17,7 @@ type BillingClient struct {
 }
 
 func NewBillingClient(ctx context.Context, profile string) (*BillingClient, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile), config.WithRegion("us-east-1"))
+	cfg, err := loadConfig(ctx, profile, config.WithRegion("us-east-1"))
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -32,7 +33,24 @@ type CostInfo struct {
 	Unit    string
 }
 
-func (c *BillingClient) GetMonthlyCosts(ctx context.Context) ([]CostInfo, error) {
+// AccountCostInfo is the cost of one member account of an organization
+type AccountCostInfo struct {
+	AccountID string
+	Name      string
+	Amount    string
+	Unit      string
+}
+
+// CostBreakdown is this month's cost grouped by service and by linked account.
+// ByAccount has a single entry outside of an organization's payer account.
+type CostBreakdown struct {
+	ByService []CostInfo
+	ByAccount []AccountCostInfo
+}
+
+// GetMonthlyCosts fetches this month's cost grouped by service and linked
+// account in one query and sums each dimension
+func (c *BillingClient) GetMonthlyCosts(ctx context.Context) (*CostBreakdown, error) {
 	now := time.Now()
 	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
 	end := start.AddDate(0, 1, 0)
@@ -49,36 +67,78 @@ func (c *BillingClient) GetMonthlyCosts(ctx context.Context) ([]CostInfo, error)
 				Type: types.GroupDefinitionTypeDimension,
 				Key:  aws.String("SERVICE"),
 			},
+			{
+				Type: types.GroupDefinitionTypeDimension,
+				Key:  aws.String("LINKED_ACCOUNT"),
+			},
 		},
 	}
 
-	output, err := c.client.GetCostAndUsage(ctx, input)
-	if err != nil {
-		return nil, fmt.Errorf("unable to get cost and usage: %w", err)
-	}
+	unit := "USD"
+	byService := make(map[string]float64)
+	byAccount := make(map[string]float64)
+	names := make(map[string]string)
+	var services, accounts []string
 
-	var costs []CostInfo
-	if len(output.ResultsByTime) > 0 {
-		for _, group := range output.ResultsByTime[0].Groups {
-			service := "Unknown"
-			if len(group.Keys) > 0 {
-				service = group.Keys[0]
-			}
+	for {
+		output, err := c.client.GetCostAndUsage(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get cost and usage: %w", err)
+		}
+
+		for _, attr := range output.DimensionValueAttributes {
+			names[aws.ToString(attr.Value)] = attr.Attributes["description"]
+		}
+
+		if len(output.ResultsByTime) > 0 {
+			for _, group := range output.ResultsByTime[0].Groups {
+				service, account := "Unknown", "Unknown"
+				if len(group.Keys) > 0 {
+					service = group.Keys[0]
+				}
+				if len(group.Keys) > 1 {
+					account = group.Keys[1]
+				}
+
+				amount := 0.0
+				if cost, ok := group.Metrics["UnblendedCost"]; ok {
+					amount, _ = strconv.ParseFloat(aws.ToString(cost.Amount), 64)
+					unit = aws.ToString(cost.Unit)
+				}
 
-			amount := "0"
-			unit := "USD"
-			if cost, ok := group.Metrics["UnblendedCost"]; ok {
-				amount = aws.ToString(cost.Amount)
-				unit = aws.ToString(cost.Unit)
+				if _, ok := byService[service]; !ok {
+					services = append(services, service)
+				}
+				byService[service] += amount
+				if _, ok := byAccount[account]; !ok {
+					accounts = append(accounts, account)
+				}
+				byAccount[account] += amount
 			}
+		}
 
-			costs = append(costs, CostInfo{
-				Service: service,
-				Amount:  amount,
-				Unit:    unit,
-			})
+		if output.NextPageToken == nil {
+			break
 		}
+		input.NextPageToken = output.NextPageToken
+	}
+
+	breakdown := &CostBreakdown{}
+	for _, service := range services {
+		breakdown.ByService = append(breakdown.ByService, CostInfo{
+			Service: service,
+			Amount:  strconv.FormatFloat(byService[service], 'f', -1, 64),
+			Unit:    unit,
+		})
+	}
+	for _, account := range accounts {
+		breakdown.ByAccount = append(breakdown.ByAccount, AccountCostInfo{
+			AccountID: account,
+			Name:      names[account],
+			Amount:    strconv.FormatFloat(byAccount[account], 'f', -1, 64),
+			Unit:      unit,
+		})
 	}
 
-	return costs, nil
+	return breakdown, nil
 }
@@ -3,10 +3,11 @@ package aws
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
 )
 
 type CloudFrontClient struct {
@@ -14,7 +15,7 @@ type CloudFrontClient struct {
 }
 
 func NewCloudFrontClient(ctx context.Context, profile string) (*CloudFrontClient, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	cfg, err := loadConfig(ctx, profile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -35,28 +36,31 @@ type CFDistributionInfo struct {
 }
 
 func (c *CloudFrontClient) ListDistributions(ctx context.Context) ([]CFDistributionInfo, error) {
-	output, err := c.client.ListDistributions(ctx, &cloudfront.ListDistributionsInput{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to list distributions: %w", err)
-	}
+	distros := []CFDistributionInfo{}
+	paginator := cloudfront.NewListDistributionsPaginator(c.client, &cloudfront.ListDistributionsInput{})
 
-	if output.DistributionList == nil {
-		return []CFDistributionInfo{}, nil
-	}
-
-	distros := make([]CFDistributionInfo, len(output.DistributionList.Items))
-	for i, d := range output.DistributionList.Items {
-		alias := ""
-		if d.Aliases != nil && len(d.Aliases.Items) > 0 {
-			alias = d.Aliases.Items[0]
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list distributions: %w", err)
+		}
+		if page.DistributionList == nil {
+			continue
 		}
-		distros[i] = CFDistributionInfo{
-			ID:         aws.ToString(d.Id),
-			Status:     aws.ToString(d.Status),
-			Domain:     aws.ToString(d.DomainName),
-			Comment:    aws.ToString(d.Comment),
-			Enabled:    aws.ToBool(d.Enabled),
-			FirstAlias: alias,
+
+		for _, d := range page.DistributionList.Items {
+			alias := ""
+			if d.Aliases != nil && len(d.Aliases.Items) > 0 {
+				alias = d.Aliases.Items[0]
+			}
+			distros = append(distros, CFDistributionInfo{
+				ID:         aws.ToString(d.Id),
+				Status:     aws.ToString(d.Status),
+				Domain:     aws.ToString(d.DomainName),
+				Comment:    aws.ToString(d.Comment),
+				Enabled:    aws.ToBool(d.Enabled),
+				FirstAlias: alias,
+			})
 		}
 	}
 
@@ -182,6 +186,7 @@ type CFFunctionInfo struct {
 	Name    string
 	Status  string
 	Runtime string
+	Stage   string // DEVELOPMENT or LIVE
 }
 
 func (c *CloudFrontClient) ListFunctions(ctx context.Context) ([]CFFunctionInfo, error) {
@@ -201,7 +206,104 @@ func (c *CloudFrontClient) ListFunctions(ctx context.Context) ([]CFFunctionInfo,
 			Status:  aws.ToString(f.Status),
 			Runtime: string(f.FunctionConfig.Runtime),
 		}
+		if f.FunctionMetadata != nil {
+			fns[i].Stage = string(f.FunctionMetadata.Stage)
+		}
 	}
 
 	return fns, nil
 }
+
+// CFFunctionDetails is one stage of a CloudFront function with its code. The
+// ETag is needed to test and publish that stage.
+type CFFunctionDetails struct {
+	Name         string
+	Stage        string
+	Status       string
+	Runtime      string
+	Comment      string
+	LastModified time.Time
+	ETag         string
+	Code         string
+}
+
+// GetFunction returns the code and configuration of a function stage
+func (c *CloudFrontClient) GetFunction(ctx context.Context, name, stage string) (CFFunctionDetails, error) {
+	desc, err := c.client.DescribeFunction(ctx, &cloudfront.DescribeFunctionInput{
+		Name:  aws.String(name),
+		Stage: types.FunctionStage(stage),
+	})
+	if err != nil {
+		return CFFunctionDetails{}, fmt.Errorf("unable to describe function %s: %w", name, err)
+	}
+	code, err := c.client.GetFunction(ctx, &cloudfront.GetFunctionInput{
+		Name:  aws.String(name),
+		Stage: types.FunctionStage(stage),
+	})
+	if err != nil {
+		return CFFunctionDetails{}, fmt.Errorf("unable to get function %s: %w", name, err)
+	}
+
+	details := CFFunctionDetails{
+		Name:  name,
+		Stage: stage,
+		ETag:  aws.ToString(desc.ETag),
+		Code:  string(code.FunctionCode),
+	}
+	if f := desc.FunctionSummary; f != nil {
+		details.Status = aws.ToString(f.Status)
+		if f.FunctionConfig != nil {
+			details.Runtime = string(f.FunctionConfig.Runtime)
+			details.Comment = aws.ToString(f.FunctionConfig.Comment)
+		}
+		if f.FunctionMetadata != nil {
+			details.LastModified = aws.ToTime(f.FunctionMetadata.LastModifiedTime)
+		}
+	}
+	return details, nil
+}
+
+// CFFunctionTestResult is the outcome of running a function against an event
+type CFFunctionTestResult struct {
+	ComputeUtilization string // Percentage of the maximum allowed compute time
+	Error              string
+	Logs               []string
+	Output             string
+}
+
+// TestFunction runs a function stage against an event object
+func (c *CloudFrontClient) TestFunction(ctx context.Context, name, stage, etag string, event []byte) (CFFunctionTestResult, error) {
+	output, err := c.client.TestFunction(ctx, &cloudfront.TestFunctionInput{
+		Name:        aws.String(name),
+		Stage:       types.FunctionStage(stage),
+		IfMatch:     aws.String(etag),
+		EventObject: event,
+	})
+	if err != nil {
+		return CFFunctionTestResult{}, fmt.Errorf("unable to test function %s: %w", name, err)
+	}
+
+	var result CFFunctionTestResult
+	if r := output.TestResult; r != nil {
+		result = CFFunctionTestResult{
+			ComputeUtilization: aws.ToString(r.ComputeUtilization),
+			Error:              aws.ToString(r.FunctionErrorMessage),
+			Logs:               r.FunctionExecutionLogs,
+			Output:             aws.ToString(r.FunctionOutput),
+		}
+	}
+	return result, nil
+}
+
+// PublishFunction copies the DEVELOPMENT stage of a function to LIVE. The
+// etag must be the one of the DEVELOPMENT stage.
+func (c *CloudFrontClient) PublishFunction(ctx context.Context, name, etag string) error {
+	_, err := c.client.PublishFunction(ctx, &cloudfront.PublishFunctionInput{
+		Name:    aws.String(name),
+		IfMatch: aws.String(etag),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to publish function %s: %w", name, err)
+	}
+	return nil
+}
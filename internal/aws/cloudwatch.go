@@ -2,26 +2,32 @@ package aws
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cwltypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
 )
 
 type CloudWatchClient struct {
-	client *cloudwatchlogs.Client
+	client  *cloudwatchlogs.Client
+	metrics *cloudwatch.Client
 }
 
 func NewCloudWatchClient(ctx context.Context, profile string) (*CloudWatchClient, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	cfg, err := loadConfig(ctx, profile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
 
 	return &CloudWatchClient{
-		client: cloudwatchlogs.NewFromConfig(cfg),
+		client:  cloudwatchlogs.NewFromConfig(cfg),
+		metrics: cloudwatch.NewFromConfig(cfg),
 	}, nil
 }
 
@@ -33,8 +39,23 @@ type LogGroupInfo struct {
 	Arn           string
 }
 
-func (c *CloudWatchClient) ListLogGroups(ctx context.Context) ([]LogGroupInfo, error) {
-	output, err := c.client.DescribeLogGroups(ctx, &cloudwatchlogs.DescribeLogGroupsInput{})
+// LogGroupPage is one page of log groups; NextToken is empty on the last
+type LogGroupPage struct {
+	Groups    []LogGroupInfo
+	NextToken string
+}
+
+// ListLogGroupsPage lists a page of the log groups whose names start with
+// prefix, which may be empty. Pass the previous page's NextToken for the next.
+func (c *CloudWatchClient) ListLogGroupsPage(ctx context.Context, prefix, nextToken string) (*LogGroupPage, error) {
+	input := &cloudwatchlogs.DescribeLogGroupsInput{}
+	if prefix != "" {
+		input.LogGroupNamePrefix = aws.String(prefix)
+	}
+	if nextToken != "" {
+		input.NextToken = aws.String(nextToken)
+	}
+	output, err := c.client.DescribeLogGroups(ctx, input)
 	if err != nil {
 		return nil, fmt.Errorf("unable to list log groups: %w", err)
 	}
@@ -54,21 +75,49 @@ func (c *CloudWatchClient) ListLogGroups(ctx context.Context) ([]LogGroupInfo, e
 		}
 	}
 
-	return groups, nil
+	return &LogGroupPage{Groups: groups, NextToken: aws.ToString(output.NextToken)}, nil
+}
+
+// ListLogGroups lists every log group, following all pages
+func (c *CloudWatchClient) ListLogGroups(ctx context.Context) ([]LogGroupInfo, error) {
+	var groups []LogGroupInfo
+	token := ""
+	for {
+		page, err := c.ListLogGroupsPage(ctx, "", token)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, page.Groups...)
+		if page.NextToken == "" {
+			return groups, nil
+		}
+		token = page.NextToken
+	}
 }
 
 type LogStreamInfo struct {
-	Name               string
-	LastEventTimestamp string
-	CreationTime       string
-	Arn                string
+	Name         string
+	LastEvent    time.Time
+	CreationTime string
+	Arn          string
 }
 
-func (c *CloudWatchClient) ListLogStreams(ctx context.Context, logGroupName string) ([]LogStreamInfo, error) {
+// LogStreamOrder is the order log streams are listed in
+type LogStreamOrder string
+
+const (
+	// LogStreamsByLastEvent lists the most recently active streams first
+	LogStreamsByLastEvent LogStreamOrder = LogStreamOrder(cwltypes.OrderByLastEventTime)
+	// LogStreamsByName lists streams alphabetically
+	LogStreamsByName LogStreamOrder = LogStreamOrder(cwltypes.OrderByLogStreamName)
+)
+
+// ListLogStreams lists the first 50 streams of a log group in the given order
+func (c *CloudWatchClient) ListLogStreams(ctx context.Context, logGroupName string, order LogStreamOrder) ([]LogStreamInfo, error) {
 	output, err := c.client.DescribeLogStreams(ctx, &cloudwatchlogs.DescribeLogStreamsInput{
 		LogGroupName: aws.String(logGroupName),
-		OrderBy:      "LastEventTime",
-		Descending:   aws.Bool(true),
+		OrderBy:      cwltypes.OrderBy(order),
+		Descending:   aws.Bool(order == LogStreamsByLastEvent),
 		Limit:        aws.Int32(50),
 	})
 	if err != nil {
@@ -77,19 +126,19 @@ func (c *CloudWatchClient) ListLogStreams(ctx context.Context, logGroupName stri
 
 	streams := make([]LogStreamInfo, len(output.LogStreams))
 	for i, s := range output.LogStreams {
-		lastEvent := ""
+		var lastEvent time.Time
 		if s.LastEventTimestamp != nil {
-			lastEvent = time.Unix(*s.LastEventTimestamp/1000, 0).Format("2006-01-02 15:04:05")
+			lastEvent = time.UnixMilli(*s.LastEventTimestamp)
 		}
 		creationTime := ""
 		if s.CreationTime != nil {
 			creationTime = time.Unix(*s.CreationTime/1000, 0).Format("2006-01-02 15:04:05")
 		}
 		streams[i] = LogStreamInfo{
-			Name:               aws.ToString(s.LogStreamName),
-			LastEventTimestamp: lastEvent,
-			CreationTime:       creationTime,
-			Arn:                aws.ToString(s.Arn),
+			Name:         aws.ToString(s.LogStreamName),
+			LastEvent:    lastEvent,
+			CreationTime: creationTime,
+			Arn:          aws.ToString(s.Arn),
 		}
 	}
 
@@ -122,3 +171,120 @@ func (c *CloudWatchClient) GetLogEvents(ctx context.Context, logGroupName, logSt
 
 	return events, nil
 }
+
+type AlarmInfo struct {
+	Name               string
+	ARN                string
+	State              string
+	StateUpdated       time.Time
+	MetricName         string
+	ComparisonOperator string
+	Threshold          float64
+	Description        string
+	StateReason        string
+	Namespace          string
+	Dimensions         []string
+	Statistic          string
+	Period             int32
+	EvaluationPeriods  int32
+	DatapointsToAlarm  int32
+	TreatMissingData   string
+}
+
+// ListAlarms lists the metric alarms, composite alarms are left out
+func (c *CloudWatchClient) ListAlarms(ctx context.Context) ([]AlarmInfo, error) {
+	var alarms []AlarmInfo
+	paginator := cloudwatch.NewDescribeAlarmsPaginator(c.metrics, &cloudwatch.DescribeAlarmsInput{
+		AlarmTypes: []cwtypes.AlarmType{cwtypes.AlarmTypeMetricAlarm},
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list alarms: %w", err)
+		}
+		for _, a := range page.MetricAlarms {
+			metric := aws.ToString(a.MetricName)
+			if metric == "" && len(a.Metrics) > 0 {
+				// Metric math alarms have no single metric
+				metric = "metric math"
+			}
+			statistic := string(a.Statistic)
+			if statistic == "" {
+				statistic = aws.ToString(a.ExtendedStatistic)
+			}
+			var dimensions []string
+			for _, d := range a.Dimensions {
+				dimensions = append(dimensions, aws.ToString(d.Name)+"="+aws.ToString(d.Value))
+			}
+			sort.Strings(dimensions)
+			alarms = append(alarms, AlarmInfo{
+				Name:               aws.ToString(a.AlarmName),
+				ARN:                aws.ToString(a.AlarmArn),
+				State:              string(a.StateValue),
+				StateUpdated:       aws.ToTime(a.StateUpdatedTimestamp),
+				MetricName:         metric,
+				ComparisonOperator: string(a.ComparisonOperator),
+				Threshold:          aws.ToFloat64(a.Threshold),
+				Description:        aws.ToString(a.AlarmDescription),
+				StateReason:        aws.ToString(a.StateReason),
+				Namespace:          aws.ToString(a.Namespace),
+				Dimensions:         dimensions,
+				Statistic:          statistic,
+				Period:             aws.ToInt32(a.Period),
+				EvaluationPeriods:  aws.ToInt32(a.EvaluationPeriods),
+				DatapointsToAlarm:  aws.ToInt32(a.DatapointsToAlarm),
+				TreatMissingData:   aws.ToString(a.TreatMissingData),
+			})
+		}
+	}
+
+	return alarms, nil
+}
+
+type AlarmHistoryInfo struct {
+	Timestamp time.Time
+	OldState  string
+	NewState  string
+	Summary   string
+}
+
+// alarmHistoryData is the part of a state update's HistoryData JSON that
+// names the states on either side of the transition
+type alarmHistoryData struct {
+	OldState struct {
+		StateValue string `json:"stateValue"`
+	} `json:"oldState"`
+	NewState struct {
+		StateValue string `json:"stateValue"`
+	} `json:"newState"`
+}
+
+// GetAlarmHistory returns the most recent state transitions of an alarm,
+// newest first
+func (c *CloudWatchClient) GetAlarmHistory(ctx context.Context, alarmName string) ([]AlarmHistoryInfo, error) {
+	output, err := c.metrics.DescribeAlarmHistory(ctx, &cloudwatch.DescribeAlarmHistoryInput{
+		AlarmName:       aws.String(alarmName),
+		HistoryItemType: cwtypes.HistoryItemTypeStateUpdate,
+		ScanBy:          cwtypes.ScanByTimestampDescending,
+		MaxRecords:      aws.Int32(50),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get alarm history: %w", err)
+	}
+
+	history := make([]AlarmHistoryInfo, len(output.AlarmHistoryItems))
+	for i, h := range output.AlarmHistoryItems {
+		var data alarmHistoryData
+		// The summary still describes the transition when the data is unreadable
+		_ = json.Unmarshal([]byte(aws.ToString(h.HistoryData)), &data)
+		history[i] = AlarmHistoryInfo{
+			Timestamp: aws.ToTime(h.Timestamp),
+			OldState:  data.OldState.StateValue,
+			NewState:  data.NewState.StateValue,
+			Summary:   aws.ToString(h.HistorySummary),
+		}
+	}
+
+	return history, nil
+}
@@ -0,0 +1,87 @@
+package aws
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/giovannirossini/aws-tui/internal/logging"
+)
+
+var (
+	regionMu       sync.RWMutex
+	regionOverride string
+)
+
+// SetRegion overrides the profile's region for every client created afterwards.
+// An empty region restores the region from the shared config.
+func SetRegion(region string) {
+	regionMu.Lock()
+	defer regionMu.Unlock()
+	regionOverride = region
+}
+
+// Region returns the region override, or an empty string when none is set
+func Region() string {
+	regionMu.RLock()
+	defer regionMu.RUnlock()
+	return regionOverride
+}
+
+// ProfileRegion returns the region a profile resolves to, honouring the region
+// override. It only reads the shared config files, so it is cheap enough to call
+// before the profile's identity has been fetched.
+func ProfileRegion(ctx context.Context, profile string) string {
+	cfg, err := loadConfig(ctx, profile)
+	if err != nil {
+		return ""
+	}
+	return cfg.Region
+}
+
+// loadConfig loads the shared config for the given profile and attaches the
+// middleware common to every client. Extra load options are applied after the
+// profile and region override, so services pinned to a region keep it.
+func loadConfig(ctx context.Context, profile string, optFns ...func(*config.LoadOptions) error) (aws.Config, error) {
+	opts := []func(*config.LoadOptions) error{config.WithSharedConfigProfile(profile)}
+	if region := Region(); region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+	opts = append(opts, endpointLoadOptions()...)
+	opts = append(opts, optFns...)
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return aws.Config{}, err
+	}
+
+	cfg.APIOptions = append(cfg.APIOptions, addRequestTimeout, addInFlightTracking, addFetchStats)
+	if logging.Enabled() {
+		cfg.APIOptions = append(cfg.APIOptions, addDebugLogging(profile))
+	}
+
+	return cfg, nil
+}
+
+// addDebugLogging logs every AWS operation with its duration and outcome
+func addDebugLogging(profile string) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("AWSTUIDebugLogging",
+			func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+				start := time.Now()
+				out, metadata, err := next.HandleInitialize(ctx, in)
+
+				service := awsmiddleware.GetServiceID(ctx)
+				operation := awsmiddleware.GetOperationName(ctx)
+				if err != nil {
+					logging.Printf("aws %s.%s profile=%s duration=%s error=%v", service, operation, profile, time.Since(start), err)
+				} else {
+					logging.Printf("aws %s.%s profile=%s duration=%s", service, operation, profile, time.Since(start))
+				}
+				return out, metadata, err
+			}), middleware.After)
+	}
+}
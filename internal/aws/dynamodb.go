@@ -2,12 +2,21 @@ package aws
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
 )
 
 type DynamoDBClient struct {
@@ -15,7 +24,7 @@ type DynamoDBClient struct {
 }
 
 func NewDynamoDBClient(ctx context.Context, profile string) (*DynamoDBClient, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	cfg, err := loadConfig(ctx, profile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -34,6 +43,9 @@ type DynamoTableInfo struct {
 	PartitionKey string
 	SortKey      string
 	BillingMode  string
+	// Attribute types of the keys: S, N or B
+	PartitionKeyType string
+	SortKeyType      string
 }
 
 func (c *DynamoDBClient) ListTables(ctx context.Context) ([]DynamoTableInfo, error) {
@@ -72,6 +84,15 @@ func (c *DynamoDBClient) ListTables(ctx context.Context) ([]DynamoTableInfo, err
 				}
 			}
 
+			for _, def := range t.AttributeDefinitions {
+				switch aws.ToString(def.AttributeName) {
+				case info.PartitionKey:
+					info.PartitionKeyType = string(def.AttributeType)
+				case info.SortKey:
+					info.SortKeyType = string(def.AttributeType)
+				}
+			}
+
 			if t.BillingModeSummary != nil {
 				info.BillingMode = string(t.BillingModeSummary.BillingMode)
 			} else {
@@ -84,3 +105,420 @@ func (c *DynamoDBClient) ListTables(ctx context.Context) ([]DynamoTableInfo, err
 
 	return tables, nil
 }
+
+// DynamoContinuousBackups is the point-in-time recovery setting of a table
+type DynamoContinuousBackups struct {
+	PITRStatus      string
+	EarliestRestore time.Time
+	LatestRestore   time.Time
+}
+
+func (c *DynamoDBClient) DescribeContinuousBackups(ctx context.Context, tableName string) (*DynamoContinuousBackups, error) {
+	output, err := c.client.DescribeContinuousBackups(ctx, &dynamodb.DescribeContinuousBackupsInput{
+		TableName: aws.String(tableName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to describe continuous backups: %w", err)
+	}
+
+	info := &DynamoContinuousBackups{PITRStatus: "DISABLED"}
+	if d := output.ContinuousBackupsDescription; d != nil && d.PointInTimeRecoveryDescription != nil {
+		pitr := d.PointInTimeRecoveryDescription
+		info.PITRStatus = string(pitr.PointInTimeRecoveryStatus)
+		info.EarliestRestore = aws.ToTime(pitr.EarliestRestorableDateTime)
+		info.LatestRestore = aws.ToTime(pitr.LatestRestorableDateTime)
+	}
+	return info, nil
+}
+
+type DynamoBackupInfo struct {
+	Name    string
+	ARN     string
+	Status  string
+	Type    string
+	Size    int64
+	Created time.Time
+}
+
+// ListBackups returns the on-demand and AWS Backup backups of a table
+func (c *DynamoDBClient) ListBackups(ctx context.Context, tableName string) ([]DynamoBackupInfo, error) {
+	var backups []DynamoBackupInfo
+	input := &dynamodb.ListBackupsInput{TableName: aws.String(tableName)}
+
+	for {
+		output, err := c.client.ListBackups(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list backups: %w", err)
+		}
+
+		for _, b := range output.BackupSummaries {
+			backups = append(backups, DynamoBackupInfo{
+				Name:    aws.ToString(b.BackupName),
+				ARN:     aws.ToString(b.BackupArn),
+				Status:  string(b.BackupStatus),
+				Type:    string(b.BackupType),
+				Size:    aws.ToInt64(b.BackupSizeBytes),
+				Created: aws.ToTime(b.BackupCreationDateTime),
+			})
+		}
+
+		if output.LastEvaluatedBackupArn == nil {
+			break
+		}
+		input.ExclusiveStartBackupArn = output.LastEvaluatedBackupArn
+	}
+
+	return backups, nil
+}
+
+// CreateBackup starts an on-demand backup and returns its ARN
+func (c *DynamoDBClient) CreateBackup(ctx context.Context, tableName, backupName string) (string, error) {
+	output, err := c.client.CreateBackup(ctx, &dynamodb.CreateBackupInput{
+		TableName:  aws.String(tableName),
+		BackupName: aws.String(backupName),
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to create backup: %w", err)
+	}
+	if output.BackupDetails == nil {
+		return "", nil
+	}
+	return aws.ToString(output.BackupDetails.BackupArn), nil
+}
+
+// DynamoItem is an item of a table. It is edited as DynamoDB JSON, the typed
+// form the console and the CLI use: {"id": {"S": "42"}}.
+type DynamoItem struct {
+	attributes map[string]types.AttributeValue
+}
+
+// Len is the number of attributes of the item
+func (i DynamoItem) Len() int {
+	return len(i.attributes)
+}
+
+// Names lists the attributes of the item in alphabetical order
+func (i DynamoItem) Names() []string {
+	return slices.Sorted(maps.Keys(i.attributes))
+}
+
+// Value renders an attribute for display, "" when the item lacks it. Sets,
+// lists and maps are shown as DynamoDB JSON.
+func (i DynamoItem) Value(name string) string {
+	av, ok := i.attributes[name]
+	if !ok {
+		return ""
+	}
+	switch v := av.(type) {
+	case *types.AttributeValueMemberS:
+		return v.Value
+	case *types.AttributeValueMemberN:
+		return v.Value
+	case *types.AttributeValueMemberB:
+		return base64.StdEncoding.EncodeToString(v.Value)
+	case *types.AttributeValueMemberBOOL:
+		return strconv.FormatBool(v.Value)
+	case *types.AttributeValueMemberNULL:
+		return "null"
+	}
+	data, _ := json.Marshal(attributeValueJSON(av))
+	return string(data)
+}
+
+// KeyText renders the key attributes of the item, e.g. "id=42, date=2024-01-01"
+func (i DynamoItem) KeyText(table DynamoTableInfo) string {
+	text := table.PartitionKey + "=" + i.Value(table.PartitionKey)
+	if table.SortKey != "" {
+		text += ", " + table.SortKey + "=" + i.Value(table.SortKey)
+	}
+	return text
+}
+
+// JSON renders the item as indented DynamoDB JSON, attributes sorted by name
+func (i DynamoItem) JSON() string {
+	out := make(map[string]any, len(i.attributes))
+	for name, av := range i.attributes {
+		out[name] = attributeValueJSON(av)
+	}
+	data, _ := json.MarshalIndent(out, "", "  ")
+	return string(data)
+}
+
+func (i DynamoItem) key(table DynamoTableInfo) map[string]types.AttributeValue {
+	key := map[string]types.AttributeValue{table.PartitionKey: i.attributes[table.PartitionKey]}
+	if table.SortKey != "" {
+		key[table.SortKey] = i.attributes[table.SortKey]
+	}
+	return key
+}
+
+func attributeValueJSON(av types.AttributeValue) any {
+	switch v := av.(type) {
+	case *types.AttributeValueMemberS:
+		return map[string]any{"S": v.Value}
+	case *types.AttributeValueMemberN:
+		return map[string]any{"N": v.Value}
+	case *types.AttributeValueMemberB:
+		return map[string]any{"B": v.Value}
+	case *types.AttributeValueMemberBOOL:
+		return map[string]any{"BOOL": v.Value}
+	case *types.AttributeValueMemberNULL:
+		return map[string]any{"NULL": v.Value}
+	case *types.AttributeValueMemberSS:
+		return map[string]any{"SS": v.Value}
+	case *types.AttributeValueMemberNS:
+		return map[string]any{"NS": v.Value}
+	case *types.AttributeValueMemberBS:
+		return map[string]any{"BS": v.Value}
+	case *types.AttributeValueMemberL:
+		list := make([]any, len(v.Value))
+		for i, e := range v.Value {
+			list[i] = attributeValueJSON(e)
+		}
+		return map[string]any{"L": list}
+	case *types.AttributeValueMemberM:
+		m := make(map[string]any, len(v.Value))
+		for name, e := range v.Value {
+			m[name] = attributeValueJSON(e)
+		}
+		return map[string]any{"M": m}
+	}
+	return nil
+}
+
+// ParseDynamoItem reads an item written as DynamoDB JSON and checks it
+// against the key schema of the table, so a mistyped or missing key is
+// reported before anything is written
+func ParseDynamoItem(data []byte, table DynamoTableInfo) (DynamoItem, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return DynamoItem{}, fmt.Errorf("item is not valid JSON at byte %d: %w", syntaxErr.Offset, err)
+		}
+		return DynamoItem{}, fmt.Errorf("item must be a JSON object of attributes: %w", err)
+	}
+
+	attributes, err := parseAttributes("", raw)
+	if err != nil {
+		return DynamoItem{}, err
+	}
+	item := DynamoItem{attributes: attributes}
+
+	if err := item.checkKey(table.PartitionKey, table.PartitionKeyType, "partition"); err != nil {
+		return DynamoItem{}, err
+	}
+	if table.SortKey != "" {
+		if err := item.checkKey(table.SortKey, table.SortKeyType, "sort"); err != nil {
+			return DynamoItem{}, err
+		}
+	}
+	return item, nil
+}
+
+func (i DynamoItem) checkKey(name, attributeType, role string) error {
+	av, ok := i.attributes[name]
+	if !ok {
+		return fmt.Errorf("item is missing the %s key %q", role, name)
+	}
+	var empty bool
+	switch v := av.(type) {
+	case *types.AttributeValueMemberS:
+		empty = attributeType == "S" && v.Value == ""
+		ok = attributeType == "S"
+	case *types.AttributeValueMemberN:
+		ok = attributeType == "N"
+	case *types.AttributeValueMemberB:
+		empty = attributeType == "B" && len(v.Value) == 0
+		ok = attributeType == "B"
+	default:
+		ok = false
+	}
+	if !ok {
+		return fmt.Errorf("%s key %q must be of type %s", role, name, attributeType)
+	}
+	if empty {
+		return fmt.Errorf("%s key %q cannot be empty", role, name)
+	}
+	return nil
+}
+
+func parseAttributes(path string, raw map[string]json.RawMessage) (map[string]types.AttributeValue, error) {
+	attributes := make(map[string]types.AttributeValue, len(raw))
+	for name, value := range raw {
+		av, err := parseAttributeValue(path+name, value)
+		if err != nil {
+			return nil, err
+		}
+		attributes[name] = av
+	}
+	return attributes, nil
+}
+
+// parseAttributeValue reads one typed value, e.g. {"N": "42"}. path names the
+// attribute in errors, with the keys and indexes leading to nested values.
+func parseAttributeValue(path string, raw json.RawMessage) (types.AttributeValue, error) {
+	var typed map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &typed); err != nil || len(typed) != 1 {
+		return nil, fmt.Errorf(`attribute %q must be an object with exactly one type, e.g. {"S": "text"}`, path)
+	}
+
+	for typ, value := range typed {
+		var av types.AttributeValue
+		var err error
+		switch typ {
+		case "S":
+			var v string
+			err = json.Unmarshal(value, &v)
+			av = &types.AttributeValueMemberS{Value: v}
+		case "N":
+			var v string
+			if err = json.Unmarshal(value, &v); err == nil {
+				err = checkNumber(v)
+			}
+			av = &types.AttributeValueMemberN{Value: v}
+		case "B":
+			var v []byte
+			err = json.Unmarshal(value, &v)
+			av = &types.AttributeValueMemberB{Value: v}
+		case "BOOL":
+			var v bool
+			err = json.Unmarshal(value, &v)
+			av = &types.AttributeValueMemberBOOL{Value: v}
+		case "NULL":
+			var v bool
+			err = json.Unmarshal(value, &v)
+			av = &types.AttributeValueMemberNULL{Value: v}
+		case "SS":
+			var v []string
+			err = json.Unmarshal(value, &v)
+			av = &types.AttributeValueMemberSS{Value: v}
+		case "NS":
+			var v []string
+			if err = json.Unmarshal(value, &v); err == nil {
+				for _, n := range v {
+					if err = checkNumber(n); err != nil {
+						break
+					}
+				}
+			}
+			av = &types.AttributeValueMemberNS{Value: v}
+		case "BS":
+			var v [][]byte
+			err = json.Unmarshal(value, &v)
+			av = &types.AttributeValueMemberBS{Value: v}
+		case "L":
+			var elems []json.RawMessage
+			if err = json.Unmarshal(value, &elems); err == nil {
+				list := make([]types.AttributeValue, len(elems))
+				for i, e := range elems {
+					if list[i], err = parseAttributeValue(fmt.Sprintf("%s[%d]", path, i), e); err != nil {
+						return nil, err
+					}
+				}
+				av = &types.AttributeValueMemberL{Value: list}
+			}
+		case "M":
+			var members map[string]json.RawMessage
+			if err = json.Unmarshal(value, &members); err == nil {
+				var m map[string]types.AttributeValue
+				if m, err = parseAttributes(path+".", members); err != nil {
+					return nil, err
+				}
+				av = &types.AttributeValueMemberM{Value: m}
+			}
+		default:
+			return nil, fmt.Errorf("attribute %q has unknown type %q (use S, N, B, BOOL, NULL, SS, NS, BS, L or M)", path, typ)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("attribute %q is not a valid %s value: %w", path, typ, err)
+		}
+		return av, nil
+	}
+	return nil, nil
+}
+
+// checkNumber rejects what DynamoDB would not store as a number. Numbers
+// are sent as strings to keep their precision.
+func checkNumber(n string) error {
+	if _, ok := new(big.Float).SetString(strings.TrimSpace(n)); !ok {
+		return fmt.Errorf("%q is not a number", n)
+	}
+	return nil
+}
+
+// ScanItems reads up to limit items of a table, in no particular order. more
+// reports that the table has items past them.
+func (c *DynamoDBClient) ScanItems(ctx context.Context, tableName string, limit int) (items []DynamoItem, more bool, err error) {
+	input := &dynamodb.ScanInput{TableName: aws.String(tableName)}
+
+	for {
+		input.Limit = aws.Int32(int32(limit - len(items)))
+		output, err := c.client.Scan(ctx, input)
+		if err != nil {
+			return nil, false, fmt.Errorf("unable to scan table: %w", err)
+		}
+
+		for _, attributes := range output.Items {
+			items = append(items, DynamoItem{attributes: attributes})
+		}
+
+		if len(output.LastEvaluatedKey) == 0 {
+			return items, false, nil
+		}
+		if len(items) >= limit {
+			return items, true, nil
+		}
+		input.ExclusiveStartKey = output.LastEvaluatedKey
+	}
+}
+
+// PutItem writes an item. With replace the item must already exist and
+// otherwise it must not, so an edit never recreates an item deleted in the
+// meantime and a new item never overwrites one.
+func (c *DynamoDBClient) PutItem(ctx context.Context, table DynamoTableInfo, item DynamoItem, replace bool) error {
+	condition := "attribute_not_exists(#pk)"
+	if replace {
+		condition = "attribute_exists(#pk)"
+	}
+
+	_, err := c.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                aws.String(table.Name),
+		Item:                     item.attributes,
+		ConditionExpression:      aws.String(condition),
+		ExpressionAttributeNames: map[string]string{"#pk": table.PartitionKey},
+	})
+	if err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			if replace {
+				return fmt.Errorf("item %s no longer exists, it was not recreated: %w", item.KeyText(table), err)
+			}
+			return fmt.Errorf("an item with %s already exists, it was not overwritten: %w", item.KeyText(table), err)
+		}
+		return fmt.Errorf("unable to put item: %w", dynamoValidationError(err))
+	}
+	return nil
+}
+
+// DeleteItem deletes the item with the key of item
+func (c *DynamoDBClient) DeleteItem(ctx context.Context, table DynamoTableInfo, item DynamoItem) error {
+	_, err := c.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(table.Name),
+		Key:       item.key(table),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to delete item: %w", dynamoValidationError(err))
+	}
+	return nil
+}
+
+// dynamoValidationError leads with what DynamoDB found wrong in a request,
+// which otherwise trails the request details of the error
+func dynamoValidationError(err error) error {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode() == "ValidationException" {
+		return fmt.Errorf("%s: %w", apiErr.ErrorMessage(), err)
+	}
+	return err
+}
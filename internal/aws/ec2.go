@@ -3,11 +3,13 @@ package aws
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
 )
 
 type EC2ResourcesClient struct {
@@ -16,7 +18,7 @@ type EC2ResourcesClient struct {
 }
 
 func NewEC2ResourcesClient(ctx context.Context, profile string) (*EC2ResourcesClient, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	cfg, err := loadConfig(ctx, profile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -35,33 +37,57 @@ type InstanceInfo struct {
 	PrivateIP        string
 	AvailabilityZone string
 	Name             string
+	ImageID          string
+	VpcID            string
+	SubnetID         string
+	KeyName          string
+	Platform         string
+	LaunchTime       time.Time
+	SecurityGroups   []string
 }
 
 func (c *EC2ResourcesClient) ListInstances(ctx context.Context) ([]InstanceInfo, error) {
-	output, err := c.ec2Client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to list instances: %w", err)
-	}
-
 	var instances []InstanceInfo
-	for _, reservation := range output.Reservations {
-		for _, i := range reservation.Instances {
-			name := ""
-			for _, tag := range i.Tags {
-				if aws.ToString(tag.Key) == "Name" {
-					name = aws.ToString(tag.Value)
-					break
+	paginator := ec2.NewDescribeInstancesPaginator(c.ec2Client, &ec2.DescribeInstancesInput{
+		Filters: CurrentTagFilter().ec2Filters(),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list instances: %w", err)
+		}
+
+		for _, reservation := range page.Reservations {
+			for _, i := range reservation.Instances {
+				name := ""
+				for _, tag := range i.Tags {
+					if aws.ToString(tag.Key) == "Name" {
+						name = aws.ToString(tag.Value)
+						break
+					}
 				}
+				var groups []string
+				for _, g := range i.SecurityGroups {
+					groups = append(groups, aws.ToString(g.GroupId))
+				}
+				instances = append(instances, InstanceInfo{
+					ID:               aws.ToString(i.InstanceId),
+					Type:             string(i.InstanceType),
+					State:            string(i.State.Name),
+					PublicIP:         aws.ToString(i.PublicIpAddress),
+					PrivateIP:        aws.ToString(i.PrivateIpAddress),
+					AvailabilityZone: aws.ToString(i.Placement.AvailabilityZone),
+					Name:             name,
+					ImageID:          aws.ToString(i.ImageId),
+					VpcID:            aws.ToString(i.VpcId),
+					SubnetID:         aws.ToString(i.SubnetId),
+					KeyName:          aws.ToString(i.KeyName),
+					Platform:         aws.ToString(i.PlatformDetails),
+					LaunchTime:       aws.ToTime(i.LaunchTime),
+					SecurityGroups:   groups,
+				})
 			}
-			instances = append(instances, InstanceInfo{
-				ID:               aws.ToString(i.InstanceId),
-				Type:             string(i.InstanceType),
-				State:            string(i.State.Name),
-				PublicIP:         aws.ToString(i.PublicIpAddress),
-				PrivateIP:        aws.ToString(i.PrivateIpAddress),
-				AvailabilityZone: aws.ToString(i.Placement.AvailabilityZone),
-				Name:             name,
-			})
 		}
 	}
 
@@ -76,24 +102,50 @@ type SecurityGroupInfo struct {
 }
 
 func (c *EC2ResourcesClient) ListSecurityGroups(ctx context.Context) ([]SecurityGroupInfo, error) {
-	output, err := c.ec2Client.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to list security groups: %w", err)
-	}
+	var sgs []SecurityGroupInfo
+	paginator := ec2.NewDescribeSecurityGroupsPaginator(c.ec2Client, &ec2.DescribeSecurityGroupsInput{
+		Filters: CurrentTagFilter().ec2Filters(),
+	})
 
-	sgs := make([]SecurityGroupInfo, len(output.SecurityGroups))
-	for i, s := range output.SecurityGroups {
-		sgs[i] = SecurityGroupInfo{
-			ID:          aws.ToString(s.GroupId),
-			Name:        aws.ToString(s.GroupName),
-			Description: aws.ToString(s.Description),
-			VpcID:       aws.ToString(s.VpcId),
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list security groups: %w", err)
+		}
+
+		for _, s := range page.SecurityGroups {
+			sgs = append(sgs, SecurityGroupInfo{
+				ID:          aws.ToString(s.GroupId),
+				Name:        aws.ToString(s.GroupName),
+				Description: aws.ToString(s.Description),
+				VpcID:       aws.ToString(s.VpcId),
+			})
 		}
 	}
 
 	return sgs, nil
 }
 
+// GetSecurityGroupNames maps the given security group IDs to their names
+func (c *EC2ResourcesClient) GetSecurityGroupNames(ctx context.Context, ids []string) (map[string]string, error) {
+	names := make(map[string]string, len(ids))
+	if len(ids) == 0 {
+		return names, nil
+	}
+
+	output, err := c.ec2Client.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{
+		GroupIds: ids,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to describe security groups: %w", err)
+	}
+
+	for _, s := range output.SecurityGroups {
+		names[aws.ToString(s.GroupId)] = aws.ToString(s.GroupName)
+	}
+	return names, nil
+}
+
 type VolumeInfo struct {
 	ID               string
 	Size             int32
@@ -105,32 +157,38 @@ type VolumeInfo struct {
 }
 
 func (c *EC2ResourcesClient) ListVolumes(ctx context.Context) ([]VolumeInfo, error) {
-	output, err := c.ec2Client.DescribeVolumes(ctx, &ec2.DescribeVolumesInput{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to list volumes: %w", err)
-	}
+	var volumes []VolumeInfo
+	paginator := ec2.NewDescribeVolumesPaginator(c.ec2Client, &ec2.DescribeVolumesInput{
+		Filters: CurrentTagFilter().ec2Filters(),
+	})
 
-	volumes := make([]VolumeInfo, len(output.Volumes))
-	for i, v := range output.Volumes {
-		name := ""
-		for _, tag := range v.Tags {
-			if aws.ToString(tag.Key) == "Name" {
-				name = aws.ToString(tag.Value)
-				break
-			}
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list volumes: %w", err)
 		}
-		instanceID := ""
-		if len(v.Attachments) > 0 {
-			instanceID = aws.ToString(v.Attachments[0].InstanceId)
-		}
-		volumes[i] = VolumeInfo{
-			ID:               aws.ToString(v.VolumeId),
-			Size:             aws.ToInt32(v.Size),
-			Type:             string(v.VolumeType),
-			State:            string(v.State),
-			AvailabilityZone: aws.ToString(v.AvailabilityZone),
-			InstanceID:       instanceID,
-			Name:             name,
+
+		for _, v := range page.Volumes {
+			name := ""
+			for _, tag := range v.Tags {
+				if aws.ToString(tag.Key) == "Name" {
+					name = aws.ToString(tag.Value)
+					break
+				}
+			}
+			instanceID := ""
+			if len(v.Attachments) > 0 {
+				instanceID = aws.ToString(v.Attachments[0].InstanceId)
+			}
+			volumes = append(volumes, VolumeInfo{
+				ID:               aws.ToString(v.VolumeId),
+				Size:             aws.ToInt32(v.Size),
+				Type:             string(v.VolumeType),
+				State:            string(v.State),
+				AvailabilityZone: aws.ToString(v.AvailabilityZone),
+				InstanceID:       instanceID,
+				Name:             name,
+			})
 		}
 	}
 
@@ -147,22 +205,124 @@ type TargetGroupInfo struct {
 }
 
 func (c *EC2ResourcesClient) ListTargetGroups(ctx context.Context) ([]TargetGroupInfo, error) {
-	output, err := c.elbClient.DescribeTargetGroups(ctx, &elasticloadbalancingv2.DescribeTargetGroupsInput{})
+	var tgs []TargetGroupInfo
+	paginator := elasticloadbalancingv2.NewDescribeTargetGroupsPaginator(c.elbClient, &elasticloadbalancingv2.DescribeTargetGroupsInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list target groups: %w", err)
+		}
+
+		for _, t := range page.TargetGroups {
+			tgs = append(tgs, TargetGroupInfo{
+				ARN:        aws.ToString(t.TargetGroupArn),
+				Name:       aws.ToString(t.TargetGroupName),
+				Protocol:   string(t.Protocol),
+				Port:       aws.ToInt32(t.Port),
+				VpcID:      aws.ToString(t.VpcId),
+				TargetType: string(t.TargetType),
+			})
+		}
+	}
+
+	return tgs, nil
+}
+
+// ListUnhealthyTargetGroups lists the target groups with at least one target
+// failing its health checks. The health of each group is a call of its own,
+// made a few at a time.
+func (c *EC2ResourcesClient) ListUnhealthyTargetGroups(ctx context.Context) ([]TargetGroupInfo, error) {
+	tgs, err := c.ListTargetGroups(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("unable to list target groups: %w", err)
+		return nil, err
 	}
 
-	tgs := make([]TargetGroupInfo, len(output.TargetGroups))
-	for i, t := range output.TargetGroups {
-		tgs[i] = TargetGroupInfo{
-			ARN:        aws.ToString(t.TargetGroupArn),
-			Name:       aws.ToString(t.TargetGroupName),
-			Protocol:   string(t.Protocol),
-			Port:       aws.ToInt32(t.Port),
-			VpcID:      aws.ToString(t.VpcId),
-			TargetType: string(t.TargetType),
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		unhealthy []TargetGroupInfo
+		firstErr  error
+	)
+	sem := make(chan struct{}, 10)
+	for _, tg := range tgs {
+		wg.Add(1)
+		go func(tg TargetGroupInfo) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			out, err := c.elbClient.DescribeTargetHealth(ctx, &elasticloadbalancingv2.DescribeTargetHealthInput{
+				TargetGroupArn: aws.String(tg.ARN),
+			})
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("unable to describe target health of %s: %w", tg.Name, err)
+				}
+				return
+			}
+			for _, d := range out.TargetHealthDescriptions {
+				if d.TargetHealth != nil && d.TargetHealth.State == elbtypes.TargetHealthStateEnumUnhealthy {
+					unhealthy = append(unhealthy, tg)
+					return
+				}
+			}
+		}(tg)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return unhealthy, nil
+}
+
+type ElasticIPInfo struct {
+	AllocationID       string
+	PublicIP           string
+	PrivateIP          string
+	AssociationID      string
+	InstanceID         string
+	NetworkInterfaceID string
+	Name               string
+}
+
+// Associated reports whether the address is in use; unassociated addresses
+// are billed for nothing
+func (e ElasticIPInfo) Associated() bool {
+	return e.AssociationID != ""
+}
+
+func (c *EC2ResourcesClient) ListElasticIPs(ctx context.Context) ([]ElasticIPInfo, error) {
+	// DescribeAddresses returns every address at once; it has no paginator
+	out, err := c.ec2Client.DescribeAddresses(ctx, &ec2.DescribeAddressesInput{
+		Filters: CurrentTagFilter().ec2Filters(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list Elastic IPs: %w", err)
+	}
+
+	eips := make([]ElasticIPInfo, 0, len(out.Addresses))
+	for _, a := range out.Addresses {
+		name := ""
+		for _, tag := range a.Tags {
+			if aws.ToString(tag.Key) == "Name" {
+				name = aws.ToString(tag.Value)
+				break
+			}
 		}
+		eips = append(eips, ElasticIPInfo{
+			AllocationID:       aws.ToString(a.AllocationId),
+			PublicIP:           aws.ToString(a.PublicIp),
+			PrivateIP:          aws.ToString(a.PrivateIpAddress),
+			AssociationID:      aws.ToString(a.AssociationId),
+			InstanceID:         aws.ToString(a.InstanceId),
+			NetworkInterfaceID: aws.ToString(a.NetworkInterfaceId),
+			Name:               name,
+		})
 	}
 
-	return tgs, nil
+	return eips, nil
 }
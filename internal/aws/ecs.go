@@ -5,9 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
 	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
 )
@@ -17,7 +17,7 @@ type ECSClient struct {
 }
 
 func NewECSClient(ctx context.Context, profile string) (*ECSClient, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	cfg, err := loadConfig(ctx, profile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -37,35 +37,174 @@ type ECSClusterInfo struct {
 }
 
 func (c *ECSClient) ListClusters(ctx context.Context) ([]ECSClusterInfo, error) {
-	output, err := c.client.ListClusters(ctx, &ecs.ListClustersInput{})
-	if err != nil {
-		return nil, err
+	var clusterArns []string
+	paginator := ecs.NewListClustersPaginator(c.client, &ecs.ListClustersInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		clusterArns = append(clusterArns, page.ClusterArns...)
 	}
 
-	if len(output.ClusterArns) == 0 {
+	if len(clusterArns) == 0 {
 		return nil, nil
 	}
 
-	describeOutput, err := c.client.DescribeClusters(ctx, &ecs.DescribeClustersInput{
-		Clusters: output.ClusterArns,
+	var clusters []ECSClusterInfo
+	// DescribeClusters has a limit of 100
+	for i := 0; i < len(clusterArns); i += 100 {
+		end := i + 100
+		if end > len(clusterArns) {
+			end = len(clusterArns)
+		}
+
+		describeOutput, err := c.client.DescribeClusters(ctx, &ecs.DescribeClustersInput{
+			Clusters: clusterArns[i:end],
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, cl := range describeOutput.Clusters {
+			clusters = append(clusters, ECSClusterInfo{
+				ARN:            aws.ToString(cl.ClusterArn),
+				Name:           aws.ToString(cl.ClusterName),
+				Status:         aws.ToString(cl.Status),
+				RunningTasks:   cl.RunningTasksCount,
+				PendingTasks:   cl.PendingTasksCount,
+				ActiveServices: cl.ActiveServicesCount,
+			})
+		}
+	}
+
+	return clusters, nil
+}
+
+type ECSClusterSummary struct {
+	Name               string
+	RunningTasks       int32
+	PendingTasks       int32
+	ContainerInstances int32
+	CapacityProviders  []string
+}
+
+func (c *ECSClient) GetClusterSummary(ctx context.Context, cluster string) (*ECSClusterSummary, error) {
+	output, err := c.client.DescribeClusters(ctx, &ecs.DescribeClustersInput{
+		Clusters: []string{cluster},
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	var clusters []ECSClusterInfo
-	for _, cl := range describeOutput.Clusters {
-		clusters = append(clusters, ECSClusterInfo{
-			ARN:            aws.ToString(cl.ClusterArn),
-			Name:           aws.ToString(cl.ClusterName),
-			Status:         aws.ToString(cl.Status),
-			RunningTasks:   cl.RunningTasksCount,
-			PendingTasks:   cl.PendingTasksCount,
-			ActiveServices: cl.ActiveServicesCount,
+	if len(output.Clusters) == 0 {
+		return nil, fmt.Errorf("cluster %s not found", cluster)
+	}
+
+	cl := output.Clusters[0]
+	return &ECSClusterSummary{
+		Name:               aws.ToString(cl.ClusterName),
+		RunningTasks:       cl.RunningTasksCount,
+		PendingTasks:       cl.PendingTasksCount,
+		ContainerInstances: cl.RegisteredContainerInstancesCount,
+		CapacityProviders:  cl.CapacityProviders,
+	}, nil
+}
+
+// ContainerInstanceInfo is an EC2 instance registered to a cluster, with the
+// capacity it has left for placing tasks. CPU is in units (1024 per vCPU)
+// and memory in MiB.
+type ContainerInstanceInfo struct {
+	ARN              string
+	ID               string
+	EC2InstanceID    string
+	Status           string
+	AgentConnected   bool
+	RunningTasks     int32
+	PendingTasks     int32
+	RegisteredCPU    int32
+	RemainingCPU     int32
+	RegisteredMemory int32
+	RemainingMemory  int32
+	CapacityProvider string
+}
+
+// ListContainerInstances lists the ARNs of the container instances registered
+// to a cluster; Fargate-only clusters have none
+func (c *ECSClient) ListContainerInstances(ctx context.Context, cluster string) ([]string, error) {
+	var arns []string
+	paginator := ecs.NewListContainerInstancesPaginator(c.client, &ecs.ListContainerInstancesInput{
+		Cluster: aws.String(cluster),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list container instances: %w", err)
+		}
+		arns = append(arns, page.ContainerInstanceArns...)
+	}
+
+	return arns, nil
+}
+
+// DescribeContainerInstances describes the given container instances of a
+// cluster, with their tasks and remaining CPU and memory
+func (c *ECSClient) DescribeContainerInstances(ctx context.Context, cluster string, arns []string) ([]ContainerInstanceInfo, error) {
+	var instances []ContainerInstanceInfo
+	// DescribeContainerInstances has a limit of 100
+	for i := 0; i < len(arns); i += 100 {
+		end := min(i+100, len(arns))
+
+		output, err := c.client.DescribeContainerInstances(ctx, &ecs.DescribeContainerInstancesInput{
+			Cluster:            aws.String(cluster),
+			ContainerInstances: arns[i:end],
 		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to describe container instances: %w", err)
+		}
+
+		for _, ci := range output.ContainerInstances {
+			id := aws.ToString(ci.ContainerInstanceArn)
+			if lastSlash := strings.LastIndex(id, "/"); lastSlash != -1 {
+				id = id[lastSlash+1:]
+			}
+			registeredCPU, registeredMemory := containerResources(ci.RegisteredResources)
+			remainingCPU, remainingMemory := containerResources(ci.RemainingResources)
+
+			instances = append(instances, ContainerInstanceInfo{
+				ARN:              aws.ToString(ci.ContainerInstanceArn),
+				ID:               id,
+				EC2InstanceID:    aws.ToString(ci.Ec2InstanceId),
+				Status:           aws.ToString(ci.Status),
+				AgentConnected:   ci.AgentConnected,
+				RunningTasks:     ci.RunningTasksCount,
+				PendingTasks:     ci.PendingTasksCount,
+				RegisteredCPU:    registeredCPU,
+				RemainingCPU:     remainingCPU,
+				RegisteredMemory: registeredMemory,
+				RemainingMemory:  remainingMemory,
+				CapacityProvider: aws.ToString(ci.CapacityProviderName),
+			})
+		}
 	}
 
-	return clusters, nil
+	return instances, nil
+}
+
+// containerResources picks the CPU and memory out of a container instance's
+// resources, which also list ports and GPUs
+func containerResources(resources []types.Resource) (cpu, memory int32) {
+	for _, r := range resources {
+		switch aws.ToString(r.Name) {
+		case "CPU":
+			cpu = r.IntegerValue
+		case "MEMORY":
+			memory = r.IntegerValue
+		}
+	}
+	return cpu, memory
 }
 
 type ServiceInfo struct {
@@ -137,60 +276,133 @@ type ECSTaskInfo struct {
 	LaunchType     string
 	CPU            string
 	Memory         string
-	CreatedAt      string
+	CreatedAt      time.Time
+	StoppedReason  string
+	StoppedAt      time.Time
 }
 
-func (c *ECSClient) ListTasks(ctx context.Context, cluster string, serviceName *string) ([]ECSTaskInfo, error) {
+// ListTasks lists the tasks of a cluster, optionally limited to one service.
+// desiredStatus is RUNNING or STOPPED; ECS defaults to RUNNING when empty.
+func (c *ECSClient) ListTasks(ctx context.Context, cluster string, serviceName *string, desiredStatus string) ([]ECSTaskInfo, error) {
 	input := &ecs.ListTasksInput{
 		Cluster: aws.String(cluster),
 	}
 	if serviceName != nil {
 		input.ServiceName = serviceName
 	}
+	if desiredStatus != "" {
+		input.DesiredStatus = types.DesiredStatus(desiredStatus)
+	}
 
-	output, err := c.client.ListTasks(ctx, input)
-	if err != nil {
-		return nil, err
+	var taskArns []string
+	paginator := ecs.NewListTasksPaginator(c.client, input)
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		taskArns = append(taskArns, page.TaskArns...)
 	}
 
-	if len(output.TaskArns) == 0 {
+	if len(taskArns) == 0 {
 		return nil, nil
 	}
 
-	describeOutput, err := c.client.DescribeTasks(ctx, &ecs.DescribeTasksInput{
+	var tasks []ECSTaskInfo
+	// DescribeTasks has a limit of 100
+	for i := 0; i < len(taskArns); i += 100 {
+		end := i + 100
+		if end > len(taskArns) {
+			end = len(taskArns)
+		}
+
+		describeOutput, err := c.client.DescribeTasks(ctx, &ecs.DescribeTasksInput{
+			Cluster: aws.String(cluster),
+			Tasks:   taskArns[i:end],
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range describeOutput.Tasks {
+			id := aws.ToString(t.TaskArn)
+			if lastSlash := strings.LastIndex(id, "/"); lastSlash != -1 {
+				id = id[lastSlash+1:]
+			}
+
+			tasks = append(tasks, ECSTaskInfo{
+				ARN:            aws.ToString(t.TaskArn),
+				ID:             id,
+				LastStatus:     aws.ToString(t.LastStatus),
+				DesiredStatus:  aws.ToString(t.DesiredStatus),
+				TaskDefinition: aws.ToString(t.TaskDefinitionArn),
+				LaunchType:     string(t.LaunchType),
+				CPU:            aws.ToString(t.Cpu),
+				Memory:         aws.ToString(t.Memory),
+				CreatedAt:      aws.ToTime(t.CreatedAt),
+				StoppedReason:  aws.ToString(t.StoppedReason),
+				StoppedAt:      aws.ToTime(t.StoppedAt),
+			})
+		}
+	}
+
+	return tasks, nil
+}
+
+type ECSContainerInfo struct {
+	Name         string
+	Image        string
+	LastStatus   string
+	HealthStatus string
+	ExitCode     *int32
+	Reason       string
+}
+
+type ECSTaskDetail struct {
+	ARN           string
+	LastStatus    string
+	DesiredStatus string
+	StopCode      string
+	StoppedReason string
+	StoppedAt     time.Time
+	Containers    []ECSContainerInfo
+}
+
+// DescribeTask returns a task with the state of each of its containers
+func (c *ECSClient) DescribeTask(ctx context.Context, cluster, taskArn string) (*ECSTaskDetail, error) {
+	output, err := c.client.DescribeTasks(ctx, &ecs.DescribeTasksInput{
 		Cluster: aws.String(cluster),
-		Tasks:   output.TaskArns,
+		Tasks:   []string{taskArn},
 	})
 	if err != nil {
 		return nil, err
 	}
+	if len(output.Tasks) == 0 {
+		return nil, fmt.Errorf("task %s not found", taskArn)
+	}
 
-	var tasks []ECSTaskInfo
-	for _, t := range describeOutput.Tasks {
-		id := aws.ToString(t.TaskArn)
-		if lastSlash := strings.LastIndex(id, "/"); lastSlash != -1 {
-			id = id[lastSlash+1:]
-		}
-
-		createdAt := ""
-		if t.CreatedAt != nil {
-			createdAt = t.CreatedAt.Format("2006-01-02 15:04")
-		}
-
-		tasks = append(tasks, ECSTaskInfo{
-			ARN:            aws.ToString(t.TaskArn),
-			ID:             id,
-			LastStatus:     aws.ToString(t.LastStatus),
-			DesiredStatus:  aws.ToString(t.DesiredStatus),
-			TaskDefinition: aws.ToString(t.TaskDefinitionArn),
-			LaunchType:     string(t.LaunchType),
-			CPU:            aws.ToString(t.Cpu),
-			Memory:         aws.ToString(t.Memory),
-			CreatedAt:      createdAt,
+	t := output.Tasks[0]
+	detail := &ECSTaskDetail{
+		ARN:           aws.ToString(t.TaskArn),
+		LastStatus:    aws.ToString(t.LastStatus),
+		DesiredStatus: aws.ToString(t.DesiredStatus),
+		StopCode:      string(t.StopCode),
+		StoppedReason: aws.ToString(t.StoppedReason),
+		StoppedAt:     aws.ToTime(t.StoppedAt),
+	}
+	for _, ct := range t.Containers {
+		detail.Containers = append(detail.Containers, ECSContainerInfo{
+			Name:         aws.ToString(ct.Name),
+			Image:        aws.ToString(ct.Image),
+			LastStatus:   aws.ToString(ct.LastStatus),
+			HealthStatus: string(ct.HealthStatus),
+			ExitCode:     ct.ExitCode,
+			Reason:       aws.ToString(ct.Reason),
 		})
 	}
 
-	return tasks, nil
+	return detail, nil
 }
 
 type TaskDefinitionInfo struct {
@@ -291,6 +503,43 @@ func (c *ECSClient) GetTaskDefinitionJSON(ctx context.Context, arn string) (stri
 	return string(data), nil
 }
 
+// RegisterTaskDefinitionJSON registers a new revision from a task definition
+// in the JSON form returned by GetTaskDefinitionJSON. Fields AWS sets itself,
+// such as the ARN, revision and status, are ignored.
+func (c *ECSClient) RegisterTaskDefinitionJSON(ctx context.Context, data []byte) (string, error) {
+	var td types.TaskDefinition
+	if err := json.Unmarshal(data, &td); err != nil {
+		return "", fmt.Errorf("invalid task definition JSON: %w", err)
+	}
+	if aws.ToString(td.Family) == "" {
+		return "", fmt.Errorf("invalid task definition: Family is required")
+	}
+
+	output, err := c.client.RegisterTaskDefinition(ctx, &ecs.RegisterTaskDefinitionInput{
+		Family:                  td.Family,
+		ContainerDefinitions:    td.ContainerDefinitions,
+		Cpu:                     td.Cpu,
+		Memory:                  td.Memory,
+		ExecutionRoleArn:        td.ExecutionRoleArn,
+		TaskRoleArn:             td.TaskRoleArn,
+		NetworkMode:             td.NetworkMode,
+		Volumes:                 td.Volumes,
+		PlacementConstraints:    td.PlacementConstraints,
+		RequiresCompatibilities: td.RequiresCompatibilities,
+		IpcMode:                 td.IpcMode,
+		PidMode:                 td.PidMode,
+		ProxyConfiguration:      td.ProxyConfiguration,
+		InferenceAccelerators:   td.InferenceAccelerators,
+		EphemeralStorage:        td.EphemeralStorage,
+		RuntimePlatform:         td.RuntimePlatform,
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to register task definition: %w", err)
+	}
+
+	return aws.ToString(output.TaskDefinition.TaskDefinitionArn), nil
+}
+
 type ECSEventInfo struct {
 	ID        string
 	CreatedAt string
@@ -326,6 +575,86 @@ func (c *ECSClient) GetServiceEvents(ctx context.Context, cluster, service strin
 	return events, nil
 }
 
+// ECSDeployment is one deployment of a service. The PRIMARY deployment runs
+// the latest configuration; ACTIVE ones are being drained.
+type ECSDeployment struct {
+	ID             string
+	Status         string
+	RolloutState   string
+	RolloutReason  string
+	TaskDefinition string
+	Running        int32
+	Pending        int32
+	Desired        int32
+	UpdatedAt      time.Time
+}
+
+// ECSDeploymentStatus is a service's rollout progress
+type ECSDeploymentStatus struct {
+	Deployments []ECSDeployment
+	Events      []ECSEventInfo
+	// Stable is set once only the primary deployment is left, running every
+	// desired task
+	Stable bool
+	// Failed is set when the deployment circuit breaker stopped the rollout
+	Failed bool
+}
+
+// GetDeploymentStatus describes the deployments of a service and its latest
+// events
+func (c *ECSClient) GetDeploymentStatus(ctx context.Context, cluster, service string) (*ECSDeploymentStatus, error) {
+	output, err := c.client.DescribeServices(ctx, &ecs.DescribeServicesInput{
+		Cluster:  aws.String(cluster),
+		Services: []string{service},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to describe service: %w", err)
+	}
+	if len(output.Services) == 0 {
+		return nil, fmt.Errorf("service %s not found", service)
+	}
+	svc := output.Services[0]
+
+	status := &ECSDeploymentStatus{}
+	for _, d := range svc.Deployments {
+		taskDef := aws.ToString(d.TaskDefinition)
+		status.Deployments = append(status.Deployments, ECSDeployment{
+			ID:             aws.ToString(d.Id),
+			Status:         aws.ToString(d.Status),
+			RolloutState:   string(d.RolloutState),
+			RolloutReason:  aws.ToString(d.RolloutStateReason),
+			TaskDefinition: taskDef[strings.LastIndex(taskDef, "/")+1:],
+			Running:        d.RunningCount,
+			Pending:        d.PendingCount,
+			Desired:        d.DesiredCount,
+			UpdatedAt:      aws.ToTime(d.UpdatedAt),
+		})
+	}
+	for _, e := range svc.Events {
+		createdAt := ""
+		if e.CreatedAt != nil {
+			createdAt = e.CreatedAt.Format("15:04:05")
+		}
+		status.Events = append(status.Events, ECSEventInfo{
+			ID:        aws.ToString(e.Id),
+			CreatedAt: createdAt,
+			Message:   aws.ToString(e.Message),
+		})
+	}
+
+	for _, d := range status.Deployments {
+		if d.Status == "PRIMARY" && d.RolloutState == string(types.DeploymentRolloutStateFailed) {
+			status.Failed = true
+		}
+	}
+	if len(status.Deployments) == 1 {
+		d := status.Deployments[0]
+		status.Stable = d.Status == "PRIMARY" && d.Running == d.Desired && d.Pending == 0 &&
+			d.RolloutState != string(types.DeploymentRolloutStateInProgress)
+	}
+	return status, nil
+}
+
 func (c *ECSClient) StopTask(ctx context.Context, cluster, taskArn string) error {
 	_, err := c.client.StopTask(ctx, &ecs.StopTaskInput{
 		Cluster: aws.String(cluster),
@@ -353,6 +682,61 @@ func (c *ECSClient) RestartService(ctx context.Context, cluster, service string)
 	return err
 }
 
+// TaskNetworkConfig places a task using the awsvpc network mode
+type TaskNetworkConfig struct {
+	Subnets        []string
+	SecurityGroups []string
+	// AssignPublicIP is only honored for Fargate tasks
+	AssignPublicIP bool
+}
+
+// LaunchedTask is a task started by RunTask
+type LaunchedTask struct {
+	TaskArn           string
+	TaskDefinitionArn string
+}
+
+// RunTask starts one task outside of any service. A nil network leaves the
+// network configuration out, for EC2 tasks not using awsvpc.
+func (c *ECSClient) RunTask(ctx context.Context, cluster, taskDef, launchType string, network *TaskNetworkConfig) (*LaunchedTask, error) {
+	input := &ecs.RunTaskInput{
+		Cluster:        aws.String(cluster),
+		TaskDefinition: aws.String(taskDef),
+		LaunchType:     types.LaunchType(launchType),
+		Count:          aws.Int32(1),
+		StartedBy:      aws.String("aws-tui"),
+	}
+	if network != nil {
+		vpc := &types.AwsVpcConfiguration{
+			Subnets:        network.Subnets,
+			SecurityGroups: network.SecurityGroups,
+		}
+		if launchType == string(types.LaunchTypeFargate) {
+			vpc.AssignPublicIp = types.AssignPublicIpDisabled
+			if network.AssignPublicIP {
+				vpc.AssignPublicIp = types.AssignPublicIpEnabled
+			}
+		}
+		input.NetworkConfiguration = &types.NetworkConfiguration{AwsvpcConfiguration: vpc}
+	}
+
+	output, err := c.client.RunTask(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("unable to run task: %w", err)
+	}
+	if len(output.Failures) > 0 {
+		f := output.Failures[0]
+		return nil, fmt.Errorf("unable to run task: %s %s", aws.ToString(f.Reason), aws.ToString(f.Detail))
+	}
+	if len(output.Tasks) == 0 {
+		return nil, fmt.Errorf("unable to run task: no task was started")
+	}
+	return &LaunchedTask{
+		TaskArn:           aws.ToString(output.Tasks[0].TaskArn),
+		TaskDefinitionArn: aws.ToString(output.Tasks[0].TaskDefinitionArn),
+	}, nil
+}
+
 func (c *ECSClient) GetLogGroupForTaskDefinition(ctx context.Context, taskDefArn string) (string, error) {
 	output, err := c.client.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
 		TaskDefinition: aws.String(taskDefArn),
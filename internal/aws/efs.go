@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/efs"
 )
 
@@ -15,7 +14,7 @@ type EFSClient struct {
 }
 
 func NewEFSClient(ctx context.Context, profile string) (*EFSClient, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	cfg, err := loadConfig(ctx, profile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -83,6 +82,7 @@ type MountTargetInfo struct {
 	NetworkInterfaceId   string
 	AvailabilityZoneId   string
 	AvailabilityZoneName string
+	SecurityGroups       []string
 }
 
 func (c *EFSClient) ListMountTargets(ctx context.Context, fileSystemId string) ([]MountTargetInfo, error) {
@@ -95,6 +95,13 @@ func (c *EFSClient) ListMountTargets(ctx context.Context, fileSystemId string) (
 
 	var targets []MountTargetInfo
 	for _, mt := range output.MountTargets {
+		groups, err := c.client.DescribeMountTargetSecurityGroups(ctx, &efs.DescribeMountTargetSecurityGroupsInput{
+			MountTargetId: mt.MountTargetId,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to get mount target security groups: %w", err)
+		}
+
 		targets = append(targets, MountTargetInfo{
 			MountTargetId:        aws.ToString(mt.MountTargetId),
 			FileSystemId:         aws.ToString(mt.FileSystemId),
@@ -104,6 +111,7 @@ func (c *EFSClient) ListMountTargets(ctx context.Context, fileSystemId string) (
 			NetworkInterfaceId:   aws.ToString(mt.NetworkInterfaceId),
 			AvailabilityZoneId:   aws.ToString(mt.AvailabilityZoneId),
 			AvailabilityZoneName: aws.ToString(mt.AvailabilityZoneName),
+			SecurityGroups:       groups.SecurityGroups,
 		})
 	}
 
@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/elasticache"
 )
 
@@ -14,7 +13,7 @@ type ElastiCacheClient struct {
 }
 
 func NewElastiCacheClient(ctx context.Context, profile string) (*ElastiCacheClient, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	cfg, err := loadConfig(ctx, profile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -31,24 +30,43 @@ type ReplicationGroupInfo struct {
 	CacheNodeType string
 	Nodes         int32
 	Description   string
+	// Endpoint is the configuration endpoint of cluster-mode groups, or the
+	// primary endpoint otherwise
+	Endpoint string
+	Port     int32
+	TLS      bool
 }
 
 func (c *ElastiCacheClient) ListReplicationGroups(ctx context.Context) ([]ReplicationGroupInfo, error) {
-	output, err := c.client.DescribeReplicationGroups(ctx, &elasticache.DescribeReplicationGroupsInput{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to list replication groups: %w", err)
-	}
-
 	var groups []ReplicationGroupInfo
-	for _, rg := range output.ReplicationGroups {
-		groups = append(groups, ReplicationGroupInfo{
-			ID:            aws.ToString(rg.ReplicationGroupId),
-			Status:        aws.ToString(rg.Status),
-			Engine:        aws.ToString(rg.Engine),
-			CacheNodeType: aws.ToString(rg.CacheNodeType),
-			Nodes:         int32(len(rg.NodeGroups)),
-			Description:   aws.ToString(rg.Description),
-		})
+	paginator := elasticache.NewDescribeReplicationGroupsPaginator(c.client, &elasticache.DescribeReplicationGroupsInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list replication groups: %w", err)
+		}
+
+		for _, rg := range page.ReplicationGroups {
+			endpoint := rg.ConfigurationEndpoint
+			if endpoint == nil && len(rg.NodeGroups) > 0 {
+				endpoint = rg.NodeGroups[0].PrimaryEndpoint
+			}
+			info := ReplicationGroupInfo{
+				ID:            aws.ToString(rg.ReplicationGroupId),
+				Status:        aws.ToString(rg.Status),
+				Engine:        aws.ToString(rg.Engine),
+				CacheNodeType: aws.ToString(rg.CacheNodeType),
+				Nodes:         int32(len(rg.NodeGroups)),
+				Description:   aws.ToString(rg.Description),
+				TLS:           aws.ToBool(rg.TransitEncryptionEnabled),
+			}
+			if endpoint != nil {
+				info.Endpoint = aws.ToString(endpoint.Address)
+				info.Port = aws.ToInt32(endpoint.Port)
+			}
+			groups = append(groups, info)
+		}
 	}
 
 	return groups, nil
@@ -62,25 +80,46 @@ type CacheClusterInfo struct {
 	CacheNodeType string
 	Nodes         int32
 	AZ            string
+	// Endpoint is the configuration endpoint of Memcached clusters, or the
+	// first node's endpoint otherwise
+	Endpoint string
+	Port     int32
+	TLS      bool
 }
 
 func (c *ElastiCacheClient) ListCacheClusters(ctx context.Context) ([]CacheClusterInfo, error) {
-	output, err := c.client.DescribeCacheClusters(ctx, &elasticache.DescribeCacheClustersInput{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to list cache clusters: %w", err)
-	}
-
 	var clusters []CacheClusterInfo
-	for _, cc := range output.CacheClusters {
-		clusters = append(clusters, CacheClusterInfo{
-			ID:            aws.ToString(cc.CacheClusterId),
-			Status:        aws.ToString(cc.CacheClusterStatus),
-			Engine:        aws.ToString(cc.Engine),
-			EngineVersion: aws.ToString(cc.EngineVersion),
-			CacheNodeType: aws.ToString(cc.CacheNodeType),
-			Nodes:         aws.ToInt32(cc.NumCacheNodes),
-			AZ:            aws.ToString(cc.PreferredAvailabilityZone),
-		})
+	paginator := elasticache.NewDescribeCacheClustersPaginator(c.client, &elasticache.DescribeCacheClustersInput{
+		ShowCacheNodeInfo: aws.Bool(true),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list cache clusters: %w", err)
+		}
+
+		for _, cc := range page.CacheClusters {
+			endpoint := cc.ConfigurationEndpoint
+			if endpoint == nil && len(cc.CacheNodes) > 0 {
+				endpoint = cc.CacheNodes[0].Endpoint
+			}
+			info := CacheClusterInfo{
+				ID:            aws.ToString(cc.CacheClusterId),
+				Status:        aws.ToString(cc.CacheClusterStatus),
+				Engine:        aws.ToString(cc.Engine),
+				EngineVersion: aws.ToString(cc.EngineVersion),
+				CacheNodeType: aws.ToString(cc.CacheNodeType),
+				Nodes:         aws.ToInt32(cc.NumCacheNodes),
+				AZ:            aws.ToString(cc.PreferredAvailabilityZone),
+				TLS:           aws.ToBool(cc.TransitEncryptionEnabled),
+			}
+			if endpoint != nil {
+				info.Endpoint = aws.ToString(endpoint.Address)
+				info.Port = aws.ToInt32(endpoint.Port)
+			}
+			clusters = append(clusters, info)
+		}
 	}
 
 	return clusters, nil
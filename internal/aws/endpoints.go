@@ -0,0 +1,49 @@
+package aws
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+)
+
+// EndpointOptions selects the variant of the service endpoints every client
+// calls, for environments that require FIPS 140 validated or IPv6 endpoints
+type EndpointOptions struct {
+	FIPS      bool
+	DualStack bool
+}
+
+var endpointOptions EndpointOptions
+
+// SetEndpointOptions changes the endpoints of the clients. It must be called
+// before any client is created. Options left off still follow the profile's
+// use_fips_endpoint and use_dualstack_endpoint settings.
+func SetEndpointOptions(o EndpointOptions) {
+	endpointOptions = o
+}
+
+// EndpointEnv returns the environment variables that give the AWS CLI the
+// endpoint options of the clients, for the commands the app shells out to
+func EndpointEnv() []string {
+	var env []string
+	if endpointOptions.FIPS {
+		env = append(env, "AWS_USE_FIPS_ENDPOINT=true")
+	}
+	if endpointOptions.DualStack {
+		env = append(env, "AWS_USE_DUALSTACK_ENDPOINT=true")
+	}
+	return env
+}
+
+// endpointLoadOptions turns the endpoint options into config load options.
+// A service without such an endpoint in the region fails its calls rather
+// than silently falling back to the standard one.
+func endpointLoadOptions() []func(*config.LoadOptions) error {
+	var opts []func(*config.LoadOptions) error
+	if endpointOptions.FIPS {
+		opts = append(opts, config.WithUseFIPSEndpoint(aws.FIPSEndpointStateEnabled))
+	}
+	if endpointOptions.DualStack {
+		opts = append(opts, config.WithUseDualStackEndpoint(aws.DualStackEndpointStateEnabled))
+	}
+	return opts
+}
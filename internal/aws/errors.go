@@ -0,0 +1,63 @@
+package aws
+
+import (
+	"errors"
+
+	"github.com/aws/smithy-go"
+)
+
+// ErrorDetail is what a failed AWS call reports about itself, the parts a
+// support case asks for
+type ErrorDetail struct {
+	Service    string
+	Operation  string
+	Code       string
+	Message    string
+	Fault      string // Client or server
+	StatusCode int
+	RequestID  string
+	// HostID is S3's extended request ID
+	HostID string
+}
+
+// DescribeError digs the details of an AWS API call out of err, which keeps
+// them through the wrapping the clients add. ok is false when err did not
+// come from a call.
+func DescribeError(err error) (detail ErrorDetail, ok bool) {
+	var opErr *smithy.OperationError
+	if errors.As(err, &opErr) {
+		detail.Service = opErr.ServiceID
+		detail.Operation = opErr.OperationName
+		ok = true
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		detail.Code = apiErr.ErrorCode()
+		detail.Message = apiErr.ErrorMessage()
+		switch apiErr.ErrorFault() {
+		case smithy.FaultClient:
+			detail.Fault = "Client"
+		case smithy.FaultServer:
+			detail.Fault = "Server"
+		}
+		ok = true
+	}
+
+	// The response errors of the SDK's HTTP transport, matched by method to
+	// cover the S3 variant as well
+	var status interface{ HTTPStatusCode() int }
+	if errors.As(err, &status) {
+		detail.StatusCode = status.HTTPStatusCode()
+	}
+	var requestID interface{ ServiceRequestID() string }
+	if errors.As(err, &requestID) {
+		detail.RequestID = requestID.ServiceRequestID()
+	}
+	var hostID interface{ ServiceHostID() string }
+	if errors.As(err, &hostID) {
+		detail.HostID = hostID.ServiceHostID()
+	}
+
+	return detail, ok
+}
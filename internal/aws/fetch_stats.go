@@ -0,0 +1,56 @@
+package aws
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+// FetchStats counts the AWS calls made with a context from WithFetchStats.
+// Each page of a listing is a call; attempts past the first of a call are
+// retries, e.g. after throttling.
+type FetchStats struct {
+	calls    atomic.Int64
+	attempts atomic.Int64
+}
+
+type fetchStatsKey struct{}
+
+// WithFetchStats returns a context whose calls are counted in the returned stats
+func WithFetchStats(ctx context.Context) (context.Context, *FetchStats) {
+	stats := &FetchStats{}
+	return context.WithValue(ctx, fetchStatsKey{}, stats), stats
+}
+
+// Calls is the number of calls made, pages included
+func (s *FetchStats) Calls() int {
+	return int(s.calls.Load())
+}
+
+// Retries is the number of attempts that repeated a failed one
+func (s *FetchStats) Retries() int {
+	return int(s.attempts.Load() - s.calls.Load())
+}
+
+// addFetchStats counts calls once and attempts inside the retry loop, which
+// runs the finalize steps added after it once per attempt
+func addFetchStats(stack *middleware.Stack) error {
+	err := stack.Initialize.Add(middleware.InitializeMiddlewareFunc("AWSTUIFetchStatsCalls",
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			if stats, ok := ctx.Value(fetchStatsKey{}).(*FetchStats); ok {
+				stats.calls.Add(1)
+			}
+			return next.HandleInitialize(ctx, in)
+		}), middleware.Before)
+	if err != nil {
+		return err
+	}
+	return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("AWSTUIFetchStatsAttempts",
+		func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+			if stats, ok := ctx.Value(fetchStatsKey{}).(*FetchStats); ok {
+				stats.attempts.Add(1)
+			}
+			return next.HandleFinalize(ctx, in)
+		}), middleware.After)
+}
@@ -2,12 +2,19 @@ package aws
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
 )
 
 type IAMClient struct {
@@ -15,7 +22,7 @@ type IAMClient struct {
 }
 
 func NewIAMClient(ctx context.Context, profile string) (*IAMClient, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	cfg, err := loadConfig(ctx, profile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -50,9 +57,26 @@ type AccessKeyInfo struct {
 }
 
 func (c *IAMClient) ListUsers(ctx context.Context) ([]IAMUserInfo, error) {
-	output, err := c.client.ListUsers(ctx, &iam.ListUsersInput{})
+	var users []IAMUserInfo
+	var marker *string
+	for {
+		page, next, err := c.ListUsersPage(ctx, marker)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, page...)
+		if next == nil {
+			return users, nil
+		}
+		marker = next
+	}
+}
+
+// ListUsersPage returns one page of users and the marker for the next one, nil on the last page
+func (c *IAMClient) ListUsersPage(ctx context.Context, marker *string) ([]IAMUserInfo, *string, error) {
+	output, err := c.client.ListUsers(ctx, &iam.ListUsersInput{Marker: marker})
 	if err != nil {
-		return nil, fmt.Errorf("unable to list users: %w", err)
+		return nil, nil, fmt.Errorf("unable to list users: %w", err)
 	}
 
 	users := make([]IAMUserInfo, len(output.Users))
@@ -67,7 +91,10 @@ func (c *IAMClient) ListUsers(ctx context.Context) ([]IAMUserInfo, error) {
 		}
 	}
 
-	return users, nil
+	if !output.IsTruncated {
+		return users, nil, nil
+	}
+	return users, output.Marker, nil
 }
 
 func (c *IAMClient) GetUserDetails(ctx context.Context, userName string) (*IAMUserInfo, []AccessKeyInfo, error) {
@@ -114,6 +141,57 @@ func (c *IAMClient) GetUserDetails(ctx context.Context, userName string) (*IAMUs
 	return info, keys, nil
 }
 
+// UserDependencies are the resources IAM requires to be removed before a
+// user can be deleted
+type UserDependencies struct {
+	AttachedPolicies int
+	InlinePolicies   int
+	AccessKeys       int
+	Groups           int
+	MFADevices       int
+	ConsoleAccess    bool
+}
+
+// GetUserDependencies counts what is still attached to a user
+func (c *IAMClient) GetUserDependencies(ctx context.Context, userName string) (UserDependencies, error) {
+	var deps UserDependencies
+
+	attached, err := c.ListAttachedUserPolicies(ctx, userName)
+	if err != nil {
+		return deps, err
+	}
+	deps.AttachedPolicies = len(attached)
+
+	inline, err := c.ListUserInlinePolicies(ctx, userName)
+	if err != nil {
+		return deps, err
+	}
+	deps.InlinePolicies = len(inline)
+
+	keysOut, err := c.client.ListAccessKeys(ctx, &iam.ListAccessKeysInput{UserName: aws.String(userName)})
+	if err != nil {
+		return deps, fmt.Errorf("unable to list access keys: %w", err)
+	}
+	deps.AccessKeys = len(keysOut.AccessKeyMetadata)
+
+	groupsOut, err := c.client.ListGroupsForUser(ctx, &iam.ListGroupsForUserInput{UserName: aws.String(userName)})
+	if err != nil {
+		return deps, fmt.Errorf("unable to list groups: %w", err)
+	}
+	deps.Groups = len(groupsOut.Groups)
+
+	mfaOut, err := c.client.ListMFADevices(ctx, &iam.ListMFADevicesInput{UserName: aws.String(userName)})
+	if err != nil {
+		return deps, fmt.Errorf("unable to list MFA devices: %w", err)
+	}
+	deps.MFADevices = len(mfaOut.MFADevices)
+
+	_, err = c.client.GetLoginProfile(ctx, &iam.GetLoginProfileInput{UserName: aws.String(userName)})
+	deps.ConsoleAccess = err == nil
+
+	return deps, nil
+}
+
 func (c *IAMClient) CreateUser(ctx context.Context, userName string) error {
 	_, err := c.client.CreateUser(ctx, &iam.CreateUserInput{
 		UserName: aws.String(userName),
@@ -153,6 +231,279 @@ func (c *IAMClient) UpdateLoginProfile(ctx context.Context, userName, password s
 	return err
 }
 
+// NewAccessKey holds a freshly created key. The secret is only available at creation time.
+type NewAccessKey struct {
+	AccessKeyId     string
+	SecretAccessKey string
+}
+
+func (c *IAMClient) CreateAccessKey(ctx context.Context, userName string) (*NewAccessKey, error) {
+	output, err := c.client.CreateAccessKey(ctx, &iam.CreateAccessKeyInput{
+		UserName: aws.String(userName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create access key: %w", err)
+	}
+
+	return &NewAccessKey{
+		AccessKeyId:     aws.ToString(output.AccessKey.AccessKeyId),
+		SecretAccessKey: aws.ToString(output.AccessKey.SecretAccessKey),
+	}, nil
+}
+
+func (c *IAMClient) UpdateAccessKeyStatus(ctx context.Context, userName, accessKeyID string, active bool) error {
+	status := types.StatusTypeInactive
+	if active {
+		status = types.StatusTypeActive
+	}
+	_, err := c.client.UpdateAccessKey(ctx, &iam.UpdateAccessKeyInput{
+		UserName:    aws.String(userName),
+		AccessKeyId: aws.String(accessKeyID),
+		Status:      status,
+	})
+	return err
+}
+
+func (c *IAMClient) DeleteAccessKey(ctx context.Context, userName, accessKeyID string) error {
+	_, err := c.client.DeleteAccessKey(ctx, &iam.DeleteAccessKeyInput{
+		UserName:    aws.String(userName),
+		AccessKeyId: aws.String(accessKeyID),
+	})
+	return err
+}
+
+type UserPolicyInfo struct {
+	Name   string
+	Arn    string
+	Inline bool
+}
+
+// ListAttachedUserPolicies returns the managed policies attached to a user
+func (c *IAMClient) ListAttachedUserPolicies(ctx context.Context, userName string) ([]UserPolicyInfo, error) {
+	var policies []UserPolicyInfo
+	paginator := iam.NewListAttachedUserPoliciesPaginator(c.client, &iam.ListAttachedUserPoliciesInput{
+		UserName: aws.String(userName),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list attached policies: %w", err)
+		}
+		for _, p := range page.AttachedPolicies {
+			policies = append(policies, UserPolicyInfo{
+				Name: aws.ToString(p.PolicyName),
+				Arn:  aws.ToString(p.PolicyArn),
+			})
+		}
+	}
+
+	return policies, nil
+}
+
+// ListUserInlinePolicies returns the names of the inline policies embedded in a user
+func (c *IAMClient) ListUserInlinePolicies(ctx context.Context, userName string) ([]UserPolicyInfo, error) {
+	var policies []UserPolicyInfo
+	paginator := iam.NewListUserPoliciesPaginator(c.client, &iam.ListUserPoliciesInput{
+		UserName: aws.String(userName),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list inline policies: %w", err)
+		}
+		for _, name := range page.PolicyNames {
+			policies = append(policies, UserPolicyInfo{
+				Name:   name,
+				Inline: true,
+			})
+		}
+	}
+
+	return policies, nil
+}
+
+func (c *IAMClient) AttachUserPolicy(ctx context.Context, userName, policyArn string) error {
+	_, err := c.client.AttachUserPolicy(ctx, &iam.AttachUserPolicyInput{
+		UserName:  aws.String(userName),
+		PolicyArn: aws.String(policyArn),
+	})
+	return err
+}
+
+func (c *IAMClient) DetachUserPolicy(ctx context.Context, userName, policyArn string) error {
+	_, err := c.client.DetachUserPolicy(ctx, &iam.DetachUserPolicyInput{
+		UserName:  aws.String(userName),
+		PolicyArn: aws.String(policyArn),
+	})
+	return err
+}
+
+type IAMGroupInfo struct {
+	GroupName  string
+	Arn        string
+	Path       string
+	CreateDate time.Time
+}
+
+func (c *IAMClient) ListGroups(ctx context.Context) ([]IAMGroupInfo, error) {
+	var groups []IAMGroupInfo
+	paginator := iam.NewListGroupsPaginator(c.client, &iam.ListGroupsInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list groups: %w", err)
+		}
+
+		for _, g := range page.Groups {
+			groups = append(groups, IAMGroupInfo{
+				GroupName:  aws.ToString(g.GroupName),
+				Arn:        aws.ToString(g.Arn),
+				Path:       aws.ToString(g.Path),
+				CreateDate: aws.ToTime(g.CreateDate),
+			})
+		}
+	}
+
+	return groups, nil
+}
+
+// GetGroupMembers returns the users in a group
+func (c *IAMClient) GetGroupMembers(ctx context.Context, groupName string) ([]IAMUserInfo, error) {
+	var users []IAMUserInfo
+	paginator := iam.NewGetGroupPaginator(c.client, &iam.GetGroupInput{GroupName: aws.String(groupName)})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get members of group %s: %w", groupName, err)
+		}
+
+		for _, u := range page.Users {
+			users = append(users, IAMUserInfo{
+				UserName:         aws.ToString(u.UserName),
+				UserID:           aws.ToString(u.UserId),
+				Path:             aws.ToString(u.Path),
+				Arn:              aws.ToString(u.Arn),
+				CreateDate:       aws.ToTime(u.CreateDate),
+				PasswordLastUsed: u.PasswordLastUsed,
+			})
+		}
+	}
+
+	return users, nil
+}
+
+func (c *IAMClient) AddUserToGroup(ctx context.Context, groupName, userName string) error {
+	_, err := c.client.AddUserToGroup(ctx, &iam.AddUserToGroupInput{
+		GroupName: aws.String(groupName),
+		UserName:  aws.String(userName),
+	})
+	return err
+}
+
+func (c *IAMClient) RemoveUserFromGroup(ctx context.Context, groupName, userName string) error {
+	_, err := c.client.RemoveUserFromGroup(ctx, &iam.RemoveUserFromGroupInput{
+		GroupName: aws.String(groupName),
+		UserName:  aws.String(userName),
+	})
+	return err
+}
+
+type PasswordPolicy struct {
+	MinimumLength              int32
+	RequireSymbols             bool
+	RequireNumbers             bool
+	RequireUppercase           bool
+	RequireLowercase           bool
+	AllowUsersToChangePassword bool
+	ExpirePasswords            bool
+	MaxPasswordAge             int32
+	PasswordReusePrevention    int32
+	HardExpiry                 bool
+}
+
+// GetAccountPasswordPolicy returns the account password policy, or nil when none is set
+func (c *IAMClient) GetAccountPasswordPolicy(ctx context.Context) (*PasswordPolicy, error) {
+	output, err := c.client.GetAccountPasswordPolicy(ctx, &iam.GetAccountPasswordPolicyInput{})
+	if err != nil {
+		var notFound *types.NoSuchEntityException
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to get password policy: %w", err)
+	}
+
+	p := output.PasswordPolicy
+	return &PasswordPolicy{
+		MinimumLength:              aws.ToInt32(p.MinimumPasswordLength),
+		RequireSymbols:             p.RequireSymbols,
+		RequireNumbers:             p.RequireNumbers,
+		RequireUppercase:           p.RequireUppercaseCharacters,
+		RequireLowercase:           p.RequireLowercaseCharacters,
+		AllowUsersToChangePassword: p.AllowUsersToChangePassword,
+		ExpirePasswords:            p.ExpirePasswords,
+		MaxPasswordAge:             aws.ToInt32(p.MaxPasswordAge),
+		PasswordReusePrevention:    aws.ToInt32(p.PasswordReusePrevention),
+		HardExpiry:                 aws.ToBool(p.HardExpiry),
+	}, nil
+}
+
+const (
+	passwordLower   = "abcdefghijkmnopqrstuvwxyz"
+	passwordUpper   = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	passwordNumbers = "23456789"
+	passwordSymbols = "!@#$%^&*()_+-=[]{}|"
+	// defaultPasswordLength is used unless the policy asks for more
+	defaultPasswordLength = 20
+)
+
+// GeneratePassword returns a random password that satisfies the given policy.
+// It always includes every character class, so a nil policy is also fine.
+func GeneratePassword(policy *PasswordPolicy) (string, error) {
+	length := defaultPasswordLength
+	if policy != nil && int(policy.MinimumLength) > length {
+		length = int(policy.MinimumLength)
+	}
+
+	classes := []string{passwordLower, passwordUpper, passwordNumbers, passwordSymbols}
+	all := passwordLower + passwordUpper + passwordNumbers + passwordSymbols
+
+	password := make([]byte, length)
+	for i := range password {
+		charset := all
+		if i < len(classes) {
+			charset = classes[i]
+		}
+		c, err := randomChar(charset)
+		if err != nil {
+			return "", err
+		}
+		password[i] = c
+	}
+
+	// Shuffle so the guaranteed characters aren't always at the start
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+
+	return string(password), nil
+}
+
+func randomChar(charset string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+	if err != nil {
+		return 0, fmt.Errorf("unable to generate password: %w", err)
+	}
+	return charset[n.Int64()], nil
+}
+
 func (c *IAMClient) ListAccountAliases(ctx context.Context) ([]string, error) {
 	output, err := c.client.ListAccountAliases(ctx, &iam.ListAccountAliasesInput{})
 	if err != nil {
@@ -160,3 +511,125 @@ func (c *IAMClient) ListAccountAliases(ctx context.Context) ([]string, error) {
 	}
 	return output.AccountAliases, nil
 }
+
+type IAMRoleInfo struct {
+	RoleName           string
+	Arn                string
+	Path               string
+	CreateDate         time.Time
+	MaxSessionDuration int32
+	// TrustPolicy is the decoded assume role policy document
+	TrustPolicy string
+	// LastUsed is nil for roles never assumed in the tracking period
+	LastUsed       *time.Time
+	LastUsedRegion string
+}
+
+func (c *IAMClient) ListRoles(ctx context.Context) ([]IAMRoleInfo, error) {
+	var roles []IAMRoleInfo
+	paginator := iam.NewListRolesPaginator(c.client, &iam.ListRolesInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list roles: %w", err)
+		}
+
+		for _, r := range page.Roles {
+			// The policy document is URL encoded
+			policy, err := url.QueryUnescape(aws.ToString(r.AssumeRolePolicyDocument))
+			if err != nil {
+				policy = aws.ToString(r.AssumeRolePolicyDocument)
+			}
+			roles = append(roles, IAMRoleInfo{
+				RoleName:           aws.ToString(r.RoleName),
+				Arn:                aws.ToString(r.Arn),
+				Path:               aws.ToString(r.Path),
+				CreateDate:         aws.ToTime(r.CreateDate),
+				MaxSessionDuration: aws.ToInt32(r.MaxSessionDuration),
+				TrustPolicy:        policy,
+			})
+		}
+	}
+
+	return roles, nil
+}
+
+// GetRoleLastUsed returns when and in which region a role was last assumed.
+// ListRoles leaves this out, so it takes a GetRole call per role.
+func (c *IAMClient) GetRoleLastUsed(ctx context.Context, roleName string) (*time.Time, string, error) {
+	output, err := c.client.GetRole(ctx, &iam.GetRoleInput{RoleName: aws.String(roleName)})
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to get role %s: %w", roleName, err)
+	}
+	if output.Role.RoleLastUsed == nil {
+		return nil, "", nil
+	}
+	return output.Role.RoleLastUsed.LastUsedDate, aws.ToString(output.Role.RoleLastUsed.Region), nil
+}
+
+// TrustedPrincipals summarizes who may assume a role from its trust policy:
+// services by their short name, AWS principals by account or ARN, and
+// federated providers as they are written
+func TrustedPrincipals(policy string) []string {
+	var doc struct {
+		Statement json.RawMessage
+	}
+	if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+		return nil
+	}
+
+	type statement struct {
+		Effect    string
+		Principal json.RawMessage
+	}
+	var statements []statement
+	if err := json.Unmarshal(doc.Statement, &statements); err != nil {
+		var single statement
+		if err := json.Unmarshal(doc.Statement, &single); err != nil {
+			return nil
+		}
+		statements = []statement{single}
+	}
+
+	var principals []string
+	for _, s := range statements {
+		if s.Effect != "Allow" {
+			continue
+		}
+		var anyone string
+		if json.Unmarshal(s.Principal, &anyone) == nil {
+			principals = append(principals, anyone)
+			continue
+		}
+		var byType map[string]json.RawMessage
+		if json.Unmarshal(s.Principal, &byType) != nil {
+			continue
+		}
+		for kind, raw := range byType {
+			var values []string
+			if json.Unmarshal(raw, &values) != nil {
+				var value string
+				if json.Unmarshal(raw, &value) != nil {
+					continue
+				}
+				values = []string{value}
+			}
+			for _, v := range values {
+				switch kind {
+				case "Service":
+					v = strings.TrimSuffix(v, ".amazonaws.com")
+				case "AWS":
+					// The account root is the account itself
+					if account, ok := strings.CutSuffix(v, ":root"); ok {
+						v = account[strings.LastIndex(account, ":")+1:]
+					}
+				}
+				principals = append(principals, v)
+			}
+		}
+	}
+
+	slices.Sort(principals)
+	return slices.Compact(principals)
+}
@@ -0,0 +1,47 @@
+package aws
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/aws/smithy-go/middleware"
+)
+
+var (
+	inFlight atomic.Int64
+	// inFlightChanged holds at most one pending notification, so requests
+	// never block on a slow reader
+	inFlightChanged = make(chan struct{}, 1)
+)
+
+// InFlight returns the number of AWS operations currently running
+func InFlight() int {
+	return int(inFlight.Load())
+}
+
+// InFlightChanged signals whenever the number of running operations changes.
+// Read InFlight after receiving to get the current count.
+func InFlightChanged() <-chan struct{} {
+	return inFlightChanged
+}
+
+func notifyInFlight() {
+	select {
+	case inFlightChanged <- struct{}{}:
+	default:
+	}
+}
+
+// addInFlightTracking counts every operation while it runs, retries included
+func addInFlightTracking(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("AWSTUIInFlight",
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			inFlight.Add(1)
+			notifyInFlight()
+			defer func() {
+				inFlight.Add(-1)
+				notifyInFlight()
+			}()
+			return next.HandleInitialize(ctx, in)
+		}), middleware.Before)
+}
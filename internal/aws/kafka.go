@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/kafka"
 )
 
@@ -14,7 +13,7 @@ type MSKClient struct {
 }
 
 func NewMSKClient(ctx context.Context, profile string) (*MSKClient, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	cfg, err := loadConfig(ctx, profile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -34,65 +33,132 @@ type ClusterInfo struct {
 }
 
 func (c *MSKClient) ListClusters(ctx context.Context) ([]ClusterInfo, error) {
-	output, err := c.client.ListClusters(ctx, &kafka.ListClustersInput{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to list clusters: %w", err)
+	var clusters []ClusterInfo
+	paginator := kafka.NewListClustersPaginator(c.client, &kafka.ListClustersInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list clusters: %w", err)
+		}
+
+		for _, cluster := range page.ClusterInfoList {
+			nodes := int32(0)
+			if cluster.BrokerNodeGroupInfo != nil {
+				nodes = aws.ToInt32(cluster.NumberOfBrokerNodes)
+			}
+
+			clusters = append(clusters, ClusterInfo{
+				ARN:           aws.ToString(cluster.ClusterArn),
+				Name:          aws.ToString(cluster.ClusterName),
+				Status:        string(cluster.State),
+				EngineVersion: aws.ToString(cluster.CurrentVersion),
+				NodeType:      "", // Not directly available in ListClusters without Describe
+				Nodes:         nodes,
+			})
+		}
 	}
 
+	return clusters, nil
+}
+
+func (c *MSKClient) ListClustersV2(ctx context.Context) ([]ClusterInfo, error) {
 	var clusters []ClusterInfo
-	for _, cluster := range output.ClusterInfoList {
-		nodes := int32(0)
-		if cluster.BrokerNodeGroupInfo != nil {
-			nodes = aws.ToInt32(cluster.NumberOfBrokerNodes)
+	paginator := kafka.NewListClustersV2Paginator(c.client, &kafka.ListClustersV2Input{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list clusters v2: %w", err)
 		}
 
-		clusters = append(clusters, ClusterInfo{
-			ARN:           aws.ToString(cluster.ClusterArn),
-			Name:          aws.ToString(cluster.ClusterName),
-			Status:        string(cluster.State),
-			EngineVersion: aws.ToString(cluster.CurrentVersion),
-			NodeType:      "", // Not directly available in ListClusters without Describe
-			Nodes:         nodes,
-		})
+		for _, cluster := range page.ClusterInfoList {
+			nodes := int32(0)
+			var version string
+			var state string
+			var name string
+			var arn string
+
+			if cluster.Provisioned != nil {
+				nodes = aws.ToInt32(cluster.Provisioned.NumberOfBrokerNodes)
+				version = aws.ToString(cluster.Provisioned.CurrentBrokerSoftwareInfo.KafkaVersion)
+				state = string(cluster.State)
+				name = aws.ToString(cluster.ClusterName)
+				arn = aws.ToString(cluster.ClusterArn)
+			} else if cluster.Serverless != nil {
+				state = string(cluster.State)
+				name = aws.ToString(cluster.ClusterName)
+				arn = aws.ToString(cluster.ClusterArn)
+			}
+
+			clusters = append(clusters, ClusterInfo{
+				ARN:           arn,
+				Name:          name,
+				Status:        state,
+				EngineVersion: version,
+				Nodes:         nodes,
+			})
+		}
 	}
 
 	return clusters, nil
 }
 
-func (c *MSKClient) ListClustersV2(ctx context.Context) ([]ClusterInfo, error) {
-	output, err := c.client.ListClustersV2(ctx, &kafka.ListClustersV2Input{})
+// GetBootstrapBrokers returns the broker list clients connect to, preferring
+// TLS, then IAM and SCRAM authentication, over plaintext
+func (c *MSKClient) GetBootstrapBrokers(ctx context.Context, clusterArn string) (string, error) {
+	output, err := c.client.GetBootstrapBrokers(ctx, &kafka.GetBootstrapBrokersInput{
+		ClusterArn: aws.String(clusterArn),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("unable to list clusters v2: %w", err)
+		return "", fmt.Errorf("unable to get bootstrap brokers: %w", err)
 	}
+	for _, brokers := range []*string{
+		output.BootstrapBrokerStringTls,
+		output.BootstrapBrokerStringSaslIam,
+		output.BootstrapBrokerStringSaslScram,
+		output.BootstrapBrokerString,
+	} {
+		if aws.ToString(brokers) != "" {
+			return aws.ToString(brokers), nil
+		}
+	}
+	return "", fmt.Errorf("cluster has no bootstrap brokers")
+}
 
-	var clusters []ClusterInfo
-	for _, cluster := range output.ClusterInfoList {
-		nodes := int32(0)
-		var version string
-		var state string
-		var name string
-		var arn string
-
-		if cluster.Provisioned != nil {
-			nodes = aws.ToInt32(cluster.Provisioned.NumberOfBrokerNodes)
-			version = aws.ToString(cluster.Provisioned.CurrentBrokerSoftwareInfo.KafkaVersion)
-			state = string(cluster.State)
-			name = aws.ToString(cluster.ClusterName)
-			arn = aws.ToString(cluster.ClusterArn)
-		} else if cluster.Serverless != nil {
-			state = string(cluster.State)
-			name = aws.ToString(cluster.ClusterName)
-			arn = aws.ToString(cluster.ClusterArn)
+type MSKConfigurationInfo struct {
+	ARN            string
+	Name           string
+	Description    string
+	LatestRevision int64
+	State          string
+}
+
+func (c *MSKClient) ListConfigurations(ctx context.Context) ([]MSKConfigurationInfo, error) {
+	var configurations []MSKConfigurationInfo
+	paginator := kafka.NewListConfigurationsPaginator(c.client, &kafka.ListConfigurationsInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list configurations: %w", err)
 		}
 
-		clusters = append(clusters, ClusterInfo{
-			ARN:           arn,
-			Name:          name,
-			Status:        state,
-			EngineVersion: version,
-			Nodes:         nodes,
-		})
+		for _, configuration := range page.Configurations {
+			var revision int64
+			if configuration.LatestRevision != nil {
+				revision = aws.ToInt64(configuration.LatestRevision.Revision)
+			}
+
+			configurations = append(configurations, MSKConfigurationInfo{
+				ARN:            aws.ToString(configuration.Arn),
+				Name:           aws.ToString(configuration.Name),
+				Description:    aws.ToString(configuration.Description),
+				LatestRevision: revision,
+				State:          string(configuration.State),
+			})
+		}
 	}
 
-	return clusters, nil
+	return configurations, nil
 }
@@ -2,12 +2,12 @@ package aws
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"strings"
 	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/kms"
 )
 
@@ -16,7 +16,7 @@ type KMSClient struct {
 }
 
 func NewKMSClient(ctx context.Context, profile string) (*KMSClient, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	cfg, err := loadConfig(ctx, profile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -129,3 +129,32 @@ func (c *KMSClient) ListKeys(ctx context.Context) ([]KMSKeyInfo, error) {
 
 	return keys, nil
 }
+
+// Encrypt encrypts a small plaintext (up to 4 KB) under the key and returns
+// the ciphertext blob as base64
+func (c *KMSClient) Encrypt(ctx context.Context, keyID, plaintext string) (string, error) {
+	output, err := c.client.Encrypt(ctx, &kms.EncryptInput{
+		KeyId:     aws.String(keyID),
+		Plaintext: []byte(plaintext),
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to encrypt: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(output.CiphertextBlob), nil
+}
+
+// Decrypt decrypts a base64 ciphertext blob produced under the key
+func (c *KMSClient) Decrypt(ctx context.Context, keyID, ciphertext string) (string, error) {
+	blob, err := base64.StdEncoding.DecodeString(strings.TrimSpace(ciphertext))
+	if err != nil {
+		return "", fmt.Errorf("ciphertext is not valid base64: %w", err)
+	}
+	output, err := c.client.Decrypt(ctx, &kms.DecryptInput{
+		KeyId:          aws.String(keyID),
+		CiphertextBlob: blob,
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to decrypt: %w", err)
+	}
+	return string(output.Plaintext), nil
+}
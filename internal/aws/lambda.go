@@ -2,11 +2,12 @@ package aws
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
 )
 
@@ -15,7 +16,7 @@ type LambdaClient struct {
 }
 
 func NewLambdaClient(ctx context.Context, profile string) (*LambdaClient, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	cfg, err := loadConfig(ctx, profile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -61,3 +62,141 @@ func (c *LambdaClient) ListFunctions(ctx context.Context) ([]FunctionInfo, error
 
 	return functions, nil
 }
+
+// InvokeResult is the outcome of a synchronous function invocation
+type InvokeResult struct {
+	StatusCode    int32
+	FunctionError string // Set when the function itself failed, e.g. "Unhandled"
+	Payload       string
+}
+
+// Invoke runs a function synchronously with the given JSON payload
+func (c *LambdaClient) Invoke(ctx context.Context, name string, payload []byte) (*InvokeResult, error) {
+	output, err := c.client.Invoke(ctx, &lambda.InvokeInput{
+		FunctionName: aws.String(name),
+		Payload:      payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to invoke function: %w", err)
+	}
+	return &InvokeResult{
+		StatusCode:    output.StatusCode,
+		FunctionError: aws.ToString(output.FunctionError),
+		Payload:       string(output.Payload),
+	}, nil
+}
+
+// ProvisionedConcurrency is the pre-initialized concurrency of one alias or version
+type ProvisionedConcurrency struct {
+	Qualifier    string
+	Requested    int32
+	Allocated    int32
+	Available    int32
+	Status       string
+	StatusReason string
+}
+
+// FunctionConcurrency is the concurrency set aside for a function
+type FunctionConcurrency struct {
+	// Reserved is nil when the function draws from the unreserved pool
+	Reserved    *int32
+	Provisioned []ProvisionedConcurrency
+}
+
+// AccountConcurrency is the concurrency limit of the account in the region
+// and how much of it no function has reserved
+type AccountConcurrency struct {
+	Limit      int32
+	Unreserved int32
+}
+
+// MinUnreservedConcurrency is the part of the account's concurrency Lambda
+// keeps unreserved for functions without a reservation
+const MinUnreservedConcurrency = 100
+
+func (c *LambdaClient) GetFunctionConcurrency(ctx context.Context, name string) (*FunctionConcurrency, error) {
+	reserved, err := c.client.GetFunctionConcurrency(ctx, &lambda.GetFunctionConcurrencyInput{FunctionName: aws.String(name)})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get concurrency of %s: %w", name, err)
+	}
+	concurrency := &FunctionConcurrency{Reserved: reserved.ReservedConcurrentExecutions}
+
+	paginator := lambda.NewListProvisionedConcurrencyConfigsPaginator(c.client, &lambda.ListProvisionedConcurrencyConfigsInput{FunctionName: aws.String(name)})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list provisioned concurrency of %s: %w", name, err)
+		}
+		for _, p := range page.ProvisionedConcurrencyConfigs {
+			arn := aws.ToString(p.FunctionArn)
+			concurrency.Provisioned = append(concurrency.Provisioned, ProvisionedConcurrency{
+				Qualifier:    arn[strings.LastIndex(arn, ":")+1:],
+				Requested:    aws.ToInt32(p.RequestedProvisionedConcurrentExecutions),
+				Allocated:    aws.ToInt32(p.AllocatedProvisionedConcurrentExecutions),
+				Available:    aws.ToInt32(p.AvailableProvisionedConcurrentExecutions),
+				Status:       string(p.Status),
+				StatusReason: aws.ToString(p.StatusReason),
+			})
+		}
+	}
+
+	return concurrency, nil
+}
+
+func (c *LambdaClient) GetAccountConcurrency(ctx context.Context) (*AccountConcurrency, error) {
+	output, err := c.client.GetAccountSettings(ctx, &lambda.GetAccountSettingsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get account settings: %w", err)
+	}
+	if output.AccountLimit == nil {
+		return nil, fmt.Errorf("account settings have no concurrency limit")
+	}
+	return &AccountConcurrency{
+		Limit:      output.AccountLimit.ConcurrentExecutions,
+		Unreserved: aws.ToInt32(output.AccountLimit.UnreservedConcurrentExecutions),
+	}, nil
+}
+
+// PutFunctionConcurrency reserves concurrency for a function. Zero stops the
+// function from being invoked at all.
+func (c *LambdaClient) PutFunctionConcurrency(ctx context.Context, name string, reserved int32) error {
+	_, err := c.client.PutFunctionConcurrency(ctx, &lambda.PutFunctionConcurrencyInput{
+		FunctionName:                 aws.String(name),
+		ReservedConcurrentExecutions: aws.Int32(reserved),
+	})
+	return err
+}
+
+// DeleteFunctionConcurrency returns a function to the unreserved pool
+func (c *LambdaClient) DeleteFunctionConcurrency(ctx context.Context, name string) error {
+	_, err := c.client.DeleteFunctionConcurrency(ctx, &lambda.DeleteFunctionConcurrencyInput{FunctionName: aws.String(name)})
+	return err
+}
+
+// GetFunctionConfigJSON returns the configuration of a function as indented
+// JSON with sorted keys, for comparing it with the same function elsewhere.
+// Fields that change on every update are left out, as they always differ.
+func (c *LambdaClient) GetFunctionConfigJSON(ctx context.Context, name string) (string, error) {
+	output, err := c.client.GetFunctionConfiguration(ctx, &lambda.GetFunctionConfigurationInput{FunctionName: aws.String(name)})
+	if err != nil {
+		return "", fmt.Errorf("unable to get function configuration: %w", err)
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", err
+	}
+	for _, volatile := range []string{"ResultMetadata", "LastModified", "RevisionId"} {
+		delete(fields, volatile)
+	}
+
+	data, err = json.MarshalIndent(fields, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
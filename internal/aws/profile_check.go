@@ -0,0 +1,64 @@
+package aws
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ProfileStatus is the outcome of checking one profile's credentials
+type ProfileStatus struct {
+	Profile string
+	Account string
+	Arn     string
+	Region  string
+	Err     error
+}
+
+// CheckProfiles calls GetCallerIdentity for every profile with at most workers
+// calls running at once, each bounded by timeout. Results keep the order of
+// profiles.
+func CheckProfiles(ctx context.Context, profiles []string, workers int, timeout time.Duration) []ProfileStatus {
+	results := make([]ProfileStatus, len(profiles))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range max(min(workers, len(profiles)), 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = checkProfile(ctx, profiles[i], timeout)
+			}
+		}()
+	}
+
+	for i := range profiles {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+func checkProfile(ctx context.Context, profile string, timeout time.Duration) ProfileStatus {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	status := ProfileStatus{Profile: profile}
+	client, err := NewSTSClient(ctx, profile)
+	if err != nil {
+		status.Err = err
+		return status
+	}
+	status.Region = client.region
+
+	id, err := client.GetCallerIdentity(ctx)
+	if err != nil {
+		status.Err = err
+		return status
+	}
+	status.Account = id.Account
+	status.Arn = id.Arn
+	return status
+}
@@ -9,23 +9,52 @@ import (
 	"strings"
 )
 
-// GetProfiles returns a list of all AWS profiles found in ~/.aws/config and ~/.aws/credentials
-func GetProfiles() ([]string, error) {
+// ConfigFilePath returns the shared config file the SDK reads: the one named
+// by AWS_CONFIG_FILE, or ~/.aws/config
+func ConfigFilePath() (string, error) {
+	return sharedFilePath("AWS_CONFIG_FILE", "config")
+}
+
+// CredentialsFilePath returns the shared credentials file the SDK reads: the
+// one named by AWS_SHARED_CREDENTIALS_FILE, or ~/.aws/credentials
+func CredentialsFilePath() (string, error) {
+	return sharedFilePath("AWS_SHARED_CREDENTIALS_FILE", "credentials")
+}
+
+// sharedFilePath resolves a shared file from its environment variable, used
+// verbatim as the SDK does, or its default under ~/.aws
+func sharedFilePath(envVar, name string) (string, error) {
+	if path := os.Getenv(envVar); path != "" {
+		return path, nil
+	}
+
 	home, err := os.UserHomeDir()
 	if err != nil {
-		return nil, fmt.Errorf("could not get home directory: %w", err)
+		return "", fmt.Errorf("could not get home directory: %w", err)
+	}
+	return filepath.Join(home, ".aws", name), nil
+}
+
+// GetProfiles returns a list of all AWS profiles found in the shared config
+// and credentials files, honoring AWS_CONFIG_FILE and AWS_SHARED_CREDENTIALS_FILE
+func GetProfiles() ([]string, error) {
+	configPath, err := ConfigFilePath()
+	if err != nil {
+		return nil, err
+	}
+	credsPath, err := CredentialsFilePath()
+	if err != nil {
+		return nil, err
 	}
 
 	profiles := make(map[string]struct{})
 
-	configPath := filepath.Join(home, ".aws", "config")
 	if _, err := os.Stat(configPath); err == nil {
 		if err := parseProfiles(configPath, profiles, true); err != nil {
 			return nil, err
 		}
 	}
 
-	credsPath := filepath.Join(home, ".aws", "credentials")
 	if _, err := os.Stat(credsPath); err == nil {
 		if err := parseProfiles(credsPath, profiles, false); err != nil {
 			return nil, err
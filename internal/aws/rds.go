@@ -3,9 +3,9 @@ package aws
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
 )
 
@@ -14,7 +14,7 @@ type RDSClient struct {
 }
 
 func NewRDSClient(ctx context.Context, profile string) (*RDSClient, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	cfg, err := loadConfig(ctx, profile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -26,36 +26,52 @@ func NewRDSClient(ctx context.Context, profile string) (*RDSClient, error) {
 
 type RDSInstanceInfo struct {
 	ID       string
+	Arn      string
 	Engine   string
 	Status   string
 	Class    string
 	Endpoint string
+	Port     int32
+	DBName   string
 	VpcID    string
 }
 
 func (c *RDSClient) ListInstances(ctx context.Context) ([]RDSInstanceInfo, error) {
-	output, err := c.client.DescribeDBInstances(ctx, &rds.DescribeDBInstancesInput{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to list RDS instances: %w", err)
-	}
-
-	instances := make([]RDSInstanceInfo, len(output.DBInstances))
-	for i, d := range output.DBInstances {
-		endpoint := ""
-		if d.Endpoint != nil {
-			endpoint = aws.ToString(d.Endpoint.Address)
-		}
-		vpcID := ""
-		if d.DBSubnetGroup != nil {
-			vpcID = aws.ToString(d.DBSubnetGroup.VpcId)
+	var instances []RDSInstanceInfo
+	filter := CurrentTagFilter()
+	paginator := rds.NewDescribeDBInstancesPaginator(c.client, &rds.DescribeDBInstancesInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list RDS instances: %w", err)
 		}
-		instances[i] = RDSInstanceInfo{
-			ID:       aws.ToString(d.DBInstanceIdentifier),
-			Engine:   aws.ToString(d.Engine),
-			Status:   aws.ToString(d.DBInstanceStatus),
-			Class:    aws.ToString(d.DBInstanceClass),
-			Endpoint: endpoint,
-			VpcID:    vpcID,
+
+		for _, d := range page.DBInstances {
+			if !filter.matchesRDS(d.TagList) {
+				continue
+			}
+			endpoint := ""
+			var port int32
+			if d.Endpoint != nil {
+				endpoint = aws.ToString(d.Endpoint.Address)
+				port = aws.ToInt32(d.Endpoint.Port)
+			}
+			vpcID := ""
+			if d.DBSubnetGroup != nil {
+				vpcID = aws.ToString(d.DBSubnetGroup.VpcId)
+			}
+			instances = append(instances, RDSInstanceInfo{
+				ID:       aws.ToString(d.DBInstanceIdentifier),
+				Arn:      aws.ToString(d.DBInstanceArn),
+				Engine:   aws.ToString(d.Engine),
+				Status:   aws.ToString(d.DBInstanceStatus),
+				Class:    aws.ToString(d.DBInstanceClass),
+				Endpoint: endpoint,
+				Port:     port,
+				DBName:   aws.ToString(d.DBName),
+				VpcID:    vpcID,
+			})
 		}
 	}
 
@@ -64,26 +80,40 @@ func (c *RDSClient) ListInstances(ctx context.Context) ([]RDSInstanceInfo, error
 
 type RDSClusterInfo struct {
 	ID       string
+	Arn      string
 	Engine   string
 	Status   string
 	Endpoint string
+	Port     int32
+	DBName   string
 	VpcID    string
 }
 
 func (c *RDSClient) ListClusters(ctx context.Context) ([]RDSClusterInfo, error) {
-	output, err := c.client.DescribeDBClusters(ctx, &rds.DescribeDBClustersInput{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to list RDS clusters: %w", err)
-	}
+	var clusters []RDSClusterInfo
+	filter := CurrentTagFilter()
+	paginator := rds.NewDescribeDBClustersPaginator(c.client, &rds.DescribeDBClustersInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list RDS clusters: %w", err)
+		}
 
-	clusters := make([]RDSClusterInfo, len(output.DBClusters))
-	for i, d := range output.DBClusters {
-		clusters[i] = RDSClusterInfo{
-			ID:       aws.ToString(d.DBClusterIdentifier),
-			Engine:   aws.ToString(d.Engine),
-			Status:   aws.ToString(d.Status),
-			Endpoint: aws.ToString(d.Endpoint),
-			VpcID:    "", // VpcId is not directly in DBCluster, usually inferred from subnet group
+		for _, d := range page.DBClusters {
+			if !filter.matchesRDS(d.TagList) {
+				continue
+			}
+			clusters = append(clusters, RDSClusterInfo{
+				ID:       aws.ToString(d.DBClusterIdentifier),
+				Arn:      aws.ToString(d.DBClusterArn),
+				Engine:   aws.ToString(d.Engine),
+				Status:   aws.ToString(d.Status),
+				Endpoint: aws.ToString(d.Endpoint),
+				Port:     aws.ToInt32(d.Port),
+				DBName:   aws.ToString(d.DatabaseName),
+				VpcID:    "", // VpcId is not directly in DBCluster, usually inferred from subnet group
+			})
 		}
 	}
 
@@ -92,30 +122,36 @@ func (c *RDSClient) ListClusters(ctx context.Context) ([]RDSClusterInfo, error)
 
 type RDSSnapshotInfo struct {
 	ID         string
+	Arn        string
 	InstanceID string
 	Status     string
 	Type       string
-	CreateTime string
+	CreateTime time.Time
 }
 
 func (c *RDSClient) ListSnapshots(ctx context.Context) ([]RDSSnapshotInfo, error) {
-	output, err := c.client.DescribeDBSnapshots(ctx, &rds.DescribeDBSnapshotsInput{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to list RDS snapshots: %w", err)
-	}
-
-	snapshots := make([]RDSSnapshotInfo, len(output.DBSnapshots))
-	for i, d := range output.DBSnapshots {
-		createTime := ""
-		if d.SnapshotCreateTime != nil {
-			createTime = d.SnapshotCreateTime.Format("2006-01-02 15:04:05")
+	var snapshots []RDSSnapshotInfo
+	filter := CurrentTagFilter()
+	paginator := rds.NewDescribeDBSnapshotsPaginator(c.client, &rds.DescribeDBSnapshotsInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list RDS snapshots: %w", err)
 		}
-		snapshots[i] = RDSSnapshotInfo{
-			ID:         aws.ToString(d.DBSnapshotIdentifier),
-			InstanceID: aws.ToString(d.DBInstanceIdentifier),
-			Status:     aws.ToString(d.Status),
-			Type:       aws.ToString(d.SnapshotType),
-			CreateTime: createTime,
+
+		for _, d := range page.DBSnapshots {
+			if !filter.matchesRDS(d.TagList) {
+				continue
+			}
+			snapshots = append(snapshots, RDSSnapshotInfo{
+				ID:         aws.ToString(d.DBSnapshotIdentifier),
+				Arn:        aws.ToString(d.DBSnapshotArn),
+				InstanceID: aws.ToString(d.DBInstanceIdentifier),
+				Status:     aws.ToString(d.Status),
+				Type:       aws.ToString(d.SnapshotType),
+				CreateTime: aws.ToTime(d.SnapshotCreateTime),
+			})
 		}
 	}
 
@@ -130,18 +166,22 @@ type RDSSubnetGroupInfo struct {
 }
 
 func (c *RDSClient) ListSubnetGroups(ctx context.Context) ([]RDSSubnetGroupInfo, error) {
-	output, err := c.client.DescribeDBSubnetGroups(ctx, &rds.DescribeDBSubnetGroupsInput{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to list RDS subnet groups: %w", err)
-	}
+	var groups []RDSSubnetGroupInfo
+	paginator := rds.NewDescribeDBSubnetGroupsPaginator(c.client, &rds.DescribeDBSubnetGroupsInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list RDS subnet groups: %w", err)
+		}
 
-	groups := make([]RDSSubnetGroupInfo, len(output.DBSubnetGroups))
-	for i, d := range output.DBSubnetGroups {
-		groups[i] = RDSSubnetGroupInfo{
-			Name:        aws.ToString(d.DBSubnetGroupName),
-			Description: aws.ToString(d.DBSubnetGroupDescription),
-			VpcID:       aws.ToString(d.VpcId),
-			Status:      aws.ToString(d.SubnetGroupStatus),
+		for _, d := range page.DBSubnetGroups {
+			groups = append(groups, RDSSubnetGroupInfo{
+				Name:        aws.ToString(d.DBSubnetGroupName),
+				Description: aws.ToString(d.DBSubnetGroupDescription),
+				VpcID:       aws.ToString(d.VpcId),
+				Status:      aws.ToString(d.SubnetGroupStatus),
+			})
 		}
 	}
 
@@ -0,0 +1,53 @@
+package aws
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// partitionRegions are the public regions of each partition
+var partitionRegions = map[string][]string{
+	"aws": {
+		"us-east-1", "us-east-2", "us-west-1", "us-west-2",
+		"ca-central-1", "ca-west-1", "mx-central-1", "sa-east-1",
+		"eu-west-1", "eu-west-2", "eu-west-3", "eu-central-1", "eu-central-2",
+		"eu-north-1", "eu-south-1", "eu-south-2",
+		"ap-south-1", "ap-south-2", "ap-east-1", "ap-east-2",
+		"ap-northeast-1", "ap-northeast-2", "ap-northeast-3",
+		"ap-southeast-1", "ap-southeast-2", "ap-southeast-3", "ap-southeast-4",
+		"ap-southeast-5", "ap-southeast-6", "ap-southeast-7",
+		"me-south-1", "me-central-1", "il-central-1", "af-south-1",
+	},
+	"aws-cn":     {"cn-north-1", "cn-northwest-1"},
+	"aws-us-gov": {"us-gov-east-1", "us-gov-west-1"},
+}
+
+// Partition returns the partition a region belongs to
+func Partition(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return "aws-cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		return "aws-us-gov"
+	}
+	return "aws"
+}
+
+// PartitionRegions returns the regions of the partition region belongs to
+func PartitionRegions(region string) []string {
+	return partitionRegions[Partition(region)]
+}
+
+// CheckRegion reports an error unless region is a region of the partition
+// current belongs to. Clients cannot switch partitions, as credentials are
+// only valid within one.
+func CheckRegion(region, current string) error {
+	if slices.Contains(PartitionRegions(current), region) {
+		return nil
+	}
+	if Partition(region) != Partition(current) {
+		return fmt.Errorf("%s is outside the %s partition of %s", region, Partition(current), current)
+	}
+	return fmt.Errorf("unknown region %s", region)
+}
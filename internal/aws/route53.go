@@ -6,8 +6,8 @@ import (
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
 )
 
 type Route53Client struct {
@@ -15,7 +15,7 @@ type Route53Client struct {
 }
 
 func NewRoute53Client(ctx context.Context, profile string) (*Route53Client, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	cfg, err := loadConfig(ctx, profile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -34,28 +34,81 @@ type HostedZoneInfo struct {
 }
 
 func (c *Route53Client) ListHostedZones(ctx context.Context) ([]HostedZoneInfo, error) {
-	output, err := c.client.ListHostedZones(ctx, &route53.ListHostedZonesInput{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to list hosted zones: %w", err)
-	}
-
 	var zones []HostedZoneInfo
-	for _, z := range output.HostedZones {
-		comment := ""
-		if z.Config != nil && z.Config.Comment != nil {
-			comment = *z.Config.Comment
+	paginator := route53.NewListHostedZonesPaginator(c.client, &route53.ListHostedZonesInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list hosted zones: %w", err)
 		}
 
-		zones = append(zones, HostedZoneInfo{
-			ID:          strings.TrimPrefix(*z.Id, "/hostedzone/"),
+		for _, z := range page.HostedZones {
+			comment := ""
+			if z.Config != nil && z.Config.Comment != nil {
+				comment = *z.Config.Comment
+			}
+
+			zones = append(zones, HostedZoneInfo{
+				ID:          strings.TrimPrefix(*z.Id, "/hostedzone/"),
+				Name:        aws.ToString(z.Name),
+				RecordCount: aws.ToInt64(z.ResourceRecordSetCount),
+				IsPrivate:   z.Config != nil && z.Config.PrivateZone,
+				Comment:     comment,
+			})
+		}
+	}
+
+	return zones, nil
+}
+
+// HostedZoneDetails adds what is needed to delegate a zone to its summary
+type HostedZoneDetails struct {
+	HostedZoneInfo
+	NameServers []string
+	// DNSSECStatus is the signing status, e.g. SIGNING or NOT_SIGNING. Private
+	// zones cannot be signed and leave it empty.
+	DNSSECStatus string
+}
+
+// GetHostedZoneDetails returns a zone with its name servers and DNSSEC status
+func (c *Route53Client) GetHostedZoneDetails(ctx context.Context, zoneID string) (HostedZoneDetails, error) {
+	output, err := c.client.GetHostedZone(ctx, &route53.GetHostedZoneInput{
+		Id: aws.String(zoneID),
+	})
+	if err != nil {
+		return HostedZoneDetails{}, fmt.Errorf("unable to get hosted zone %s: %w", zoneID, err)
+	}
+
+	z := output.HostedZone
+	details := HostedZoneDetails{
+		HostedZoneInfo: HostedZoneInfo{
+			ID:          strings.TrimPrefix(aws.ToString(z.Id), "/hostedzone/"),
 			Name:        aws.ToString(z.Name),
 			RecordCount: aws.ToInt64(z.ResourceRecordSetCount),
 			IsPrivate:   z.Config != nil && z.Config.PrivateZone,
-			Comment:     comment,
+		},
+	}
+	if z.Config != nil {
+		details.Comment = aws.ToString(z.Config.Comment)
+	}
+	if output.DelegationSet != nil {
+		details.NameServers = output.DelegationSet.NameServers
+	}
+
+	if !details.IsPrivate {
+		dnssec, err := c.client.GetDNSSEC(ctx, &route53.GetDNSSECInput{
+			HostedZoneId: aws.String(zoneID),
 		})
+		if err != nil {
+			return HostedZoneDetails{}, fmt.Errorf("unable to get DNSSEC status of %s: %w", zoneID, err)
+		}
+		if dnssec.Status != nil {
+			details.DNSSECStatus = aws.ToString(dnssec.Status.ServeSignature)
+		}
 	}
 
-	return zones, nil
+	return details, nil
 }
 
 type ResourceRecordSetInfo struct {
@@ -97,3 +150,44 @@ func (c *Route53Client) ListResourceRecordSets(ctx context.Context, zoneID strin
 
 	return records, nil
 }
+
+// MatchHostedZone returns the public zone that holds name: the zone whose
+// name is the longest suffix of it
+func MatchHostedZone(zones []HostedZoneInfo, name string) (HostedZoneInfo, bool) {
+	name = strings.TrimSuffix(strings.ToLower(name), ".") + "."
+	var match HostedZoneInfo
+	longest := 0
+	for _, z := range zones {
+		zone := strings.TrimSuffix(strings.ToLower(z.Name), ".") + "."
+		if z.IsPrivate || (name != zone && !strings.HasSuffix(name, "."+zone)) {
+			continue
+		}
+		if len(zone) > longest {
+			match = z
+			longest = len(zone)
+		}
+	}
+	return match, longest > 0
+}
+
+// UpsertRecord creates or replaces a simple record with a single value
+func (c *Route53Client) UpsertRecord(ctx context.Context, zoneID, name, recordType, value string) error {
+	_, err := c.client.ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(zoneID),
+		ChangeBatch: &types.ChangeBatch{
+			Changes: []types.Change{{
+				Action: types.ChangeActionUpsert,
+				ResourceRecordSet: &types.ResourceRecordSet{
+					Name:            aws.String(name),
+					Type:            types.RRType(recordType),
+					TTL:             aws.Int64(300),
+					ResourceRecords: []types.ResourceRecord{{Value: aws.String(value)}},
+				},
+			}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to upsert %s record %s: %w", recordType, name, err)
+	}
+	return nil
+}
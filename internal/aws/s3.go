@@ -2,16 +2,18 @@ package aws
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 )
 
 type S3Client struct {
@@ -19,7 +21,7 @@ type S3Client struct {
 }
 
 func NewS3Client(ctx context.Context, profile string) (*S3Client, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	cfg, err := loadConfig(ctx, profile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -56,40 +58,49 @@ type ObjectInfo struct {
 	Size         int64
 	LastModified time.Time
 	IsFolder     bool
+	StorageClass string
+	ETag         string
 }
 
+// ListObjects lists the objects and, with a delimiter, the folders under
+// prefix, following every page
 func (c *S3Client) ListObjects(ctx context.Context, bucketName, prefix, delimiter string) ([]ObjectInfo, error) {
-	output, err := c.client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+	var objects []ObjectInfo
+	paginator := s3.NewListObjectsV2Paginator(c.client, &s3.ListObjectsV2Input{
 		Bucket:    aws.String(bucketName),
 		Prefix:    aws.String(prefix),
 		Delimiter: aws.String(delimiter),
 	})
-	if err != nil {
-		return nil, fmt.Errorf("unable to list objects: %w", err)
-	}
 
-	var objects []ObjectInfo
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list objects: %w", err)
+		}
 
-	// Folders (CommonPrefixes)
-	for _, cp := range output.CommonPrefixes {
-		objects = append(objects, ObjectInfo{
-			Key:      aws.ToString(cp.Prefix),
-			IsFolder: true,
-		})
-	}
+		// Folders (CommonPrefixes)
+		for _, cp := range output.CommonPrefixes {
+			objects = append(objects, ObjectInfo{
+				Key:      aws.ToString(cp.Prefix),
+				IsFolder: true,
+			})
+		}
 
-	// Objects
-	for _, obj := range output.Contents {
-		// Skip the folder itself if it's in the list
-		if aws.ToString(obj.Key) == prefix {
-			continue
+		// Objects
+		for _, obj := range output.Contents {
+			// Skip the folder itself if it's in the list
+			if aws.ToString(obj.Key) == prefix {
+				continue
+			}
+			objects = append(objects, ObjectInfo{
+				Key:          aws.ToString(obj.Key),
+				Size:         aws.ToInt64(obj.Size),
+				LastModified: aws.ToTime(obj.LastModified),
+				IsFolder:     false,
+				StorageClass: string(obj.StorageClass),
+				ETag:         strings.Trim(aws.ToString(obj.ETag), `"`),
+			})
 		}
-		objects = append(objects, ObjectInfo{
-			Key:          aws.ToString(obj.Key),
-			Size:         aws.ToInt64(obj.Size),
-			LastModified: aws.ToTime(obj.LastModified),
-			IsFolder:     false,
-		})
 	}
 
 	return objects, nil
@@ -115,6 +126,41 @@ func (c *S3Client) DeleteBucket(ctx context.Context, name string) error {
 	return err
 }
 
+// BucketContents summarizes the objects in a bucket
+type BucketContents struct {
+	Objects   int
+	Size      int64
+	Truncated bool // The bucket holds more than the objects counted
+}
+
+// CountObjects counts a bucket's objects and their size, stopping once limit
+// objects have been seen so huge buckets stay quick to summarize. onPage, if
+// not nil, is called after each page with the objects counted so far.
+func (c *S3Client) CountObjects(ctx context.Context, bucket string, limit int, onPage func(counted int)) (BucketContents, error) {
+	var contents BucketContents
+	paginator := s3.NewListObjectsV2Paginator(c.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+	})
+	for paginator.HasMorePages() {
+		if contents.Objects >= limit {
+			contents.Truncated = true
+			break
+		}
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return contents, fmt.Errorf("unable to count objects: %w", err)
+		}
+		for _, obj := range page.Contents {
+			contents.Objects++
+			contents.Size += aws.ToInt64(obj.Size)
+		}
+		if onPage != nil {
+			onPage(contents.Objects)
+		}
+	}
+	return contents, nil
+}
+
 func (c *S3Client) CreateFolder(ctx context.Context, bucket, prefix string) error {
 	// Ensure prefix ends with /
 	if !strings.HasSuffix(prefix, "/") {
@@ -151,10 +197,20 @@ func (c *S3Client) UploadFile(ctx context.Context, bucket, key, localPath string
 }
 
 func (c *S3Client) DownloadFile(ctx context.Context, bucket, key, localPath string) error {
-	output, err := c.client.GetObject(ctx, &s3.GetObjectInput{
+	return c.DownloadObjectVersion(ctx, bucket, key, "", localPath)
+}
+
+// DownloadObjectVersion downloads a specific version of an object, or the
+// current one when versionID is empty
+func (c *S3Client) DownloadObjectVersion(ctx context.Context, bucket, key, versionID, localPath string) error {
+	input := &s3.GetObjectInput{
 		Bucket: aws.String(bucket),
 		Key:    aws.String(key),
-	})
+	}
+	if versionID != "" {
+		input.VersionId = aws.String(versionID)
+	}
+	output, err := c.client.GetObject(ctx, input)
 	if err != nil {
 		return err
 	}
@@ -169,3 +225,208 @@ func (c *S3Client) DownloadFile(ctx context.Context, bucket, key, localPath stri
 	_, err = io.Copy(file, output.Body)
 	return err
 }
+
+type ObjectVersionInfo struct {
+	Key            string
+	VersionID      string
+	Size           int64
+	LastModified   time.Time
+	IsLatest       bool
+	IsDeleteMarker bool
+}
+
+// ListObjectVersions lists every version and delete marker of a single key, newest first
+func (c *S3Client) ListObjectVersions(ctx context.Context, bucket, key string) ([]ObjectVersionInfo, error) {
+	var versions []ObjectVersionInfo
+	paginator := s3.NewListObjectVersionsPaginator(c.client, &s3.ListObjectVersionsInput{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(key),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list object versions: %w", err)
+		}
+
+		// The prefix also matches longer keys, so keep only exact matches
+		for _, v := range page.Versions {
+			if aws.ToString(v.Key) != key {
+				continue
+			}
+			versions = append(versions, ObjectVersionInfo{
+				Key:          key,
+				VersionID:    aws.ToString(v.VersionId),
+				Size:         aws.ToInt64(v.Size),
+				LastModified: aws.ToTime(v.LastModified),
+				IsLatest:     aws.ToBool(v.IsLatest),
+			})
+		}
+		for _, v := range page.DeleteMarkers {
+			if aws.ToString(v.Key) != key {
+				continue
+			}
+			versions = append(versions, ObjectVersionInfo{
+				Key:            key,
+				VersionID:      aws.ToString(v.VersionId),
+				LastModified:   aws.ToTime(v.LastModified),
+				IsLatest:       aws.ToBool(v.IsLatest),
+				IsDeleteMarker: true,
+			})
+		}
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].LastModified.After(versions[j].LastModified)
+	})
+
+	return versions, nil
+}
+
+// DeleteObjectVersion permanently deletes one version of an object. Deleting
+// a delete marker restores the version beneath it.
+func (c *S3Client) DeleteObjectVersion(ctx context.Context, bucket, key, versionID string) error {
+	_, err := c.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(key),
+		VersionId: aws.String(versionID),
+	})
+	return err
+}
+
+// GetBucketVersioning returns the versioning status of a bucket: Enabled,
+// Suspended or Disabled when it was never enabled
+func (c *S3Client) GetBucketVersioning(ctx context.Context, bucket string) (string, error) {
+	output, err := c.client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{Bucket: aws.String(bucket)})
+	if err != nil {
+		return "", fmt.Errorf("unable to get bucket versioning: %w", err)
+	}
+	if output.Status == "" {
+		return "Disabled", nil
+	}
+	return string(output.Status), nil
+}
+
+type PublicAccessBlock struct {
+	BlockPublicAcls       bool
+	IgnorePublicAcls      bool
+	BlockPublicPolicy     bool
+	RestrictPublicBuckets bool
+}
+
+type BucketDetails struct {
+	Name              string
+	Region            string
+	Versioning        string // Enabled, Suspended or Disabled when never enabled
+	MFADelete         string
+	Encryption        string // Default SSE algorithm, empty when not configured
+	KMSKeyID          string
+	BucketKeyEnabled  bool
+	PublicAccessBlock *PublicAccessBlock // nil when not configured
+	Policy            string             // Empty when the bucket has no policy
+	// Errors holds the failure of each setting that could not be read, e.g. on
+	// access denied, keyed by "region", "versioning", "encryption",
+	// "public-access-block" or "policy"
+	Errors map[string]string
+}
+
+// bucketSettingNotFoundCodes are returned when an optional bucket setting has
+// never been configured, which is a valid state rather than an error
+var bucketSettingNotFoundCodes = map[string]bool{
+	"ServerSideEncryptionConfigurationNotFoundError": true,
+	"NoSuchPublicAccessBlockConfiguration":           true,
+	"NoSuchBucketPolicy":                             true,
+}
+
+func isBucketSettingNotFound(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && bucketSettingNotFoundCodes[apiErr.ErrorCode()]
+}
+
+// GetBucketDetails reads the region, versioning, encryption, public access
+// block and policy of a bucket. A setting that cannot be read is recorded in
+// Errors so the others can still be shown.
+func (c *S3Client) GetBucketDetails(ctx context.Context, name string) (*BucketDetails, error) {
+	details := &BucketDetails{Name: name, Errors: map[string]string{}}
+
+	location, err := c.client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{Bucket: aws.String(name)})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchBucket" {
+			return nil, fmt.Errorf("unable to get bucket %s: %w", name, err)
+		}
+		details.Errors["region"] = err.Error()
+	} else {
+		switch location.LocationConstraint {
+		case "":
+			details.Region = "us-east-1"
+		case types.BucketLocationConstraintEu:
+			details.Region = "eu-west-1"
+		default:
+			details.Region = string(location.LocationConstraint)
+		}
+	}
+
+	// Requests outside the bucket's region are answered with a redirect
+	inRegion := func(o *s3.Options) {
+		if details.Region != "" {
+			o.Region = details.Region
+		}
+	}
+
+	versioning, err := c.client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{Bucket: aws.String(name)}, inRegion)
+	if err != nil {
+		details.Errors["versioning"] = err.Error()
+	} else {
+		details.Versioning = string(versioning.Status)
+		if details.Versioning == "" {
+			details.Versioning = "Disabled"
+		}
+		details.MFADelete = string(versioning.MFADelete)
+		if details.MFADelete == "" {
+			details.MFADelete = "Disabled"
+		}
+	}
+
+	encryption, err := c.client.GetBucketEncryption(ctx, &s3.GetBucketEncryptionInput{Bucket: aws.String(name)}, inRegion)
+	if err != nil {
+		if !isBucketSettingNotFound(err) {
+			details.Errors["encryption"] = err.Error()
+		}
+	} else if encryption.ServerSideEncryptionConfiguration != nil {
+		for _, rule := range encryption.ServerSideEncryptionConfiguration.Rules {
+			if rule.ApplyServerSideEncryptionByDefault == nil {
+				continue
+			}
+			details.Encryption = string(rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm)
+			details.KMSKeyID = aws.ToString(rule.ApplyServerSideEncryptionByDefault.KMSMasterKeyID)
+			details.BucketKeyEnabled = aws.ToBool(rule.BucketKeyEnabled)
+			break
+		}
+	}
+
+	block, err := c.client.GetPublicAccessBlock(ctx, &s3.GetPublicAccessBlockInput{Bucket: aws.String(name)}, inRegion)
+	if err != nil {
+		if !isBucketSettingNotFound(err) {
+			details.Errors["public-access-block"] = err.Error()
+		}
+	} else if cfg := block.PublicAccessBlockConfiguration; cfg != nil {
+		details.PublicAccessBlock = &PublicAccessBlock{
+			BlockPublicAcls:       aws.ToBool(cfg.BlockPublicAcls),
+			IgnorePublicAcls:      aws.ToBool(cfg.IgnorePublicAcls),
+			BlockPublicPolicy:     aws.ToBool(cfg.BlockPublicPolicy),
+			RestrictPublicBuckets: aws.ToBool(cfg.RestrictPublicBuckets),
+		}
+	}
+
+	policy, err := c.client.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{Bucket: aws.String(name)}, inRegion)
+	if err != nil {
+		if !isBucketSettingNotFound(err) {
+			details.Errors["policy"] = err.Error()
+		}
+	} else {
+		details.Policy = aws.ToString(policy.Policy)
+	}
+
+	return details, nil
+}
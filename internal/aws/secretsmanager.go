@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
 )
 
@@ -15,7 +14,7 @@ type SecretsManagerClient struct {
 }
 
 func NewSecretsManagerClient(ctx context.Context, profile string) (*SecretsManagerClient, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	cfg, err := loadConfig(ctx, profile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -33,6 +32,10 @@ type SecretInfo struct {
 	LastRotated *time.Time
 }
 
+// BinarySecretValue is returned in place of a binary secret value, which is
+// not shown or edited
+const BinarySecretValue = "Binary secret values are not supported yet"
+
 func (c *SecretsManagerClient) GetSecretValue(ctx context.Context, secretID string) (string, error) {
 	output, err := c.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
 		SecretId: aws.String(secretID),
@@ -45,25 +48,105 @@ func (c *SecretsManagerClient) GetSecretValue(ctx context.Context, secretID stri
 		return *output.SecretString, nil
 	}
 
-	return "Binary secret values are not supported yet", nil
+	return BinarySecretValue, nil
 }
 
 func (c *SecretsManagerClient) ListSecrets(ctx context.Context) ([]SecretInfo, error) {
-	output, err := c.client.ListSecrets(ctx, &secretsmanager.ListSecretsInput{})
+	var secrets []SecretInfo
+	paginator := secretsmanager.NewListSecretsPaginator(c.client, &secretsmanager.ListSecretsInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list secrets: %w", err)
+		}
+
+		for _, s := range page.SecretList {
+			secrets = append(secrets, SecretInfo{
+				Name:        aws.ToString(s.Name),
+				ARN:         aws.ToString(s.ARN),
+				Description: aws.ToString(s.Description),
+				LastChanged: s.LastChangedDate,
+				LastRotated: s.LastRotatedDate,
+			})
+		}
+	}
+
+	return secrets, nil
+}
+
+// CreateSecret stores a new secret with a string value and returns its ARN
+func (c *SecretsManagerClient) CreateSecret(ctx context.Context, name, value string) (string, error) {
+	output, err := c.client.CreateSecret(ctx, &secretsmanager.CreateSecretInput{
+		Name:         aws.String(name),
+		SecretString: aws.String(value),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("unable to list secrets: %w", err)
+		return "", fmt.Errorf("unable to create secret: %w", err)
 	}
+	return aws.ToString(output.ARN), nil
+}
 
-	var secrets []SecretInfo
-	for _, s := range output.SecretList {
-		secrets = append(secrets, SecretInfo{
-			Name:        aws.ToString(s.Name),
-			ARN:         aws.ToString(s.ARN),
-			Description: aws.ToString(s.Description),
-			LastChanged: s.LastChangedDate,
-			LastRotated: s.LastRotatedDate,
-		})
+// PutSecretValue stores a new version of a secret, which becomes its current
+// value, and returns the version ID
+func (c *SecretsManagerClient) PutSecretValue(ctx context.Context, secretID, value string) (string, error) {
+	output, err := c.client.PutSecretValue(ctx, &secretsmanager.PutSecretValueInput{
+		SecretId:     aws.String(secretID),
+		SecretString: aws.String(value),
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to put secret value: %w", err)
+	}
+	return aws.ToString(output.VersionId), nil
+}
+
+// SecretRotation is how a secret is rotated. Schedule is the rotation rule,
+// either a schedule expression or a number of days.
+type SecretRotation struct {
+	Enabled      bool
+	LambdaARN    string
+	Schedule     string
+	Window       string // How long each rotation may take, e.g. "3h"
+	LastRotated  *time.Time
+	NextRotation *time.Time
+}
+
+// DescribeSecret reads the rotation settings of a secret
+func (c *SecretsManagerClient) DescribeSecret(ctx context.Context, secretID string) (*SecretRotation, error) {
+	output, err := c.client.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
+		SecretId: aws.String(secretID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to describe secret: %w", err)
 	}
 
-	return secrets, nil
+	rotation := &SecretRotation{
+		Enabled:      aws.ToBool(output.RotationEnabled),
+		LambdaARN:    aws.ToString(output.RotationLambdaARN),
+		LastRotated:  output.LastRotatedDate,
+		NextRotation: output.NextRotationDate,
+	}
+	if rules := output.RotationRules; rules != nil {
+		switch {
+		case rules.ScheduleExpression != nil:
+			rotation.Schedule = aws.ToString(rules.ScheduleExpression)
+		case rules.AutomaticallyAfterDays != nil:
+			rotation.Schedule = fmt.Sprintf("every %d days", aws.ToInt64(rules.AutomaticallyAfterDays))
+		}
+		rotation.Window = aws.ToString(rules.Duration)
+	}
+	return rotation, nil
+}
+
+// RotateSecret starts a rotation of a secret with its configured rotation
+// function right away and returns the ID of the version being created
+func (c *SecretsManagerClient) RotateSecret(ctx context.Context, secretID string) (string, error) {
+	output, err := c.client.RotateSecret(ctx, &secretsmanager.RotateSecretInput{
+		SecretId:          aws.String(secretID),
+		RotateImmediately: aws.Bool(true),
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to rotate secret: %w", err)
+	}
+	return aws.ToString(output.VersionId), nil
 }
@@ -7,7 +7,6 @@ import (
 	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/sns"
 )
 
@@ -16,7 +15,7 @@ type SNSClient struct {
 }
 
 func NewSNSClient(ctx context.Context, profile string) (*SNSClient, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	cfg, err := loadConfig(ctx, profile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -99,3 +98,21 @@ func (c *SNSClient) ListTopics(ctx context.Context) ([]TopicInfo, error) {
 
 	return topics, nil
 }
+
+// Publish sends a message to a topic and returns its message ID. FIFO topics
+// get a fixed message group and a content-derived deduplication ID.
+func (c *SNSClient) Publish(ctx context.Context, topicArn, message string) (string, error) {
+	input := &sns.PublishInput{
+		TopicArn: aws.String(topicArn),
+		Message:  aws.String(message),
+	}
+	if strings.HasSuffix(topicArn, ".fifo") {
+		input.MessageGroupId = aws.String("aws-tui")
+		input.MessageDeduplicationId = aws.String(deduplicationID(message))
+	}
+	output, err := c.client.Publish(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("unable to publish message: %w", err)
+	}
+	return aws.ToString(output.MessageId), nil
+}
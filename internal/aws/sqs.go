@@ -2,11 +2,15 @@ package aws
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
 )
@@ -16,7 +20,7 @@ type SQSClient struct {
 }
 
 func NewSQSClient(ctx context.Context, profile string) (*SQSClient, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	cfg, err := loadConfig(ctx, profile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -28,6 +32,7 @@ func NewSQSClient(ctx context.Context, profile string) (*SQSClient, error) {
 
 type QueueInfo struct {
 	URL                string
+	ARN                string
 	Name               string
 	Type               string // Standard or FIFO
 	MessagesAvailable  string
@@ -35,54 +40,166 @@ type QueueInfo struct {
 	MessagesNotVisible string
 	VisibilityTimeout  string
 	CreatedTimestamp   string
+	DLQARN             string // Dead-letter target from the redrive policy, if any
+	DLQName            string
 }
 
-func (c *SQSClient) ListQueues(ctx context.Context) ([]QueueInfo, error) {
-	output, err := c.client.ListQueues(ctx, &sqs.ListQueuesInput{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to list queues: %w", err)
-	}
+// redrivePolicy is the JSON document stored in the RedrivePolicy queue attribute
+type redrivePolicy struct {
+	DeadLetterTargetArn string `json:"deadLetterTargetArn"`
+}
 
+// queueNameFromARN returns the queue name, the last segment of an SQS ARN
+func queueNameFromARN(arn string) string {
+	return arn[strings.LastIndex(arn, ":")+1:]
+}
+
+func (c *SQSClient) ListQueues(ctx context.Context) ([]QueueInfo, error) {
 	var queues []QueueInfo
-	for _, url := range output.QueueUrls {
-		name := url[strings.LastIndex(url, "/")+1:]
-		qType := "Standard"
-		if strings.HasSuffix(name, ".fifo") {
-			qType = "FIFO"
-		}
+	paginator := sqs.NewListQueuesPaginator(c.client, &sqs.ListQueuesInput{
+		MaxResults: aws.Int32(1000),
+	})
 
-		// Fetch attributes for each queue
-		attrOutput, err := c.client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
-			QueueUrl: aws.String(url),
-			AttributeNames: []types.QueueAttributeName{
-				types.QueueAttributeNameApproximateNumberOfMessages,
-				types.QueueAttributeNameApproximateNumberOfMessagesDelayed,
-				types.QueueAttributeNameApproximateNumberOfMessagesNotVisible,
-				types.QueueAttributeNameVisibilityTimeout,
-				types.QueueAttributeNameCreatedTimestamp,
-			},
-		})
-
-		var avail, delayed, notVisible, timeout, created string
-		if err == nil {
-			avail = attrOutput.Attributes[string(types.QueueAttributeNameApproximateNumberOfMessages)]
-			delayed = attrOutput.Attributes[string(types.QueueAttributeNameApproximateNumberOfMessagesDelayed)]
-			notVisible = attrOutput.Attributes[string(types.QueueAttributeNameApproximateNumberOfMessagesNotVisible)]
-			timeout = attrOutput.Attributes[string(types.QueueAttributeNameVisibilityTimeout)]
-			created = attrOutput.Attributes[string(types.QueueAttributeNameCreatedTimestamp)]
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list queues: %w", err)
 		}
 
-		queues = append(queues, QueueInfo{
-			URL:                url,
-			Name:               name,
-			Type:               qType,
-			MessagesAvailable:  avail,
-			MessagesDelayed:    delayed,
-			MessagesNotVisible: notVisible,
-			VisibilityTimeout:  timeout,
-			CreatedTimestamp:   created,
-		})
+		for _, url := range page.QueueUrls {
+			name := url[strings.LastIndex(url, "/")+1:]
+			qType := "Standard"
+			if strings.HasSuffix(name, ".fifo") {
+				qType = "FIFO"
+			}
+
+			// Fetch attributes for each queue
+			attrOutput, err := c.client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+				QueueUrl: aws.String(url),
+				AttributeNames: []types.QueueAttributeName{
+					types.QueueAttributeNameApproximateNumberOfMessages,
+					types.QueueAttributeNameApproximateNumberOfMessagesDelayed,
+					types.QueueAttributeNameApproximateNumberOfMessagesNotVisible,
+					types.QueueAttributeNameVisibilityTimeout,
+					types.QueueAttributeNameCreatedTimestamp,
+					types.QueueAttributeNameQueueArn,
+					types.QueueAttributeNameRedrivePolicy,
+				},
+			})
+
+			var avail, delayed, notVisible, timeout, created, arn, dlqARN string
+			if err == nil {
+				avail = attrOutput.Attributes[string(types.QueueAttributeNameApproximateNumberOfMessages)]
+				delayed = attrOutput.Attributes[string(types.QueueAttributeNameApproximateNumberOfMessagesDelayed)]
+				notVisible = attrOutput.Attributes[string(types.QueueAttributeNameApproximateNumberOfMessagesNotVisible)]
+				timeout = attrOutput.Attributes[string(types.QueueAttributeNameVisibilityTimeout)]
+				created = attrOutput.Attributes[string(types.QueueAttributeNameCreatedTimestamp)]
+				arn = attrOutput.Attributes[string(types.QueueAttributeNameQueueArn)]
+
+				var policy redrivePolicy
+				if raw := attrOutput.Attributes[string(types.QueueAttributeNameRedrivePolicy)]; raw != "" && json.Unmarshal([]byte(raw), &policy) == nil {
+					dlqARN = policy.DeadLetterTargetArn
+				}
+			}
+
+			var dlqName string
+			if dlqARN != "" {
+				dlqName = queueNameFromARN(dlqARN)
+			}
+
+			queues = append(queues, QueueInfo{
+				URL:                url,
+				ARN:                arn,
+				Name:               name,
+				Type:               qType,
+				MessagesAvailable:  avail,
+				MessagesDelayed:    delayed,
+				MessagesNotVisible: notVisible,
+				VisibilityTimeout:  timeout,
+				CreatedTimestamp:   created,
+				DLQARN:             dlqARN,
+				DLQName:            dlqName,
+			})
+		}
 	}
 
 	return queues, nil
 }
+
+// QueueAttributes are the editable settings of a queue. Periods are in
+// seconds and the message size in bytes.
+type QueueAttributes struct {
+	VisibilityTimeout      int
+	MessageRetentionPeriod int
+	MaximumMessageSize     int
+	DelaySeconds           int
+}
+
+// GetQueueAttributes returns the editable settings of a queue
+func (c *SQSClient) GetQueueAttributes(ctx context.Context, queueURL string) (QueueAttributes, error) {
+	output, err := c.client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl: aws.String(queueURL),
+		AttributeNames: []types.QueueAttributeName{
+			types.QueueAttributeNameVisibilityTimeout,
+			types.QueueAttributeNameMessageRetentionPeriod,
+			types.QueueAttributeNameMaximumMessageSize,
+			types.QueueAttributeNameDelaySeconds,
+		},
+	})
+	if err != nil {
+		return QueueAttributes{}, fmt.Errorf("unable to get queue attributes: %w", err)
+	}
+
+	value := func(name types.QueueAttributeName) int {
+		n, _ := strconv.Atoi(output.Attributes[string(name)])
+		return n
+	}
+	return QueueAttributes{
+		VisibilityTimeout:      value(types.QueueAttributeNameVisibilityTimeout),
+		MessageRetentionPeriod: value(types.QueueAttributeNameMessageRetentionPeriod),
+		MaximumMessageSize:     value(types.QueueAttributeNameMaximumMessageSize),
+		DelaySeconds:           value(types.QueueAttributeNameDelaySeconds),
+	}, nil
+}
+
+// SetQueueAttributes applies the editable settings to a queue
+func (c *SQSClient) SetQueueAttributes(ctx context.Context, queueURL string, attrs QueueAttributes) error {
+	_, err := c.client.SetQueueAttributes(ctx, &sqs.SetQueueAttributesInput{
+		QueueUrl: aws.String(queueURL),
+		Attributes: map[string]string{
+			string(types.QueueAttributeNameVisibilityTimeout):      strconv.Itoa(attrs.VisibilityTimeout),
+			string(types.QueueAttributeNameMessageRetentionPeriod): strconv.Itoa(attrs.MessageRetentionPeriod),
+			string(types.QueueAttributeNameMaximumMessageSize):     strconv.Itoa(attrs.MaximumMessageSize),
+			string(types.QueueAttributeNameDelaySeconds):           strconv.Itoa(attrs.DelaySeconds),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to set queue attributes: %w", err)
+	}
+	return nil
+}
+
+// SendMessage sends a message to a queue and returns its message ID. FIFO
+// queues get a fixed message group and a content-derived deduplication ID.
+func (c *SQSClient) SendMessage(ctx context.Context, queueURL, body string) (string, error) {
+	input := &sqs.SendMessageInput{
+		QueueUrl:    aws.String(queueURL),
+		MessageBody: aws.String(body),
+	}
+	if strings.HasSuffix(queueURL, ".fifo") {
+		input.MessageGroupId = aws.String("aws-tui")
+		input.MessageDeduplicationId = aws.String(deduplicationID(body))
+	}
+	output, err := c.client.SendMessage(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("unable to send message: %w", err)
+	}
+	return aws.ToString(output.MessageId), nil
+}
+
+// deduplicationID derives a FIFO deduplication ID from the message and the
+// current time, so sending the same test message twice is not dropped
+func deduplicationID(body string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%d", body, time.Now().UnixNano())))
+	return hex.EncodeToString(sum[:])
+}
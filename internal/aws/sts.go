@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
 
@@ -14,7 +13,7 @@ type STSClient struct {
 }
 
 func NewSTSClient(ctx context.Context, profile string) (*STSClient, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	cfg, err := loadConfig(ctx, profile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -31,6 +30,8 @@ type IdentityInfo struct {
 	UserId  string
 	Alias   string
 	Region  string
+	// Profile is the profile the identity was fetched with
+	Profile string
 }
 
 func (c *STSClient) GetCallerIdentity(ctx context.Context) (*IdentityInfo, error) {
@@ -0,0 +1,80 @@
+package aws
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+// TagFilter narrows the listings that support it to resources carrying a tag.
+// An empty Value matches any value of the key.
+type TagFilter struct {
+	Key   string
+	Value string
+}
+
+var (
+	tagFilterMu sync.RWMutex
+	tagFilter   TagFilter
+)
+
+// ParseTagFilter parses "Key=Value" or a bare "Key"
+func ParseTagFilter(s string) TagFilter {
+	key, value, _ := strings.Cut(strings.TrimSpace(s), "=")
+	return TagFilter{Key: strings.TrimSpace(key), Value: strings.TrimSpace(value)}
+}
+
+// SetTagFilter applies the filter to every listing made afterwards. The zero
+// filter turns filtering off.
+func SetTagFilter(f TagFilter) {
+	tagFilterMu.Lock()
+	defer tagFilterMu.Unlock()
+	tagFilter = f
+}
+
+// CurrentTagFilter returns the filter in effect
+func CurrentTagFilter() TagFilter {
+	tagFilterMu.RLock()
+	defer tagFilterMu.RUnlock()
+	return tagFilter
+}
+
+// IsZero reports whether no filter is set
+func (f TagFilter) IsZero() bool {
+	return f.Key == ""
+}
+
+func (f TagFilter) String() string {
+	if f.Value == "" {
+		return f.Key
+	}
+	return f.Key + "=" + f.Value
+}
+
+// ec2Filters returns the describe filter for EC2 APIs, which filter server side
+func (f TagFilter) ec2Filters() []ec2types.Filter {
+	if f.IsZero() {
+		return nil
+	}
+	if f.Value == "" {
+		return []ec2types.Filter{{Name: aws.String("tag-key"), Values: []string{f.Key}}}
+	}
+	return []ec2types.Filter{{Name: aws.String("tag:" + f.Key), Values: []string{f.Value}}}
+}
+
+// matchesRDS reports whether an RDS resource's tags satisfy the filter. The
+// RDS describe filters do not support tags, so this runs client side.
+func (f TagFilter) matchesRDS(tags []rdstypes.Tag) bool {
+	if f.IsZero() {
+		return true
+	}
+	for _, t := range tags {
+		if aws.ToString(t.Key) == f.Key && (f.Value == "" || aws.ToString(t.Value) == f.Value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,218 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+// Tag is a key/value pair attached to a resource
+type Tag struct {
+	Key   string
+	Value string
+}
+
+// Limits shared by the services that can be tagged
+const (
+	MaxTagKeyLength   = 128
+	MaxTagValueLength = 256
+)
+
+// ValidateTag checks a tag against the limits the services enforce, so an
+// invalid tag is caught before a request is made
+func ValidateTag(key, value string) error {
+	switch {
+	case strings.TrimSpace(key) == "":
+		return errors.New("tag key is required")
+	case utf8.RuneCountInString(key) > MaxTagKeyLength:
+		return fmt.Errorf("tag key is %d characters long, the limit is %d", utf8.RuneCountInString(key), MaxTagKeyLength)
+	case utf8.RuneCountInString(value) > MaxTagValueLength:
+		return fmt.Errorf("tag value is %d characters long, the limit is %d", utf8.RuneCountInString(value), MaxTagValueLength)
+	case strings.HasPrefix(strings.ToLower(key), "aws:"):
+		return errors.New("tag keys starting with aws: are reserved")
+	}
+	return nil
+}
+
+// Tagger reads and changes the tags of a service's resources. The ID is the
+// instance or volume ID for EC2, the ARN for RDS and the bucket name for S3.
+type Tagger interface {
+	GetTags(ctx context.Context, id string) ([]Tag, error)
+	// SetTags adds the tags, overwriting the values of existing keys
+	SetTags(ctx context.Context, id string, tags []Tag) error
+	RemoveTags(ctx context.Context, id string, keys []string) error
+}
+
+// sortTags orders tags by key, as the consoles show them
+func sortTags(tags []Tag) []Tag {
+	slices.SortFunc(tags, func(a, b Tag) int { return strings.Compare(a.Key, b.Key) })
+	return tags
+}
+
+func (c *EC2ResourcesClient) GetTags(ctx context.Context, id string) ([]Tag, error) {
+	var tags []Tag
+	paginator := ec2.NewDescribeTagsPaginator(c.ec2Client, &ec2.DescribeTagsInput{
+		Filters: []ec2types.Filter{{Name: aws.String("resource-id"), Values: []string{id}}},
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get tags of %s: %w", id, err)
+		}
+		for _, t := range page.Tags {
+			tags = append(tags, Tag{Key: aws.ToString(t.Key), Value: aws.ToString(t.Value)})
+		}
+	}
+
+	return sortTags(tags), nil
+}
+
+func (c *EC2ResourcesClient) SetTags(ctx context.Context, id string, tags []Tag) error {
+	ec2Tags := make([]ec2types.Tag, len(tags))
+	for i, t := range tags {
+		ec2Tags[i] = ec2types.Tag{Key: aws.String(t.Key), Value: aws.String(t.Value)}
+	}
+	_, err := c.ec2Client.CreateTags(ctx, &ec2.CreateTagsInput{Resources: []string{id}, Tags: ec2Tags})
+	return err
+}
+
+func (c *EC2ResourcesClient) RemoveTags(ctx context.Context, id string, keys []string) error {
+	ec2Tags := make([]ec2types.Tag, len(keys))
+	for i, k := range keys {
+		ec2Tags[i] = ec2types.Tag{Key: aws.String(k)}
+	}
+	_, err := c.ec2Client.DeleteTags(ctx, &ec2.DeleteTagsInput{Resources: []string{id}, Tags: ec2Tags})
+	return err
+}
+
+func (c *RDSClient) GetTags(ctx context.Context, arn string) ([]Tag, error) {
+	output, err := c.client.ListTagsForResource(ctx, &rds.ListTagsForResourceInput{ResourceName: aws.String(arn)})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get tags of %s: %w", arn, err)
+	}
+
+	tags := make([]Tag, len(output.TagList))
+	for i, t := range output.TagList {
+		tags[i] = Tag{Key: aws.ToString(t.Key), Value: aws.ToString(t.Value)}
+	}
+	return sortTags(tags), nil
+}
+
+func (c *RDSClient) SetTags(ctx context.Context, arn string, tags []Tag) error {
+	rdsTags := make([]rdstypes.Tag, len(tags))
+	for i, t := range tags {
+		rdsTags[i] = rdstypes.Tag{Key: aws.String(t.Key), Value: aws.String(t.Value)}
+	}
+	_, err := c.client.AddTagsToResource(ctx, &rds.AddTagsToResourceInput{ResourceName: aws.String(arn), Tags: rdsTags})
+	return err
+}
+
+func (c *RDSClient) RemoveTags(ctx context.Context, arn string, keys []string) error {
+	_, err := c.client.RemoveTagsFromResource(ctx, &rds.RemoveTagsFromResourceInput{ResourceName: aws.String(arn), TagKeys: keys})
+	return err
+}
+
+// bucketRegion returns the option that sends a request to the bucket's
+// region; requests outside it are answered with a redirect
+func (c *S3Client) bucketRegion(ctx context.Context, bucket string) (func(*s3.Options), error) {
+	location, err := c.client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{Bucket: aws.String(bucket)})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get region of bucket %s: %w", bucket, err)
+	}
+	region := string(location.LocationConstraint)
+	switch location.LocationConstraint {
+	case "":
+		region = "us-east-1"
+	case s3types.BucketLocationConstraintEu:
+		region = "eu-west-1"
+	}
+	return func(o *s3.Options) { o.Region = region }, nil
+}
+
+// getBucketTags reads a bucket's tag set. A bucket that was never tagged has
+// no tag set, which is returned as no tags.
+func (c *S3Client) getBucketTags(ctx context.Context, bucket string, inRegion func(*s3.Options)) ([]Tag, error) {
+	output, err := c.client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{Bucket: aws.String(bucket)}, inRegion)
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchTagSet" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to get tags of bucket %s: %w", bucket, err)
+	}
+
+	tags := make([]Tag, len(output.TagSet))
+	for i, t := range output.TagSet {
+		tags[i] = Tag{Key: aws.ToString(t.Key), Value: aws.ToString(t.Value)}
+	}
+	return sortTags(tags), nil
+}
+
+// putBucketTags replaces a bucket's tag set; S3 has no call to change a
+// single tag
+func (c *S3Client) putBucketTags(ctx context.Context, bucket string, tags []Tag, inRegion func(*s3.Options)) error {
+	if len(tags) == 0 {
+		_, err := c.client.DeleteBucketTagging(ctx, &s3.DeleteBucketTaggingInput{Bucket: aws.String(bucket)}, inRegion)
+		return err
+	}
+
+	tagSet := make([]s3types.Tag, len(tags))
+	for i, t := range tags {
+		tagSet[i] = s3types.Tag{Key: aws.String(t.Key), Value: aws.String(t.Value)}
+	}
+	_, err := c.client.PutBucketTagging(ctx, &s3.PutBucketTaggingInput{
+		Bucket:  aws.String(bucket),
+		Tagging: &s3types.Tagging{TagSet: tagSet},
+	}, inRegion)
+	return err
+}
+
+func (c *S3Client) GetTags(ctx context.Context, bucket string) ([]Tag, error) {
+	inRegion, err := c.bucketRegion(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+	return c.getBucketTags(ctx, bucket, inRegion)
+}
+
+func (c *S3Client) SetTags(ctx context.Context, bucket string, tags []Tag) error {
+	inRegion, err := c.bucketRegion(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	current, err := c.getBucketTags(ctx, bucket, inRegion)
+	if err != nil {
+		return err
+	}
+	for _, t := range tags {
+		current = slices.DeleteFunc(current, func(c Tag) bool { return c.Key == t.Key })
+		current = append(current, t)
+	}
+	return c.putBucketTags(ctx, bucket, current, inRegion)
+}
+
+func (c *S3Client) RemoveTags(ctx context.Context, bucket string, keys []string) error {
+	inRegion, err := c.bucketRegion(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	current, err := c.getBucketTags(ctx, bucket, inRegion)
+	if err != nil {
+		return err
+	}
+	current = slices.DeleteFunc(current, func(t Tag) bool { return slices.Contains(keys, t.Key) })
+	return c.putBucketTags(ctx, bucket, current, inRegion)
+}
@@ -0,0 +1,61 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// DefaultRequestTimeout bounds a single AWS operation unless overridden
+const DefaultRequestTimeout = 15 * time.Second
+
+// ErrRequestTimeout is returned when an AWS operation exceeds the request timeout
+var ErrRequestTimeout = errors.New("request timed out")
+
+var requestTimeout = DefaultRequestTimeout
+
+// untimedOperations stream object bodies whose duration depends on their size
+var untimedOperations = map[string]bool{
+	"S3.GetObject": true,
+	"S3.PutObject": true,
+}
+
+// SetRequestTimeout changes the per-operation timeout. It must be called
+// before any client is created; a non-positive value disables the timeout.
+func SetRequestTimeout(d time.Duration) {
+	requestTimeout = d
+}
+
+// WithTimeout derives a context that expires after the request timeout
+func WithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if requestTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, requestTimeout)
+}
+
+// addRequestTimeout applies the request timeout to every operation and turns
+// an expired deadline into ErrRequestTimeout
+func addRequestTimeout(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("AWSTUIRequestTimeout",
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			service := awsmiddleware.GetServiceID(ctx)
+			operation := awsmiddleware.GetOperationName(ctx)
+			if untimedOperations[service+"."+operation] {
+				return next.HandleInitialize(ctx, in)
+			}
+
+			tctx, cancel := WithTimeout(ctx)
+			defer cancel()
+
+			out, metadata, err := next.HandleInitialize(tctx, in)
+			if err != nil && ctx.Err() == nil && errors.Is(tctx.Err(), context.DeadlineExceeded) {
+				err = fmt.Errorf("%s %s: %w after %s", service, operation, ErrRequestTimeout, requestTimeout)
+			}
+			return out, metadata, err
+		}), middleware.After)
+}
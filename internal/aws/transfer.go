@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/transfer"
 )
 
@@ -14,7 +13,7 @@ type TransferClient struct {
 }
 
 func NewTransferClient(ctx context.Context, profile string) (*TransferClient, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	cfg, err := loadConfig(ctx, profile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -98,3 +97,26 @@ func (c *TransferClient) ListUsers(ctx context.Context, serverId string) ([]Tran
 
 	return users, nil
 }
+
+// StartServer brings an offline server back online
+func (c *TransferClient) StartServer(ctx context.Context, serverId string) error {
+	_, err := c.client.StartServer(ctx, &transfer.StartServerInput{
+		ServerId: aws.String(serverId),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to start server: %w", err)
+	}
+	return nil
+}
+
+// StopServer takes a server offline. Stopped servers are not billed for the
+// endpoint, but keep their users and configuration.
+func (c *TransferClient) StopServer(ctx context.Context, serverId string) error {
+	_, err := c.client.StopServer(ctx, &transfer.StopServerInput{
+		ServerId: aws.String(serverId),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to stop server: %w", err)
+	}
+	return nil
+}
@@ -5,8 +5,8 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 )
 
 type EC2Client struct {
@@ -14,7 +14,7 @@ type EC2Client struct {
 }
 
 func NewEC2Client(ctx context.Context, profile string) (*EC2Client, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
+	cfg, err := loadConfig(ctx, profile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -33,26 +33,30 @@ type VPCInfo struct {
 }
 
 func (c *EC2Client) ListVpcs(ctx context.Context) ([]VPCInfo, error) {
-	output, err := c.client.DescribeVpcs(ctx, &ec2.DescribeVpcsInput{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to list VPCs: %w", err)
-	}
+	var vpcs []VPCInfo
+	paginator := ec2.NewDescribeVpcsPaginator(c.client, &ec2.DescribeVpcsInput{})
 
-	vpcs := make([]VPCInfo, len(output.Vpcs))
-	for i, v := range output.Vpcs {
-		name := ""
-		for _, tag := range v.Tags {
-			if aws.ToString(tag.Key) == "Name" {
-				name = aws.ToString(tag.Value)
-				break
-			}
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list VPCs: %w", err)
 		}
-		vpcs[i] = VPCInfo{
-			ID:        aws.ToString(v.VpcId),
-			CidrBlock: aws.ToString(v.CidrBlock),
-			State:     string(v.State),
-			IsDefault: aws.ToBool(v.IsDefault),
-			Name:      name,
+
+		for _, v := range page.Vpcs {
+			name := ""
+			for _, tag := range v.Tags {
+				if aws.ToString(tag.Key) == "Name" {
+					name = aws.ToString(tag.Value)
+					break
+				}
+			}
+			vpcs = append(vpcs, VPCInfo{
+				ID:        aws.ToString(v.VpcId),
+				CidrBlock: aws.ToString(v.CidrBlock),
+				State:     string(v.State),
+				IsDefault: aws.ToBool(v.IsDefault),
+				Name:      name,
+			})
 		}
 	}
 
@@ -69,27 +73,31 @@ type SubnetInfo struct {
 }
 
 func (c *EC2Client) ListSubnets(ctx context.Context) ([]SubnetInfo, error) {
-	output, err := c.client.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to list subnets: %w", err)
-	}
+	var subnets []SubnetInfo
+	paginator := ec2.NewDescribeSubnetsPaginator(c.client, &ec2.DescribeSubnetsInput{})
 
-	subnets := make([]SubnetInfo, len(output.Subnets))
-	for i, s := range output.Subnets {
-		name := ""
-		for _, tag := range s.Tags {
-			if aws.ToString(tag.Key) == "Name" {
-				name = aws.ToString(tag.Value)
-				break
-			}
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list subnets: %w", err)
 		}
-		subnets[i] = SubnetInfo{
-			ID:               aws.ToString(s.SubnetId),
-			VpcID:            aws.ToString(s.VpcId),
-			CidrBlock:        aws.ToString(s.CidrBlock),
-			AvailabilityZone: aws.ToString(s.AvailabilityZone),
-			State:            string(s.State),
-			Name:             name,
+
+		for _, s := range page.Subnets {
+			name := ""
+			for _, tag := range s.Tags {
+				if aws.ToString(tag.Key) == "Name" {
+					name = aws.ToString(tag.Value)
+					break
+				}
+			}
+			subnets = append(subnets, SubnetInfo{
+				ID:               aws.ToString(s.SubnetId),
+				VpcID:            aws.ToString(s.VpcId),
+				CidrBlock:        aws.ToString(s.CidrBlock),
+				AvailabilityZone: aws.ToString(s.AvailabilityZone),
+				State:            string(s.State),
+				Name:             name,
+			})
 		}
 	}
 
@@ -106,33 +114,37 @@ type NatGatewayInfo struct {
 }
 
 func (c *EC2Client) ListNatGateways(ctx context.Context) ([]NatGatewayInfo, error) {
-	output, err := c.client.DescribeNatGateways(ctx, &ec2.DescribeNatGatewaysInput{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to list NAT gateways: %w", err)
-	}
+	var nats []NatGatewayInfo
+	paginator := ec2.NewDescribeNatGatewaysPaginator(c.client, &ec2.DescribeNatGatewaysInput{})
 
-	nats := make([]NatGatewayInfo, len(output.NatGateways))
-	for i, n := range output.NatGateways {
-		name := ""
-		for _, tag := range n.Tags {
-			if aws.ToString(tag.Key) == "Name" {
-				name = aws.ToString(tag.Value)
-				break
-			}
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list NAT gateways: %w", err)
 		}
-		publicIP := ""
-		privateIP := ""
-		if len(n.NatGatewayAddresses) > 0 {
-			publicIP = aws.ToString(n.NatGatewayAddresses[0].PublicIp)
-			privateIP = aws.ToString(n.NatGatewayAddresses[0].PrivateIp)
-		}
-		nats[i] = NatGatewayInfo{
-			ID:        aws.ToString(n.NatGatewayId),
-			VpcID:     aws.ToString(n.VpcId),
-			State:     string(n.State),
-			PublicIP:  publicIP,
-			PrivateIP: privateIP,
-			Name:      name,
+
+		for _, n := range page.NatGateways {
+			name := ""
+			for _, tag := range n.Tags {
+				if aws.ToString(tag.Key) == "Name" {
+					name = aws.ToString(tag.Value)
+					break
+				}
+			}
+			publicIP := ""
+			privateIP := ""
+			if len(n.NatGatewayAddresses) > 0 {
+				publicIP = aws.ToString(n.NatGatewayAddresses[0].PublicIp)
+				privateIP = aws.ToString(n.NatGatewayAddresses[0].PrivateIp)
+			}
+			nats = append(nats, NatGatewayInfo{
+				ID:        aws.ToString(n.NatGatewayId),
+				VpcID:     aws.ToString(n.VpcId),
+				State:     string(n.State),
+				PublicIP:  publicIP,
+				PrivateIP: privateIP,
+				Name:      name,
+			})
 		}
 	}
 
@@ -146,24 +158,28 @@ type RouteTableInfo struct {
 }
 
 func (c *EC2Client) ListRouteTables(ctx context.Context) ([]RouteTableInfo, error) {
-	output, err := c.client.DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to list route tables: %w", err)
-	}
+	var rts []RouteTableInfo
+	paginator := ec2.NewDescribeRouteTablesPaginator(c.client, &ec2.DescribeRouteTablesInput{})
 
-	rts := make([]RouteTableInfo, len(output.RouteTables))
-	for i, r := range output.RouteTables {
-		name := ""
-		for _, tag := range r.Tags {
-			if aws.ToString(tag.Key) == "Name" {
-				name = aws.ToString(tag.Value)
-				break
-			}
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list route tables: %w", err)
 		}
-		rts[i] = RouteTableInfo{
-			ID:    aws.ToString(r.RouteTableId),
-			VpcID: aws.ToString(r.VpcId),
-			Name:  name,
+
+		for _, r := range page.RouteTables {
+			name := ""
+			for _, tag := range r.Tags {
+				if aws.ToString(tag.Key) == "Name" {
+					name = aws.ToString(tag.Value)
+					break
+				}
+			}
+			rts = append(rts, RouteTableInfo{
+				ID:    aws.ToString(r.RouteTableId),
+				VpcID: aws.ToString(r.VpcId),
+				Name:  name,
+			})
 		}
 	}
 
@@ -202,3 +218,52 @@ func (c *EC2Client) ListVpnGateways(ctx context.Context) ([]VpnGatewayInfo, erro
 
 	return vpns, nil
 }
+
+// FlowLogInfo describes a flow log publishing the traffic of a VPC
+type FlowLogInfo struct {
+	ID string
+	// Status is the state of the flow log, e.g. ACTIVE
+	Status string
+	// DeliverStatus is SUCCESS or FAILED, with the reason in DeliverError
+	DeliverStatus string
+	DeliverError  string
+	// DestinationType is cloud-watch-logs, s3 or kinesis-data-firehose
+	DestinationType string
+	// Destination is the log group name, or the ARN of the bucket or stream
+	Destination string
+	TrafficType string
+}
+
+// GetFlowLogsForVpc returns the flow logs attached to a VPC; none means flow
+// logs are disabled
+func (c *EC2Client) GetFlowLogsForVpc(ctx context.Context, vpcID string) ([]FlowLogInfo, error) {
+	var flowLogs []FlowLogInfo
+	paginator := ec2.NewDescribeFlowLogsPaginator(c.client, &ec2.DescribeFlowLogsInput{
+		Filter: []types.Filter{{Name: aws.String("resource-id"), Values: []string{vpcID}}},
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to describe flow logs of %s: %w", vpcID, err)
+		}
+
+		for _, f := range page.FlowLogs {
+			destination := aws.ToString(f.LogGroupName)
+			if destination == "" {
+				destination = aws.ToString(f.LogDestination)
+			}
+			flowLogs = append(flowLogs, FlowLogInfo{
+				ID:              aws.ToString(f.FlowLogId),
+				Status:          aws.ToString(f.FlowLogStatus),
+				DeliverStatus:   aws.ToString(f.DeliverLogsStatus),
+				DeliverError:    aws.ToString(f.DeliverLogsErrorMessage),
+				DestinationType: string(f.LogDestinationType),
+				Destination:     destination,
+				TrafficType:     string(f.TrafficType),
+			})
+		}
+	}
+
+	return flowLogs, nil
+}
@@ -15,7 +15,7 @@ type WAFClient struct {
 }
 
 func NewWAFClient(ctx context.Context, profile string, region string) (*WAFClient, error) {
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile), config.WithRegion(region))
+	cfg, err := loadConfig(ctx, profile, config.WithRegion(region))
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config: %w", err)
 	}
@@ -38,19 +38,26 @@ func (c *WAFClient) ListWebACLs(ctx context.Context, scope types.Scope) ([]WebAC
 		Limit: aws.Int32(100),
 	}
 
-	output, err := c.client.ListWebACLs(ctx, input)
-	if err != nil {
-		return nil, fmt.Errorf("unable to list web ACLs: %w", err)
-	}
-
 	var webACLs []WebACLInfo
-	for _, acl := range output.WebACLs {
-		webACLs = append(webACLs, WebACLInfo{
-			Name:        aws.ToString(acl.Name),
-			ID:          aws.ToString(acl.Id),
-			ARN:         aws.ToString(acl.ARN),
-			Description: aws.ToString(acl.Description),
-		})
+	for {
+		page, err := c.client.ListWebACLs(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list web ACLs: %w", err)
+		}
+
+		for _, acl := range page.WebACLs {
+			webACLs = append(webACLs, WebACLInfo{
+				Name:        aws.ToString(acl.Name),
+				ID:          aws.ToString(acl.Id),
+				ARN:         aws.ToString(acl.ARN),
+				Description: aws.ToString(acl.Description),
+			})
+		}
+
+		if aws.ToString(page.NextMarker) == "" {
+			break
+		}
+		input.NextMarker = page.NextMarker
 	}
 
 	return webACLs, nil
@@ -69,19 +76,26 @@ func (c *WAFClient) ListIPSets(ctx context.Context, scope types.Scope) ([]IPSetI
 		Limit: aws.Int32(100),
 	}
 
-	output, err := c.client.ListIPSets(ctx, input)
-	if err != nil {
-		return nil, fmt.Errorf("unable to list IP sets: %w", err)
-	}
-
 	var ipSets []IPSetInfo
-	for _, ipSet := range output.IPSets {
-		ipSets = append(ipSets, IPSetInfo{
-			Name:        aws.ToString(ipSet.Name),
-			ID:          aws.ToString(ipSet.Id),
-			ARN:         aws.ToString(ipSet.ARN),
-			Description: aws.ToString(ipSet.Description),
-		})
+	for {
+		page, err := c.client.ListIPSets(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list IP sets: %w", err)
+		}
+
+		for _, ipSet := range page.IPSets {
+			ipSets = append(ipSets, IPSetInfo{
+				Name:        aws.ToString(ipSet.Name),
+				ID:          aws.ToString(ipSet.Id),
+				ARN:         aws.ToString(ipSet.ARN),
+				Description: aws.ToString(ipSet.Description),
+			})
+		}
+
+		if aws.ToString(page.NextMarker) == "" {
+			break
+		}
+		input.NextMarker = page.NextMarker
 	}
 
 	return ipSets, nil
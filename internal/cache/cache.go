@@ -3,6 +3,8 @@ package cache
 import (
 	"sync"
 	"time"
+
+	"github.com/giovannirossini/aws-tui/internal/logging"
 )
 
 // CacheEntry represents a cached value with TTL
@@ -12,7 +14,8 @@ type CacheEntry struct {
 	LastUpdate time.Time
 }
 
-// Cache is a thread-safe TTL-based cache
+// Cache is a thread-safe TTL-based cache. Stored values are shared between
+// goroutines, so callers must copy slices before modifying them.
 type Cache struct {
 	mu      sync.RWMutex
 	entries map[string]CacheEntry
@@ -45,14 +48,17 @@ func (c *Cache) Get(key string) (interface{}, bool) {
 
 	entry, exists := c.entries[key]
 	if !exists {
+		logging.Printf("cache miss key=%s", key)
 		return nil, false
 	}
 
 	if time.Now().After(entry.ExpiresAt) {
 		// Entry expired, return miss (will be cleaned up by background job)
+		logging.Printf("cache expired key=%s", key)
 		return nil, false
 	}
 
+	logging.Printf("cache hit key=%s", key)
 	return entry.Value, true
 }
 
@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestConcurrentAccess hammers one cache from many goroutines, as the views'
+// commands do, so that `go test -race` catches unguarded access
+func TestConcurrentAccess(t *testing.T) {
+	c := New()
+	const goroutines, iterations = 32, 500
+
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range iterations {
+				key := fmt.Sprintf("profile:%d:ec2:%d", g%4, i%16)
+				switch i % 8 {
+				case 0, 1, 2:
+					// Some entries expire right away for CleanExpired to find
+					ttl := time.Minute
+					if i%3 == 0 {
+						ttl = -time.Second
+					}
+					c.Set(key, []string{key}, ttl)
+				case 3, 4:
+					if v, ok := c.Get(key); ok {
+						if s, ok := v.([]string); !ok || len(s) != 1 {
+							t.Errorf("Get(%q) = %v, want a one-element []string", key, v)
+						}
+					}
+					c.GetAge(key)
+				case 5:
+					c.Delete(key)
+				case 6:
+					c.DeletePrefix(fmt.Sprintf("profile:%d:", g%4))
+					c.Size()
+				case 7:
+					c.CleanExpired()
+					if i%64 == 7 {
+						c.Clear()
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	c.Set("key", "value", time.Minute)
+	if v, ok := c.Get("key"); !ok || v != "value" {
+		t.Fatalf("Get after concurrent use = %v, %v; want value, true", v, ok)
+	}
+}
@@ -11,6 +11,8 @@ const (
 	TTLIAMUsers             = 5 * time.Minute  // IAM ListUsers
 	TTLIAMUserDetails       = 2 * time.Minute  // IAM User detailed info
 	TTLIAMAccessKeys        = 2 * time.Minute  // IAM Access Keys
+	TTLIAMRoles             = 5 * time.Minute  // IAM ListRoles with last use
+	TTLIAMGroups            = 5 * time.Minute  // IAM ListGroups and group members
 	TTLS3Buckets            = 10 * time.Minute // S3 ListBuckets
 	TTLLongS3Objects        = 2 * time.Minute  // S3 ListObjects (large buckets)
 	TTLShortS3Objects       = 30 * time.Second // S3 ListObjects (small/active buckets)
@@ -39,6 +41,7 @@ const (
 	TTLDynamoDBResources    = 10 * time.Minute // DynamoDB resources
 	TTLTransferResources    = 10 * time.Minute // AWS Transfer resources
 	TTLAPIGatewayResources  = 10 * time.Minute // API Gateway resources
+	TTLResourceCounts       = 10 * time.Minute // Home screen resource counts
 )
 
 // KeyBuilder provides methods to build cache keys
@@ -66,6 +69,26 @@ func (kb *KeyBuilder) IAMUserDetails(userName string) string {
 	return fmt.Sprintf("%s:iam:user:%s", kb.profile, userName)
 }
 
+// IAMUserPolicies returns the cache key for the policies of a specific IAM user
+func (kb *KeyBuilder) IAMUserPolicies(userName string) string {
+	return fmt.Sprintf("%s:iam:user:%s:policies", kb.profile, userName)
+}
+
+// IAMRoles returns the cache key for IAM roles list
+func (kb *KeyBuilder) IAMRoles() string {
+	return fmt.Sprintf("%s:iam:roles", kb.profile)
+}
+
+// IAMGroups returns the cache key for IAM groups list
+func (kb *KeyBuilder) IAMGroups() string {
+	return fmt.Sprintf("%s:iam:groups", kb.profile)
+}
+
+// IAMGroupMembers returns the cache key for the members of a specific IAM group
+func (kb *KeyBuilder) IAMGroupMembers(groupName string) string {
+	return fmt.Sprintf("%s:iam:group:%s:members", kb.profile, groupName)
+}
+
 // S3Buckets returns the cache key for S3 buckets list
 func (kb *KeyBuilder) S3Buckets() string {
 	return fmt.Sprintf("%s:s3:buckets", kb.profile)
@@ -76,6 +99,11 @@ func (kb *KeyBuilder) S3Objects(bucket, prefix string) string {
 	return fmt.Sprintf("%s:s3:bucket:%s:prefix:%s", kb.profile, bucket, prefix)
 }
 
+// S3BucketDetails returns the cache key for the settings of a specific bucket
+func (kb *KeyBuilder) S3BucketDetails(bucket string) string {
+	return fmt.Sprintf("%s:s3:details:%s", kb.profile, bucket)
+}
+
 // ProfilePrefix returns the prefix for all cache keys for this profile
 func (kb *KeyBuilder) ProfilePrefix() string {
 	return kb.profile + ":"
@@ -235,3 +263,8 @@ func (kb *KeyBuilder) TransferUsers(serverId string) string {
 func (kb *KeyBuilder) APIGatewayResources(resourceType string) string {
 	return fmt.Sprintf("%s:apigateway:%s", kb.profile, resourceType)
 }
+
+// ResourceCount returns the cache key for the home screen count of a service
+func (kb *KeyBuilder) ResourceCount(service string) string {
+	return fmt.Sprintf("%s:counts:%s", kb.profile, service)
+}
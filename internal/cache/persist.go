@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/giovannirossini/aws-tui/internal/logging"
+)
+
+// storedEntry is the on-disk form of a CacheEntry. The value is gob-encoded
+// separately so a single unregistered type doesn't prevent saving the rest.
+type storedEntry struct {
+	Data       []byte
+	ExpiresAt  time.Time
+	LastUpdate time.Time
+}
+
+// Register makes a value type persistable. Every concrete type stored in the
+// cache must be registered for it to survive a restart; others are skipped.
+func Register(value interface{}) {
+	gob.Register(value)
+}
+
+// DefaultPath returns the cache file location under the user's cache directory
+func DefaultPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("could not get cache directory: %w", err)
+	}
+	return filepath.Join(dir, "aws-tui", "cache.gob"), nil
+}
+
+// Save writes all unexpired entries to path
+func (c *Cache) Save(path string) error {
+	c.mu.RLock()
+	now := time.Now()
+	stored := make(map[string]storedEntry, len(c.entries))
+	for key, entry := range c.entries {
+		if now.After(entry.ExpiresAt) {
+			continue
+		}
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(&entry.Value); err != nil {
+			logging.Printf("cache skip key=%s: %v", key, err)
+			continue
+		}
+		stored[key] = storedEntry{
+			Data:       buf.Bytes(),
+			ExpiresAt:  entry.ExpiresAt,
+			LastUpdate: entry.LastUpdate,
+		}
+	}
+	c.mu.RUnlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("could not create cache directory: %w", err)
+	}
+
+	// Write to a temp file and rename so a crash never leaves a truncated cache
+	tmp, err := os.CreateTemp(filepath.Dir(path), "cache-*.tmp")
+	if err != nil {
+		return fmt.Errorf("could not create cache file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := gob.NewEncoder(tmp).Encode(stored); err != nil {
+		tmp.Close()
+		return fmt.Errorf("could not encode cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("could not write cache file: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// Load reads entries previously written by Save, dropping expired ones.
+// A missing file is not an error.
+func (c *Cache) Load(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("could not open cache file: %w", err)
+	}
+	defer f.Close()
+
+	var stored map[string]storedEntry
+	if err := gob.NewDecoder(f).Decode(&stored); err != nil {
+		return fmt.Errorf("could not decode cache: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for key, s := range stored {
+		if now.After(s.ExpiresAt) {
+			continue
+		}
+		var value interface{}
+		if err := gob.NewDecoder(bytes.NewReader(s.Data)).Decode(&value); err != nil {
+			logging.Printf("cache skip key=%s: %v", key, err)
+			continue
+		}
+		c.entries[key] = CacheEntry{
+			Value:      value,
+			ExpiresAt:  s.ExpiresAt,
+			LastUpdate: s.LastUpdate,
+		}
+	}
+
+	return nil
+}
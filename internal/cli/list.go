@@ -0,0 +1,148 @@
+// Package cli runs aws-tui without the terminal UI, printing resource lists
+// for scripts
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+// listFunc fetches one kind of resource with a fresh client for the profile
+type listFunc func(ctx context.Context, profile string) (any, error)
+
+// lister adapts a client constructor and one of its list methods to a listFunc
+func lister[C, T any](newClient func(context.Context, string) (C, error), list func(C, context.Context) ([]T, error)) listFunc {
+	return func(ctx context.Context, profile string) (any, error) {
+		client, err := newClient(ctx, profile)
+		if err != nil {
+			return nil, err
+		}
+		return list(client, ctx)
+	}
+}
+
+// resources are the lists that can be printed, named service:resource like
+// the command palette's subcommands
+var resources = map[string]listFunc{
+	"acm:certificates":               lister(aws.NewACMClient, (*aws.ACMClient).ListCertificates),
+	"apigateway:rest-apis":           lister(aws.NewAPIGatewayClient, (*aws.APIGatewayClient).ListRestAPIs),
+	"apigateway:http-apis":           lister(aws.NewAPIGatewayClient, (*aws.APIGatewayClient).ListHTTPAPIs),
+	"backup:plans":                   lister(aws.NewBackupClient, (*aws.BackupClient).ListBackupPlans),
+	"backup:jobs":                    lister(aws.NewBackupClient, (*aws.BackupClient).ListBackupJobs),
+	"cf:distributions":               lister(aws.NewCloudFrontClient, (*aws.CloudFrontClient).ListDistributions),
+	"cw:log-groups":                  lister(aws.NewCloudWatchClient, (*aws.CloudWatchClient).ListLogGroups),
+	"cw:alarms":                      lister(aws.NewCloudWatchClient, (*aws.CloudWatchClient).ListAlarms),
+	"dms:tasks":                      lister(aws.NewDMSClient, (*aws.DMSClient).ListReplicationTasks),
+	"dms:endpoints":                  lister(aws.NewDMSClient, (*aws.DMSClient).ListEndpoints),
+	"dms:instances":                  lister(aws.NewDMSClient, (*aws.DMSClient).ListReplicationInstances),
+	"dynamodb:tables":                lister(aws.NewDynamoDBClient, (*aws.DynamoDBClient).ListTables),
+	"ec2:instances":                  lister(aws.NewEC2ResourcesClient, (*aws.EC2ResourcesClient).ListInstances),
+	"ec2:security-groups":            lister(aws.NewEC2ResourcesClient, (*aws.EC2ResourcesClient).ListSecurityGroups),
+	"ec2:volumes":                    lister(aws.NewEC2ResourcesClient, (*aws.EC2ResourcesClient).ListVolumes),
+	"ec2:target-groups":              lister(aws.NewEC2ResourcesClient, (*aws.EC2ResourcesClient).ListTargetGroups),
+	"ec2:elastic-ips":                lister(aws.NewEC2ResourcesClient, (*aws.EC2ResourcesClient).ListElasticIPs),
+	"ecr:repositories":               lister(aws.NewECRClient, (*aws.ECRClient).ListRepositories),
+	"ecs:clusters":                   lister(aws.NewECSClient, (*aws.ECSClient).ListClusters),
+	"ecs:task-definitions":           lister(aws.NewECSClient, (*aws.ECSClient).ListAllTaskDefinitions),
+	"efs:file-systems":               lister(aws.NewEFSClient, (*aws.EFSClient).ListFileSystems),
+	"elasticache:replication-groups": lister(aws.NewElastiCacheClient, (*aws.ElastiCacheClient).ListReplicationGroups),
+	"elasticache:clusters":           lister(aws.NewElastiCacheClient, (*aws.ElastiCacheClient).ListCacheClusters),
+	"iam:users":                      lister(aws.NewIAMClient, (*aws.IAMClient).ListUsers),
+	"kms:keys":                       lister(aws.NewKMSClient, (*aws.KMSClient).ListKeys),
+	"lambda:functions":               lister(aws.NewLambdaClient, (*aws.LambdaClient).ListFunctions),
+	"msk:clusters":                   lister(aws.NewMSKClient, (*aws.MSKClient).ListClustersV2),
+	"msk:configurations":             lister(aws.NewMSKClient, (*aws.MSKClient).ListConfigurations),
+	"rds:instances":                  lister(aws.NewRDSClient, (*aws.RDSClient).ListInstances),
+	"rds:clusters":                   lister(aws.NewRDSClient, (*aws.RDSClient).ListClusters),
+	"rds:snapshots":                  lister(aws.NewRDSClient, (*aws.RDSClient).ListSnapshots),
+	"rds:subnet-groups":              lister(aws.NewRDSClient, (*aws.RDSClient).ListSubnetGroups),
+	"route53:hosted-zones":           lister(aws.NewRoute53Client, (*aws.Route53Client).ListHostedZones),
+	"s3:buckets":                     lister(aws.NewS3Client, (*aws.S3Client).ListBuckets),
+	"secrets:secrets":                lister(aws.NewSecretsManagerClient, (*aws.SecretsManagerClient).ListSecrets),
+	"sns:topics":                     lister(aws.NewSNSClient, (*aws.SNSClient).ListTopics),
+	"sqs:queues":                     lister(aws.NewSQSClient, (*aws.SQSClient).ListQueues),
+	"transfer:servers":               lister(aws.NewTransferClient, (*aws.TransferClient).ListServers),
+	"vpc:vpcs":                       lister(aws.NewEC2Client, (*aws.EC2Client).ListVpcs),
+	"vpc:subnets":                    lister(aws.NewEC2Client, (*aws.EC2Client).ListSubnets),
+	"vpc:nat-gateways":               lister(aws.NewEC2Client, (*aws.EC2Client).ListNatGateways),
+	"vpc:route-tables":               lister(aws.NewEC2Client, (*aws.EC2Client).ListRouteTables),
+	"vpc:vpn-gateways":               lister(aws.NewEC2Client, (*aws.EC2Client).ListVpnGateways),
+}
+
+// defaultResources is listed when only a service is given
+var defaultResources = map[string]string{
+	"acm":         "acm:certificates",
+	"apigateway":  "apigateway:rest-apis",
+	"backup":      "backup:plans",
+	"cf":          "cf:distributions",
+	"cw":          "cw:log-groups",
+	"dms":         "dms:tasks",
+	"dynamodb":    "dynamodb:tables",
+	"ec2":         "ec2:instances",
+	"ecr":         "ecr:repositories",
+	"ecs":         "ecs:clusters",
+	"efs":         "efs:file-systems",
+	"elasticache": "elasticache:replication-groups",
+	"iam":         "iam:users",
+	"kms":         "kms:keys",
+	"lambda":      "lambda:functions",
+	"msk":         "msk:clusters",
+	"rds":         "rds:instances",
+	"route53":     "route53:hosted-zones",
+	"s3":          "s3:buckets",
+	"secrets":     "secrets:secrets",
+	"sns":         "sns:topics",
+	"sqs":         "sqs:queues",
+	"transfer":    "transfer:servers",
+	"vpc":         "vpc:vpcs",
+}
+
+// Options configures a headless run
+type Options struct {
+	Target  string // service or service:resource, e.g. s3 or ec2:instances
+	Output  string // json or csv
+	Profile string
+}
+
+// Targets returns every accepted service:resource name, sorted
+func Targets() []string {
+	targets := make([]string, 0, len(resources))
+	for name := range resources {
+		targets = append(targets, name)
+	}
+	sort.Strings(targets)
+	return targets
+}
+
+// List fetches the target's resources and writes them to w
+func List(ctx context.Context, opts Options, w io.Writer) error {
+	name := strings.ToLower(opts.Target)
+	if full, ok := defaultResources[name]; ok {
+		name = full
+	}
+	fetch, ok := resources[name]
+	if !ok {
+		return fmt.Errorf("unknown resource %q, expected one of: %s", opts.Target, strings.Join(Targets(), ", "))
+	}
+
+	var write func(io.Writer, any) error
+	switch strings.ToLower(opts.Output) {
+	case "json", "":
+		write = writeJSON
+	case "csv":
+		write = writeCSV
+	default:
+		return fmt.Errorf("unknown output format %q, expected json or csv", opts.Output)
+	}
+
+	items, err := fetch(ctx, opts.Profile)
+	if err != nil {
+		return err
+	}
+	return write(w, items)
+}
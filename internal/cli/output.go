@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+)
+
+func writeJSON(w io.Writer, items any) error {
+	// Print an empty list as [] rather than null
+	if v := reflect.ValueOf(items); v.Kind() == reflect.Slice && v.IsNil() {
+		items = []any{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(items)
+}
+
+// writeCSV writes a slice with one row per element: one column per exported
+// field for structs or pointers to them, and a single Value column for
+// anything else. A nil pointer is written as an empty row.
+func writeCSV(w io.Writer, items any) error {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice {
+		return fmt.Errorf("cannot write %T as CSV", items)
+	}
+	elem := v.Type().Elem()
+	if elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	structs := elem.Kind() == reflect.Struct && elem != reflect.TypeFor[time.Time]()
+
+	var fields []int
+	header := []string{"Value"}
+	if structs {
+		header = nil
+		for i := range elem.NumField() {
+			if f := elem.Field(i); f.IsExported() {
+				fields = append(fields, i)
+				header = append(header, f.Name)
+			}
+		}
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for i := range v.Len() {
+		item := v.Index(i)
+		row := make([]string, len(header))
+		switch {
+		case !structs:
+			row[0] = csvValue(item)
+		case item.Kind() == reflect.Pointer && item.IsNil():
+		default:
+			item = reflect.Indirect(item)
+			for j, f := range fields {
+				row[j] = csvValue(item.Field(f))
+			}
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvValue flattens a field to one cell: times as RFC 3339, lists joined
+// with semicolons and nested structs as JSON
+func csvValue(v reflect.Value) string {
+	if t, ok := v.Interface().(time.Time); ok {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return ""
+		}
+		return csvValue(v.Elem())
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.String {
+			values := make([]string, v.Len())
+			for i := range v.Len() {
+				values[i] = v.Index(i).String()
+			}
+			return strings.Join(values, ";")
+		}
+		fallthrough
+	case reflect.Struct, reflect.Map:
+		b, err := json.Marshal(v.Interface())
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
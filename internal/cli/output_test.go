@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type outputRow struct {
+	Name    string
+	Count   int
+	Created time.Time
+	Tags    []string
+	hidden  string
+}
+
+func TestWriteJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		items any
+		want  string
+	}{
+		{"nil slice", []outputRow(nil), "[]\n"},
+		{"empty slice", []string{}, "[]\n"},
+		{"strings", []string{"a", "b"}, "[\n  \"a\",\n  \"b\"\n]\n"},
+		{"structs", []struct{ Name string }{{"web"}}, "[\n  {\n    \"Name\": \"web\"\n  }\n]\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := writeJSON(&b, tt.items); err != nil {
+				t.Fatalf("writeJSON: %v", err)
+			}
+			if b.String() != tt.want {
+				t.Errorf("writeJSON = %q, want %q", b.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	row := outputRow{Name: "web", Count: 2, Created: created, Tags: []string{"a", "b"}, hidden: "x"}
+
+	tests := []struct {
+		name    string
+		items   any
+		want    string
+		wantErr bool
+	}{
+		{"structs", []outputRow{row}, "Name,Count,Created,Tags\nweb,2,2024-05-01T12:00:00Z,a;b\n", false},
+		{"empty", []outputRow{}, "Name,Count,Created,Tags\n", false},
+		{"pointers", []*outputRow{&row, nil}, "Name,Count,Created,Tags\nweb,2,2024-05-01T12:00:00Z,a;b\n,,,\n", false},
+		{"strings", []string{"a", "b,c"}, "Value\na\n\"b,c\"\n", false},
+		{"times", []time.Time{created}, "Value\n2024-05-01T12:00:00Z\n", false},
+		{"any", []any{"a", nil, 3}, "Value\na\n\n3\n", false},
+		{"not a slice", outputRow{}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			err := writeCSV(&b, tt.items)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("writeCSV error = %v, want error %t", err, tt.wantErr)
+			}
+			if b.String() != tt.want {
+				t.Errorf("writeCSV = %q, want %q", b.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCSVValue(t *testing.T) {
+	name := "web"
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{"string", "web", "web"},
+		{"int", 42, "42"},
+		{"bool", true, "true"},
+		{"time", time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), "2024-05-01T12:00:00Z"},
+		{"zero time", time.Time{}, ""},
+		{"pointer", &name, "web"},
+		{"nil pointer", (*string)(nil), ""},
+		{"strings", []string{"a", "b"}, "a;b"},
+		{"ints", []int{1, 2}, "[1,2]"},
+		{"map", map[string]int{"a": 1}, `{"a":1}`},
+		{"struct", struct{ ID string }{"i-1"}, `{"ID":"i-1"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := csvValue(reflect.ValueOf(tt.value)); got != tt.want {
+				t.Errorf("csvValue(%#v) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// Config holds the preferences changed from inside the app. It is saved as
+// JSON so it can also be edited by hand.
+type Config struct {
+	// HiddenColumns lists the column titles hidden in the tables of each
+	// service, keyed by the service's breadcrumb name (e.g. "EC2")
+	HiddenColumns map[string][]string `json:"hidden_columns,omitempty"`
+	// Keys remaps global actions to other keys, e.g. {"refresh": "f5"}. Keys
+	// are named as Bubble Tea reports them, such as "ctrl+p" or "f5".
+	Keys map[string]string `json:"keys,omitempty"`
+	// Bell rings the terminal bell when a long operation completes, such as
+	// a restore job starting or a watched ECS deployment finishing
+	Bell bool `json:"bell,omitempty"`
+	// Compact drops the blank line between table rows and the descriptions
+	// of menu entries; toggled with z
+	Compact bool `json:"compact,omitempty"`
+	// FIPS and DualStack make every client call the FIPS or dual-stack
+	// (IPv6) endpoints of the services, like the --fips and --dualstack flags
+	FIPS      bool `json:"fips,omitempty"`
+	DualStack bool `json:"dualstack,omitempty"`
+	// Attention adds a panel to the home screen counting resources that need
+	// a look, such as alarms firing or certificates about to expire. It is
+	// off by default as its checks cost a few API calls per start.
+	Attention bool `json:"attention,omitempty"`
+}
+
+// DefaultPath returns the config file location under the user's config directory
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("could not get config directory: %w", err)
+	}
+	return filepath.Join(dir, "aws-tui", "config.json"), nil
+}
+
+// Load reads the config at path. A missing file is an empty config.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read config: %w", err)
+	}
+
+	var c Config
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("could not parse config %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes the config to path
+func (c *Config) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode config: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("could not create config directory: %w", err)
+	}
+
+	// Write to a temp file and rename so a crash never leaves a truncated config
+	tmp, err := os.CreateTemp(filepath.Dir(path), "config-*.tmp")
+	if err != nil {
+		return fmt.Errorf("could not create config file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("could not write config file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("could not write config file: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// ColumnHidden reports whether the column is hidden in the service's tables
+func (c *Config) ColumnHidden(service, title string) bool {
+	return slices.Contains(c.HiddenColumns[service], title)
+}
+
+// SetColumnHidden hides or shows the column in the service's tables
+func (c *Config) SetColumnHidden(service, title string, hidden bool) {
+	titles := slices.DeleteFunc(slices.Clone(c.HiddenColumns[service]), func(t string) bool { return t == title })
+	if hidden {
+		titles = append(titles, title)
+	}
+	if c.HiddenColumns == nil {
+		c.HiddenColumns = make(map[string][]string)
+	}
+	if len(titles) == 0 {
+		delete(c.HiddenColumns, service)
+		return
+	}
+	c.HiddenColumns[service] = titles
+}
@@ -0,0 +1,49 @@
+package logging
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// EnvVar enables debug logging when set to "1"
+const EnvVar = "AWS_TUI_DEBUG"
+
+var enabled bool
+
+// Setup enables debug logging to debug.log under the user's cache directory.
+// It returns the log file, which the caller should close on exit.
+func Setup() (*os.File, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("could not get cache directory: %w", err)
+	}
+	dir = filepath.Join(dir, "aws-tui")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("could not create %s: %w", dir, err)
+	}
+
+	f, err := tea.LogToFile(filepath.Join(dir, "debug.log"), "aws-tui")
+	if err != nil {
+		return nil, fmt.Errorf("could not open debug log: %w", err)
+	}
+	log.SetFlags(log.LstdFlags | log.Lmicroseconds)
+	enabled = true
+	return f, nil
+}
+
+// Enabled reports whether debug logging has been set up
+func Enabled() bool {
+	return enabled
+}
+
+// Printf writes a timestamped entry to the debug log when logging is enabled
+func Printf(format string, args ...interface{}) {
+	if !enabled {
+		return
+	}
+	log.Printf(format, args...)
+}
@@ -0,0 +1,34 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+// handleAccessDenied shows a permissions error as a toast instead of replacing
+// the view, so a profile that can list resources but not describe them keeps a
+// usable list. It reports false when the error should be handled as usual,
+// e.g. when the list itself could not be loaded.
+func (m *Model) handleAccessDenied(msg tea.Msg) (tea.Cmd, bool) {
+	err, ok := msg.(error)
+	if !ok {
+		return nil, false
+	}
+	action, denied := aws.AccessDenied(err)
+	if !denied {
+		return nil, false
+	}
+	if l := m.activeList(); l == nil || len(l.Items()) == 0 {
+		return nil, false
+	}
+	return m.showMutedToast(accessDeniedText(action)), true
+}
+
+// accessDeniedText names the denied IAM action when it is known
+func accessDeniedText(action string) string {
+	text := "Insufficient permissions for this action"
+	if action != "" {
+		text += " (" + action + ")"
+	}
+	return text
+}
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+// ACMCertificateDetailsMsg carries the certificate picked for deletion
+type ACMCertificateDetailsMsg *aws.CertificateDetails
+
+type ACMDeletedMsg string
+
+// fetchCertificateForDelete looks up whether the certificate is still in use
+// before asking to delete it
+func (m ACMModel) fetchCertificateForDelete(arn string) tea.Cmd {
+	return func() tea.Msg {
+		client, err := aws.NewACMClient(context.Background(), m.profile)
+		if err != nil {
+			return ACMErrorMsg(err)
+		}
+		details, err := client.GetCertificateDetails(context.Background(), arn)
+		if err != nil {
+			return ACMErrorMsg(err)
+		}
+		return ACMCertificateDetailsMsg(details)
+	}
+}
+
+func (m ACMModel) deleteCertificate(arn string) tea.Cmd {
+	return armed(func() tea.Msg {
+		client, err := aws.NewACMClient(context.Background(), m.profile)
+		if err != nil {
+			return ACMErrorMsg(err)
+		}
+		if err := client.DeleteCertificate(context.Background(), arn); err != nil {
+			return ACMErrorMsg(err)
+		}
+		m.cache.Delete(m.cacheKeys.ACMResources("certificates"))
+		return ACMDeletedMsg(arn)
+	})
+}
+
+// openConfirmDelete asks to delete the certificate, which is not in use
+func (m *ACMModel) openConfirmDelete() {
+	cert := m.selectedCert
+	m.state = ACMStateConfirmDelete
+	m.confirm = NewConfirmDialog("Confirm Deletion", "Are you sure you want to delete "+
+		lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render(cert.DomainName),
+		true, func() tea.Cmd { return m.deleteCertificate(cert.ARN) })
+	m.confirm.Width = 60
+	if len(cert.SubjectAlternativeNames) > 1 {
+		m.confirm.Detail = " " + m.styles.StatusMuted.Render("Also covers "+strings.Join(cert.SubjectAlternativeNames[1:], ", "))
+	}
+}
+
+// renderDeleteBlocked explains that ACM refuses to delete a certificate that
+// is still associated with other resources
+func (m ACMModel) renderDeleteBlocked() string {
+	cert := m.selectedCert
+	var s strings.Builder
+	s.WriteString(" " + m.styles.Error.Bold(true).Render("✘ Certificate in use") + "\n\n")
+	s.WriteString(fmt.Sprintf(" %s is used by %d resource(s) and cannot be deleted:\n\n", cert.DomainName, len(cert.InUseBy)))
+	for _, arn := range cert.InUseBy {
+		s.WriteString("  • " + arn + "\n")
+	}
+	s.WriteString("\n " + m.styles.StatusMuted.Render("Remove it from these resources first. Press any key to continue..."))
+	w, _ := GetMainContainerSize(m.width, m.height)
+	return m.renderPopup(m.styles.Popup.Width(min(w-4, 100)).BorderForeground(m.styles.ErrorColor).Render(s.String()))
+}
@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+	"github.com/giovannirossini/aws-tui/internal/cache"
+)
+
+// ACMRequestedMsg carries the ARN of a newly requested certificate
+type ACMRequestedMsg string
+
+// ACMValidationMsg lists the validation records of a new certificate that
+// can be created in one of the account's hosted zones
+type ACMValidationMsg []acmValidationTarget
+
+// ACMRecordsCreatedMsg reports how many validation records were created
+type ACMRecordsCreatedMsg int
+
+type acmValidationTarget struct {
+	record aws.ValidationRecord
+	zone   aws.HostedZoneInfo
+}
+
+// parseDomains splits the request input on commas and whitespace
+func parseDomains(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+}
+
+// openRequest shows the domain prompt for a new certificate
+func (m *ACMModel) openRequest() {
+	m.input = textinput.New()
+	m.input.Placeholder = "example.com, *.example.com"
+	m.input.CharLimit = 1024
+	m.input.Width = 50
+	m.input.Focus()
+	m.requestErr = ""
+	m.state = ACMStateRequestInput
+}
+
+func (m ACMModel) updateRequest(msg tea.KeyMsg) (ACMModel, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "esc":
+		m.state = ACMStateList
+		return m, nil
+	case "enter":
+		domains := parseDomains(m.input.Value())
+		if len(domains) == 0 {
+			m.requestErr = "enter at least one domain name"
+			return m, nil
+		}
+		m.state = ACMStateList
+		return m, m.requestCertificate(domains)
+	}
+
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m ACMModel) requestCertificate(domains []string) tea.Cmd {
+	return armed(func() tea.Msg {
+		client, err := aws.NewACMClient(context.Background(), m.profile)
+		if err != nil {
+			return ACMErrorMsg(err)
+		}
+		arn, err := client.RequestCertificate(context.Background(), domains)
+		if err != nil {
+			return ACMErrorMsg(err)
+		}
+		m.cache.Delete(m.cacheKeys.ACMResources("certificates"))
+		return ACMRequestedMsg(arn)
+	})
+}
+
+// fetchValidationTargets matches the certificate's validation records with
+// the public hosted zones of the account
+func (m ACMModel) fetchValidationTargets(arn string) tea.Cmd {
+	return func() tea.Msg {
+		client, err := aws.NewACMClient(context.Background(), m.profile)
+		if err != nil {
+			return ACMErrorMsg(err)
+		}
+		records, err := client.GetValidationRecords(context.Background(), arn)
+		if err != nil {
+			return ACMErrorMsg(err)
+		}
+		if len(records) == 0 {
+			return ACMValidationMsg(nil)
+		}
+
+		var zones []aws.HostedZoneInfo
+		if cached, ok := m.cache.Get(m.cacheKeys.Route53Resources("hosted-zones")); ok {
+			zones, _ = cached.([]aws.HostedZoneInfo)
+		}
+		if zones == nil {
+			r53, err := aws.NewRoute53Client(context.Background(), m.profile)
+			if err != nil {
+				return ACMErrorMsg(err)
+			}
+			// Without access to Route 53 the records are simply not offered
+			if zones, err = r53.ListHostedZones(context.Background()); err != nil {
+				return ACMValidationMsg(nil)
+			}
+			m.cache.Set(m.cacheKeys.Route53Resources("hosted-zones"), zones, cache.TTLRoute53Resources)
+		}
+
+		var targets []acmValidationTarget
+		for _, r := range records {
+			if zone, ok := aws.MatchHostedZone(zones, r.Name); ok {
+				targets = append(targets, acmValidationTarget{record: r, zone: zone})
+			}
+		}
+		return ACMValidationMsg(targets)
+	}
+}
+
+func (m ACMModel) createValidationRecords() tea.Cmd {
+	targets := m.validationTargets
+	return armed(func() tea.Msg {
+		client, err := aws.NewRoute53Client(context.Background(), m.profile)
+		if err != nil {
+			return ACMErrorMsg(err)
+		}
+		for _, t := range targets {
+			err := client.UpsertRecord(context.Background(), t.zone.ID, t.record.Name, t.record.Type, t.record.Value)
+			if err != nil {
+				return ACMErrorMsg(err)
+			}
+		}
+		m.cache.Delete(m.cacheKeys.Route53Resources("hosted-zones"))
+		return ACMRecordsCreatedMsg(len(targets))
+	})
+}
+
+func (m ACMModel) renderRequest() string {
+	content := fmt.Sprintf(
+		" %s\n %s\n\n %s\n",
+		lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render("Request a public certificate"),
+		m.styles.StatusMuted.Render("Domain names, separated by commas. Validated through DNS."),
+		m.input.View(),
+	)
+	if m.requestErr != "" {
+		content += "\n " + m.styles.Error.Render("✘ "+m.requestErr) + "\n"
+	}
+	content += "\n " + m.styles.StatusMuted.Render("(enter to request, esc to cancel)")
+	return m.renderPopup(m.styles.Popup.Width(64).Render(content))
+}
+
+// openConfirmRecords asks to publish the validation records of the new
+// certificate in the hosted zones found for its domains
+func (m *ACMModel) openConfirmRecords() {
+	var s strings.Builder
+	for _, t := range m.validationTargets {
+		s.WriteString(fmt.Sprintf(" %s %s\n   %s %s\n",
+			m.styles.StatusMuted.Render(t.record.Type),
+			t.record.Name,
+			m.styles.StatusMuted.Render("in zone"),
+			t.zone.Name))
+	}
+	m.state = ACMStateConfirmRecords
+	m.confirm = NewConfirmDialog("DNS Validation", "Create the DNS validation records?", false, m.createValidationRecords)
+	m.confirm.Detail = s.String()
+	w, _ := GetMainContainerSize(m.width, m.height)
+	m.confirm.Width = min(w-4, 90)
+}
+
+func (m ACMModel) renderPopup(popup string) string {
+	w, h := GetMainContainerSize(m.width, m.height)
+	return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, popup)
+}
@@ -7,39 +7,67 @@ import (
 	"time"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/giovannirossini/aws-tui/internal/aws"
 	"github.com/giovannirossini/aws-tui/internal/cache"
 )
 
+type ACMState int
+
+const (
+	ACMStateList ACMState = iota
+	ACMStateRequestInput
+	ACMStateConfirmRecords
+	ACMStateConfirmDelete
+	ACMStateDeleteBlocked
+)
+
 type acmItem struct {
 	title       string
 	description string
 	id          string
 	values      []string
+	info        aws.CertificateInfo
 }
 
 func (i acmItem) Title() string       { return i.title }
 func (i acmItem) Description() string { return i.description }
 func (i acmItem) FilterValue() string { return i.title + " " + i.description + " " + i.id }
+func (i acmItem) rowID() string       { return i.id }
+func (i acmItem) rowValues() []string { return i.values }
+func (i acmItem) source() any         { return i.info }
 
 type ACMModel struct {
 	client    *aws.ACMClient
 	list      list.Model
 	delegate  acmItemDelegate
 	styles    Styles
+	table     *tableLayout
 	width     int
 	height    int
 	profile   string
 	err       error
+	loaded    bool
 	cache     *cache.Cache
 	cacheKeys *cache.KeyBuilder
+
+	// Certificate request flow
+	state             ACMState
+	input             textinput.Model
+	requestErr        string
+	validationTargets []acmValidationTarget
+	confirm           ConfirmDialog
+
+	// selectedCert is the certificate picked for deletion
+	selectedCert *aws.CertificateDetails
 }
 
 type acmItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 }
 
 var acmColumns = []Column{
@@ -55,20 +83,24 @@ func (d acmItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 		return
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, acmColumns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, acmColumns, d.table)
 	isSelected := index == m.Index()
 
-	RenderTableRow(w, m, d.styles, colStyles, i.values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, i.values, isSelected)
 }
 
 func (d acmItemDelegate) Height() int {
 	return 1
 }
 
-func NewACMModel(profile string, styles Styles, appCache *cache.Cache) ACMModel {
+func (d acmItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewACMModel(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) ACMModel {
+	table := &tableLayout{display: display}
 	d := acmItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
 	d.Styles.SelectedDesc = styles.ListSelectedDesc
@@ -83,6 +115,7 @@ func NewACMModel(profile string, styles Styles, appCache *cache.Cache) ACMModel
 		list:      l,
 		delegate:  d,
 		styles:    styles,
+		table:     table,
 		profile:   profile,
 		cache:     appCache,
 		cacheKeys: cache.NewKeyBuilder(profile),
@@ -127,6 +160,7 @@ func (m ACMModel) Update(msg tea.Msg) (ACMModel, tea.Cmd) {
 		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
 	case CertificatesMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			expires := "N/A"
@@ -139,17 +173,10 @@ func (m ACMModel) Update(msg tea.Msg) (ACMModel, tea.Cmd) {
 				}
 			}
 
-			status := v.Status
-			switch status {
-			case "ISSUED":
-				status = lipgloss.NewStyle().Foreground(m.styles.Success.GetForeground()).Render(status)
-			case "PENDING_VALIDATION":
-				status = lipgloss.NewStyle().Foreground(m.styles.Warning.GetForeground()).Render(status)
-			case "EXPIRED", "FAILED":
-				status = lipgloss.NewStyle().Foreground(m.styles.Error.GetForeground()).Render(status)
-			}
+			status := renderStatus(m.styles, v.Status)
 
 			items[i] = acmItem{
+				info:        v,
 				title:       v.DomainName,
 				description: v.ARN,
 				id:          v.ARN,
@@ -161,9 +188,35 @@ func (m ACMModel) Update(msg tea.Msg) (ACMModel, tea.Cmd) {
 				},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 
+	case ACMRequestedMsg:
+		// Show the new certificate while its validation records are published
+		return m, tea.Batch(m.fetchCertificates(), m.fetchValidationTargets(string(msg)))
+
+	case ACMValidationMsg:
+		if len(msg) > 0 {
+			m.validationTargets = msg
+			m.openConfirmRecords()
+		}
+
+	case ACMRecordsCreatedMsg:
+		m.cache.Delete(m.cacheKeys.ACMResources("certificates"))
+		return m, m.fetchCertificates()
+
+	case ACMCertificateDetailsMsg:
+		m.selectedCert = msg
+		if len(msg.InUseBy) > 0 {
+			m.state = ACMStateDeleteBlocked
+		} else {
+			m.openConfirmDelete()
+		}
+
+	case ACMDeletedMsg:
+		m.selectedCert = nil
+		return m, m.fetchCertificates()
+
 	case ACMErrorMsg:
 		m.err = msg
 
@@ -173,7 +226,28 @@ func (m ACMModel) Update(msg tea.Msg) (ACMModel, tea.Cmd) {
 			return m, nil
 		}
 
+		switch m.state {
+		case ACMStateRequestInput:
+			return m.updateRequest(msg)
+		case ACMStateConfirmRecords, ACMStateConfirmDelete:
+			result, cmd := m.confirm.Update(msg)
+			if result != confirmPending {
+				m.state = ACMStateList
+			}
+			return m, cmd
+		case ACMStateDeleteBlocked:
+			m.state = ACMStateList
+			return m, nil
+		}
+
 		switch msg.String() {
+		case "n":
+			m.openRequest()
+			return m, textinput.Blink
+		case "d":
+			if item, ok := m.list.SelectedItem().(acmItem); ok {
+				return m, m.fetchCertificateForDelete(item.id)
+			}
 		case "r":
 			m.cache.Delete(m.cacheKeys.ACMResources("certificates"))
 			return m, m.fetchCertificates()
@@ -184,12 +258,30 @@ func (m ACMModel) Update(msg tea.Msg) (ACMModel, tea.Cmd) {
 	return m, cmd
 }
 
+// columns are the columns of the table on screen
+func (m ACMModel) columns() []Column {
+	return acmColumns
+}
+
 func (m ACMModel) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
+	}
+
+	switch m.state {
+	case ACMStateRequestInput:
+		return m.renderRequest()
+	case ACMStateConfirmRecords, ACMStateConfirmDelete:
+		return m.renderPopup(m.confirm.View(m.styles))
+	case ACMStateDeleteBlocked:
+		return m.renderDeleteBlocked()
+	}
+
+	if m.loaded && len(m.list.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.list, "ACM certificates", m.profile, m.table)
 	}
 
-	_, header := RenderTableHelpers(m.list, m.styles, acmColumns)
+	_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
 	return header + "\n" + m.list.View()
 }
 
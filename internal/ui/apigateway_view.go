@@ -23,21 +23,26 @@ type apiGatewayItem struct {
 	title       string
 	description string
 	values      []string
+	info        any
 }
 
 func (i apiGatewayItem) Title() string       { return i.title }
+func (i apiGatewayItem) source() any         { return i.info }
 func (i apiGatewayItem) Description() string { return i.description }
 func (i apiGatewayItem) FilterValue() string { return i.title + " " + i.description }
+func (i apiGatewayItem) rowValues() []string { return i.values }
 
 type APIGatewayModel struct {
 	client    *aws.APIGatewayClient
 	list      list.Model
 	styles    Styles
+	table     *tableLayout
 	state     APIGatewayState
 	width     int
 	height    int
 	profile   string
 	err       error
+	loaded    bool
 	cache     *cache.Cache
 	cacheKeys *cache.KeyBuilder
 }
@@ -45,6 +50,7 @@ type APIGatewayModel struct {
 type apiGatewayItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 	state  APIGatewayState
 }
 
@@ -71,7 +77,7 @@ func (d apiGatewayItemDelegate) Render(w io.Writer, m list.Model, index int, lis
 	}
 
 	if d.state == APIGatewayStateMenu {
-		d.DefaultDelegate.Render(w, m, index, listItem)
+		d.table.renderMenuItem(d.DefaultDelegate, w, m, index, listItem)
 		return
 	}
 
@@ -83,20 +89,27 @@ func (d apiGatewayItemDelegate) Render(w io.Writer, m list.Model, index int, lis
 		columns = apiGatewayHTTPAPIColumns
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, columns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, columns, d.table)
 	isSelected := index == m.Index()
 
-	RenderTableRow(w, m, d.styles, colStyles, i.values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, i.values, isSelected)
 }
 
 func (d apiGatewayItemDelegate) Height() int {
+	if d.state == APIGatewayStateMenu {
+		return d.table.menuItemHeight()
+	}
 	return 1
 }
 
-func NewAPIGatewayModel(profile string, styles Styles, appCache *cache.Cache) APIGatewayModel {
+func (d apiGatewayItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewAPIGatewayModel(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) APIGatewayModel {
+	table := &tableLayout{display: display}
 	d := apiGatewayItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 		state:           APIGatewayStateMenu,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
@@ -111,6 +124,7 @@ func NewAPIGatewayModel(profile string, styles Styles, appCache *cache.Cache) AP
 	m := APIGatewayModel{
 		list:      l,
 		styles:    styles,
+		table:     table,
 		state:     APIGatewayStateMenu,
 		profile:   profile,
 		cache:     appCache,
@@ -124,6 +138,7 @@ func (m *APIGatewayModel) updateDelegate() {
 	d := apiGatewayItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          m.styles,
+		table:           m.table,
 		state:           m.state,
 	}
 	d.Styles.SelectedTitle = m.styles.ListSelectedTitle
@@ -212,15 +227,17 @@ func (m APIGatewayModel) Update(msg tea.Msg) (APIGatewayModel, tea.Cmd) {
 		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
 	case APIGatewayMenuMsg:
-		m.list.SetItems([]list.Item(msg))
+		m.table.setItems(&m.list, []list.Item(msg))
 		m.list.ResetSelected()
 		m.state = APIGatewayStateMenu
 		m.updateDelegate()
 
 	case APIGatewayRestAPIsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, api := range msg {
 			items[i] = apiGatewayItem{
+				info:        api,
 				title:       api.Name,
 				description: api.Description,
 				values: []string{
@@ -232,14 +249,16 @@ func (m APIGatewayModel) Update(msg tea.Msg) (APIGatewayModel, tea.Cmd) {
 				},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.state = APIGatewayStateRestAPIs
 		m.updateDelegate()
 
 	case APIGatewayHTTPAPIsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, api := range msg {
 			items[i] = apiGatewayItem{
+				info:        api,
 				title:       api.Name,
 				description: api.Description,
 				values: []string{
@@ -251,7 +270,7 @@ func (m APIGatewayModel) Update(msg tea.Msg) (APIGatewayModel, tea.Cmd) {
 				},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.state = APIGatewayStateHTTPAPIs
 		m.updateDelegate()
 
@@ -297,21 +316,34 @@ func (m APIGatewayModel) Update(msg tea.Msg) (APIGatewayModel, tea.Cmd) {
 	return m, cmd
 }
 
-func (m APIGatewayModel) View() string {
-	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
-	}
+// apiGatewayResourceNames names the resources listed in each table state, for the empty-state message
+var apiGatewayResourceNames = map[APIGatewayState]string{
+	APIGatewayStateRestAPIs: "REST APIs",
+	APIGatewayStateHTTPAPIs: "HTTP APIs",
+}
 
-	var columns []Column
+// columns are the columns of the table on screen
+func (m APIGatewayModel) columns() []Column {
 	switch m.state {
 	case APIGatewayStateRestAPIs:
-		columns = apiGatewayRestAPIColumns
+		return apiGatewayRestAPIColumns
 	case APIGatewayStateHTTPAPIs:
-		columns = apiGatewayHTTPAPIColumns
+		return apiGatewayHTTPAPIColumns
+	}
+	return nil
+}
+
+func (m APIGatewayModel) View() string {
+	if m.err != nil {
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
+	}
+
+	if resource, ok := apiGatewayResourceNames[m.state]; ok && m.loaded && len(m.list.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.list, resource, m.profile, m.table)
 	}
 
-	if len(columns) > 0 {
-		_, header := RenderTableHelpers(m.list, m.styles, columns)
+	if columns := m.columns(); len(columns) > 0 {
+		_, header := RenderTableHelpers(m.list, m.styles, columns, m.table)
 		return header + "\n" + m.list.View()
 	}
 
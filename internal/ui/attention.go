@@ -0,0 +1,202 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+	"github.com/giovannirossini/aws-tui/internal/cache"
+	"github.com/giovannirossini/aws-tui/internal/logging"
+)
+
+// attentionCheck finds resources of one kind that likely need a look. command
+// is the palette command that opens the view listing them.
+type attentionCheck struct {
+	id      string
+	label   string
+	command string
+	count   countFunc
+}
+
+// attentionChecks are the home screen's "needs attention" panel, in the order
+// their number keys jump to them
+var attentionChecks = []attentionCheck{
+	{"stopped-instances", "stopped EC2 instances", "ec2 instances",
+		func(ctx context.Context, profile string) (int, error) {
+			client, err := aws.NewEC2ResourcesClient(ctx, profile)
+			if err != nil {
+				return 0, err
+			}
+			instances, err := client.ListInstances(ctx)
+			// Their EBS volumes are billed while they are stopped
+			return countWhere(instances, func(i aws.InstanceInfo) bool { return i.State == "stopped" }), err
+		}},
+	{"unassociated-eips", "unassociated Elastic IPs", "ec2 elastic-ips",
+		func(ctx context.Context, profile string) (int, error) {
+			client, err := aws.NewEC2ResourcesClient(ctx, profile)
+			if err != nil {
+				return 0, err
+			}
+			eips, err := client.ListElasticIPs(ctx)
+			return countWhere(eips, func(e aws.ElasticIPInfo) bool { return !e.Associated() }), err
+		}},
+	{"unhealthy-target-groups", "unhealthy target groups", "ec2 target-groups",
+		counter(aws.NewEC2ResourcesClient, (*aws.EC2ResourcesClient).ListUnhealthyTargetGroups)},
+	{"failed-backup-jobs", "failed Backup jobs (7 days)", "backup jobs",
+		func(ctx context.Context, profile string) (int, error) {
+			client, err := aws.NewBackupClient(ctx, profile)
+			if err != nil {
+				return 0, err
+			}
+			jobs, err := client.ListFailedBackupJobs(ctx, time.Now().AddDate(0, 0, -7))
+			return len(jobs), err
+		}},
+	{"alarms", "CloudWatch alarms in ALARM", "cw alarms",
+		func(ctx context.Context, profile string) (int, error) {
+			client, err := aws.NewCloudWatchClient(ctx, profile)
+			if err != nil {
+				return 0, err
+			}
+			alarms, err := client.ListAlarms(ctx)
+			return countWhere(alarms, func(a aws.AlarmInfo) bool { return a.State == "ALARM" }), err
+		}},
+	{"expiring-certificates", "ACM certificates expiring (30 days)", "acm",
+		func(ctx context.Context, profile string) (int, error) {
+			client, err := aws.NewACMClient(ctx, profile)
+			if err != nil {
+				return 0, err
+			}
+			certs, err := client.ListCertificates(ctx)
+			// The same month the certificate list highlights expiry dates in
+			soon := time.Now().AddDate(0, 1, 0)
+			return countWhere(certs, func(c aws.CertificateInfo) bool {
+				return c.ExpiresAt != nil && c.ExpiresAt.Before(soon)
+			}), err
+		}},
+}
+
+func countWhere[T any](items []T, match func(T) bool) int {
+	n := 0
+	for _, item := range items {
+		if match(item) {
+			n++
+		}
+	}
+	return n
+}
+
+// attentionResult is the outcome of a check; failed checks show as unknown
+type attentionResult struct {
+	count  int
+	failed bool
+}
+
+// attentionMsg carries the result of one check, for the profile it ran with
+type attentionMsg struct {
+	profile string
+	id      string
+	result  attentionResult
+}
+
+// fetchAttention runs every check concurrently, like the resource counts, so
+// the panel fills in as they arrive. It only runs when enabled in the config,
+// as it costs a handful of calls on every start and profile change.
+func (m *Model) fetchAttention() tea.Cmd {
+	if !m.config.Attention {
+		return nil
+	}
+	m.attention = make(map[string]attentionResult, len(attentionChecks))
+
+	profile, appCache := m.selectedProfile, m.cache
+	cmds := make([]tea.Cmd, 0, len(attentionChecks))
+	for _, check := range attentionChecks {
+		key := m.cacheKeys.ResourceCount("attention:" + check.id)
+		cmds = append(cmds, func() tea.Msg {
+			if cached, ok := appCache.Get(key); ok {
+				if n, ok := cached.(int); ok {
+					return attentionMsg{profile: profile, id: check.id, result: attentionResult{count: n}}
+				}
+			}
+			n, err := check.count(context.Background(), profile)
+			if err != nil {
+				logging.Printf("attention check=%s profile=%s: %v", check.id, profile, err)
+				return attentionMsg{profile: profile, id: check.id, result: attentionResult{failed: true}}
+			}
+			appCache.Set(key, n, cache.TTLResourceCounts)
+			return attentionMsg{profile: profile, id: check.id, result: attentionResult{count: n}}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// refreshAttention drops the cached results and runs the checks again
+func (m *Model) refreshAttention() tea.Cmd {
+	for _, check := range attentionChecks {
+		m.cache.Delete(m.cacheKeys.ResourceCount("attention:" + check.id))
+	}
+	return m.fetchAttention()
+}
+
+func (m *Model) handleAttention(msg attentionMsg) (tea.Model, tea.Cmd) {
+	if msg.profile == m.selectedProfile && m.attention != nil {
+		m.attention[msg.id] = msg.result
+	}
+	return *m, nil
+}
+
+// openAttention jumps to the view listing the resources of the nth check
+func (m *Model) openAttention(key string) (tea.Model, tea.Cmd, bool) {
+	if !m.config.Attention {
+		return *m, nil, false
+	}
+	for i, check := range attentionChecks {
+		if key == fmt.Sprintf("%d", i+1) {
+			model, cmd := m.runPaletteCommand(check.command)
+			return model, cmd, true
+		}
+	}
+	return *m, nil, false
+}
+
+// renderAttention renders the "needs attention" panel shown under the
+// services on the home screen, laid out in rows of three
+func (m Model) renderAttention(colWidth int) string {
+	entries := make([]string, len(attentionChecks))
+	for i, check := range attentionChecks {
+		count := m.styles.StatusMuted.Render("…")
+		if result, ok := m.attention[check.id]; ok {
+			switch {
+			case result.failed:
+				count = m.styles.StatusMuted.Render("?")
+			case result.count > 0:
+				count = m.styles.Warning.Bold(true).Render(fmt.Sprintf("%d", result.count))
+			default:
+				count = m.styles.Success.Render("0")
+			}
+		}
+		entries[i] = lipgloss.NewStyle().Width(colWidth).Render(
+			m.styles.StatusKey.Render(fmt.Sprintf("%d", i+1)) + " " + count + " " + lipgloss.NewStyle().Foreground(m.styles.Snow).Render(check.label))
+	}
+
+	var rows []string
+	for i := 0; i < len(entries); i += 3 {
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, entries[i:min(i+3, len(entries))]...))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(lipgloss.NewStyle().
+		Foreground(m.styles.Primary).
+		Bold(true).
+		Underline(true).
+		Render("NEEDS ATTENTION") + "\n")
+	sb.WriteString(strings.Join(rows, "\n"))
+
+	return m.styles.MenuContainer.Copy().
+		Border(lipgloss.RoundedBorder()).
+		Padding(0, 2).
+		Render(sb.String())
+}
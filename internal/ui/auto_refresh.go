@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// autoRefreshIntervals are cycled through with the auto-refresh key; zero turns it off
+var autoRefreshIntervals = []time.Duration{0, 5 * time.Second, 15 * time.Second, 30 * time.Second}
+
+// autoRefreshMsg fires when the current auto-refresh interval elapses. Ticks
+// carrying a stale id, or started from another view or state, are dropped.
+type autoRefreshMsg struct {
+	id    int
+	view  viewState
+	state int
+}
+
+// autoRefreshState reports the sub-state of the current view if it lists
+// resources that can be refreshed periodically
+func (m Model) autoRefreshState() (int, bool) {
+	switch m.view {
+	case viewECS:
+		switch m.ecsModel.state {
+		case ECSStateClusters, ECSStateServices, ECSStateTasks, ECSStateEvents:
+			return int(m.ecsModel.state), true
+		}
+	case viewDMS:
+		switch m.dmsModel.state {
+		case DMSStateTasks, DMSStateEndpoints, DMSStateInstances:
+			return int(m.dmsModel.state), true
+		}
+	case viewBackup:
+		switch m.backupModel.state {
+		case BackupStatePlans, BackupStateJobs:
+			return int(m.backupModel.state), true
+		}
+	case viewEC2:
+		if m.ec2Model.state == EC2StateInstances {
+			return int(m.ec2Model.state), true
+		}
+	case viewRDS:
+		if m.rdsModel.state == RDSStateInstances {
+			return int(m.rdsModel.state), true
+		}
+	}
+	return 0, false
+}
+
+// cycleAutoRefresh advances to the next auto-refresh interval for the current view
+func (m *Model) cycleAutoRefresh() tea.Cmd {
+	state, _ := m.autoRefreshState()
+
+	next := autoRefreshIntervals[0]
+	if m.autoRefresh == 0 || m.autoRefreshView != m.view || m.autoRefreshViewState != state {
+		next = autoRefreshIntervals[1]
+	} else {
+		for i, d := range autoRefreshIntervals {
+			if d == m.autoRefresh && i+1 < len(autoRefreshIntervals) {
+				next = autoRefreshIntervals[i+1]
+				break
+			}
+		}
+	}
+
+	// Bumping the id orphans any tick already in flight
+	m.autoRefreshID++
+	m.autoRefresh = next
+	m.autoRefreshView = m.view
+	m.autoRefreshViewState = state
+
+	if next == 0 {
+		return m.showToast("Auto-refresh off")
+	}
+	return tea.Batch(m.showToast(fmt.Sprintf("Auto-refresh every %s", next)), m.scheduleAutoRefresh())
+}
+
+func (m Model) scheduleAutoRefresh() tea.Cmd {
+	msg := autoRefreshMsg{id: m.autoRefreshID, view: m.autoRefreshView, state: m.autoRefreshViewState}
+	return tea.Tick(m.autoRefresh, func(time.Time) tea.Msg {
+		return msg
+	})
+}
+
+// handleAutoRefresh re-issues the refresh of the current view and schedules
+// the next tick, or stops auto-refresh once the user has left the view or state
+func (m *Model) handleAutoRefresh(msg autoRefreshMsg) (tea.Model, tea.Cmd) {
+	if msg.id != m.autoRefreshID || m.autoRefresh == 0 {
+		return *m, nil
+	}
+
+	state, ok := m.autoRefreshState()
+	if !ok || m.view != msg.view || state != msg.state {
+		m.stopAutoRefresh()
+		return *m, nil
+	}
+
+	// The refresh key re-runs whichever fetch the view's state shows
+	m.snapshotRows()
+	refresh := m.handleViewKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	return *m, tea.Batch(refresh, m.scheduleAutoRefresh())
+}
+
+func (m *Model) stopAutoRefresh() {
+	m.autoRefreshID++
+	m.autoRefresh = 0
+}
+
+// autoRefreshActive reports whether auto-refresh is running for what is on screen
+func (m Model) autoRefreshActive() bool {
+	if m.autoRefresh == 0 || m.view != m.autoRefreshView {
+		return false
+	}
+	state, ok := m.autoRefreshState()
+	return ok && state == m.autoRefreshViewState
+}
@@ -0,0 +1,198 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+// restoreForm edits the IAM role and restore metadata of a restore job. The
+// first input is the role; the rest follow the sorted metadata keys.
+type restoreForm struct {
+	keys   []string
+	inputs []textinput.Model
+	focus  int
+	err    string
+}
+
+// restoreJob looks up the recovery point a job produced so its restore
+// metadata can be edited
+func (m BackupModel) restoreJob(job aws.BackupJobInfo) tea.Cmd {
+	return func() tea.Msg {
+		if job.State != "COMPLETED" || job.RecoveryPointArn == "" {
+			return BackupErrorMsg(errors.New("only completed backup jobs can be restored"))
+		}
+
+		client, err := aws.NewBackupClient(context.Background(), m.profile)
+		if err != nil {
+			return BackupErrorMsg(err)
+		}
+		rp, err := client.GetRecoveryPointDetails(context.Background(), job.BackupVaultName, job.RecoveryPointArn)
+		if err != nil {
+			return BackupErrorMsg(err)
+		}
+		return BackupRecoveryPointMsg(rp)
+	}
+}
+
+func (m *BackupModel) openRestoreForm() {
+	rp := m.recoveryPoint
+	keys := make([]string, 0, len(rp.RestoreMetadata))
+	for k := range rp.RestoreMetadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	newInput := func(value string) textinput.Model {
+		ti := textinput.New()
+		ti.SetValue(value)
+		ti.CharLimit = 2048
+		ti.Width = 60
+		return ti
+	}
+
+	role := rp.IamRoleArn
+	if role == "" {
+		role = m.selectedJob.IamRoleArn
+	}
+	form := restoreForm{keys: append([]string{"IAM role ARN"}, keys...)}
+	form.inputs = append(form.inputs, newInput(role))
+	for _, k := range keys {
+		form.inputs = append(form.inputs, newInput(rp.RestoreMetadata[k]))
+	}
+	form.inputs[0].Focus()
+
+	m.restoreForm = form
+	m.state = BackupStateRestoreInput
+}
+
+func (m BackupModel) updateRestoreForm(msg tea.KeyMsg) (BackupModel, tea.Cmd) {
+	f := &m.restoreForm
+
+	switch msg.String() {
+	case "esc":
+		m.state = BackupStateJobs
+		return m, nil
+	case "tab", "down":
+		f.inputs[f.focus].Blur()
+		f.focus = (f.focus + 1) % len(f.inputs)
+		return m, f.inputs[f.focus].Focus()
+	case "shift+tab", "up":
+		f.inputs[f.focus].Blur()
+		f.focus = (f.focus - 1 + len(f.inputs)) % len(f.inputs)
+		return m, f.inputs[f.focus].Focus()
+	case "enter":
+		role := strings.TrimSpace(f.inputs[0].Value())
+		if role == "" {
+			f.err = "an IAM role is required to restore"
+			return m, nil
+		}
+		metadata := make(map[string]string, len(f.keys)-1)
+		for i, k := range f.keys[1:] {
+			if v := strings.TrimSpace(f.inputs[i+1].Value()); v != "" {
+				metadata[k] = v
+			}
+		}
+		m.state = BackupStateJobs
+		return m, m.startRestore(role, metadata)
+	}
+
+	var cmd tea.Cmd
+	f.inputs[f.focus], cmd = f.inputs[f.focus].Update(msg)
+	return m, cmd
+}
+
+func (m BackupModel) startRestore(role string, metadata map[string]string) tea.Cmd {
+	rp := m.recoveryPoint
+	return armed(func() tea.Msg {
+		client, err := aws.NewBackupClient(context.Background(), m.profile)
+		if err != nil {
+			return BackupErrorMsg(err)
+		}
+		id, err := client.StartRestoreJob(context.Background(), rp.RecoveryPointArn, role, rp.ResourceType, metadata)
+		if err != nil {
+			return BackupErrorMsg(err)
+		}
+		return BackupRestoreStartedMsg(id)
+	})
+}
+
+// renderJobDetail shows every field of the selected job, including the full
+// status message of failed jobs that the table truncates
+func (m BackupModel) renderJobDetail() string {
+	j := m.selectedJob
+	w, h := GetMainContainerSize(m.width, m.height)
+
+	completed := ""
+	if !j.CompletionDate.IsZero() {
+		completed = j.CompletionDate.Local().Format(absoluteTimeFormat)
+	}
+	fields := []detailField{
+		{"Job ID", j.BackupJobId},
+		{"State", renderStatus(m.styles, j.State)},
+		{"Resource type", j.ResourceType},
+		{"Resource", j.ResourceArn},
+		{"Vault", j.BackupVaultName},
+		{"Recovery point", j.RecoveryPointArn},
+		{"IAM role", j.IamRoleArn},
+		{"Size", humanizeBytes(j.BackupSizeInBytes)},
+		{"Created", j.CreationDate.Local().Format(absoluteTimeFormat)},
+		{"Completed", completed},
+	}
+	if j.StatusMessage != "" {
+		message := j.StatusMessage
+		if statusLevelOf(j.State) == statusFailed {
+			message = m.styles.Error.Render(message)
+		}
+		fields = append(fields, detailField{"Message", message})
+	}
+
+	labelStyle := lipgloss.NewStyle().Foreground(m.styles.Primary).Width(16)
+	valueStyle := lipgloss.NewStyle().Width(max(w-24, 20))
+
+	var s strings.Builder
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		s.WriteString(" " + lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render(f.label), valueStyle.Render(f.value)) + "\n")
+	}
+	hint := "(esc to go back)"
+	if j.State == "COMPLETED" && j.RecoveryPointArn != "" {
+		hint = "(s to restore, esc to go back)"
+	}
+	s.WriteString("\n " + m.styles.StatusMuted.Render(hint))
+
+	return lipgloss.NewStyle().Height(h - AppInternalFooterHeight - 2).MaxHeight(h - AppInternalFooterHeight - 2).Render(s.String())
+}
+
+func (m BackupModel) renderRestoreForm() string {
+	f := m.restoreForm
+	rp := m.recoveryPoint
+
+	var s strings.Builder
+	s.WriteString(" " + lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render("Restore "+rp.ResourceType+" backup") + "\n")
+	s.WriteString(" " + m.styles.StatusMuted.Render("Prefilled from "+rp.ResourceArn+". Empty values are left out.") + "\n\n")
+	for i, k := range f.keys {
+		label := m.styles.StatusMuted.Render(k)
+		if i == f.focus {
+			label = lipgloss.NewStyle().Foreground(m.styles.Primary).Render(k)
+		}
+		s.WriteString(" " + label + "\n " + f.inputs[i].View() + "\n")
+	}
+	if f.err != "" {
+		s.WriteString("\n " + m.styles.Error.Render("✘ "+f.err) + "\n")
+	}
+	s.WriteString("\n " + m.styles.StatusMuted.Render(fmt.Sprintf("(tab to move between %d fields, enter to start, esc to cancel)", len(f.inputs))))
+
+	w, h := GetMainContainerSize(m.width, m.height)
+	popup := m.styles.Popup.Width(min(w-4, 80)).Render(s.String())
+	return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, popup)
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/giovannirossini/aws-tui/internal/aws"
@@ -19,34 +20,48 @@ const (
 	BackupStateMenu BackupState = iota
 	BackupStatePlans
 	BackupStateJobs
+	BackupStateJobDetail
+	BackupStateRestoreInput
 )
 
 type backupItem struct {
 	title       string
 	description string
 	state       BackupState
+	job         aws.BackupJobInfo
 }
 
 func (i backupItem) Title() string       { return i.title }
 func (i backupItem) Description() string { return i.description }
-func (i backupItem) FilterValue() string { return i.title }
+func (i backupItem) FilterValue() string {
+	return i.title + " " + i.description + " " + i.job.ResourceArn
+}
+
+func (i backupItem) rowID() string { return i.job.BackupJobId }
 
 type BackupModel struct {
 	client    *aws.BackupClient
 	list      list.Model
 	styles    Styles
+	table     *tableLayout
 	state     BackupState
 	width     int
 	height    int
 	profile   string
 	err       error
+	loaded    bool
 	cache     *cache.Cache
 	cacheKeys *cache.KeyBuilder
+	// Job detail and restore
+	selectedJob   aws.BackupJobInfo
+	recoveryPoint *aws.RecoveryPointDetails
+	restoreForm   restoreForm
 }
 
 type backupItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 	state  BackupState
 }
 
@@ -57,11 +72,12 @@ var backupPlanColumns = []Column{
 }
 
 var backupJobColumns = []Column{
-	{Title: "Job ID", Width: 0.2},
-	{Title: "Resource Type", Width: 0.2},
-	{Title: "State", Width: 0.15},
-	{Title: "Size", Width: 0.15},
-	{Title: "Created At", Width: 0.3},
+	{Title: "Job ID", Width: 0.18},
+	{Title: "Resource Type", Width: 0.14},
+	{Title: "State", Width: 0.12},
+	{Title: "Size", Width: 0.1},
+	{Title: "Created At", Width: 0.16},
+	{Title: "Reason", Width: 0.3},
 }
 
 func (d backupItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
@@ -77,11 +93,11 @@ func (d backupItemDelegate) Render(w io.Writer, m list.Model, index int, listIte
 	case BackupStateJobs:
 		columns = backupJobColumns
 	default:
-		d.DefaultDelegate.Render(w, m, index, listItem)
+		d.table.renderMenuItem(d.DefaultDelegate, w, m, index, listItem)
 		return
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, columns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, columns, d.table)
 	isSelected := index == m.Index()
 
 	var values []string
@@ -110,29 +126,40 @@ func (d backupItemDelegate) Render(w io.Writer, m list.Model, index int, listIte
 			size = strings.TrimPrefix(parts[2], "Size: ")
 			createdAt = strings.TrimPrefix(parts[3], "Created: ")
 		}
+		// Only failures carry a reason worth showing; successful jobs may
+		// still report informational messages
+		reason := ""
+		if statusLevelOf(i.job.State) == statusFailed {
+			reason = d.styles.Error.Render(i.job.StatusMessage)
+		}
 		values = []string{
 			"⚙️ " + i.title,
 			resType,
-			state,
+			renderStatus(d.styles, state),
 			size,
 			createdAt,
+			reason,
 		}
 	}
 
-	RenderTableRow(w, m, d.styles, colStyles, values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, values, isSelected)
 }
 
 func (d backupItemDelegate) Height() int {
 	if d.state == BackupStateMenu {
-		return 2
+		return d.table.menuItemHeight()
 	}
 	return 1
 }
 
-func NewBackupModel(profile string, styles Styles, appCache *cache.Cache) BackupModel {
+func (d backupItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewBackupModel(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) BackupModel {
+	table := &tableLayout{display: display}
 	d := backupItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 		state:           BackupStateMenu,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
@@ -150,6 +177,7 @@ func NewBackupModel(profile string, styles Styles, appCache *cache.Cache) Backup
 	m := BackupModel{
 		list:      l,
 		styles:    styles,
+		table:     table,
 		state:     BackupStateMenu,
 		profile:   profile,
 		cache:     appCache,
@@ -164,12 +192,13 @@ func (m *BackupModel) setMenu() {
 		backupItem{title: "Backup Plans", description: "View configured backup plans", state: BackupStatePlans},
 		backupItem{title: "Backup Jobs", description: "View recent backup jobs", state: BackupStateJobs},
 	}
-	m.list.SetItems(items)
+	m.table.setItems(&m.list, items)
 	m.state = BackupStateMenu
 
 	d := backupItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          m.styles,
+		table:           m.table,
 		state:           BackupStateMenu,
 	}
 	d.Styles.SelectedTitle = m.styles.ListSelectedTitle
@@ -181,6 +210,12 @@ type BackupPlansMsg []aws.BackupPlanInfo
 type BackupJobsMsg []aws.BackupJobInfo
 type BackupErrorMsg error
 
+// BackupRecoveryPointMsg carries the recovery point of the job being restored
+type BackupRecoveryPointMsg *aws.RecoveryPointDetails
+
+// BackupRestoreStartedMsg carries the ID of a new restore job
+type BackupRestoreStartedMsg string
+
 func (m BackupModel) Init() tea.Cmd {
 	return nil
 }
@@ -241,6 +276,7 @@ func (m BackupModel) Update(msg tea.Msg) (BackupModel, tea.Cmd) {
 		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
 	case BackupPlansMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, p := range msg {
 			items[i] = backupItem{
@@ -248,13 +284,14 @@ func (m BackupModel) Update(msg tea.Msg) (BackupModel, tea.Cmd) {
 				description: fmt.Sprintf("ID: %s | Created: %s", p.BackupPlanId, p.CreationDate.Format("2006-01-02")),
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.state = BackupStatePlans
 		m.list.Title = "Backup Plans"
 
 		d := backupItemDelegate{
 			DefaultDelegate: list.NewDefaultDelegate(),
 			styles:          m.styles,
+			table:           m.table,
 			state:           BackupStatePlans,
 		}
 		d.Styles.SelectedTitle = m.styles.ListSelectedTitle
@@ -262,27 +299,39 @@ func (m BackupModel) Update(msg tea.Msg) (BackupModel, tea.Cmd) {
 		m.list.SetDelegate(d)
 
 	case BackupJobsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, j := range msg {
 			sizeMB := float64(j.BackupSizeInBytes) / 1024 / 1024
 			items[i] = backupItem{
 				title:       j.BackupJobId,
+				job:         j,
 				description: fmt.Sprintf("Type: %s | State: %s | Size: %.2f MB | Created: %s", j.ResourceType, j.State, sizeMB, j.CreationDate.Format("2006-01-02 15:04")),
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.state = BackupStateJobs
 		m.list.Title = "Backup Jobs"
 
 		d := backupItemDelegate{
 			DefaultDelegate: list.NewDefaultDelegate(),
 			styles:          m.styles,
+			table:           m.table,
 			state:           BackupStateJobs,
 		}
 		d.Styles.SelectedTitle = m.styles.ListSelectedTitle
 		d.Styles.SelectedDesc = m.styles.ListSelectedDesc
 		m.list.SetDelegate(d)
 
+	case BackupRecoveryPointMsg:
+		m.recoveryPoint = msg
+		m.openRestoreForm()
+		return m, textinput.Blink
+
+	case BackupRestoreStartedMsg:
+		m.state = BackupStateJobs
+		return m, nil
+
 	case BackupErrorMsg:
 		m.err = msg
 
@@ -292,6 +341,19 @@ func (m BackupModel) Update(msg tea.Msg) (BackupModel, tea.Cmd) {
 			return m, nil
 		}
 
+		switch m.state {
+		case BackupStateRestoreInput:
+			return m.updateRestoreForm(msg)
+		case BackupStateJobDetail:
+			switch msg.String() {
+			case "backspace", "esc", "q":
+				m.state = BackupStateJobs
+			case "s":
+				return m, m.restoreJob(m.selectedJob)
+			}
+			return m, nil
+		}
+
 		switch msg.String() {
 		case "r":
 			if m.state == BackupStatePlans {
@@ -301,8 +363,18 @@ func (m BackupModel) Update(msg tea.Msg) (BackupModel, tea.Cmd) {
 				m.cache.Delete(m.cacheKeys.BackupResources("jobs"))
 				return m, m.fetchJobs()
 			}
+		case "s":
+			if item, ok := m.list.SelectedItem().(backupItem); ok && m.state == BackupStateJobs {
+				m.selectedJob = item.job
+				return m, m.restoreJob(item.job)
+			}
 		case "enter":
 			if item, ok := m.list.SelectedItem().(backupItem); ok {
+				if m.state == BackupStateJobs {
+					m.selectedJob = item.job
+					m.state = BackupStateJobDetail
+					return m, nil
+				}
 				if m.state == BackupStateMenu {
 					if item.state == BackupStatePlans {
 						return m, m.fetchPlans()
@@ -323,26 +395,46 @@ func (m BackupModel) Update(msg tea.Msg) (BackupModel, tea.Cmd) {
 	return m, cmd
 }
 
+// backupResourceNames names the resources listed in each table state, for the empty-state message
+var backupResourceNames = map[BackupState]string{
+	BackupStatePlans: "backup plans",
+	BackupStateJobs:  "backup jobs",
+}
+
+// columns are the columns of the table on screen
+func (m BackupModel) columns() []Column {
+	if m.state == BackupStatePlans {
+		return backupPlanColumns
+	}
+	if m.state == BackupStateJobs {
+		return backupJobColumns
+	}
+	return nil
+}
+
 func (m BackupModel) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
 	}
 
-	if m.state == BackupStateMenu {
+	switch m.state {
+	case BackupStateMenu:
 		return m.list.View()
+	case BackupStateJobDetail:
+		return m.renderJobDetail()
+	case BackupStateRestoreInput:
+		return m.renderRestoreForm()
+	}
+
+	if resource, ok := backupResourceNames[m.state]; ok && m.loaded && len(m.list.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.list, resource, m.profile, m.table)
 	}
 
 	return m.renderHeader() + "\n" + m.list.View()
 }
 
 func (m BackupModel) renderHeader() string {
-	var columns []Column
-	if m.state == BackupStatePlans {
-		columns = backupPlanColumns
-	} else if m.state == BackupStateJobs {
-		columns = backupJobColumns
-	}
-	_, header := RenderTableHelpers(m.list, m.styles, columns)
+	_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
 	return header
 }
 
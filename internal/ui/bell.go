@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// bellFor returns a command ringing the terminal bell when msg completes a
+// long operation, so the user can look away while it runs. It is off unless
+// enabled in the config, and runs before the message is routed so a rollout
+// can be seen going from in progress to done.
+func (m *Model) bellFor(msg tea.Msg) tea.Cmd {
+	if !m.config.Bell {
+		return nil
+	}
+	switch msg := msg.(type) {
+	case BackupRestoreStartedMsg:
+		return ringBell
+	case ECSDeploymentMsg:
+		// Only the poll that ends a rollout seen in progress, not the first
+		// poll of a service that was already stable
+		last := m.ecsModel.deployment
+		watched := m.ecsModel.state == ECSStateDeployment && msg.watch == m.ecsModel.deploymentWatch
+		wasRunning := last != nil && !last.Stable && !last.Failed
+		if watched && wasRunning && msg.status != nil && (msg.status.Stable || msg.status.Failed) {
+			return ringBell
+		}
+	}
+	return nil
+}
+
+// ringBell writes BEL to the terminal. Bubble Tea has no bell of its own;
+// BEL moves no cursor, so the frame on screen is left intact.
+func ringBell() tea.Msg {
+	os.Stdout.WriteString("\a")
+	return nil
+}
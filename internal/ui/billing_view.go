@@ -14,30 +14,39 @@ import (
 	"github.com/giovannirossini/aws-tui/internal/cache"
 )
 
+// billingItem is the cost of a service, or of a linked account when the
+// costs are grouped by account
 type billingItem struct {
-	service string
-	amount  string
-	unit    string
+	name   string
+	amount string
+	unit   string
+	cost   float64
 }
 
-func (i billingItem) Title() string       { return i.service }
+func (i billingItem) Title() string       { return i.name }
 func (i billingItem) Description() string { return fmt.Sprintf("%s %s", i.amount, i.unit) }
-func (i billingItem) FilterValue() string { return i.service }
+func (i billingItem) FilterValue() string { return i.name }
 
 type BillingModel struct {
 	list      list.Model
 	styles    Styles
+	table     *tableLayout
 	profile   string
 	width     int
 	height    int
 	err       error
+	loaded    bool
 	cache     *cache.Cache
 	cacheKeys *cache.KeyBuilder
+	costs     aws.CostBreakdown
+	byAccount bool
 }
 
 type billingItemDelegate struct {
 	list.DefaultDelegate
-	styles Styles
+	styles    Styles
+	table     *tableLayout
+	byAccount bool
 }
 
 var billingColumns = []Column{
@@ -45,29 +54,45 @@ var billingColumns = []Column{
 	{Title: "Cost (This Month)", Width: 0.3},
 }
 
+var billingAccountColumns = []Column{
+	{Title: "Linked Account", Width: 0.7},
+	{Title: "Cost (This Month)", Width: 0.3},
+}
+
+func billingColumnsFor(byAccount bool) []Column {
+	if byAccount {
+		return billingAccountColumns
+	}
+	return billingColumns
+}
+
 func (d billingItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
 	i, ok := listItem.(billingItem)
 	if !ok {
 		return
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, billingColumns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, billingColumnsFor(d.byAccount), d.table)
 	isSelected := index == m.Index()
 
 	values := []string{
-		i.service,
+		i.name,
 		fmt.Sprintf("%s %s", i.amount, i.unit),
 	}
 
-	RenderTableRow(w, m, d.styles, colStyles, values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, values, isSelected)
 }
 
 func (d billingItemDelegate) Height() int { return 1 }
 
-func NewBillingModel(profile string, styles Styles, appCache *cache.Cache) BillingModel {
+func (d billingItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewBillingModel(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) BillingModel {
+	table := &tableLayout{display: display}
 	d := billingItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
 	d.Styles.SelectedDesc = styles.ListSelectedDesc
@@ -84,13 +109,14 @@ func NewBillingModel(profile string, styles Styles, appCache *cache.Cache) Billi
 	return BillingModel{
 		list:      l,
 		styles:    styles,
+		table:     table,
 		profile:   profile,
 		cache:     appCache,
 		cacheKeys: cache.NewKeyBuilder(profile),
 	}
 }
 
-type BillingMsg []aws.CostInfo
+type BillingMsg aws.CostBreakdown
 type BillingErrorMsg error
 
 func (m BillingModel) Init() tea.Cmd {
@@ -100,7 +126,7 @@ func (m BillingModel) Init() tea.Cmd {
 func (m BillingModel) fetchCosts() tea.Cmd {
 	return func() tea.Msg {
 		if cached, ok := m.cache.Get(m.cacheKeys.BillingResources()); ok {
-			if costs, ok := cached.([]aws.CostInfo); ok {
+			if costs, ok := cached.(aws.CostBreakdown); ok {
 				return BillingMsg(costs)
 			}
 		}
@@ -114,14 +140,8 @@ func (m BillingModel) fetchCosts() tea.Cmd {
 			return BillingErrorMsg(err)
 		}
 
-		sort.Slice(costs, func(i, j int) bool {
-			valI, _ := strconv.ParseFloat(costs[i].Amount, 64)
-			valJ, _ := strconv.ParseFloat(costs[j].Amount, 64)
-			return valI > valJ
-		})
-
-		m.cache.Set(m.cacheKeys.BillingResources(), costs, cache.TTLBillingResources)
-		return BillingMsg(costs)
+		m.cache.Set(m.cacheKeys.BillingResources(), *costs, cache.TTLBillingResources)
+		return BillingMsg(*costs)
 	}
 }
 
@@ -135,19 +155,12 @@ func (m BillingModel) Update(msg tea.Msg) (BillingModel, tea.Cmd) {
 		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
 	case BillingMsg:
-		items := make([]list.Item, len(msg))
-		for i, c := range msg {
-			amount := c.Amount
-			if val, err := strconv.ParseFloat(c.Amount, 64); err == nil {
-				amount = fmt.Sprintf("%.2f", val)
-			}
-			items[i] = billingItem{
-				service: c.Service,
-				amount:  amount,
-				unit:    c.Unit,
-			}
+		m.loaded = true
+		m.costs = aws.CostBreakdown(msg)
+		if !m.hasLinkedAccounts() {
+			m.byAccount = false
 		}
-		m.list.SetItems(items)
+		m.setItems()
 
 	case BillingErrorMsg:
 		m.err = msg
@@ -162,6 +175,12 @@ func (m BillingModel) Update(msg tea.Msg) (BillingModel, tea.Cmd) {
 		case "r":
 			m.cache.Delete(m.cacheKeys.BillingResources())
 			return m, m.fetchCosts()
+		case "g":
+			if m.hasLinkedAccounts() && m.list.FilterState() != list.Filtering {
+				m.byAccount = !m.byAccount
+				m.setItems()
+				return m, nil
+			}
 		}
 	}
 
@@ -169,12 +188,72 @@ func (m BillingModel) Update(msg tea.Msg) (BillingModel, tea.Cmd) {
 	return m, cmd
 }
 
+// hasLinkedAccounts reports whether the costs span several accounts, as they
+// do for an organization's payer account
+func (m BillingModel) hasLinkedAccounts() bool {
+	return len(m.costs.ByAccount) > 1
+}
+
+// setItems lists the costs by service or by account, most expensive first
+func (m *BillingModel) setItems() {
+	var items []billingItem
+	if m.byAccount {
+		for _, c := range m.costs.ByAccount {
+			name := c.AccountID
+			if c.Name != "" {
+				name += " (" + c.Name + ")"
+			}
+			items = append(items, newBillingItem(name, c.Amount, c.Unit))
+		}
+	} else {
+		for _, c := range m.costs.ByService {
+			items = append(items, newBillingItem(c.Service, c.Amount, c.Unit))
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].cost > items[j].cost })
+
+	listItems := make([]list.Item, len(items))
+	for i, item := range items {
+		listItems[i] = item
+	}
+	m.table.setItems(&m.list, listItems)
+	m.list.ResetSelected()
+
+	d := billingItemDelegate{
+		DefaultDelegate: list.NewDefaultDelegate(),
+		styles:          m.styles,
+		table:           m.table,
+		byAccount:       m.byAccount,
+	}
+	d.Styles.SelectedTitle = m.styles.ListSelectedTitle
+	d.Styles.SelectedDesc = m.styles.ListSelectedDesc
+	m.list.SetDelegate(d)
+}
+
+func newBillingItem(name, amount, unit string) billingItem {
+	item := billingItem{name: name, amount: amount, unit: unit}
+	if val, err := strconv.ParseFloat(amount, 64); err == nil {
+		item.cost = val
+		item.amount = fmt.Sprintf("%.2f", val)
+	}
+	return item
+}
+
+// columns are the columns of the table on screen
+func (m BillingModel) columns() []Column {
+	return billingColumnsFor(m.byAccount)
+}
+
 func (m BillingModel) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
+	}
+
+	if m.loaded && len(m.list.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.list, "costs", m.profile, m.table)
 	}
 
-	_, header := RenderTableHelpers(m.list, m.styles, billingColumns)
+	_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
 	return header + "\n" + m.list.View()
 }
 
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"github.com/giovannirossini/aws-tui/internal/aws"
+	"github.com/giovannirossini/aws-tui/internal/cache"
+)
+
+// Register every value type stored in the cache so it can be persisted to disk
+func init() {
+	for _, v := range []interface{}{
+		&aws.BucketDetails{},
+		&aws.DynamoContinuousBackups{},
+		&aws.IdentityInfo{},
+		EFSMountTargetsMsg{},
+		IAMUserDetailsMsg{},
+		aws.CostBreakdown{},
+		aws.LogGroupPage{},
+		[]string{},
+		[]aws.BackupJobInfo{},
+		[]aws.BackupPlanInfo{},
+		[]aws.BucketInfo{},
+		[]aws.CFDistributionInfo{},
+		[]aws.CacheClusterInfo{},
+		[]aws.CertificateInfo{},
+		[]aws.ClusterInfo{},
+		[]aws.DMSEndpointInfo{},
+		[]aws.DynamoBackupInfo{},
+		[]aws.DynamoTableInfo{},
+		[]aws.ElasticIPInfo{},
+		[]aws.ECSClusterInfo{},
+		[]aws.FileSystemInfo{},
+		[]aws.FlowLogInfo{},
+		[]aws.FunctionInfo{},
+		[]aws.HTTPAPIInfo{},
+		[]aws.HostedZoneInfo{},
+		[]aws.IAMGroupInfo{},
+		[]aws.IAMRoleInfo{},
+		[]aws.IAMUserInfo{},
+		[]aws.IPSetInfo{},
+		[]aws.ImageInfo{},
+		[]aws.InstanceInfo{},
+		[]aws.KMSKeyInfo{},
+		[]aws.MSKConfigurationInfo{},
+		[]aws.MountTargetInfo{},
+		[]aws.NatGatewayInfo{},
+		[]aws.ObjectInfo{},
+		[]aws.QueueInfo{},
+		[]aws.RDSClusterInfo{},
+		[]aws.RDSInstanceInfo{},
+		[]aws.RDSSnapshotInfo{},
+		[]aws.RDSSubnetGroupInfo{},
+		[]aws.ReplicationGroupInfo{},
+		[]aws.ReplicationInstanceInfo{},
+		[]aws.ReplicationTaskInfo{},
+		[]aws.RepositoryInfo{},
+		[]aws.RestAPIInfo{},
+		[]aws.RouteTableInfo{},
+		[]aws.SecretInfo{},
+		[]aws.SecurityFinding{},
+		[]aws.SecurityGroupInfo{},
+		[]aws.SubnetInfo{},
+		[]aws.TargetGroupInfo{},
+		[]aws.TaskDefinitionInfo{},
+		[]aws.TopicInfo{},
+		[]aws.TransferServerInfo{},
+		[]aws.TransferUserInfo{},
+		[]aws.UserPolicyInfo{},
+		[]aws.VPCInfo{},
+		[]aws.VolumeInfo{},
+		[]aws.VpnGatewayInfo{},
+		[]aws.WebACLInfo{},
+	} {
+		cache.Register(v)
+	}
+}
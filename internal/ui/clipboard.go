@@ -0,0 +1,29 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ClipboardMsg reports the outcome of copying text to the system clipboard
+type ClipboardMsg struct {
+	What string
+	Err  error
+}
+
+// copyToClipboard copies text and reports back with a ClipboardMsg, where
+// what names the copied thing for the toast
+func copyToClipboard(text, what string) tea.Cmd {
+	return func() tea.Msg {
+		return ClipboardMsg{What: what, Err: clipboard.WriteAll(text)}
+	}
+}
+
+func (m *Model) handleClipboard(msg ClipboardMsg) tea.Cmd {
+	if msg.Err != nil {
+		return m.showToast(fmt.Sprintf("✘ Could not copy %s: %v", msg.What, msg.Err))
+	}
+	return m.showToast(fmt.Sprintf("✔ Copied %s", msg.What))
+}
@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+// cfSampleEvent is the viewer request functions are tested against
+const cfSampleEvent = `{
+  "version": "1.0",
+  "context": {"eventType": "viewer-request"},
+  "viewer": {"ip": "198.51.100.11"},
+  "request": {
+    "method": "GET",
+    "uri": "/index.html",
+    "querystring": {"test": {"value": "aws-tui"}},
+    "headers": {"host": {"value": "example.com"}, "accept": {"value": "text/html"}},
+    "cookies": {}
+  }
+}`
+
+type CFFunctionDetailMsg aws.CFFunctionDetails
+type CFFunctionTestMsg aws.CFFunctionTestResult
+
+// CFFunctionPublishedMsg carries the name of the function published to LIVE
+type CFFunctionPublishedMsg string
+
+func (m CFModel) fetchFunction(name, stage string) tea.Cmd {
+	return func() tea.Msg {
+		client, err := aws.NewCloudFrontClient(context.Background(), m.profile)
+		if err != nil {
+			return CFErrorMsg(err)
+		}
+		fn, err := client.GetFunction(context.Background(), name, stage)
+		if err != nil {
+			return CFErrorMsg(err)
+		}
+		return CFFunctionDetailMsg(fn)
+	}
+}
+
+func (m CFModel) testFunction() tea.Cmd {
+	fn := m.function
+	return func() tea.Msg {
+		client, err := aws.NewCloudFrontClient(context.Background(), m.profile)
+		if err != nil {
+			return CFErrorMsg(err)
+		}
+		result, err := client.TestFunction(context.Background(), fn.Name, fn.Stage, fn.ETag, []byte(cfSampleEvent))
+		if err != nil {
+			return CFErrorMsg(err)
+		}
+		return CFFunctionTestMsg(result)
+	}
+}
+
+func (m CFModel) publishFunction() tea.Cmd {
+	fn := m.function
+	return armed(func() tea.Msg {
+		client, err := aws.NewCloudFrontClient(context.Background(), m.profile)
+		if err != nil {
+			return CFErrorMsg(err)
+		}
+		if err := client.PublishFunction(context.Background(), fn.Name, fn.ETag); err != nil {
+			return CFErrorMsg(err)
+		}
+		return CFFunctionPublishedMsg(fn.Name)
+	})
+}
+
+func (m CFModel) updateFunctionDetail(msg tea.KeyMsg) (CFModel, tea.Cmd) {
+	if m.state == CFStateConfirmPublish {
+		result, cmd := m.confirm.Update(msg)
+		if result != confirmPending {
+			m.state = CFStateFunctionDetail
+		}
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "esc", "backspace":
+		m.state = CFStateFunctions
+		m.updateDelegate()
+		return m, nil
+	case "t":
+		if !m.functionTesting {
+			m.functionTesting = true
+			m.functionTest = nil
+			m.setFunctionContent()
+			return m, m.testFunction()
+		}
+		return m, nil
+	case "u":
+		if m.function.Stage == "DEVELOPMENT" {
+			m.state = CFStateConfirmPublish
+			m.confirm = NewConfirmDialog("Confirm Publish", fmt.Sprintf("Publish the DEVELOPMENT stage of %s to LIVE?", m.function.Name), true, m.publishFunction)
+			m.confirm.Width = 56
+			m.confirm.Detail = " " + m.styles.StatusMuted.Render("Viewers are served the new code right away.")
+		}
+		return m, nil
+	}
+
+	if scrollViewport(&m.viewport, msg.String()) {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// setFunctionContent fills the viewport with the function's configuration,
+// the last test result and its code
+func (m *CFModel) setFunctionContent() {
+	fn := m.function
+	label := lipgloss.NewStyle().Foreground(m.styles.Primary).Width(22)
+	section := lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true)
+
+	var s strings.Builder
+	for _, f := range []detailField{
+		{"Name", fn.Name},
+		{"Stage", fn.Stage},
+		{"Status", renderStatus(m.styles, fn.Status)},
+		{"Runtime", fn.Runtime},
+		{"Last modified", fn.LastModified.Local().Format(absoluteTimeFormat)},
+		{"Comment", fn.Comment},
+	} {
+		if f.value != "" {
+			s.WriteString(label.Render(f.label) + f.value + "\n")
+		}
+	}
+
+	switch {
+	case m.functionTesting:
+		s.WriteString("\n" + section.Render("Test (viewer-request)") + "\n")
+		s.WriteString(m.styles.StatusMuted.Render("Running...") + "\n")
+	case m.functionTest != nil:
+		r := m.functionTest
+		s.WriteString("\n" + section.Render("Test (viewer-request)") + "\n")
+		s.WriteString(label.Render("Compute utilization") + r.ComputeUtilization + "%\n")
+		if r.Error != "" {
+			s.WriteString(label.Render("Error") + m.styles.Error.Render(r.Error) + "\n")
+		}
+		if r.Output != "" {
+			output := r.Output
+			var pretty bytes.Buffer
+			if json.Indent(&pretty, []byte(output), "", "  ") == nil {
+				output = pretty.String()
+			}
+			s.WriteString(label.Render("Output") + "\n" + output + "\n")
+		}
+		if len(r.Logs) > 0 {
+			s.WriteString(label.Render("Logs") + "\n" + strings.Join(r.Logs, "\n") + "\n")
+		}
+	}
+
+	s.WriteString("\n" + section.Render("Code") + "\n")
+	s.WriteString(fn.Code)
+
+	m.viewport.SetContent(s.String())
+	m.viewport.GotoTop()
+}
+
+func (m CFModel) renderFunctionDetail() string {
+	hint := "t test"
+	if m.function.Stage == "DEVELOPMENT" {
+		hint += ", u publish to LIVE"
+	}
+	detail := lipgloss.NewStyle().
+		Padding(1, 2).
+		Render(m.viewport.View() + "\n" + renderScrollIndicator(m.styles, m.viewport, m.styles.StatusMuted.Render("("+hint+", esc to go back)")))
+
+	if m.state != CFStateConfirmPublish {
+		return detail
+	}
+
+	w, h := GetMainContainerSize(m.width, m.height)
+	return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, m.confirm.View(m.styles))
+}
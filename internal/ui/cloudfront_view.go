@@ -6,6 +6,7 @@ import (
 	"io"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/giovannirossini/aws-tui/internal/aws"
 	"github.com/giovannirossini/aws-tui/internal/cache"
@@ -22,6 +23,8 @@ const (
 	CFStateInvalidations
 	CFStatePolicies
 	CFStateFunctions
+	CFStateFunctionDetail
+	CFStateConfirmPublish
 )
 
 type cfItem struct {
@@ -29,30 +32,44 @@ type cfItem struct {
 	description string
 	id          string
 	category    string
+	stage       string
 	values      []string
+	info        any
 }
 
 func (i cfItem) Title() string       { return i.title }
+func (i cfItem) source() any         { return i.info }
 func (i cfItem) Description() string { return i.description }
 func (i cfItem) FilterValue() string { return i.title + " " + i.description + " " + i.id }
+func (i cfItem) rowID() string       { return i.id }
+func (i cfItem) rowValues() []string { return i.values }
 
 type CFModel struct {
 	client         *aws.CloudFrontClient
 	list           list.Model
 	styles         Styles
+	table          *tableLayout
 	state          CFState
 	width          int
 	height         int
 	profile        string
 	err            error
+	loaded         bool
 	cache          *cache.Cache
 	cacheKeys      *cache.KeyBuilder
 	selectedDistro string
+	// Function detail
+	viewport        viewport.Model
+	function        aws.CFFunctionDetails
+	functionTest    *aws.CFFunctionTestResult
+	functionTesting bool
+	confirm         ConfirmDialog
 }
 
 type cfItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 	state  CFState
 }
 
@@ -89,9 +106,10 @@ var cfPolicyColumns = []Column{
 }
 
 var cfFunctionColumns = []Column{
-	{Title: "Name", Width: 0.4},
-	{Title: "Status", Width: 0.3},
-	{Title: "Runtime", Width: 0.3},
+	{Title: "Name", Width: 0.35},
+	{Title: "Status", Width: 0.25},
+	{Title: "Stage", Width: 0.2},
+	{Title: "Runtime", Width: 0.2},
 }
 
 func (d cfItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
@@ -101,7 +119,7 @@ func (d cfItemDelegate) Render(w io.Writer, m list.Model, index int, listItem li
 	}
 
 	if d.state == CFStateMenu || d.state == CFStateDistroSubMenu {
-		d.DefaultDelegate.Render(w, m, index, listItem)
+		d.table.renderMenuItem(d.DefaultDelegate, w, m, index, listItem)
 		return
 	}
 
@@ -121,23 +139,27 @@ func (d cfItemDelegate) Render(w io.Writer, m list.Model, index int, listItem li
 		columns = cfFunctionColumns
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, columns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, columns, d.table)
 	isSelected := index == m.Index()
 
-	RenderTableRow(w, m, d.styles, colStyles, i.values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, i.values, isSelected)
 }
 
 func (d cfItemDelegate) Height() int {
 	if d.state == CFStateMenu || d.state == CFStateDistroSubMenu {
-		return 2
+		return d.table.menuItemHeight()
 	}
 	return 1
 }
 
-func NewCFModel(profile string, styles Styles, appCache *cache.Cache) CFModel {
+func (d cfItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewCFModel(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) CFModel {
+	table := &tableLayout{display: display}
 	d := cfItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 		state:           CFStateMenu,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
@@ -152,7 +174,9 @@ func NewCFModel(profile string, styles Styles, appCache *cache.Cache) CFModel {
 	return CFModel{
 		list:      l,
 		styles:    styles,
+		table:     table,
 		state:     CFStateMenu,
+		viewport:  viewport.New(0, 0),
 		profile:   profile,
 		cache:     appCache,
 		cacheKeys: cache.NewKeyBuilder(profile),
@@ -284,12 +308,10 @@ func (m CFModel) Update(msg tea.Msg) (CFModel, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		m.width = msg.Width
-		m.height = msg.Height
-		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
+		m.SetSize(msg.Width, msg.Height)
 
 	case CFMenuMsg:
-		m.list.SetItems(msg)
+		m.table.setItems(&m.list, msg)
 		m.list.ResetSelected()
 		// Determine state based on items
 		if len(msg) > 0 {
@@ -302,6 +324,7 @@ func (m CFModel) Update(msg tea.Msg) (CFModel, tea.Cmd) {
 		m.updateDelegate()
 
 	case CFDistributionsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			enabled := "No"
@@ -314,19 +337,21 @@ func (m CFModel) Update(msg tea.Msg) (CFModel, tea.Cmd) {
 				description: v.Comment,
 				id:          v.ID,
 				category:    "distribution",
-				values:      []string{displayName, v.Status, v.Domain, v.Comment, enabled},
+				values:      []string{displayName, renderStatus(m.styles, v.Status), v.Domain, v.Comment, enabled},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = CFStateDistributions
 		m.updateDelegate()
 		return m, nil
 
 	case CFOriginsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			items[i] = cfItem{
+				info:        v,
 				title:       v.ID,
 				description: v.Domain,
 				id:          v.ID,
@@ -334,15 +359,17 @@ func (m CFModel) Update(msg tea.Msg) (CFModel, tea.Cmd) {
 				values:      []string{v.ID, v.Domain, v.Path},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = CFStateOrigins
 		m.updateDelegate()
 
 	case CFBehaviorsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			items[i] = cfItem{
+				info:        v,
 				title:       v.PathPattern,
 				description: v.TargetOriginID,
 				id:          v.PathPattern,
@@ -350,31 +377,35 @@ func (m CFModel) Update(msg tea.Msg) (CFModel, tea.Cmd) {
 				values:      []string{v.PathPattern, v.TargetOriginID, v.ViewerProtocol},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = CFStateBehaviors
 		m.updateDelegate()
 
 	case CFInvalidationsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			items[i] = cfItem{
+				info:        v,
 				title:       v.ID,
 				description: v.Status,
 				id:          v.ID,
 				category:    "invalidation",
-				values:      []string{v.ID, v.Status, v.CreateTime},
+				values:      []string{v.ID, renderStatus(m.styles, v.Status), v.CreateTime},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = CFStateInvalidations
 		m.updateDelegate()
 
 	case CFPoliciesMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			items[i] = cfItem{
+				info:        v,
 				title:       v.Name,
 				description: v.ID,
 				id:          v.ID,
@@ -382,12 +413,13 @@ func (m CFModel) Update(msg tea.Msg) (CFModel, tea.Cmd) {
 				values:      []string{v.ID, v.Name, v.Type},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = CFStatePolicies
 		m.updateDelegate()
 
 	case CFFunctionsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			items[i] = cfItem{
@@ -395,16 +427,36 @@ func (m CFModel) Update(msg tea.Msg) (CFModel, tea.Cmd) {
 				description: v.Status,
 				id:          v.Name,
 				category:    "function",
-				values:      []string{v.Name, v.Status, v.Runtime},
+				stage:       v.Stage,
+				values:      []string{v.Name, renderStatus(m.styles, v.Status), v.Stage, v.Runtime},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = CFStateFunctions
 		m.updateDelegate()
 
+	case CFFunctionDetailMsg:
+		m.function = aws.CFFunctionDetails(msg)
+		m.functionTest = nil
+		m.functionTesting = false
+		m.state = CFStateFunctionDetail
+		m.setFunctionContent()
+		return m, nil
+
+	case CFFunctionTestMsg:
+		result := aws.CFFunctionTestResult(msg)
+		m.functionTest = &result
+		m.functionTesting = false
+		m.setFunctionContent()
+		return m, nil
+
+	case CFFunctionPublishedMsg:
+		return m, m.fetchFunctions()
+
 	case CFErrorMsg:
 		m.err = msg
+		m.functionTesting = false
 
 	case tea.KeyMsg:
 		if m.err != nil {
@@ -412,6 +464,10 @@ func (m CFModel) Update(msg tea.Msg) (CFModel, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.state == CFStateFunctionDetail || m.state == CFStateConfirmPublish {
+			return m.updateFunctionDetail(msg)
+		}
+
 		switch msg.String() {
 		case "r":
 			switch m.state {
@@ -444,6 +500,8 @@ func (m CFModel) Update(msg tea.Msg) (CFModel, tea.Cmd) {
 				case CFStateDistributions:
 					m.selectedDistro = item.id
 					return m, m.showDistroSubMenu(item.id)
+				case CFStateFunctions:
+					return m, m.fetchFunction(item.id, item.stage)
 				case CFStateDistroSubMenu:
 					switch item.title {
 					case "Origins":
@@ -475,6 +533,7 @@ func (m *CFModel) updateDelegate() {
 	d := cfItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          m.styles,
+		table:           m.table,
 		state:           m.state,
 	}
 	d.Styles.SelectedTitle = m.styles.ListSelectedTitle
@@ -482,28 +541,50 @@ func (m *CFModel) updateDelegate() {
 	m.list.SetDelegate(d)
 }
 
+// cfResourceNames names the resources listed in each table state, for the empty-state message
+var cfResourceNames = map[CFState]string{
+	CFStateDistributions: "CloudFront distributions",
+	CFStateOrigins:       "origins",
+	CFStateBehaviors:     "behaviors",
+	CFStateInvalidations: "invalidations",
+	CFStatePolicies:      "policies",
+	CFStateFunctions:     "CloudFront functions",
+}
+
+// columns are the columns of the table on screen
+func (m CFModel) columns() []Column {
+	switch m.state {
+	case CFStateDistributions:
+		return cfDistroColumns
+	case CFStateOrigins:
+		return cfOriginColumns
+	case CFStateBehaviors:
+		return cfBehaviorColumns
+	case CFStateInvalidations:
+		return cfInvalidationColumns
+	case CFStatePolicies:
+		return cfPolicyColumns
+	case CFStateFunctions:
+		return cfFunctionColumns
+	}
+	return nil
+}
+
 func (m CFModel) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
+	}
+
+	if m.state == CFStateFunctionDetail || m.state == CFStateConfirmPublish {
+		return m.renderFunctionDetail()
+	}
+
+	if resource, ok := cfResourceNames[m.state]; ok && m.loaded && len(m.list.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.list, resource, m.profile, m.table)
 	}
 
 	if m.state != CFStateMenu && m.state != CFStateDistroSubMenu {
-		var columns []Column
-		switch m.state {
-		case CFStateDistributions:
-			columns = cfDistroColumns
-		case CFStateOrigins:
-			columns = cfOriginColumns
-		case CFStateBehaviors:
-			columns = cfBehaviorColumns
-		case CFStateInvalidations:
-			columns = cfInvalidationColumns
-		case CFStatePolicies:
-			columns = cfPolicyColumns
-		case CFStateFunctions:
-			columns = cfFunctionColumns
-		}
-		_, header := RenderTableHelpers(m.list, m.styles, columns)
+		_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
 		return header + "\n" + m.list.View()
 	}
 
@@ -514,4 +595,6 @@ func (m *CFModel) SetSize(width, height int) {
 	m.width = width
 	m.height = height
 	m.list.SetSize(GetInnerListSize(width, height))
+	m.viewport.Width = width - InnerContentWidthOffset
+	m.viewport.Height = height - AppInternalFooterHeight - 5
 }
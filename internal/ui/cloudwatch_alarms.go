@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+type CWAlarmsMsg []aws.AlarmInfo
+type CWAlarmHistoryMsg []aws.AlarmHistoryInfo
+
+var alarmColumns = []Column{
+	{Title: "Alarm Name", Width: 0.35},
+	{Title: "State", Width: 0.15},
+	{Title: "Metric", Width: 0.2},
+	{Title: "Threshold", Width: 0.15},
+	{Title: "Updated", Width: 0.15},
+}
+
+var alarmHistoryColumns = []Column{
+	{Title: "Time", Width: 0.2},
+	{Title: "From", Width: 0.15},
+	{Title: "To", Width: 0.15},
+	{Title: "Summary", Width: 0.5},
+}
+
+// comparisonSymbols shortens the alarm comparison operators for the table
+var comparisonSymbols = map[string]string{
+	"GreaterThanOrEqualToThreshold": ">=",
+	"GreaterThanThreshold":          ">",
+	"LessThanThreshold":             "<",
+	"LessThanOrEqualToThreshold":    "<=",
+}
+
+// formatThreshold renders an alarm condition such as "> 80". Anomaly
+// detection operators compare against a band and keep their name.
+func formatThreshold(operator string, threshold float64) string {
+	symbol, ok := comparisonSymbols[operator]
+	if !ok {
+		return operator
+	}
+	return symbol + " " + strconv.FormatFloat(threshold, 'g', -1, 64)
+}
+
+func (m CWModel) fetchAlarms() tea.Cmd {
+	return func() tea.Msg {
+		client, err := aws.NewCloudWatchClient(context.Background(), m.profile)
+		if err != nil {
+			return CWErrorMsg(err)
+		}
+		alarms, err := client.ListAlarms(context.Background())
+		if err != nil {
+			return CWErrorMsg(err)
+		}
+		return CWAlarmsMsg(alarms)
+	}
+}
+
+func (m CWModel) fetchAlarmHistory(name string) tea.Cmd {
+	return func() tea.Msg {
+		client, err := aws.NewCloudWatchClient(context.Background(), m.profile)
+		if err != nil {
+			return CWErrorMsg(err)
+		}
+		history, err := client.GetAlarmHistory(context.Background(), name)
+		if err != nil {
+			return CWErrorMsg(err)
+		}
+		return CWAlarmHistoryMsg(history)
+	}
+}
+
+// alarmSummaryLines is the height of the configuration shown above the history
+const alarmSummaryLines = 3
+
+// renderAlarmSummary shows the selected alarm's configuration and current
+// state above its state-change history
+func (m CWModel) renderAlarmSummary() string {
+	a := m.selectedAlarm
+	sep := m.styles.StatusMuted.Render(" • ")
+	label := func(s string) string { return m.styles.StatusMuted.Render(s + ": ") }
+
+	dimensions := "none"
+	if len(a.Dimensions) > 0 {
+		dimensions = strings.Join(a.Dimensions, ", ")
+	}
+	evaluation := fmt.Sprintf("%d periods", a.EvaluationPeriods)
+	if a.DatapointsToAlarm > 0 {
+		evaluation = fmt.Sprintf("%d of %d datapoints", a.DatapointsToAlarm, a.EvaluationPeriods)
+	}
+	missing := a.TreatMissingData
+	if missing == "" {
+		missing = "missing"
+	}
+
+	metric := []string{
+		label("Namespace") + a.Namespace,
+		label("Metric") + a.MetricName,
+		label("Dimensions") + dimensions,
+	}
+	condition := []string{
+		label("Statistic") + a.Statistic,
+		label("Period") + (time.Duration(a.Period) * time.Second).String(),
+		label("Evaluation") + evaluation,
+		label("Condition") + formatThreshold(a.ComparisonOperator, a.Threshold),
+		label("Missing data") + missing,
+	}
+	state := label("State") + renderStatus(m.styles, a.State)
+	if a.StateReason != "" {
+		state += sep + m.styles.StatusMuted.Render(a.StateReason)
+	}
+
+	line := func(s string) string {
+		return lipgloss.NewStyle().MaxWidth(m.width - InnerContentWidthOffset).Render("  " + s)
+	}
+	return strings.Join([]string{
+		line(strings.Join(metric, sep)),
+		line(strings.Join(condition, sep)),
+		line(state),
+	}, "\n")
+}
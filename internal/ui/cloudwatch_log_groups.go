@@ -0,0 +1,178 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+	"github.com/giovannirossini/aws-tui/internal/cache"
+)
+
+// logGroupsLookahead is how close to the last loaded log group the cursor
+// gets before the next page is requested
+const logGroupsLookahead = 10
+
+// CWLogGroupsMsg carries a page of the log groups matching prefix. Pages after
+// the first are appended to the groups already listed. stats is nil for a
+// first page read from the cache.
+type CWLogGroupsMsg struct {
+	prefix    string
+	groups    []aws.LogGroupInfo
+	nextToken string
+	appended  bool
+	stats     *fetchStats
+}
+
+// logGroupsCacheKey keys the first page of the log groups of the current prefix
+func (m CWModel) logGroupsCacheKey() string {
+	if m.groupPrefix == "" {
+		return m.cacheKeys.CWResources("log-groups")
+	}
+	return m.cacheKeys.CWResources("log-groups:" + m.groupPrefix)
+}
+
+// fetchLogGroups lists the first page of the log groups of the current prefix
+func (m CWModel) fetchLogGroups() tea.Cmd {
+	prefix := m.groupPrefix
+	cacheKey := m.logGroupsCacheKey()
+	return func() tea.Msg {
+		if cached, ok := m.cache.Get(cacheKey); ok {
+			if page, ok := cached.(aws.LogGroupPage); ok {
+				return CWLogGroupsMsg{prefix: prefix, groups: page.Groups, nextToken: page.NextToken}
+			}
+		}
+
+		client, err := aws.NewCloudWatchClient(context.Background(), m.profile)
+		if err != nil {
+			return CWErrorMsg(err)
+		}
+		ctx, done := measureFetch()
+		page, err := client.ListLogGroupsPage(ctx, prefix, "")
+		if err != nil {
+			return CWErrorMsg(err)
+		}
+		m.cache.Set(cacheKey, *page, cache.TTLCWResources)
+		return CWLogGroupsMsg{prefix: prefix, groups: page.Groups, nextToken: page.NextToken, stats: done(len(page.Groups))}
+	}
+}
+
+// fetchMoreLogGroups lists the page after the loaded log groups. Later pages
+// are not cached: the token that reaches them goes stale with the first page.
+func (m CWModel) fetchMoreLogGroups() tea.Cmd {
+	prefix, token := m.groupPrefix, m.groupsNextToken
+	return func() tea.Msg {
+		client, err := aws.NewCloudWatchClient(context.Background(), m.profile)
+		if err != nil {
+			return CWErrorMsg(err)
+		}
+		ctx, done := measureFetch()
+		page, err := client.ListLogGroupsPage(ctx, prefix, token)
+		if err != nil {
+			return CWErrorMsg(err)
+		}
+		return CWLogGroupsMsg{prefix: prefix, groups: page.Groups, nextToken: page.NextToken, appended: true, stats: done(len(page.Groups))}
+	}
+}
+
+func (m *CWModel) setLogGroups(msg CWLogGroupsMsg) tea.Cmd {
+	// A page of a prefix search that has since been changed
+	if msg.prefix != m.groupPrefix {
+		return nil
+	}
+	if msg.appended {
+		m.loadingMoreGroups = false
+		if m.state != CWStateLogGroups {
+			return nil
+		}
+	}
+
+	items := make([]list.Item, len(msg.groups))
+	for i, v := range msg.groups {
+		retention := "Never"
+		if v.RetentionDays > 0 {
+			retention = fmt.Sprintf("%d days", v.RetentionDays)
+		}
+		items[i] = cwItem{
+			title:       v.Name,
+			description: v.Arn,
+			id:          v.Name,
+			category:    "log-group",
+			values:      []string{v.Name, retention, fmt.Sprintf("%d", v.StoredBytes), v.CreationTime},
+		}
+	}
+
+	m.loaded = true
+	m.groupsNextToken = msg.nextToken
+	if msg.appended {
+		m.list.SetItems(slices.Concat(m.list.Items(), items))
+		// A page after a cached first one is counted on its own
+		m.groupsStats = m.groupsStats.add(msg.stats)
+		m.groupsStats.items = len(m.list.Items())
+	} else {
+		m.groupsStats = msg.stats
+		m.list.SetItems(items)
+		m.list.ResetSelected()
+		m.state = CWStateLogGroups
+		m.updateDelegate()
+	}
+	// Pages filtered by a prefix can come back short, even empty
+	return m.loadMoreLogGroups()
+}
+
+// loadMoreLogGroups requests the next page once the cursor nears the end of
+// the loaded log groups
+func (m *CWModel) loadMoreLogGroups() tea.Cmd {
+	if m.state != CWStateLogGroups || m.groupsNextToken == "" || m.loadingMoreGroups {
+		return nil
+	}
+	if m.list.FilterState() != list.Unfiltered || m.list.Index() < len(m.list.Items())-logGroupsLookahead {
+		return nil
+	}
+	m.loadingMoreGroups = true
+	return m.fetchMoreLogGroups()
+}
+
+func (m *CWModel) openPrefixInput() {
+	m.prefixInput = textinput.New()
+	m.prefixInput.Placeholder = "/aws/lambda/"
+	m.prefixInput.Width = 50
+	m.prefixInput.SetValue(m.groupPrefix)
+	m.prefixInput.Focus()
+	m.prefixing = true
+}
+
+// updatePrefixInput searches the log groups on the server for the typed
+// prefix; an empty prefix lists every group again
+func (m CWModel) updatePrefixInput(msg tea.KeyMsg) (CWModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.prefixing = false
+		return m, nil
+	case "enter":
+		m.prefixing = false
+		m.groupPrefix = strings.TrimSpace(m.prefixInput.Value())
+		m.groupsNextToken = ""
+		m.loaded = false
+		m.list.SetItems(nil)
+		return m, m.fetchLogGroups()
+	}
+	var cmd tea.Cmd
+	m.prefixInput, cmd = m.prefixInput.Update(msg)
+	return m, cmd
+}
+
+func (m CWModel) renderPrefixInput(base string) string {
+	return RenderOverlay(base, m.styles.Popup.Width(60).Render(fmt.Sprintf(
+		" %s\n %s\n\n %s\n\n %s",
+		lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render("Search log groups by prefix"),
+		m.styles.StatusMuted.Render("Names are matched from the start and case-sensitively"),
+		m.prefixInput.View(),
+		m.styles.StatusMuted.Render("(enter to search, empty for all, esc to cancel)"),
+	)), m.width, m.height)
+}
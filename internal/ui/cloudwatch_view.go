@@ -12,6 +12,8 @@ import (
 	"github.com/alecthomas/chroma/v2/lexers"
 	"github.com/alecthomas/chroma/v2/styles"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/giovannirossini/aws-tui/internal/aws"
@@ -26,6 +28,8 @@ const (
 	CWStateLogStreams
 	CWStateLogEvents
 	CWStateLogDetail
+	CWStateAlarms
+	CWStateAlarmDetail
 )
 
 type cwItem struct {
@@ -39,27 +43,53 @@ type cwItem struct {
 func (i cwItem) Title() string       { return i.title }
 func (i cwItem) Description() string { return i.description }
 func (i cwItem) FilterValue() string { return i.title + " " + i.description + " " + i.id }
+func (i cwItem) rowID() string       { return i.id }
+func (i cwItem) rowValues() []string { return i.values }
 
 type CWModel struct {
 	client          *aws.CloudWatchClient
 	list            list.Model
+	viewport        viewport.Model
+	search          viewportSearch
 	styles          Styles
+	table           *tableLayout
 	state           CWState
 	width           int
 	height          int
 	profile         string
 	err             error
+	loaded          bool
 	cache           *cache.Cache
 	cacheKeys       *cache.KeyBuilder
 	selectedGroup   string
 	selectedStream  string
 	selectedMessage string
 	originView      viewState
+
+	// noWrap shows long log lines unwrapped, scrolled with left/right
+	noWrap bool
+	// streamsByName lists log streams alphabetically instead of most
+	// recently active first
+	streamsByName bool
+	// alarms keeps the listed alarms for the detail of the selected one
+	alarms        []aws.AlarmInfo
+	selectedAlarm aws.AlarmInfo
+
+	// groupPrefix narrows the log groups on the server; groupsNextToken is
+	// the page listed next when the cursor nears the end of the loaded ones
+	groupPrefix       string
+	groupsNextToken   string
+	loadingMoreGroups bool
+	prefixing         bool
+	prefixInput       textinput.Model
+	// How the loaded log groups were fetched
+	groupsStats *fetchStats
 }
 
 type cwItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 	state  CWState
 }
 
@@ -88,7 +118,7 @@ func (d cwItemDelegate) Render(w io.Writer, m list.Model, index int, listItem li
 	}
 
 	if d.state == CWStateMenu {
-		d.DefaultDelegate.Render(w, m, index, listItem)
+		d.table.renderMenuItem(d.DefaultDelegate, w, m, index, listItem)
 		return
 	}
 
@@ -100,25 +130,33 @@ func (d cwItemDelegate) Render(w io.Writer, m list.Model, index int, listItem li
 		columns = logStreamColumns
 	case CWStateLogEvents:
 		columns = logEventColumns
+	case CWStateAlarms:
+		columns = alarmColumns
+	case CWStateAlarmDetail:
+		columns = alarmHistoryColumns
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, columns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, columns, d.table)
 	isSelected := index == m.Index()
 
-	RenderTableRow(w, m, d.styles, colStyles, i.values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, i.values, isSelected)
 }
 
 func (d cwItemDelegate) Height() int {
 	if d.state == CWStateMenu {
-		return 2
+		return d.table.menuItemHeight()
 	}
 	return 1
 }
 
-func NewCWModel(profile string, styles Styles, appCache *cache.Cache) CWModel {
+func (d cwItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewCWModel(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) CWModel {
+	table := &tableLayout{display: display}
 	d := cwItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 		state:           CWStateMenu,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
@@ -132,7 +170,10 @@ func NewCWModel(profile string, styles Styles, appCache *cache.Cache) CWModel {
 
 	return CWModel{
 		list:      l,
+		viewport:  viewport.New(0, 0),
+		search:    newViewportSearch(),
 		styles:    styles,
+		table:     table,
 		state:     CWStateMenu,
 		profile:   profile,
 		cache:     appCache,
@@ -140,7 +181,6 @@ func NewCWModel(profile string, styles Styles, appCache *cache.Cache) CWModel {
 	}
 }
 
-type CWLogGroupsMsg []aws.LogGroupInfo
 type CWLogStreamsMsg []aws.LogStreamInfo
 type CWLogEventsMsg []aws.LogEventInfo
 type CWErrorMsg error
@@ -154,44 +194,31 @@ func (m CWModel) showMenu() tea.Cmd {
 	return func() tea.Msg {
 		items := []list.Item{
 			cwItem{title: "Log Groups", description: "CloudWatch Log Groups", category: "menu"},
+			cwItem{title: "Alarms", description: "CloudWatch Metric Alarms", category: "menu"},
 		}
 		return CWMenuMsg(items)
 	}
 }
 
-func (m CWModel) fetchLogGroups() tea.Cmd {
+func (m CWModel) fetchLogStreams(groupName string) tea.Cmd {
 	return func() tea.Msg {
-		if cached, ok := m.cache.Get(m.cacheKeys.CWResources("log-groups")); ok {
-			if groups, ok := cached.([]aws.LogGroupInfo); ok {
-				return CWLogGroupsMsg(groups)
-			}
-		}
-
 		client, err := aws.NewCloudWatchClient(context.Background(), m.profile)
 		if err != nil {
 			return CWErrorMsg(err)
 		}
-		groups, err := client.ListLogGroups(context.Background())
+		streams, err := client.ListLogStreams(context.Background(), groupName, m.streamOrder())
 		if err != nil {
 			return CWErrorMsg(err)
 		}
-		m.cache.Set(m.cacheKeys.CWResources("log-groups"), groups, cache.TTLCWResources)
-		return CWLogGroupsMsg(groups)
+		return CWLogStreamsMsg(streams)
 	}
 }
 
-func (m CWModel) fetchLogStreams(groupName string) tea.Cmd {
-	return func() tea.Msg {
-		client, err := aws.NewCloudWatchClient(context.Background(), m.profile)
-		if err != nil {
-			return CWErrorMsg(err)
-		}
-		streams, err := client.ListLogStreams(context.Background(), groupName)
-		if err != nil {
-			return CWErrorMsg(err)
-		}
-		return CWLogStreamsMsg(streams)
+func (m CWModel) streamOrder() aws.LogStreamOrder {
+	if m.streamsByName {
+		return aws.LogStreamsByName
 	}
+	return aws.LogStreamsByLastEvent
 }
 
 func (m CWModel) fetchLogEvents(groupName, streamName string) tea.Cmd {
@@ -218,32 +245,16 @@ func (m CWModel) Update(msg tea.Msg) (CWModel, tea.Cmd) {
 		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
 	case CWMenuMsg:
-		m.list.SetItems(msg)
+		m.table.setItems(&m.list, msg)
 		m.list.ResetSelected()
 		m.state = CWStateMenu
 		m.updateDelegate()
 
 	case CWLogGroupsMsg:
-		items := make([]list.Item, len(msg))
-		for i, v := range msg {
-			retention := "Never"
-			if v.RetentionDays > 0 {
-				retention = fmt.Sprintf("%d days", v.RetentionDays)
-			}
-			items[i] = cwItem{
-				title:       v.Name,
-				description: v.Arn,
-				id:          v.Name,
-				category:    "log-group",
-				values:      []string{v.Name, retention, fmt.Sprintf("%d", v.StoredBytes), v.CreationTime},
-			}
-		}
-		m.list.SetItems(items)
-		m.list.ResetSelected()
-		m.state = CWStateLogGroups
-		m.updateDelegate()
+		return m, m.setLogGroups(msg)
 
 	case CWLogStreamsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			items[i] = cwItem{
@@ -251,15 +262,16 @@ func (m CWModel) Update(msg tea.Msg) (CWModel, tea.Cmd) {
 				description: v.Arn,
 				id:          v.Name,
 				category:    "log-stream",
-				values:      []string{v.Name, v.LastEventTimestamp, v.CreationTime},
+				values:      []string{v.Name, humanizeTime(v.LastEvent), v.CreationTime},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = CWStateLogStreams
 		m.updateDelegate()
 
 	case CWLogEventsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			ts := time.Unix(v.Timestamp/1000, 0).Format("15:04:05")
@@ -271,11 +283,56 @@ func (m CWModel) Update(msg tea.Msg) (CWModel, tea.Cmd) {
 				values:      []string{ts, v.Message},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = CWStateLogEvents
 		m.updateDelegate()
 
+	case CWAlarmsMsg:
+		m.loaded = true
+		m.alarms = msg
+		items := make([]list.Item, len(msg))
+		for i, v := range msg {
+			items[i] = cwItem{
+				title:       v.Name,
+				description: v.ARN,
+				id:          v.Name,
+				category:    "alarm",
+				values: []string{
+					v.Name,
+					renderStatus(m.styles, v.State),
+					v.MetricName,
+					formatThreshold(v.ComparisonOperator, v.Threshold),
+					humanizeTime(v.StateUpdated),
+				},
+			}
+		}
+		m.table.setItems(&m.list, items)
+		m.list.ResetSelected()
+		m.state = CWStateAlarms
+		m.updateDelegate()
+
+	case CWAlarmHistoryMsg:
+		m.loaded = true
+		items := make([]list.Item, len(msg))
+		for i, v := range msg {
+			items[i] = cwItem{
+				title:       v.Summary,
+				description: v.Timestamp.Format(absoluteTimeFormat),
+				category:    "alarm-history",
+				values: []string{
+					humanizeTime(v.Timestamp),
+					renderStatus(m.styles, v.OldState),
+					renderStatus(m.styles, v.NewState),
+					v.Summary,
+				},
+			}
+		}
+		m.table.setItems(&m.list, items)
+		m.list.ResetSelected()
+		m.state = CWStateAlarmDetail
+		m.updateDelegate()
+
 	case CWErrorMsg:
 		m.err = msg
 
@@ -285,21 +342,73 @@ func (m CWModel) Update(msg tea.Msg) (CWModel, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.state == CWStateLogDetail {
+			if handled, cmd := m.search.update(&m.viewport, m.styles, msg); handled {
+				return m, cmd
+			}
+			switch msg.String() {
+			case "esc", "backspace":
+				m.state = CWStateLogEvents
+				return m, nil
+			case "w":
+				m.noWrap = !m.noWrap
+				m.viewport.SetXOffset(0)
+				m.setDetailContent()
+				return m, nil
+			case "y":
+				// Copy the message as logged, not the highlighted rendering
+				return m, copyToClipboard(m.selectedMessage, "log event")
+			}
+			if scrollViewport(&m.viewport, msg.String()) {
+				return m, nil
+			}
+			m.viewport, cmd = m.viewport.Update(msg)
+			return m, cmd
+		}
+
+		if m.state == CWStateLogGroups && m.prefixing {
+			return m.updatePrefixInput(msg)
+		}
+
 		switch msg.String() {
+		case "s":
+			if m.state == CWStateLogStreams && m.list.FilterState() != list.Filtering {
+				m.streamsByName = !m.streamsByName
+				return m, m.fetchLogStreams(m.selectedGroup)
+			}
+		case "/":
+			if m.state == CWStateLogGroups && m.list.FilterState() == list.Unfiltered {
+				m.openPrefixInput()
+				return m, textinput.Blink
+			}
 		case "r":
 			if m.state == CWStateLogGroups {
-				m.cache.Delete(m.cacheKeys.CWResources("log-groups"))
+				m.cache.Delete(m.logGroupsCacheKey())
 				return m, m.fetchLogGroups()
 			} else if m.state == CWStateLogStreams {
 				return m, m.fetchLogStreams(m.selectedGroup)
 			} else if m.state == CWStateLogEvents {
 				return m, m.fetchLogEvents(m.selectedGroup, m.selectedStream)
+			} else if m.state == CWStateAlarms {
+				return m, m.fetchAlarms()
+			} else if m.state == CWStateAlarmDetail {
+				return m, m.fetchAlarmHistory(m.selectedAlarm.Name)
 			}
 		case "enter":
 			if item, ok := m.list.SelectedItem().(cwItem); ok {
 				if m.state == CWStateMenu {
-					if item.title == "Log Groups" {
+					switch item.title {
+					case "Log Groups":
 						return m, m.fetchLogGroups()
+					case "Alarms":
+						return m, m.fetchAlarms()
+					}
+				} else if m.state == CWStateAlarms {
+					for _, a := range m.alarms {
+						if a.Name == item.id {
+							m.selectedAlarm = a
+							return m, m.fetchAlarmHistory(a.Name)
+						}
 					}
 				} else if m.state == CWStateLogGroups {
 					m.selectedGroup = item.id
@@ -310,24 +419,35 @@ func (m CWModel) Update(msg tea.Msg) (CWModel, tea.Cmd) {
 				} else if m.state == CWStateLogEvents {
 					m.selectedMessage = item.title
 					m.state = CWStateLogDetail
+					m.search.reset()
+					m.setDetailContent()
+					m.viewport.GotoTop()
 					return m, nil
 				}
 			}
 		case "backspace", "esc":
-			if m.state == CWStateLogGroups {
+			if m.state == CWStateLogGroups && m.groupPrefix != "" && m.list.FilterState() == list.Unfiltered {
+				// Clear the prefix search before leaving the log groups
+				m.groupPrefix = ""
+				return m, m.fetchLogGroups()
+			}
+			if m.state == CWStateLogGroups || m.state == CWStateAlarms {
 				return m, m.showMenu()
 			} else if m.state == CWStateLogStreams {
 				return m, m.fetchLogGroups()
 			} else if m.state == CWStateLogEvents {
 				return m, m.fetchLogStreams(m.selectedGroup)
-			} else if m.state == CWStateLogDetail {
-				m.state = CWStateLogEvents
-				return m, nil
+			} else if m.state == CWStateAlarmDetail {
+				alarms := m.alarms
+				return m, func() tea.Msg { return CWAlarmsMsg(alarms) }
 			}
 		}
 	}
 
 	m.list, cmd = m.list.Update(msg)
+	if more := m.loadMoreLogGroups(); more != nil {
+		cmd = tea.Batch(cmd, more)
+	}
 	return m, cmd
 }
 
@@ -335,6 +455,7 @@ func (m *CWModel) updateDelegate() {
 	d := cwItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          m.styles,
+		table:           m.table,
 		state:           m.state,
 	}
 	d.Styles.SelectedTitle = m.styles.ListSelectedTitle
@@ -343,6 +464,12 @@ func (m *CWModel) updateDelegate() {
 }
 
 func (m CWModel) highlightLog(content string) string {
+	return highlightLog(m.styles, content)
+}
+
+// highlightLog colours content with the lexer it looks like, pretty-printing
+// JSON first
+func highlightLog(st Styles, content string) string {
 	lexer := lexers.Analyse(content)
 	if lexer == nil {
 		lexer = lexers.Fallback
@@ -357,12 +484,15 @@ func (m CWModel) highlightLog(content string) string {
 		}
 	}
 
-	style := styles.Get("monokai")
+	style := styles.Get(st.ChromaStyle)
 	if style == nil {
 		style = styles.Fallback
 	}
 
 	formatter := formatters.Get("terminal256")
+	if st.NoColor {
+		formatter = formatters.NoOp
+	}
 	if formatter == nil {
 		formatter = formatters.Fallback
 	}
@@ -385,46 +515,102 @@ func (m CWModel) highlightLog(content string) string {
 		if i == len(lines)-1 && line == "" {
 			continue
 		}
-		lineNumber := m.styles.StatusMuted.Render(fmt.Sprintf("%3d | ", i+1))
+		lineNumber := st.StatusMuted.Render(fmt.Sprintf("%3d | ", i+1))
 		numberedLines = append(numberedLines, lineNumber+line)
 	}
 
 	return strings.Join(numberedLines, "\n")
 }
 
+// cwResourceNames names the resources listed in each table state, for the empty-state message
+var cwResourceNames = map[CWState]string{
+	CWStateLogGroups:  "log groups",
+	CWStateLogStreams: "log streams",
+	CWStateLogEvents:  "log events",
+	CWStateAlarms:     "metric alarms",
+}
+
+// alarmHistoryEmpty is shown when an alarm has not changed state recently
+const alarmHistoryEmpty = "No state changes in the alarm history"
+
+// columns are the columns of the table on screen
+func (m CWModel) columns() []Column {
+	switch m.state {
+	case CWStateLogGroups:
+		return logGroupColumns
+	case CWStateLogStreams:
+		return logStreamColumns
+	case CWStateLogEvents:
+		return logEventColumns
+	case CWStateAlarms:
+		return alarmColumns
+	case CWStateAlarmDetail:
+		return alarmHistoryColumns
+	}
+	return nil
+}
+
 func (m CWModel) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
 	}
 
 	if m.state == CWStateLogDetail {
-		displayMsg := m.highlightLog(m.selectedMessage)
-
 		return lipgloss.NewStyle().
-			Width(m.width-InnerContentWidthOffset).
 			Padding(1, 2).
-			Render(displayMsg)
+			Render(m.viewport.View() + "\n" + renderScrollIndicator(m.styles, m.viewport, m.search.view(m.styles)))
+	}
+
+	if resource, ok := cwResourceNames[m.state]; ok && m.loaded && len(m.list.Items()) == 0 {
+		if m.state == CWStateLogGroups && m.groupPrefix != "" {
+			resource = "log groups starting with " + m.groupPrefix
+		}
+		empty := RenderEmptyState(m.styles, m.list, resource, m.profile, m.table)
+		if m.state == CWStateLogGroups && m.prefixing {
+			return m.renderPrefixInput(empty)
+		}
+		return empty
 	}
 
 	if m.state != CWStateMenu {
-		var columns []Column
-		switch m.state {
-		case CWStateLogGroups:
-			columns = logGroupColumns
-		case CWStateLogStreams:
-			columns = logStreamColumns
-		case CWStateLogEvents:
-			columns = logEventColumns
+		_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
+		if m.state == CWStateAlarmDetail {
+			if m.loaded && len(m.list.Items()) == 0 {
+				return m.renderAlarmSummary() + "\n\n  " + m.styles.StatusMuted.Render(alarmHistoryEmpty)
+			}
+			// Make room for the alarm configuration above the table
+			m.list.SetHeight(m.list.Height() - alarmSummaryLines - 1)
+			return m.renderAlarmSummary() + "\n\n" + header + "\n" + m.list.View()
+		}
+		if m.state == CWStateLogGroups && m.prefixing {
+			return m.renderPrefixInput(header + "\n" + m.list.View())
 		}
-		_, header := RenderTableHelpers(m.list, m.styles, columns)
 		return header + "\n" + m.list.View()
 	}
 
 	return m.list.View()
 }
 
+// setDetailContent renders the selected log event into the detail viewport,
+// wrapped to its width unless wrapping is turned off
+func (m *CWModel) setDetailContent() {
+	content := m.highlightLog(m.selectedMessage)
+	if m.noWrap {
+		m.viewport.SetHorizontalStep(4)
+	} else {
+		m.viewport.SetHorizontalStep(0)
+		content = lipgloss.NewStyle().Width(m.viewport.Width).Render(content)
+	}
+	m.search.setContent(&m.viewport, m.styles, content)
+}
+
 func (m *CWModel) SetSize(width, height int) {
 	m.width = width
 	m.height = height
 	m.list.SetSize(GetInnerListSize(width, height))
+	m.viewport.Width = width - InnerContentWidthOffset - 4
+	m.viewport.Height = height - AppInternalFooterHeight - 5
+	if m.state == CWStateLogDetail {
+		m.setDetailContent()
+	}
 }
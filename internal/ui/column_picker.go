@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/logging"
+)
+
+// columnService is the service the column choices are kept under: the first
+// part of the breadcrumb, such as "EC2" or "Route 53"
+func (m Model) columnService() string {
+	service, _, _ := strings.Cut(m.getViewTitle(), " / ")
+	return service
+}
+
+// hiddenColumnSet returns the titles hidden in the service on screen
+func (m Model) hiddenColumnSet() map[string]bool {
+	titles := m.config.HiddenColumns[m.columnService()]
+	if len(titles) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(titles))
+	for _, t := range titles {
+		set[t] = true
+	}
+	return set
+}
+
+// syncTable hands the table on screen the columns hidden in its service, the
+// rows pinned in the view, its region and the refresh key, since the view
+// renders without access to the config, the pins or the keymap
+func (m *Model) syncTable() {
+	if t, _ := m.activeTable(); t != nil {
+		t.hidden = m.hiddenColumnSet()
+		t.pinned = m.pins[m.getViewTitle()]
+		t.region = m.listingRegion()
+		t.refreshKey = m.keys.key("refresh")
+	}
+}
+
+// listingRegion is the region the view on screen lists, or none for the
+// global services
+func (m Model) listingRegion() string {
+	switch m.view {
+	case viewIAM, viewRoute53, viewCF, viewBilling:
+		return ""
+	}
+	return m.region()
+}
+
+// openColumnPicker lists the columns of the table on screen
+func (m *Model) openColumnPicker() tea.Cmd {
+	t, columns := m.activeTable()
+	if m.view == viewHome || t == nil || len(columns) < 2 {
+		return m.showToast("No table to pick columns for")
+	}
+	m.columnPickerColumns = columns
+	m.columnPickerService = m.columnService()
+	m.columnPickerFocus = 1
+	m.columnPickerActive = true
+	return nil
+}
+
+func (m *Model) handleColumnPickerKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return *m, tea.Quit
+	case "esc", "q", "C":
+		m.columnPickerActive = false
+		return *m, nil
+	case "up", "k":
+		// The first column names the row and cannot be hidden
+		m.columnPickerFocus = max(m.columnPickerFocus-1, 1)
+	case "down", "j":
+		m.columnPickerFocus = min(m.columnPickerFocus+1, len(m.columnPickerColumns)-1)
+	case " ", "enter":
+		title := m.columnPickerColumns[m.columnPickerFocus].Title
+		hidden := m.config.ColumnHidden(m.columnPickerService, title)
+		m.config.SetColumnHidden(m.columnPickerService, title, !hidden)
+		if m.configPath != "" {
+			if err := m.config.Save(m.configPath); err != nil {
+				logging.Printf("could not save config: %v", err)
+				return *m, m.showToast(fmt.Sprintf("✘ Could not save column choice: %v", err))
+			}
+		}
+	}
+	return *m, nil
+}
+
+func (m Model) renderColumnPicker() string {
+	var s strings.Builder
+	s.WriteString(" " + lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render(m.columnPickerService+" Columns") + "\n\n")
+	for i, col := range m.columnPickerColumns {
+		check := "[x]"
+		if m.config.ColumnHidden(m.columnPickerService, col.Title) {
+			check = "[ ]"
+		}
+		line := fmt.Sprintf("%s %s", check, col.Title)
+		switch {
+		case i == 0:
+			line = m.styles.StatusMuted.Render(line + " (always shown)")
+		case i == m.columnPickerFocus:
+			line = lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render(line)
+		}
+		s.WriteString(" " + line + "\n")
+	}
+	s.WriteString("\n " + m.styles.StatusMuted.Render("(space to toggle, esc to close)"))
+
+	w, h := GetMainContainerSize(m.width, m.height)
+	popup := m.styles.Popup.Width(44).Render(s.String())
+	return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, popup)
+}
@@ -0,0 +1,391 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+	"github.com/sahilm/fuzzy"
+)
+
+// paletteMaxSuggestions caps the suggestion list, matching the home search
+const paletteMaxSuggestions = 10
+
+// paletteAliases maps the short names accepted by the command palette to services
+var paletteAliases = map[string]string{
+	"s3":          "Simple Storage Service (S3)",
+	"iam":         "Identity & Access (IAM)",
+	"vpc":         "Virtual Private Cloud (VPC)",
+	"lambda":      "Lambda Functions",
+	"ec2":         "Elastic Compute Cloud (EC2)",
+	"rds":         "Relational Database Service (RDS)",
+	"cw":          "CloudWatch",
+	"cf":          "CloudFront",
+	"elasticache": "ElastiCache (Redis)",
+	"msk":         "Managed Streaming for Kakfa (MSK)",
+	"sqs":         "Simple Queue Service (SQS)",
+	"secrets":     "Secrets Manager",
+	"route53":     "Route 53",
+	"acm":         "Certificate Manager (ACM)",
+	"sns":         "Simple Notification Service (SNS)",
+	"kms":         "KMS Keys",
+	"dms":         "Data Migration Service (DMS)",
+	"ecs":         "Elastic Container Service (ECS)",
+	"billing":     "Billing & Costs",
+	"securityhub": "Security Hub",
+	"waf":         "Web Application Firewall (WAFv2)",
+	"ecr":         "Elastic Container Repository (ECR)",
+	"efs":         "Elastic File System (EFS)",
+	"backup":      "AWS Backup",
+	"dynamodb":    "DynamoDB",
+	"transfer":    "AWS Transfer",
+	"apigateway":  "API Gateway",
+}
+
+// paletteSubcommands open a screen behind a service menu. They run after the
+// service view has been created, so they only need to start the right fetch.
+var paletteSubcommands = map[string]map[string]func(m *Model) tea.Cmd{
+	"ec2": {
+		"instances":       func(m *Model) tea.Cmd { return m.ec2Model.fetchInstances() },
+		"security-groups": func(m *Model) tea.Cmd { return m.ec2Model.fetchSecurityGroups() },
+		"volumes":         func(m *Model) tea.Cmd { return m.ec2Model.fetchVolumes() },
+		"target-groups":   func(m *Model) tea.Cmd { return m.ec2Model.fetchTargetGroups() },
+		"elastic-ips":     func(m *Model) tea.Cmd { return m.ec2Model.fetchElasticIPs() },
+	},
+	"vpc": {
+		"vpcs":         func(m *Model) tea.Cmd { return m.vpcModel.fetchVPCs() },
+		"subnets":      func(m *Model) tea.Cmd { return m.vpcModel.fetchSubnets() },
+		"nat-gateways": func(m *Model) tea.Cmd { return m.vpcModel.fetchNatGateways() },
+		"route-tables": func(m *Model) tea.Cmd { return m.vpcModel.fetchRouteTables() },
+		"vpn-gateways": func(m *Model) tea.Cmd { return m.vpcModel.fetchVpnGateways() },
+	},
+	"rds": {
+		"instances":     func(m *Model) tea.Cmd { return m.rdsModel.fetchInstances() },
+		"clusters":      func(m *Model) tea.Cmd { return m.rdsModel.fetchClusters() },
+		"snapshots":     func(m *Model) tea.Cmd { return m.rdsModel.fetchSnapshots() },
+		"subnet-groups": func(m *Model) tea.Cmd { return m.rdsModel.fetchSubnetGroups() },
+	},
+	"iam": {
+		"users": func(m *Model) tea.Cmd {
+			m.iamModel.state = IAMStateLoading
+			return m.iamModel.fetchUsers()
+		},
+		"summary": func(m *Model) tea.Cmd {
+			m.iamModel.state = IAMStateSummary
+			return m.iamModel.fetchSummary()
+		},
+	},
+	"elasticache": {
+		"replication-groups": func(m *Model) tea.Cmd { return m.elasticacheModel.fetchReplicationGroups() },
+		"clusters":           func(m *Model) tea.Cmd { return m.elasticacheModel.fetchCacheClusters() },
+	},
+	"msk": {
+		"clusters":       func(m *Model) tea.Cmd { return m.mskModel.fetchClusters() },
+		"configurations": func(m *Model) tea.Cmd { return m.mskModel.fetchConfigurations() },
+	},
+	"cw": {
+		"log-groups": func(m *Model) tea.Cmd { return m.cwModel.fetchLogGroups() },
+		"alarms":     func(m *Model) tea.Cmd { return m.cwModel.fetchAlarms() },
+	},
+	"backup": {
+		"plans": func(m *Model) tea.Cmd { return m.backupModel.fetchPlans() },
+		"jobs":  func(m *Model) tea.Cmd { return m.backupModel.fetchJobs() },
+	},
+	"dms": {
+		"tasks":     func(m *Model) tea.Cmd { return m.dmsModel.fetchTasks() },
+		"endpoints": func(m *Model) tea.Cmd { return m.dmsModel.fetchEndpoints() },
+		"instances": func(m *Model) tea.Cmd { return m.dmsModel.fetchInstances() },
+	},
+}
+
+// paletteCommands are the commands that are not services; they take an argument
+var paletteCommands = []string{"logs", "profile", "region"}
+
+func newPaletteInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "s3, ec2 instances, logs <group>, profile <name>..."
+	ti.Prompt = ": "
+	ti.CharLimit = 256
+	ti.Width = 50
+	return ti
+}
+
+func (m *Model) openPalette() tea.Cmd {
+	m.paletteActive = true
+	m.paletteSelected = 0
+	m.paletteInput.SetValue("")
+	m.paletteInput.Focus()
+	m.updatePaletteSuggestions()
+	return textinput.Blink
+}
+
+// openPaletteWith opens the palette with a command already typed, so its
+// arguments are offered right away
+func (m *Model) openPaletteWith(input string) tea.Cmd {
+	cmd := m.openPalette()
+	m.paletteInput.SetValue(input)
+	m.paletteInput.CursorEnd()
+	m.updatePaletteSuggestions()
+	return cmd
+}
+
+func (m *Model) closePalette() {
+	m.paletteActive = false
+	m.paletteInput.Blur()
+	m.paletteInput.SetValue("")
+}
+
+// handlePaletteInput handles key presses while the command palette is open
+func (m *Model) handlePaletteInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.closePalette()
+		return *m, nil
+	case "enter":
+		input := strings.TrimSpace(m.paletteInput.Value())
+		if !m.isPaletteCommand(input) && len(m.paletteSuggestions) > 0 {
+			input = m.paletteSuggestions[m.paletteSelected]
+		}
+		m.closePalette()
+		return m.runPaletteCommand(input)
+	case "tab":
+		if len(m.paletteSuggestions) > 0 {
+			completion := m.paletteSuggestions[m.paletteSelected]
+			if m.paletteTakesArgument(completion) {
+				completion += " "
+			}
+			m.paletteInput.SetValue(completion)
+			m.paletteInput.CursorEnd()
+			m.paletteSelected = 0
+			m.updatePaletteSuggestions()
+		}
+		return *m, nil
+	case "up":
+		if m.paletteSelected > 0 {
+			m.paletteSelected--
+		}
+		return *m, nil
+	case "down":
+		if m.paletteSelected < len(m.paletteSuggestions)-1 {
+			m.paletteSelected++
+		}
+		return *m, nil
+	}
+
+	var cmd tea.Cmd
+	m.paletteInput, cmd = m.paletteInput.Update(msg)
+	m.updatePaletteSuggestions()
+	return *m, cmd
+}
+
+// paletteTakesArgument reports whether a completed command expects another word
+func (m Model) paletteTakesArgument(command string) bool {
+	if strings.Contains(command, " ") {
+		return false
+	}
+	for _, c := range paletteCommands {
+		if c == command {
+			return true
+		}
+	}
+	_, ok := paletteSubcommands[command]
+	return ok
+}
+
+// isPaletteCommand reports whether input names a command without needing a suggestion
+func (m Model) isPaletteCommand(input string) bool {
+	fields := strings.Fields(input)
+	if len(fields) == 0 {
+		return false
+	}
+
+	name := strings.ToLower(fields[0])
+	switch name {
+	case "logs":
+		return true
+	case "profile", "region":
+		return len(fields) == 2
+	}
+
+	if _, ok := paletteAliases[name]; ok {
+		if len(fields) == 1 {
+			return true
+		}
+		_, ok := paletteSubcommands[name][strings.ToLower(fields[1])]
+		return ok
+	}
+	_, ok := featureIcons[input]
+	return ok
+}
+
+// updatePaletteSuggestions fuzzy matches the input against the commands that
+// make sense for the word being typed
+func (m *Model) updatePaletteSuggestions() {
+	query := m.paletteInput.Value()
+
+	var candidates []string
+	if name, _, found := strings.Cut(query, " "); found {
+		name = strings.ToLower(name)
+		var args []string
+		switch name {
+		case "profile":
+			args = m.profiles
+		case "region":
+			args = aws.PartitionRegions(m.region())
+		case "logs":
+			if cached, ok := m.cache.Get(m.cacheKeys.CWResources("log-groups")); ok {
+				if page, ok := cached.(aws.LogGroupPage); ok {
+					for _, g := range page.Groups {
+						args = append(args, g.Name)
+					}
+				}
+			}
+		default:
+			for sub := range paletteSubcommands[name] {
+				args = append(args, sub)
+			}
+			sort.Strings(args)
+		}
+		for _, arg := range args {
+			candidates = append(candidates, name+" "+arg)
+		}
+	} else {
+		for alias := range paletteAliases {
+			candidates = append(candidates, alias)
+		}
+		candidates = append(candidates, paletteCommands...)
+		sort.Strings(candidates)
+		for _, cat := range m.categories {
+			candidates = append(candidates, cat.Services...)
+		}
+	}
+
+	if strings.TrimSpace(query) == "" {
+		m.paletteSuggestions = candidates
+	} else {
+		matches := fuzzy.Find(query, candidates)
+		m.paletteSuggestions = make([]string, len(matches))
+		for i, match := range matches {
+			m.paletteSuggestions[i] = match.Str
+		}
+	}
+
+	if len(m.paletteSuggestions) > paletteMaxSuggestions {
+		m.paletteSuggestions = m.paletteSuggestions[:paletteMaxSuggestions]
+	}
+	if m.paletteSelected >= len(m.paletteSuggestions) {
+		m.paletteSelected = len(m.paletteSuggestions) - 1
+	}
+	if m.paletteSelected < 0 {
+		m.paletteSelected = 0
+	}
+}
+
+// runPaletteCommand executes a palette command and routes to its view
+func (m *Model) runPaletteCommand(input string) (tea.Model, tea.Cmd) {
+	fields := strings.Fields(input)
+	if len(fields) == 0 {
+		return *m, nil
+	}
+
+	name := strings.ToLower(fields[0])
+	args := fields[1:]
+
+	switch name {
+	case "profile":
+		if len(args) != 1 {
+			return *m, m.showToast("✘ Usage: profile <name>")
+		}
+		for _, p := range m.profiles {
+			if p == args[0] {
+				m.profileSelector.selected = p
+				return m.handleProfileChange(p)
+			}
+		}
+		return *m, m.showToast(fmt.Sprintf("✘ Unknown profile %s", args[0]))
+
+	case "region":
+		if len(args) != 1 {
+			return *m, m.showToast("✘ Usage: region <name>")
+		}
+		if err := aws.CheckRegion(args[0], m.region()); err != nil {
+			return *m, m.showToast("✘ " + err.Error())
+		}
+		aws.SetRegion(args[0])
+		// Cache keys are per profile, so drop anything fetched in the old region
+		m.cache.Clear()
+		toastCmd := m.showToast(fmt.Sprintf("✔ Region set to %s", args[0]))
+		_, cmd := m.handleProfileChange(m.selectedProfile)
+		return *m, tea.Batch(cmd, toastCmd)
+
+	case "logs":
+		m.view = viewCW
+		m.cwModel = NewCWModel(m.selectedProfile, m.styles, m.cache, m.display)
+		m.cwModel.SetSize(m.width, m.height)
+		if len(args) == 0 {
+			return *m, m.cwModel.fetchLogGroups()
+		}
+		m.cwModel.selectedGroup = args[0]
+		m.cwModel.state = CWStateLogStreams
+		return *m, m.cwModel.fetchLogStreams(args[0])
+	}
+
+	service, ok := paletteAliases[name]
+	if !ok {
+		if _, ok := featureIcons[input]; !ok {
+			return *m, m.showToast(fmt.Sprintf("✘ Unknown command %s", input))
+		}
+		service, args = input, nil
+	}
+
+	if len(args) == 0 {
+		return m.handleServiceSelection(service)
+	}
+
+	sub, ok := paletteSubcommands[name][strings.ToLower(args[0])]
+	if !ok {
+		return *m, m.showToast(fmt.Sprintf("✘ Unknown %s view %s", name, args[0]))
+	}
+	// The view's own start, such as its menu, lands before the subcommand's list
+	_, cmd := m.handleServiceSelection(service)
+	return *m, tea.Sequence(cmd, sub(m))
+}
+
+// renderCommandPalette renders the palette input with its suggestions
+func (m Model) renderCommandPalette() string {
+	var sb strings.Builder
+	sb.WriteString(m.paletteInput.View() + "\n\n")
+
+	if len(m.paletteSuggestions) == 0 {
+		sb.WriteString(m.styles.StatusMuted.Render("No matching commands.") + "\n")
+	}
+	for i, suggestion := range m.paletteSuggestions {
+		if i == m.paletteSelected {
+			sb.WriteString(m.styles.SelectedMenuItem.Render("➜ "+suggestion) + m.paletteHint(suggestion) + "\n")
+		} else {
+			sb.WriteString(m.styles.MenuItem.Render("  "+suggestion) + m.paletteHint(suggestion) + "\n")
+		}
+	}
+
+	sb.WriteString("\n" + m.styles.StatusKey.Render("tab") + " " + m.styles.StatusMuted.Render("Complete") +
+		m.styles.StatusMuted.Render(" • ") +
+		m.styles.StatusKey.Render("enter") + " " + m.styles.StatusMuted.Render("Run") +
+		m.styles.StatusMuted.Render(" • ") +
+		m.styles.StatusKey.Render("esc") + " " + m.styles.StatusMuted.Render("Close"))
+
+	return m.styles.Popup.Width(64).Render(
+		lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render("Command") + "\n\n" + sb.String(),
+	)
+}
+
+// paletteHint names the service behind a short alias
+func (m Model) paletteHint(suggestion string) string {
+	if service, ok := paletteAliases[suggestion]; ok {
+		return m.styles.StatusMuted.Render("  " + service)
+	}
+	return ""
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/giovannirossini/aws-tui/internal/logging"
+)
+
+// rowSpacing is the number of blank lines between the rows of a list
+func (t *tableLayout) rowSpacing() int {
+	if t.display.compact {
+		return 0
+	}
+	return 1
+}
+
+// menuItemHeight is the height of a menu entry: its title and description,
+// or only the title in compact mode
+func (t *tableLayout) menuItemHeight() int {
+	if t.display.compact {
+		return 1
+	}
+	return 2
+}
+
+// renderMenuItem renders a menu entry with the default delegate, leaving out
+// the description in compact mode
+func (t *tableLayout) renderMenuItem(d list.DefaultDelegate, w io.Writer, m list.Model, index int, item list.Item) {
+	d.ShowDescription = !t.display.compact
+	d.Render(w, m, index, item)
+}
+
+// toggleCompact switches compact mode and saves the choice. The lists are
+// resized to page by the new row height.
+func (m *Model) toggleCompact() tea.Cmd {
+	m.display.compact = !m.display.compact
+	m.config.Compact = m.display.compact
+
+	toast := m.showMutedToast("Compact lists off")
+	if m.display.compact {
+		toast = m.showMutedToast("Compact lists on")
+	}
+	if m.configPath != "" {
+		if err := m.config.Save(m.configPath); err != nil {
+			logging.Printf("could not save config: %v", err)
+			toast = m.showToast(fmt.Sprintf("✘ Could not save compact mode: %v", err))
+		}
+	}
+
+	_, cmd := m.handleWindowSize(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	return tea.Batch(toast, cmd)
+}
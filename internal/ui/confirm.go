@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type confirmResult int
+
+const (
+	confirmPending confirmResult = iota
+	confirmAccepted
+	confirmCancelled
+)
+
+// ConfirmDialog asks a yes/no question before an action. y runs OnConfirm,
+// n and esc cancel, and other keys are ignored so a stray key neither runs
+// the action nor silently dismisses the question.
+type ConfirmDialog struct {
+	Title   string
+	Message string
+	// Detail is shown under the message, e.g. what else the action affects.
+	// It is rendered by the caller, one indented line per row.
+	Detail string
+	// Danger marks actions that destroy or cut off access with a red border
+	Danger    bool
+	Width     int
+	OnConfirm func() tea.Cmd
+}
+
+// NewConfirmDialog returns a dialog of the default width
+func NewConfirmDialog(title, message string, danger bool, onConfirm func() tea.Cmd) ConfirmDialog {
+	return ConfirmDialog{Title: title, Message: message, Danger: danger, Width: 52, OnConfirm: onConfirm}
+}
+
+// Update answers the dialog with a key. The command is the confirmed action.
+func (d ConfirmDialog) Update(msg tea.KeyMsg) (confirmResult, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		if d.OnConfirm == nil {
+			return confirmAccepted, nil
+		}
+		return confirmAccepted, d.OnConfirm()
+	case "n", "N", "esc":
+		return confirmCancelled, nil
+	}
+	return confirmPending, nil
+}
+
+// View renders the dialog box; the caller places it over its own content
+func (d ConfirmDialog) View(styles Styles) string {
+	title := lipgloss.NewStyle().Foreground(styles.Primary).Bold(true).Render(d.Title)
+	popup := styles.Popup.Width(d.Width)
+	if d.Danger {
+		title = styles.Error.Bold(true).Render("⚠ " + d.Title)
+		popup = popup.BorderForeground(styles.ErrorColor)
+	}
+
+	content := " " + title + "\n\n " + d.Message + "\n"
+	if d.Detail != "" {
+		content += "\n" + strings.TrimRight(d.Detail, "\n") + "\n"
+	}
+	content += "\n " + styles.StatusMuted.Render("(y/n)")
+	return popup.Render(content)
+}
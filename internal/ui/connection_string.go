@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+// Connection strings never carry credentials; these markers stand in for them
+const (
+	connUserMarker     = "<username>"
+	connPasswordMarker = "<password>"
+)
+
+// rdsConnectionString formats a URL for the database engine. Engines without
+// a common URL form get host:port.
+func rdsConnectionString(engine, host string, port int32, dbName string) string {
+	creds := connUserMarker + ":" + connPasswordMarker
+	switch {
+	case strings.Contains(engine, "postgres"):
+		if dbName == "" {
+			dbName = "postgres"
+		}
+		return fmt.Sprintf("postgresql://%s@%s:%d/%s", creds, host, port, dbName)
+	case strings.Contains(engine, "mysql"), engine == "aurora", engine == "mariadb":
+		return fmt.Sprintf("mysql://%s@%s:%d/%s", creds, host, port, dbName)
+	case strings.HasPrefix(engine, "sqlserver"):
+		url := fmt.Sprintf("sqlserver://%s@%s:%d", creds, host, port)
+		if dbName != "" {
+			url += "?database=" + dbName
+		}
+		return url
+	case strings.HasPrefix(engine, "oracle"):
+		if dbName == "" {
+			dbName = "ORCL"
+		}
+		return fmt.Sprintf("oracle://%s@%s:%d/%s", creds, host, port, dbName)
+	default:
+		return fmt.Sprintf("%s:%d", host, port)
+	}
+}
+
+// cacheConnectionString formats a redis:// URL, rediss:// with in-transit
+// encryption, or host:port for Memcached
+func cacheConnectionString(engine, host string, port int32, tls bool) string {
+	if engine == "memcached" {
+		return fmt.Sprintf("%s:%d", host, port)
+	}
+	scheme := "redis"
+	if tls {
+		scheme = "rediss"
+	}
+	return fmt.Sprintf("%s://%s:%d", scheme, host, port)
+}
+
+// MSKBootstrapMsg carries the bootstrap brokers of a cluster to copy
+type MSKBootstrapMsg string
+
+func (m MSKModel) fetchBootstrapBrokers(clusterArn string) tea.Cmd {
+	return func() tea.Msg {
+		client, err := aws.NewMSKClient(context.Background(), m.profile)
+		if err != nil {
+			return MSKErrorMsg(err)
+		}
+		brokers, err := client.GetBootstrapBrokers(context.Background(), clusterArn)
+		if err != nil {
+			return MSKErrorMsg(err)
+		}
+		return MSKBootstrapMsg(brokers)
+	}
+}
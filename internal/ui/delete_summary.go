@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+// bucketSummaryLimit caps how many objects are counted for a bucket summary
+const bucketSummaryLimit = 10000
+
+// deleteSummary describes what a delete would affect. It is fetched when the
+// confirmation opens and shown in the dialog.
+type deleteSummary struct {
+	loading bool
+	fields  []detailField
+	warning string
+	err     error
+}
+
+// S3DeleteSummaryMsg carries the contents of the bucket being deleted
+type S3DeleteSummaryMsg struct {
+	Bucket   string
+	Contents aws.BucketContents
+	Err      error
+}
+
+// IAMDeleteSummaryMsg carries what is still attached to the user being deleted
+type IAMDeleteSummaryMsg struct {
+	UserName string
+	Deps     aws.UserDependencies
+	Err      error
+}
+
+// fetchBucketDeleteSummary counts the bucket's objects page by page, in the
+// progress overlay since a full bucket takes a few seconds
+func (m S3Model) fetchBucketDeleteSummary(bucket string) tea.Cmd {
+	return startProgress("Counting objects in "+bucket, func(report progressReport) tea.Msg {
+		client, err := aws.NewS3Client(context.Background(), m.profile)
+		if err != nil {
+			return S3DeleteSummaryMsg{Bucket: bucket, Err: err}
+		}
+		contents, err := client.CountObjects(context.Background(), bucket, bucketSummaryLimit, func(counted int) {
+			report(float64(counted)/bucketSummaryLimit, fmt.Sprintf("%d objects so far, stopping at %d", counted, bucketSummaryLimit))
+		})
+		return S3DeleteSummaryMsg{Bucket: bucket, Contents: contents, Err: err}
+	})
+}
+
+func (m IAMModel) fetchUserDeleteSummary(userName string) tea.Cmd {
+	return func() tea.Msg {
+		client, err := aws.NewIAMClient(context.Background(), m.profile)
+		if err != nil {
+			return IAMDeleteSummaryMsg{UserName: userName, Err: err}
+		}
+		deps, err := client.GetUserDependencies(context.Background(), userName)
+		return IAMDeleteSummaryMsg{UserName: userName, Deps: deps, Err: err}
+	}
+}
+
+func bucketDeleteSummary(msg S3DeleteSummaryMsg) deleteSummary {
+	if msg.Err != nil {
+		return deleteSummary{err: msg.Err}
+	}
+	c := msg.Contents
+	objects := fmt.Sprintf("%d", c.Objects)
+	if c.Truncated {
+		objects += "+"
+	}
+	s := deleteSummary{fields: []detailField{
+		{"Objects", objects},
+		{"Size", humanizeBytes(c.Size)},
+	}}
+	if c.Objects > 0 {
+		s.warning = "The bucket is not empty; S3 rejects the delete until every object is removed."
+	}
+	return s
+}
+
+func userDeleteSummary(msg IAMDeleteSummaryMsg) deleteSummary {
+	if msg.Err != nil {
+		return deleteSummary{err: msg.Err}
+	}
+	d := msg.Deps
+	console := "no"
+	if d.ConsoleAccess {
+		console = "yes"
+	}
+	s := deleteSummary{fields: []detailField{
+		{"Attached policies", fmt.Sprintf("%d", d.AttachedPolicies)},
+		{"Inline policies", fmt.Sprintf("%d", d.InlinePolicies)},
+		{"Access keys", fmt.Sprintf("%d", d.AccessKeys)},
+		{"Groups", fmt.Sprintf("%d", d.Groups)},
+		{"MFA devices", fmt.Sprintf("%d", d.MFADevices)},
+		{"Console access", console},
+	}}
+	if d.AttachedPolicies+d.InlinePolicies+d.AccessKeys+d.Groups+d.MFADevices > 0 || d.ConsoleAccess {
+		s.warning = "IAM rejects the delete until these are removed."
+	}
+	return s
+}
+
+// renderDeleteSummary renders the summary as lines for a confirmation popup,
+// or nothing when the delete has no summary
+func renderDeleteSummary(styles Styles, s deleteSummary) string {
+	switch {
+	case s.loading:
+		return " " + styles.StatusMuted.Render("Checking contents...") + "\n\n"
+	case s.err != nil:
+		return " " + styles.StatusMuted.Render("Could not check contents: "+s.err.Error()) + "\n\n"
+	case len(s.fields) == 0:
+		return ""
+	}
+
+	label := lipgloss.NewStyle().Foreground(styles.Muted).Width(20)
+	var b strings.Builder
+	for _, f := range s.fields {
+		b.WriteString(" " + label.Render(f.label) + f.value + "\n")
+	}
+	if s.warning != "" {
+		b.WriteString("\n " + styles.Warning.Render("⚠ "+s.warning) + "\n")
+	}
+	return b.String() + "\n"
+}
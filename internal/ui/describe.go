@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sourcedItem is implemented by items that keep the struct they were built
+// from, so rows without a detail screen of their own can still be described
+type sourcedItem interface {
+	source() any
+}
+
+// describeSource returns the struct behind the selected row when the view on
+// screen has no use of its own for enter
+func (m *Model) describeSource() (any, bool) {
+	var listed bool
+	switch m.view {
+	case viewACM:
+		listed = m.acmModel.state == ACMStateList
+	case viewEFS:
+		listed = m.efsModel.state == EFSStateMountTargets
+	case viewSQS:
+		listed = m.sqsModel.state == SQSStateQueues
+	case viewKMS:
+		listed = m.kmsModel.state == KMSStateKeys
+	case viewLambda:
+		listed = m.lambdaModel.state == LambdaStateFunctions
+	case viewSNS, viewSecurityHub:
+		listed = true
+	case viewEC2:
+		switch m.ec2Model.state {
+		case EC2StateSecurityGroups, EC2StateVolumes, EC2StateTargetGroups, EC2StateElasticIPs:
+			listed = true
+		}
+	case viewVPC:
+		switch m.vpcModel.state {
+		case VPCStateSubnets, VPCStateNatGateways, VPCStateRouteTables, VPCStateVpnGateways:
+			listed = true
+		}
+	case viewRDS:
+		listed = m.rdsModel.state != RDSStateMenu && m.rdsModel.state != RDSStateConnectInput
+	case viewElastiCache:
+		listed = m.elasticacheModel.state != ElastiCacheStateMenu
+	case viewDMS:
+		listed = m.dmsModel.state == DMSStateEndpoints || m.dmsModel.state == DMSStateInstances
+	case viewECR:
+		listed = m.ecrModel.state == ECRStateImages
+	case viewWAF:
+		listed = m.wafModel.state != WAFStateMenu
+	case viewAPIGateway:
+		listed = m.apiGatewayModel.state != APIGatewayStateMenu
+	case viewTransfer:
+		listed = m.transferModel.state == TransferStateUsers
+	case viewRoute53:
+		listed = m.route53Model.state == Route53StateRecords
+	case viewCF:
+		switch m.cfModel.state {
+		case CFStateOrigins, CFStateBehaviors, CFStateInvalidations, CFStatePolicies:
+			listed = true
+		}
+	}
+	l := m.activeList()
+	if !listed || l == nil || l.FilterState() == list.Filtering {
+		return nil, false
+	}
+	item, ok := l.SelectedItem().(sourcedItem)
+	if !ok || item.source() == nil {
+		return nil, false
+	}
+	return item.source(), true
+}
+
+// openDescribe shows the struct behind the selected row as JSON
+func (m *Model) openDescribe(v any) tea.Cmd {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return m.showToast("✘ Could not describe: " + err.Error())
+	}
+	m.describeJSON = string(data)
+	m.describeTitle = m.getViewTitle()
+	if item, ok := m.activeList().SelectedItem().(list.DefaultItem); ok {
+		m.describeTitle += " / " + item.Title()
+	}
+
+	content := highlightLog(m.styles, m.describeJSON)
+	w, h := GetMainContainerSize(m.width, m.height)
+	m.describeViewport = viewport.New(min(w-8, 110), max(min(strings.Count(content, "\n")+1, h-AppInternalFooterHeight-10), 1))
+	m.describeViewport.SetContent(content)
+	m.describeActive = true
+	return nil
+}
+
+func (m *Model) handleDescribeKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return *m, tea.Quit
+	case "esc", "q", "enter":
+		m.describeActive = false
+		return *m, nil
+	case "y":
+		return *m, copyToClipboard(m.describeJSON, "JSON")
+	}
+
+	if scrollViewport(&m.describeViewport, msg.String()) {
+		return *m, nil
+	}
+	var cmd tea.Cmd
+	m.describeViewport, cmd = m.describeViewport.Update(msg)
+	return *m, cmd
+}
+
+func (m Model) renderDescribe() string {
+	title := lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render(m.describeTitle)
+	popup := m.styles.Popup.Width(m.describeViewport.Width + 4).Render(
+		title + "\n\n" + m.describeViewport.View() + "\n\n" +
+			renderScrollIndicator(m.styles, m.describeViewport, m.styles.StatusMuted.Render("(↑/↓ to scroll, y to copy, esc to close)")),
+	)
+	w, h := GetMainContainerSize(m.width, m.height)
+	return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, popup)
+}
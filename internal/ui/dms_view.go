@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -29,11 +28,15 @@ type dmsItem struct {
 	id          string
 	arn         string
 	values      []string
+	info        any
 }
 
 func (i dmsItem) Title() string       { return i.title }
+func (i dmsItem) source() any         { return i.info }
 func (i dmsItem) Description() string { return i.description }
 func (i dmsItem) FilterValue() string { return i.title + " " + i.description + " " + i.id }
+func (i dmsItem) rowID() string       { return i.id }
+func (i dmsItem) rowValues() []string { return i.values }
 
 type DMSModel struct {
 	client       *aws.DMSClient
@@ -41,11 +44,13 @@ type DMSModel struct {
 	actionList   list.Model
 	delegate     dmsItemDelegate
 	styles       Styles
+	table        *tableLayout
 	state        DMSState
 	width        int
 	height       int
 	profile      string
 	err          error
+	loaded       bool
 	cache        *cache.Cache
 	cacheKeys    *cache.KeyBuilder
 	selectedTask string
@@ -54,6 +59,7 @@ type DMSModel struct {
 type dmsItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 	state  DMSState
 }
 
@@ -104,20 +110,24 @@ func (d dmsItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 		columns = dmsInstanceColumns
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, columns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, columns, d.table)
 	isSelected := index == m.Index()
 
-	RenderTableRow(w, m, d.styles, colStyles, i.values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, i.values, isSelected)
 }
 
 func (d dmsItemDelegate) Height() int {
 	return 1
 }
 
-func NewDMSModel(profile string, styles Styles, appCache *cache.Cache) DMSModel {
+func (d dmsItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewDMSModel(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) DMSModel {
+	table := &tableLayout{display: display}
 	d := dmsItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 		state:           DMSStateMenu,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
@@ -133,6 +143,7 @@ func NewDMSModel(profile string, styles Styles, appCache *cache.Cache) DMSModel
 		list:      l,
 		delegate:  d,
 		styles:    styles,
+		table:     table,
 		state:     DMSStateMenu,
 		profile:   profile,
 		cache:     appCache,
@@ -148,7 +159,7 @@ func (m *DMSModel) loadMenu() {
 		dmsItem{title: "Endpoints", id: "endpoints", values: []string{"Endpoints"}},
 		dmsItem{title: "Replication Instances", id: "instances", values: []string{"Replication Instances"}},
 	}
-	m.list.SetItems(items)
+	m.table.setItems(&m.list, items)
 	m.list.ResetSelected()
 	m.state = DMSStateMenu
 }
@@ -244,7 +255,7 @@ func (m DMSModel) fetchInstances() tea.Cmd {
 }
 
 func (m DMSModel) runAction(action string) tea.Cmd {
-	return func() tea.Msg {
+	return armed(func() tea.Msg {
 		client, err := aws.NewDMSClient(context.Background(), m.profile)
 		if err != nil {
 			return DMSErrorMsg(err)
@@ -266,7 +277,7 @@ func (m DMSModel) runAction(action string) tea.Cmd {
 			return DMSErrorMsg(cmdErr)
 		}
 		return DMSSuccessMsg(fmt.Sprintf("Task %s successful", action))
-	}
+	})
 }
 
 func (m DMSModel) Update(msg tea.Msg) (DMSModel, tea.Cmd) {
@@ -279,20 +290,10 @@ func (m DMSModel) Update(msg tea.Msg) (DMSModel, tea.Cmd) {
 		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
 	case DMSTasksMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
-			status := v.Status
-			if status == "running" {
-				status = lipgloss.NewStyle().Foreground(m.styles.Success.GetForeground()).Render(status)
-			} else if strings.Contains(status, "failed") || strings.Contains(status, "error") {
-				status = lipgloss.NewStyle().Foreground(m.styles.Error.GetForeground()).Render(status)
-			}
-
-			// Shorten ARNs for display
-			instance := v.Instance
-			if idx := strings.LastIndex(instance, ":"); idx != -1 {
-				instance = instance[idx+1:]
-			}
+			status := renderStatus(m.styles, v.Status)
 
 			items[i] = dmsItem{
 				title:       v.ID,
@@ -304,23 +305,22 @@ func (m DMSModel) Update(msg tea.Msg) (DMSModel, tea.Cmd) {
 					status,
 					v.Type,
 					fmt.Sprintf("%d%%", v.FullLoadProgress),
-					instance,
+					v.Instance,
 				},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = DMSStateTasks
 
 	case DMSEndpointsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
-			status := v.Status
-			if status == "active" {
-				status = lipgloss.NewStyle().Foreground(m.styles.Success.GetForeground()).Render(status)
-			}
+			status := renderStatus(m.styles, v.Status)
 
 			items[i] = dmsItem{
+				info:        v,
 				title:       v.ID,
 				description: v.Type,
 				id:          v.ID,
@@ -333,17 +333,15 @@ func (m DMSModel) Update(msg tea.Msg) (DMSModel, tea.Cmd) {
 				},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = DMSStateEndpoints
 
 	case DMSInstancesMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
-			status := v.Status
-			if status == "available" {
-				status = lipgloss.NewStyle().Foreground(m.styles.Success.GetForeground()).Render(status)
-			}
+			status := renderStatus(m.styles, v.Status)
 
 			public := "No"
 			if v.PubliclyAccessible {
@@ -351,6 +349,7 @@ func (m DMSModel) Update(msg tea.Msg) (DMSModel, tea.Cmd) {
 			}
 
 			items[i] = dmsItem{
+				info:        v,
 				title:       v.ID,
 				description: v.Class,
 				id:          v.ID,
@@ -364,7 +363,7 @@ func (m DMSModel) Update(msg tea.Msg) (DMSModel, tea.Cmd) {
 				},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = DMSStateInstances
 
@@ -445,9 +444,31 @@ func (m DMSModel) Update(msg tea.Msg) (DMSModel, tea.Cmd) {
 	return m, cmd
 }
 
+// dmsResourceNames names the resources listed in each table state, for the empty-state message
+var dmsResourceNames = map[DMSState]string{
+	DMSStateTasks:     "replication tasks",
+	DMSStateEndpoints: "DMS endpoints",
+	DMSStateInstances: "replication instances",
+}
+
+// columns are the columns of the table on screen
+func (m DMSModel) columns() []Column {
+	switch m.state {
+	case DMSStateMenu:
+		return dmsMenuColumns
+	case DMSStateTasks:
+		return dmsTaskColumns
+	case DMSStateEndpoints:
+		return dmsEndpointColumns
+	case DMSStateInstances:
+		return dmsInstanceColumns
+	}
+	return nil
+}
+
 func (m DMSModel) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
 	}
 
 	if m.state == DMSStateActions {
@@ -458,18 +479,11 @@ func (m DMSModel) View() string {
 		return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, popup)
 	}
 
-	var columns []Column
-	switch m.state {
-	case DMSStateMenu:
-		columns = dmsMenuColumns
-	case DMSStateTasks:
-		columns = dmsTaskColumns
-	case DMSStateEndpoints:
-		columns = dmsEndpointColumns
-	case DMSStateInstances:
-		columns = dmsInstanceColumns
+	if resource, ok := dmsResourceNames[m.state]; ok && m.loaded && len(m.list.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.list, resource, m.profile, m.table)
 	}
-	_, header := RenderTableHelpers(m.list, m.styles, columns)
+
+	_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
 	return header + "\n" + m.list.View()
 }
 
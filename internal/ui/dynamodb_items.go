@@ -0,0 +1,211 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+// dynamoItemsLimit caps the items listed for a table, as a scan would
+// otherwise read all of it
+const dynamoItemsLimit = 200
+
+type dynamoRecordItem struct {
+	item   aws.DynamoItem
+	key    string
+	values []string
+}
+
+func (i dynamoRecordItem) Title() string       { return i.key }
+func (i dynamoRecordItem) Description() string { return i.values[3] }
+func (i dynamoRecordItem) FilterValue() string { return i.key + " " + i.values[3] }
+func (i dynamoRecordItem) rowValues() []string { return i.values }
+
+var dynamoItemColumns = []Column{
+	{Title: "Partition Key", Width: 0.25},
+	{Title: "Sort Key", Width: 0.2},
+	{Title: "Attributes", Width: 0.1},
+	{Title: "Preview", Width: 0.45},
+}
+
+// DynamoItemsMsg carries the first items of a table; More reports that the
+// table has items past them
+type DynamoItemsMsg struct {
+	Items []aws.DynamoItem
+	More  bool
+}
+
+// DynamoItemSavedMsg carries the key of an item written from the editor
+type DynamoItemSavedMsg struct {
+	Key     string
+	Created bool
+}
+
+// DynamoItemDeletedMsg carries the key of a deleted item
+type DynamoItemDeletedMsg string
+
+// dynamoItemUnchangedMsg reports that the editor was closed without changes
+type dynamoItemUnchangedMsg struct{}
+
+// tableInfo looks up the key schema of the table the items are listed for
+func (m DynamoDBModel) tableInfo() (aws.DynamoTableInfo, bool) {
+	for _, t := range m.tables {
+		if t.Name == m.currentTable {
+			return t, true
+		}
+	}
+	return aws.DynamoTableInfo{}, false
+}
+
+// fetchItems scans the first items of a table. They are not cached, as they
+// are edited from the list.
+func (m DynamoDBModel) fetchItems(table string) tea.Cmd {
+	return func() tea.Msg {
+		client, err := aws.NewDynamoDBClient(context.Background(), m.profile)
+		if err != nil {
+			return DynamoErrorMsg(err)
+		}
+		items, more, err := client.ScanItems(context.Background(), table, dynamoItemsLimit)
+		if err != nil {
+			return DynamoErrorMsg(err)
+		}
+		return DynamoItemsMsg{Items: items, More: more}
+	}
+}
+
+func (m *DynamoDBModel) setItems(msg DynamoItemsMsg) {
+	table, _ := m.tableInfo()
+	items := make([]list.Item, len(msg.Items))
+	for i, item := range msg.Items {
+		var preview []string
+		for _, name := range item.Names() {
+			if name != table.PartitionKey && name != table.SortKey {
+				preview = append(preview, name+"="+item.Value(name))
+			}
+		}
+		items[i] = dynamoRecordItem{
+			item: item,
+			key:  item.KeyText(table),
+			values: []string{
+				item.Value(table.PartitionKey),
+				item.Value(table.SortKey),
+				fmt.Sprintf("%d", item.Len()),
+				strings.Join(preview, ", "),
+			},
+		}
+	}
+	m.itemsMore = msg.More
+	m.list.SetItems(items)
+	m.list.ResetSelected()
+	m.state = DynamoDBStateItems
+}
+
+func (m DynamoDBModel) deleteItem(table aws.DynamoTableInfo, item aws.DynamoItem) tea.Cmd {
+	return armed(func() tea.Msg {
+		client, err := aws.NewDynamoDBClient(context.Background(), m.profile)
+		if err != nil {
+			return DynamoErrorMsg(err)
+		}
+		if err := client.DeleteItem(context.Background(), table, item); err != nil {
+			return DynamoErrorMsg(err)
+		}
+		return DynamoItemDeletedMsg(item.KeyText(table))
+	})
+}
+
+// openDeleteItem asks before deleting the selected item, showing its key
+func (m *DynamoDBModel) openDeleteItem() {
+	record, ok := m.list.SelectedItem().(dynamoRecordItem)
+	table, known := m.tableInfo()
+	if !ok || !known {
+		return
+	}
+	m.confirm = NewConfirmDialog("Delete Item", "Delete this item from "+
+		lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render(table.Name)+"?",
+		true, func() tea.Cmd { return m.deleteItem(table, record.item) })
+	m.confirm.Width = 64
+	m.confirm.Detail = " " + m.styles.StatusMuted.Render(record.key)
+	m.state = DynamoDBStateConfirmDeleteItem
+}
+
+// itemEdit is what an item edit started from; key is empty for a new item
+type itemEdit struct {
+	table    aws.DynamoTableInfo
+	original string
+	key      string
+}
+
+// getItemEditCommand writes the selected item, or a new item with only the
+// key attributes to fill in, to a temp file as DynamoDB JSON and opens it in
+// $EDITOR. The caller removes the file once it is written back.
+func (m DynamoDBModel) getItemEditCommand(create bool) (c *exec.Cmd, path string, edit itemEdit, err error) {
+	table, ok := m.tableInfo()
+	if !ok {
+		return nil, "", itemEdit{}, fmt.Errorf("key schema of table %s is not known, refresh the tables", m.currentTable)
+	}
+	edit.table = table
+
+	if create {
+		template := map[string]any{table.PartitionKey: map[string]string{table.PartitionKeyType: ""}}
+		if table.SortKey != "" {
+			template[table.SortKey] = map[string]string{table.SortKeyType: ""}
+		}
+		data, _ := json.MarshalIndent(template, "", "  ")
+		edit.original = string(data)
+	} else {
+		record, ok := m.list.SelectedItem().(dynamoRecordItem)
+		if !ok {
+			return nil, "", itemEdit{}, fmt.Errorf("no item selected")
+		}
+		edit.original = record.item.JSON()
+		edit.key = record.key
+	}
+
+	cmd, path, err := editTempFile("aws-tui-item-*.json", edit.original)
+	if err != nil {
+		return nil, "", itemEdit{}, err
+	}
+	return cmd, path, edit, nil
+}
+
+// saveEditedItem checks the edited file against the key schema of the table
+// and writes it back with PutItem
+func (m DynamoDBModel) saveEditedItem(path string, edit itemEdit) tea.Msg {
+	defer os.Remove(path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return DynamoErrorMsg(err)
+	}
+	if bytes.Equal(bytes.TrimSpace(data), bytes.TrimSpace([]byte(edit.original))) {
+		return dynamoItemUnchangedMsg{}
+	}
+
+	item, err := aws.ParseDynamoItem(data, edit.table)
+	if err != nil {
+		return DynamoErrorMsg(err)
+	}
+	// A changed key would write a second item and leave the edited one behind
+	replace := edit.key != ""
+	if replace && item.KeyText(edit.table) != edit.key {
+		return DynamoErrorMsg(fmt.Errorf("the key of an item cannot be edited (was %s, now %s); add the item with n instead", edit.key, item.KeyText(edit.table)))
+	}
+
+	client, err := aws.NewDynamoDBClient(context.Background(), m.profile)
+	if err != nil {
+		return DynamoErrorMsg(err)
+	}
+	if err := client.PutItem(context.Background(), edit.table, item, replace); err != nil {
+		return DynamoErrorMsg(err)
+	}
+	return DynamoItemSavedMsg{Key: item.KeyText(edit.table), Created: !replace}
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/giovannirossini/aws-tui/internal/aws"
@@ -17,33 +19,64 @@ type DynamoDBState int
 
 const (
 	DynamoDBStateTables DynamoDBState = iota
+	DynamoDBStateBackups
+	DynamoDBStateBackupInput
+	DynamoDBStateItems
+	DynamoDBStateConfirmDeleteItem
 )
 
 type dynamoItem struct {
 	title       string
 	description string
+	values      []string
 }
 
 func (i dynamoItem) Title() string       { return i.title }
 func (i dynamoItem) Description() string { return i.description }
-func (i dynamoItem) FilterValue() string { return i.title }
+func (i dynamoItem) FilterValue() string { return i.title + " " + i.description }
+func (i dynamoItem) rowValues() []string { return i.values }
+
+type dynamoBackupItem struct {
+	title  string
+	arn    string
+	values []string
+}
+
+func (i dynamoBackupItem) Title() string       { return i.title }
+func (i dynamoBackupItem) Description() string { return i.arn }
+func (i dynamoBackupItem) FilterValue() string { return i.title + " " + i.arn }
+func (i dynamoBackupItem) rowID() string       { return i.arn }
+func (i dynamoBackupItem) rowValues() []string { return i.values }
 
 type DynamoDBModel struct {
 	client    *aws.DynamoDBClient
 	list      list.Model
 	styles    Styles
+	table     *tableLayout
 	state     DynamoDBState
 	width     int
 	height    int
 	profile   string
 	err       error
+	loaded    bool
 	cache     *cache.Cache
 	cacheKeys *cache.KeyBuilder
+	// Detail pane and backups
+	split        bool
+	tables       []aws.DynamoTableInfo
+	pitr         map[string]DynamoContinuousBackupsMsg
+	pitrPending  string
+	currentTable string
+	input        textinput.Model
+	// Items of currentTable
+	itemsMore bool
+	confirm   ConfirmDialog
 }
 
 type dynamoItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 }
 
 var dynamoTableColumns = []Column{
@@ -54,13 +87,33 @@ var dynamoTableColumns = []Column{
 	{Title: "Partition Key", Width: 0.25},
 }
 
+var dynamoBackupColumns = []Column{
+	{Title: "Backup Name", Width: 0.35},
+	{Title: "Status", Width: 0.15},
+	{Title: "Type", Width: 0.15},
+	{Title: "Size", Width: 0.15},
+	{Title: "Created", Width: 0.2},
+}
+
 func (d dynamoItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	if r, ok := listItem.(dynamoRecordItem); ok {
+		colStyles, _ := RenderTableHelpers(m, d.styles, dynamoItemColumns, d.table)
+		RenderTableRow(w, m, listItem, d.styles, colStyles, r.values, index == m.Index())
+		return
+	}
+	if b, ok := listItem.(dynamoBackupItem); ok {
+		colStyles, _ := RenderTableHelpers(m, d.styles, dynamoBackupColumns, d.table)
+		values := append([]string{"󰁯 " + b.values[0], renderStatus(d.styles, b.values[1])}, b.values[2:]...)
+		RenderTableRow(w, m, listItem, d.styles, colStyles, values, index == m.Index())
+		return
+	}
+
 	i, ok := listItem.(dynamoItem)
 	if !ok {
 		return
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, dynamoTableColumns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, dynamoTableColumns, d.table)
 	isSelected := index == m.Index()
 
 	parts := strings.Split(i.description, " | ")
@@ -77,21 +130,25 @@ func (d dynamoItemDelegate) Render(w io.Writer, m list.Model, index int, listIte
 
 	values := []string{
 		"📊 " + i.title,
-		status,
+		renderStatus(d.styles, status),
 		items,
 		size,
 		pk,
 	}
 
-	RenderTableRow(w, m, d.styles, colStyles, values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, values, isSelected)
 }
 
 func (d dynamoItemDelegate) Height() int { return 1 }
 
-func NewDynamoDBModel(profile string, styles Styles, appCache *cache.Cache) DynamoDBModel {
+func (d dynamoItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewDynamoDBModel(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) DynamoDBModel {
+	table := &tableLayout{display: display}
 	d := dynamoItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
 	d.Styles.SelectedDesc = styles.ListSelectedDesc
@@ -108,16 +165,30 @@ func NewDynamoDBModel(profile string, styles Styles, appCache *cache.Cache) Dyna
 	return DynamoDBModel{
 		list:      l,
 		styles:    styles,
+		table:     table,
 		state:     DynamoDBStateTables,
 		profile:   profile,
 		cache:     appCache,
 		cacheKeys: cache.NewKeyBuilder(profile),
+		pitr:      make(map[string]DynamoContinuousBackupsMsg),
 	}
 }
 
 type DynamoTablesMsg []aws.DynamoTableInfo
+type DynamoBackupsMsg []aws.DynamoBackupInfo
 type DynamoErrorMsg error
 
+// DynamoContinuousBackupsMsg carries a table's point-in-time recovery
+// setting. A failed lookup only marks the status as unknown.
+type DynamoContinuousBackupsMsg struct {
+	Table string
+	Info  *aws.DynamoContinuousBackups
+	Err   error
+}
+
+// DynamoBackupCreatedMsg carries the name of a backup that was started
+type DynamoBackupCreatedMsg string
+
 func (m DynamoDBModel) Init() tea.Cmd {
 	return m.fetchTables()
 }
@@ -145,6 +216,94 @@ func (m DynamoDBModel) fetchTables() tea.Cmd {
 	}
 }
 
+func (m DynamoDBModel) fetchBackups(table string) tea.Cmd {
+	return func() tea.Msg {
+		cacheKey := m.cacheKeys.DynamoDBResources("backups:" + table)
+		if cached, ok := m.cache.Get(cacheKey); ok {
+			if backups, ok := cached.([]aws.DynamoBackupInfo); ok {
+				return DynamoBackupsMsg(backups)
+			}
+		}
+
+		client, err := aws.NewDynamoDBClient(context.Background(), m.profile)
+		if err != nil {
+			return DynamoErrorMsg(err)
+		}
+		backups, err := client.ListBackups(context.Background(), table)
+		if err != nil {
+			return DynamoErrorMsg(err)
+		}
+
+		m.cache.Set(cacheKey, backups, cache.TTLDynamoDBResources)
+		return DynamoBackupsMsg(backups)
+	}
+}
+
+func (m DynamoDBModel) fetchContinuousBackups(table string) tea.Cmd {
+	return func() tea.Msg {
+		cacheKey := m.cacheKeys.DynamoDBResources("pitr:" + table)
+		if cached, ok := m.cache.Get(cacheKey); ok {
+			if info, ok := cached.(*aws.DynamoContinuousBackups); ok {
+				return DynamoContinuousBackupsMsg{Table: table, Info: info}
+			}
+		}
+
+		client, err := aws.NewDynamoDBClient(context.Background(), m.profile)
+		if err != nil {
+			return DynamoContinuousBackupsMsg{Table: table, Err: err}
+		}
+		info, err := client.DescribeContinuousBackups(context.Background(), table)
+		if err != nil {
+			return DynamoContinuousBackupsMsg{Table: table, Err: err}
+		}
+
+		m.cache.Set(cacheKey, info, cache.TTLDynamoDBResources)
+		return DynamoContinuousBackupsMsg{Table: table, Info: info}
+	}
+}
+
+func (m DynamoDBModel) createBackup(table, name string) tea.Cmd {
+	return armed(func() tea.Msg {
+		client, err := aws.NewDynamoDBClient(context.Background(), m.profile)
+		if err != nil {
+			return DynamoErrorMsg(err)
+		}
+		if _, err := client.CreateBackup(context.Background(), table, name); err != nil {
+			return DynamoErrorMsg(err)
+		}
+		m.cache.Delete(m.cacheKeys.DynamoDBResources("backups:" + table))
+		return DynamoBackupCreatedMsg(name)
+	})
+}
+
+// openBackupInput prompts for the name of a new backup, suggesting one based
+// on the table and the current time
+func (m *DynamoDBModel) openBackupInput() {
+	m.input = textinput.New()
+	m.input.SetValue(m.currentTable + "-" + time.Now().Format("20060102-1504"))
+	m.input.CharLimit = 255
+	m.input.Width = 50
+	m.input.Focus()
+	m.state = DynamoDBStateBackupInput
+}
+
+// selectedTablePITR starts looking up the PITR setting of the selected table
+// when the detail pane shows it and it is not known yet
+func (m *DynamoDBModel) selectedTablePITR() tea.Cmd {
+	if !m.split || m.state != DynamoDBStateTables {
+		return nil
+	}
+	item, ok := m.list.SelectedItem().(dynamoItem)
+	if !ok || m.pitrPending == item.title {
+		return nil
+	}
+	if _, ok := m.pitr[item.title]; ok {
+		return nil
+	}
+	m.pitrPending = item.title
+	return m.fetchContinuousBackups(item.title)
+}
+
 func (m DynamoDBModel) Update(msg tea.Msg) (DynamoDBModel, tea.Cmd) {
 	var cmd tea.Cmd
 
@@ -155,17 +314,56 @@ func (m DynamoDBModel) Update(msg tea.Msg) (DynamoDBModel, tea.Cmd) {
 		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
 	case DynamoTablesMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, t := range msg {
 			sizeMB := float64(t.TableSize) / 1024 / 1024
 			items[i] = dynamoItem{
 				title:       t.Name,
 				description: fmt.Sprintf("Status: %s | Items: %d | Size: %.2f MB | PK: %s", t.Status, t.ItemCount, sizeMB, t.PartitionKey),
+				values:      []string{t.Name, t.Status, fmt.Sprintf("%d", t.ItemCount), fmt.Sprintf("%.2f MB", sizeMB), t.PartitionKey},
 			}
 		}
-		m.list.SetItems(items)
+		m.tables = msg
+		m.table.setItems(&m.list, items)
 		m.state = DynamoDBStateTables
 		m.list.Title = "DynamoDB Tables"
+		return m, m.selectedTablePITR()
+
+	case DynamoBackupsMsg:
+		items := make([]list.Item, len(msg))
+		for i, b := range msg {
+			items[i] = dynamoBackupItem{
+				title: b.Name,
+				arn:   b.ARN,
+				values: []string{
+					b.Name,
+					b.Status,
+					b.Type,
+					fmt.Sprintf("%.2f MB", float64(b.Size)/1024/1024),
+					b.Created.Local().Format(absoluteTimeFormat),
+				},
+			}
+		}
+		m.table.setItems(&m.list, items)
+		m.list.ResetSelected()
+		m.state = DynamoDBStateBackups
+
+	case DynamoContinuousBackupsMsg:
+		m.pitr[msg.Table] = msg
+		if m.pitrPending == msg.Table {
+			m.pitrPending = ""
+		}
+		return m, nil
+
+	case DynamoBackupCreatedMsg:
+		return m, m.fetchBackups(m.currentTable)
+
+	case DynamoItemsMsg:
+		m.setItems(msg)
+
+	case DynamoItemSavedMsg, DynamoItemDeletedMsg:
+		return m, m.fetchItems(m.currentTable)
 
 	case DynamoErrorMsg:
 		m.err = msg
@@ -176,30 +374,209 @@ func (m DynamoDBModel) Update(msg tea.Msg) (DynamoDBModel, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.state == DynamoDBStateBackupInput {
+			switch msg.String() {
+			case "esc":
+				m.state = DynamoDBStateBackups
+				return m, nil
+			case "enter":
+				name := strings.TrimSpace(m.input.Value())
+				if name == "" {
+					return m, nil
+				}
+				m.state = DynamoDBStateBackups
+				return m, m.createBackup(m.currentTable, name)
+			}
+			m.input, cmd = m.input.Update(msg)
+			return m, cmd
+		}
+
+		if m.state == DynamoDBStateConfirmDeleteItem {
+			result, cmd := m.confirm.Update(msg)
+			if result != confirmPending {
+				m.state = DynamoDBStateItems
+			}
+			return m, cmd
+		}
+
+		if m.list.SettingFilter() {
+			break
+		}
+
 		switch msg.String() {
 		case "r":
+			if m.state == DynamoDBStateItems {
+				return m, m.fetchItems(m.currentTable)
+			}
+			if m.state == DynamoDBStateBackups {
+				m.cache.Delete(m.cacheKeys.DynamoDBResources("backups:" + m.currentTable))
+				m.cache.Delete(m.cacheKeys.DynamoDBResources("pitr:" + m.currentTable))
+				delete(m.pitr, m.currentTable)
+				return m, tea.Batch(m.fetchBackups(m.currentTable), m.fetchContinuousBackups(m.currentTable))
+			}
 			m.cache.Delete(m.cacheKeys.DynamoDBResources("tables"))
 			return m, m.fetchTables()
+		case "|":
+			if m.state == DynamoDBStateTables {
+				m.split = !m.split
+				return m, m.selectedTablePITR()
+			}
+		case "enter":
+			if m.state == DynamoDBStateTables {
+				if item, ok := m.list.SelectedItem().(dynamoItem); ok {
+					m.currentTable = item.title
+					cmds := []tea.Cmd{m.fetchBackups(item.title)}
+					if _, ok := m.pitr[item.title]; !ok {
+						cmds = append(cmds, m.fetchContinuousBackups(item.title))
+					}
+					return m, tea.Batch(cmds...)
+				}
+			}
+		case "i":
+			if m.state == DynamoDBStateTables {
+				if item, ok := m.list.SelectedItem().(dynamoItem); ok {
+					m.currentTable = item.title
+					return m, m.fetchItems(item.title)
+				}
+			}
+		case "n":
+			if m.state == DynamoDBStateBackups {
+				m.openBackupInput()
+				return m, textinput.Blink
+			}
+		case "d":
+			if m.state == DynamoDBStateItems {
+				m.openDeleteItem()
+				return m, nil
+			}
+		case "esc", "backspace":
+			if m.state == DynamoDBStateBackups || m.state == DynamoDBStateItems {
+				m.currentTable = ""
+				m.state = DynamoDBStateTables
+				return m, m.fetchTables()
+			}
 		}
 	}
 
 	m.list, cmd = m.list.Update(msg)
-	return m, cmd
+	return m, tea.Batch(cmd, m.selectedTablePITR())
+}
+
+// columns are the columns of the table on screen
+func (m DynamoDBModel) columns() []Column {
+	switch m.state {
+	case DynamoDBStateItems, DynamoDBStateConfirmDeleteItem:
+		return dynamoItemColumns
+	case DynamoDBStateBackups:
+		return dynamoBackupColumns
+	}
+	return dynamoTableColumns
 }
 
 func (m DynamoDBModel) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
+	}
+
+	// An empty table still shows its header, n adds the first item
+	if m.loaded && len(m.list.Items()) == 0 && m.state != DynamoDBStateItems {
+		return RenderEmptyState(m.styles, m.list, "DynamoDB tables", m.profile, m.table)
 	}
 
+	switch m.state {
+	case DynamoDBStateBackupInput:
+		return m.renderBackupInput()
+	case DynamoDBStateItems, DynamoDBStateConfirmDeleteItem:
+		_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
+		view := header + "\n" + m.list.View()
+		if m.state == DynamoDBStateConfirmDeleteItem {
+			return RenderOverlay(view, m.confirm.View(m.styles), m.width, m.height)
+		}
+		return view
+	case DynamoDBStateBackups:
+		// Make room for the PITR line above the table
+		m.list.SetHeight(m.list.Height() - 1)
+		_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
+		return m.renderPITRLine() + "\n" + header + "\n" + m.list.View()
+	}
+
+	if m.split {
+		if listWidth, paneWidth := splitWidths(m.width); paneWidth > 0 {
+			m.list.SetWidth(listWidth)
+			return renderSplit(m.renderHeader()+"\n"+m.list.View(), m.renderTableDetail(paneWidth))
+		}
+	}
 	return m.renderHeader() + "\n" + m.list.View()
 }
 
 func (m DynamoDBModel) renderHeader() string {
-	_, header := RenderTableHelpers(m.list, m.styles, dynamoTableColumns)
+	_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
 	return header
 }
 
+// pitrText describes a table's point-in-time recovery setting
+func (m DynamoDBModel) pitrText(table string) string {
+	status, ok := m.pitr[table]
+	switch {
+	case !ok:
+		return m.styles.StatusMuted.Render("loading...")
+	case status.Err != nil:
+		return m.styles.StatusMuted.Render("unknown")
+	case status.Info.PITRStatus != "ENABLED":
+		return renderStatus(m.styles, status.Info.PITRStatus)
+	}
+	text := renderStatus(m.styles, status.Info.PITRStatus)
+	if !status.Info.EarliestRestore.IsZero() {
+		text += m.styles.StatusMuted.Render(" (restorable from " + status.Info.EarliestRestore.Local().Format(absoluteTimeFormat) + ")")
+	}
+	return text
+}
+
+func (m DynamoDBModel) renderPITRLine() string {
+	return "  " + m.styles.StatusKey.Render("Point-in-time recovery: ") + m.pitrText(m.currentTable)
+}
+
+// renderTableDetail renders the selected table for the split layout
+func (m DynamoDBModel) renderTableDetail(width int) string {
+	height := m.list.Height() + TableColumnHeaderHeight
+	item, ok := m.list.SelectedItem().(dynamoItem)
+	if !ok {
+		return renderDetailPane(m.styles, "No table selected", nil, width, height)
+	}
+	for _, t := range m.tables {
+		if t.Name != item.title {
+			continue
+		}
+		created := ""
+		if !t.CreationTime.IsZero() {
+			created = t.CreationTime.Local().Format(absoluteTimeFormat) + " (" + humanizeTime(t.CreationTime) + ")"
+		}
+		return renderDetailPane(m.styles, t.Name, []detailField{
+			{"Status", renderStatus(m.styles, t.Status)},
+			{"Partition key", t.PartitionKey},
+			{"Sort key", t.SortKey},
+			{"Billing mode", t.BillingMode},
+			{"Items", fmt.Sprintf("%d", t.ItemCount)},
+			{"Size", fmt.Sprintf("%.2f MB", float64(t.TableSize)/1024/1024)},
+			{"Created", created},
+			{"PITR", m.pitrText(t.Name)},
+		}, width, height)
+	}
+	return renderDetailPane(m.styles, item.title, nil, width, height)
+}
+
+func (m DynamoDBModel) renderBackupInput() string {
+	content := fmt.Sprintf(
+		" %s\n %s\n\n %s\n\n %s",
+		lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render("Create an on-demand backup"),
+		m.styles.StatusMuted.Render("Backup name for table "+m.currentTable),
+		m.input.View(),
+		m.styles.StatusMuted.Render("(enter to create, esc to cancel)"),
+	)
+	w, h := GetMainContainerSize(m.width, m.height)
+	return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, m.styles.Popup.Width(64).Render(content))
+}
+
 func (m *DynamoDBModel) SetSize(width, height int) {
 	m.width = width
 	m.height = height
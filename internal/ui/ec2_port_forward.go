@@ -0,0 +1,161 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+// SSMPortForwardMsg asks the main model to run an SSM port-forwarding session.
+// With a Host the Target acts as a bastion that forwards to Host instead.
+type SSMPortForwardMsg struct {
+	Target     string
+	Host       string
+	RemotePort string
+	LocalPort  string
+}
+
+// command builds the aws CLI invocation for the session
+func (msg SSMPortForwardMsg) command(profile, region string) *exec.Cmd {
+	document := "AWS-StartPortForwardingSession"
+	params := fmt.Sprintf("portNumber=%s,localPortNumber=%s", msg.RemotePort, msg.LocalPort)
+	if msg.Host != "" {
+		document = "AWS-StartPortForwardingSessionToRemoteHost"
+		params = fmt.Sprintf("host=%s,", msg.Host) + params
+	}
+	return awsCLI(profile, region, "ssm", "start-session",
+		"--target", msg.Target,
+		"--document-name", document,
+		"--parameters", params)
+}
+
+// awsCLI builds an aws CLI command against the profile and region the views
+// list, with the endpoint options of the clients
+func awsCLI(profile, region string, args ...string) *exec.Cmd {
+	c := exec.Command("aws", append(args, "--profile", profile, "--region", region)...)
+	c.Env = append(os.Environ(), aws.EndpointEnv()...)
+	return c
+}
+
+// banner tells the user where to connect while the session holds the terminal.
+// A tunnel through a bastion names the region the bastion is looked up in.
+func (msg SSMPortForwardMsg) banner(region string) string {
+	remote := msg.Target
+	if msg.Host != "" {
+		remote = fmt.Sprintf("%s via %s in %s", msg.Host, msg.Target, region)
+	}
+	return fmt.Sprintf("Connect to localhost:%s (forwarded to port %s on %s). Press Ctrl+C to close the tunnel.",
+		msg.LocalPort, msg.RemotePort, remote)
+}
+
+// bannerExecCommand prints a line to the released terminal before running cmd
+type bannerExecCommand struct {
+	cmd    *exec.Cmd
+	banner string
+}
+
+func (c *bannerExecCommand) Run() error {
+	if c.cmd.Stdout != nil {
+		fmt.Fprintf(c.cmd.Stdout, "\n%s\n\n", c.banner)
+	}
+	return c.cmd.Run()
+}
+
+func (c *bannerExecCommand) SetStdin(r io.Reader)  { c.cmd.Stdin = r }
+func (c *bannerExecCommand) SetStdout(w io.Writer) { c.cmd.Stdout = w }
+func (c *bannerExecCommand) SetStderr(w io.Writer) { c.cmd.Stderr = w }
+
+func newPortInput(placeholder string) textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = placeholder
+	ti.CharLimit = 5
+	ti.Width = 10
+	return ti
+}
+
+// openPortForward shows the port prompt for the selected instance
+func (m *EC2Model) openPortForward() {
+	m.remotePortInput = newPortInput("e.g. 5432")
+	m.localPortInput = newPortInput("same as remote")
+	m.remotePortInput.Focus()
+	m.portForwardErr = ""
+	m.state = EC2StatePortForwardInput
+}
+
+// parsePort validates a TCP port number
+func parsePort(value string) (string, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return "", fmt.Errorf("invalid port %q", value)
+	}
+	return strconv.Itoa(port), nil
+}
+
+func (m EC2Model) updatePortForward(msg tea.KeyMsg) (EC2Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "esc":
+		m.state = EC2StateInstances
+		return m, nil
+	case "tab", "shift+tab", "up", "down":
+		if m.remotePortInput.Focused() {
+			m.remotePortInput.Blur()
+			m.localPortInput.Focus()
+		} else {
+			m.localPortInput.Blur()
+			m.remotePortInput.Focus()
+		}
+		return m, nil
+	case "enter":
+		remote, err := parsePort(m.remotePortInput.Value())
+		if err != nil {
+			m.portForwardErr = err.Error()
+			return m, nil
+		}
+		local := remote
+		if m.localPortInput.Value() != "" {
+			if local, err = parsePort(m.localPortInput.Value()); err != nil {
+				m.portForwardErr = err.Error()
+				return m, nil
+			}
+		}
+		m.state = EC2StateInstances
+		target := m.selectedInstance
+		return m, func() tea.Msg {
+			return SSMPortForwardMsg{Target: target, RemotePort: remote, LocalPort: local}
+		}
+	}
+
+	if m.remotePortInput.Focused() {
+		m.remotePortInput, cmd = m.remotePortInput.Update(msg)
+	} else {
+		m.localPortInput, cmd = m.localPortInput.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m EC2Model) renderPortForward() string {
+	labelStyle := lipgloss.NewStyle().Foreground(m.styles.Primary).Width(14)
+	content := fmt.Sprintf(
+		" %s\n\n %s%s\n %s%s\n",
+		lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render("Port Forwarding to "+m.selectedInstance),
+		labelStyle.Render("Remote port"), m.remotePortInput.View(),
+		labelStyle.Render("Local port"), m.localPortInput.View(),
+	)
+	if m.portForwardErr != "" {
+		content += "\n " + m.styles.Error.Render("✘ "+m.portForwardErr) + "\n"
+	}
+	content += "\n " + m.styles.StatusMuted.Render("(tab to switch, esc to cancel)")
+
+	popup := m.styles.Popup.Width(44).Render(content)
+	w, h := GetMainContainerSize(m.width, m.height)
+	return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, popup)
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/giovannirossini/aws-tui/internal/aws"
@@ -20,7 +22,9 @@ const (
 	EC2StateSecurityGroups
 	EC2StateVolumes
 	EC2StateTargetGroups
+	EC2StateElasticIPs
 	EC2StateInstanceActions
+	EC2StatePortForwardInput
 )
 
 type ec2Item struct {
@@ -29,11 +33,27 @@ type ec2Item struct {
 	id          string
 	category    string
 	values      []string
+	// keywords holds identifiers the filter matches besides the visible ones
+	keywords string
+	links    []relatedLink
+	info     any
 }
 
 func (i ec2Item) Title() string       { return i.title }
+func (i ec2Item) source() any         { return i.info }
 func (i ec2Item) Description() string { return i.description }
-func (i ec2Item) FilterValue() string { return i.title + " " + i.description + " " + i.id }
+func (i ec2Item) FilterValue() string {
+	return i.title + " " + i.description + " " + i.id + " " + i.keywords
+}
+func (i ec2Item) rowID() string               { return i.id }
+func (i ec2Item) rowValues() []string         { return i.values }
+func (i ec2Item) relatedLinks() []relatedLink { return i.links }
+func (i ec2Item) tagTarget() (tagTarget, bool) {
+	if i.category != "instance" && i.category != "volume" {
+		return tagTarget{}, false
+	}
+	return tagTarget{kind: tagResourceEC2, id: i.id, name: i.title}, true
+}
 
 type EC2Model struct {
 	client           *aws.EC2ResourcesClient
@@ -41,19 +61,31 @@ type EC2Model struct {
 	actionList       list.Model
 	delegate         ec2ItemDelegate
 	styles           Styles
+	table            *tableLayout
 	state            EC2State
 	width            int
 	height           int
 	profile          string
 	err              error
+	loaded           bool
 	cache            *cache.Cache
 	cacheKeys        *cache.KeyBuilder
 	selectedInstance string
+	// Port forwarding prompt
+	remotePortInput textinput.Model
+	localPortInput  textinput.Model
+	portForwardErr  string
+	// Split layout showing the selected instance beside the table
+	split     bool
+	instances []aws.InstanceInfo
+	// Instance to select once the instances load, set when reopened from the history
+	selectID string
 }
 
 type ec2ItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 	state  EC2State
 }
 
@@ -90,6 +122,14 @@ var tgColumns = []Column{
 	{Title: "VPC ID", Width: 0.4},
 }
 
+var eipColumns = []Column{
+	{Title: "Name", Width: 0.2},
+	{Title: "Allocation ID", Width: 0.2},
+	{Title: "Public IP", Width: 0.15},
+	{Title: "Private IP", Width: 0.15},
+	{Title: "Associated With", Width: 0.3},
+}
+
 func (d ec2ItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
 	i, ok := listItem.(ec2Item)
 	if !ok {
@@ -97,7 +137,7 @@ func (d ec2ItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 	}
 
 	if d.state == EC2StateMenu {
-		d.DefaultDelegate.Render(w, m, index, listItem)
+		d.table.renderMenuItem(d.DefaultDelegate, w, m, index, listItem)
 		return
 	}
 
@@ -111,25 +151,31 @@ func (d ec2ItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 		columns = volumeColumns
 	case EC2StateTargetGroups:
 		columns = tgColumns
+	case EC2StateElasticIPs:
+		columns = eipColumns
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, columns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, columns, d.table)
 	isSelected := index == m.Index()
 
-	RenderTableRow(w, m, d.styles, colStyles, i.values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, i.values, isSelected)
 }
 
 func (d ec2ItemDelegate) Height() int {
 	if d.state == EC2StateMenu {
-		return 2
+		return d.table.menuItemHeight()
 	}
 	return 1
 }
 
-func NewEC2Model(profile string, styles Styles, appCache *cache.Cache) EC2Model {
+func (d ec2ItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewEC2Model(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) EC2Model {
+	table := &tableLayout{display: display}
 	d := ec2ItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 		state:           EC2StateMenu,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
@@ -145,6 +191,7 @@ func NewEC2Model(profile string, styles Styles, appCache *cache.Cache) EC2Model
 		list:      l,
 		delegate:  d,
 		styles:    styles,
+		table:     table,
 		state:     EC2StateMenu,
 		profile:   profile,
 		cache:     appCache,
@@ -156,6 +203,7 @@ type InstancesMsg []aws.InstanceInfo
 type SecurityGroupsMsg []aws.SecurityGroupInfo
 type VolumesMsg []aws.VolumeInfo
 type TargetGroupsMsg []aws.TargetGroupInfo
+type ElasticIPsMsg []aws.ElasticIPInfo
 type EC2ErrorMsg error
 type EC2MenuMsg []list.Item
 
@@ -170,6 +218,7 @@ func (m EC2Model) showMenu() tea.Cmd {
 			ec2Item{title: "Security Groups", description: "Network Firewall Rules", category: "menu"},
 			ec2Item{title: "Volumes", description: "Elastic Block Store Volumes", category: "menu"},
 			ec2Item{title: "Target Groups", description: "Load Balancer Target Groups", category: "menu"},
+			ec2Item{title: "Elastic IPs", description: "Static Public IPv4 Addresses", category: "menu"},
 		}
 		return EC2MenuMsg(items)
 	}
@@ -259,6 +308,27 @@ func (m EC2Model) fetchTargetGroups() tea.Cmd {
 	}
 }
 
+func (m EC2Model) fetchElasticIPs() tea.Cmd {
+	return func() tea.Msg {
+		if cached, ok := m.cache.Get(m.cacheKeys.EC2Resources("elastic-ips")); ok {
+			if eips, ok := cached.([]aws.ElasticIPInfo); ok {
+				return ElasticIPsMsg(eips)
+			}
+		}
+
+		client, err := aws.NewEC2ResourcesClient(context.Background(), m.profile)
+		if err != nil {
+			return EC2ErrorMsg(err)
+		}
+		eips, err := client.ListElasticIPs(context.Background())
+		if err != nil {
+			return EC2ErrorMsg(err)
+		}
+		m.cache.Set(m.cacheKeys.EC2Resources("elastic-ips"), eips, cache.TTLEC2Resources)
+		return ElasticIPsMsg(eips)
+	}
+}
+
 func (m *EC2Model) loadActionMenu() {
 	d := list.NewDefaultDelegate()
 	d.Styles.SelectedTitle = m.styles.ListSelectedTitle
@@ -266,6 +336,7 @@ func (m *EC2Model) loadActionMenu() {
 
 	m.actionList = list.New([]list.Item{
 		ec2Item{title: "SSM", description: "Connect to instance via SSM Session Manager"},
+		ec2Item{title: "Port Forward", description: "Forward a local port via SSM"},
 	}, d, 30, 10)
 	m.actionList.Title = "Instance Actions"
 	m.actionList.SetShowStatusBar(false)
@@ -291,12 +362,14 @@ func (m EC2Model) Update(msg tea.Msg) (EC2Model, tea.Cmd) {
 		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
 	case EC2MenuMsg:
-		m.list.SetItems(msg)
+		m.table.setItems(&m.list, msg)
 		m.list.ResetSelected()
 		m.state = EC2StateMenu
 		m.updateDelegate()
 
 	case InstancesMsg:
+		m.loaded = true
+		m.instances = msg
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			items[i] = ec2Item{
@@ -304,63 +377,113 @@ func (m EC2Model) Update(msg tea.Msg) (EC2Model, tea.Cmd) {
 				description: v.ID,
 				id:          v.ID,
 				category:    "instance",
-				values:      []string{v.Name, v.ID, v.Type, v.State, v.PublicIP, v.AvailabilityZone},
+				values:      []string{v.Name, v.ID, v.Type, renderStatus(m.styles, v.State), v.PublicIP, v.AvailabilityZone},
+				keywords:    strings.Join(append([]string{v.VpcID, v.SubnetID, v.PrivateIP, v.PublicIP, v.ImageID}, v.SecurityGroups...), " "),
+				links:       instanceLinks(v),
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
+		if m.selectID != "" {
+			for i, item := range items {
+				if item.(ec2Item).id == m.selectID {
+					m.list.Select(i)
+				}
+			}
+			m.selectID = ""
+		}
 		m.state = EC2StateInstances
 		m.updateDelegate()
 		return m, nil
 
 	case SecurityGroupsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			items[i] = ec2Item{
+				info:        v,
 				title:       v.Name,
 				description: v.ID,
 				id:          v.ID,
 				category:    "sg",
 				values:      []string{v.Name, v.ID, v.Description, v.VpcID},
+				keywords:    v.VpcID + " " + v.Description,
+				links:       linksTo("vpc", v.VpcID),
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = EC2StateSecurityGroups
 		m.updateDelegate()
 
 	case VolumesMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			items[i] = ec2Item{
+				info:        v,
 				title:       v.Name,
 				description: v.ID,
 				id:          v.ID,
 				category:    "volume",
-				values:      []string{v.Name, v.ID, fmt.Sprintf("%d", v.Size), v.Type, v.State, v.InstanceID},
+				values:      []string{v.Name, v.ID, fmt.Sprintf("%d", v.Size), v.Type, renderStatus(m.styles, v.State), v.InstanceID},
+				keywords:    v.InstanceID + " " + v.AvailabilityZone,
+				links:       linksTo("instance", v.InstanceID),
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = EC2StateVolumes
 		m.updateDelegate()
 
 	case TargetGroupsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			items[i] = ec2Item{
+				info:        v,
 				title:       v.Name,
 				description: v.ARN,
 				id:          v.ARN,
 				category:    "tg",
+				keywords:    v.VpcID,
+				links:       linksTo("vpc", v.VpcID),
 				values:      []string{v.Name, v.Protocol, fmt.Sprintf("%d", v.Port), v.TargetType, v.VpcID},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = EC2StateTargetGroups
 		m.updateDelegate()
 
+	case ElasticIPsMsg:
+		m.loaded = true
+		items := make([]list.Item, len(msg))
+		for i, e := range msg {
+			// Unassociated addresses are charged for while doing nothing
+			association := m.styles.Warning.Render("⚠ unassociated")
+			if e.Associated() {
+				association = e.InstanceID
+				if association == "" {
+					association = e.NetworkInterfaceID
+				}
+			}
+			items[i] = ec2Item{
+				info:        e,
+				title:       e.Name,
+				description: e.PublicIP,
+				id:          e.AllocationID,
+				category:    "eip",
+				keywords:    e.PublicIP + " " + e.PrivateIP + " " + e.InstanceID + " " + e.NetworkInterfaceID,
+				links:       linksTo("instance", e.InstanceID),
+				values:      []string{e.Name, e.AllocationID, e.PublicIP, e.PrivateIP, association},
+			}
+		}
+		m.table.setItems(&m.list, items)
+		m.list.ResetSelected()
+		m.state = EC2StateElasticIPs
+		m.updateDelegate()
+
 	case EC2ErrorMsg:
 		m.err = msg
 
@@ -370,6 +493,10 @@ func (m EC2Model) Update(msg tea.Msg) (EC2Model, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.state == EC2StatePortForwardInput {
+			return m.updatePortForward(msg)
+		}
+
 		if m.state == EC2StateInstanceActions {
 			switch msg.String() {
 			case "esc", "q":
@@ -377,8 +504,12 @@ func (m EC2Model) Update(msg tea.Msg) (EC2Model, tea.Cmd) {
 				return m, nil
 			case "enter":
 				if item, ok := m.actionList.SelectedItem().(ec2Item); ok {
-					if item.title == "SSM" {
+					switch item.title {
+					case "SSM":
 						return m, m.openSSM()
+					case "Port Forward":
+						m.openPortForward()
+						return m, textinput.Blink
 					}
 				}
 			}
@@ -387,6 +518,11 @@ func (m EC2Model) Update(msg tea.Msg) (EC2Model, tea.Cmd) {
 		}
 
 		switch msg.String() {
+		case "|":
+			if m.state == EC2StateInstances {
+				m.split = !m.split
+				return m, nil
+			}
 		case "o":
 			if m.state == EC2StateInstances {
 				if item, ok := m.list.SelectedItem().(ec2Item); ok {
@@ -410,6 +546,9 @@ func (m EC2Model) Update(msg tea.Msg) (EC2Model, tea.Cmd) {
 			case EC2StateTargetGroups:
 				m.cache.Delete(m.cacheKeys.EC2Resources("target-groups"))
 				return m, m.fetchTargetGroups()
+			case EC2StateElasticIPs:
+				m.cache.Delete(m.cacheKeys.EC2Resources("elastic-ips"))
+				return m, m.fetchElasticIPs()
 			}
 		case "enter":
 			if item, ok := m.list.SelectedItem().(ec2Item); ok {
@@ -423,6 +562,8 @@ func (m EC2Model) Update(msg tea.Msg) (EC2Model, tea.Cmd) {
 						return m, m.fetchVolumes()
 					case "Target Groups":
 						return m, m.fetchTargetGroups()
+					case "Elastic IPs":
+						return m, m.fetchElasticIPs()
 					}
 				}
 			}
@@ -444,6 +585,7 @@ func (m *EC2Model) updateDelegate() {
 	d := ec2ItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          m.styles,
+		table:           m.table,
 		state:           m.state,
 	}
 	d.Styles.SelectedTitle = m.styles.ListSelectedTitle
@@ -451,9 +593,39 @@ func (m *EC2Model) updateDelegate() {
 	m.list.SetDelegate(d)
 }
 
+// ec2ResourceNames names the resources listed in each table state, for the empty-state message
+var ec2ResourceNames = map[EC2State]string{
+	EC2StateInstances:      "EC2 instances",
+	EC2StateSecurityGroups: "security groups",
+	EC2StateVolumes:        "EBS volumes",
+	EC2StateTargetGroups:   "target groups",
+	EC2StateElasticIPs:     "Elastic IPs",
+}
+
+// columns are the columns of the table on screen
+func (m EC2Model) columns() []Column {
+	switch m.state {
+	case EC2StateInstances:
+		return instanceColumns
+	case EC2StateSecurityGroups:
+		return sgColumns
+	case EC2StateVolumes:
+		return volumeColumns
+	case EC2StateTargetGroups:
+		return tgColumns
+	case EC2StateElasticIPs:
+		return eipColumns
+	}
+	return nil
+}
+
 func (m EC2Model) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
+	}
+
+	if m.state == EC2StatePortForwardInput {
+		return m.renderPortForward()
 	}
 
 	if m.state == EC2StateInstanceActions {
@@ -464,25 +636,64 @@ func (m EC2Model) View() string {
 		return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, popup)
 	}
 
+	if resource, ok := ec2ResourceNames[m.state]; ok && m.loaded && len(m.list.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.list, resource, m.profile, m.table)
+	}
+
 	if m.state != EC2StateMenu {
-		var columns []Column
-		switch m.state {
-		case EC2StateInstances:
-			columns = instanceColumns
-		case EC2StateSecurityGroups:
-			columns = sgColumns
-		case EC2StateVolumes:
-			columns = volumeColumns
-		case EC2StateTargetGroups:
-			columns = tgColumns
+		columns := m.columns()
+		if m.state == EC2StateInstances && m.split {
+			if listWidth, paneWidth := splitWidths(m.width); paneWidth > 0 {
+				m.list.SetWidth(listWidth)
+				_, header := RenderTableHelpers(m.list, m.styles, columns, m.table)
+				return renderSplit(header+"\n"+m.list.View(), m.renderInstanceDetail(paneWidth))
+			}
 		}
-		_, header := RenderTableHelpers(m.list, m.styles, columns)
+		_, header := RenderTableHelpers(m.list, m.styles, columns, m.table)
 		return header + "\n" + m.list.View()
 	}
 
 	return m.list.View()
 }
 
+// renderInstanceDetail renders the selected instance for the split layout
+func (m EC2Model) renderInstanceDetail(width int) string {
+	height := m.list.Height() + TableColumnHeaderHeight
+	item, ok := m.list.SelectedItem().(ec2Item)
+	if !ok {
+		return renderDetailPane(m.styles, "No instance selected", nil, width, height)
+	}
+	for _, inst := range m.instances {
+		if inst.ID != item.id {
+			continue
+		}
+		launched := ""
+		if !inst.LaunchTime.IsZero() {
+			launched = inst.LaunchTime.Local().Format(absoluteTimeFormat) + " (" + humanizeTime(inst.LaunchTime) + ")"
+		}
+		title := inst.ID
+		if inst.Name != "" {
+			title = inst.Name
+		}
+		return renderDetailPane(m.styles, title, []detailField{
+			{"Instance ID", inst.ID},
+			{"State", renderStatus(m.styles, inst.State)},
+			{"Type", inst.Type},
+			{"Platform", inst.Platform},
+			{"AMI", inst.ImageID},
+			{"Key pair", inst.KeyName},
+			{"Launched", launched},
+			{"AZ", inst.AvailabilityZone},
+			{"VPC", inst.VpcID},
+			{"Subnet", inst.SubnetID},
+			{"Private IP", inst.PrivateIP},
+			{"Public IP", inst.PublicIP},
+			{"Security groups", strings.Join(inst.SecurityGroups, "\n")},
+		}, width, height)
+	}
+	return renderDetailPane(m.styles, item.id, nil, width, height)
+}
+
 func (m *EC2Model) SetSize(width, height int) {
 	m.width = width
 	m.height = height
@@ -25,22 +25,26 @@ type ecrItem struct {
 	description string
 	isRepo      bool
 	repository  string
+	info        any
 }
 
 func (i ecrItem) Title() string       { return i.title }
+func (i ecrItem) source() any         { return i.info }
 func (i ecrItem) Description() string { return i.description }
-func (i ecrItem) FilterValue() string { return i.title }
+func (i ecrItem) FilterValue() string { return i.title + " " + i.description }
 
 type ECRModel struct {
 	client            *aws.ECRClient
 	list              list.Model
 	styles            Styles
+	table             *tableLayout
 	state             ECRState
 	currentRepository string
 	width             int
 	height            int
 	profile           string
 	err               error
+	loaded            bool
 	cache             *cache.Cache
 	cacheKeys         *cache.KeyBuilder
 }
@@ -48,6 +52,7 @@ type ECRModel struct {
 type ecrItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 	state  ECRState
 }
 
@@ -77,7 +82,7 @@ func (d ecrItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 		columns = ecrImageColumns
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, columns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, columns, d.table)
 	isSelected := index == m.Index()
 
 	var values []string
@@ -116,15 +121,19 @@ func (d ecrItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 		}
 	}
 
-	RenderTableRow(w, m, d.styles, colStyles, values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, values, isSelected)
 }
 
 func (d ecrItemDelegate) Height() int { return 1 }
 
-func NewECRModel(profile string, styles Styles, appCache *cache.Cache) ECRModel {
+func (d ecrItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewECRModel(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) ECRModel {
+	table := &tableLayout{display: display}
 	d := ecrItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 		state:           ECRStateRepositories,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
@@ -142,6 +151,7 @@ func NewECRModel(profile string, styles Styles, appCache *cache.Cache) ECRModel
 	return ECRModel{
 		list:      l,
 		styles:    styles,
+		table:     table,
 		state:     ECRStateRepositories,
 		profile:   profile,
 		cache:     appCache,
@@ -213,6 +223,7 @@ func (m ECRModel) Update(msg tea.Msg) (ECRModel, tea.Cmd) {
 		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
 	case ECRReposMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, r := range msg {
 			items[i] = ecrItem{
@@ -221,13 +232,14 @@ func (m ECRModel) Update(msg tea.Msg) (ECRModel, tea.Cmd) {
 				isRepo:      true,
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.state = ECRStateRepositories
 		m.list.Title = "ECR Repositories"
 
 		d := ecrItemDelegate{
 			DefaultDelegate: list.NewDefaultDelegate(),
 			styles:          m.styles,
+			table:           m.table,
 			state:           ECRStateRepositories,
 		}
 		d.Styles.SelectedTitle = m.styles.ListSelectedTitle
@@ -235,6 +247,7 @@ func (m ECRModel) Update(msg tea.Msg) (ECRModel, tea.Cmd) {
 		m.list.SetDelegate(d)
 
 	case ECRImagesMsg:
+		m.loaded = true
 		items := make([]list.Item, 0)
 		items = append(items, ecrItem{title: "..", description: "Back", isRepo: false})
 
@@ -245,19 +258,21 @@ func (m ECRModel) Update(msg tea.Msg) (ECRModel, tea.Cmd) {
 			}
 			sizeMB := float64(img.Size) / 1024 / 1024
 			items = append(items, ecrItem{
+				info:        img,
 				title:       tags,
 				description: fmt.Sprintf("Pushed: %s | Size: %.2f MB | Digest: %s", img.PushedAt.Format("2006-01-02 15:04"), sizeMB, img.Digest),
 				isRepo:      false,
 				repository:  m.currentRepository,
 			})
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.state = ECRStateImages
 		m.list.Title = fmt.Sprintf("ECR Images: %s", m.currentRepository)
 
 		d := ecrItemDelegate{
 			DefaultDelegate: list.NewDefaultDelegate(),
 			styles:          m.styles,
+			table:           m.table,
 			state:           ECRStateImages,
 		}
 		d.Styles.SelectedTitle = m.styles.ListSelectedTitle
@@ -306,22 +321,34 @@ func (m ECRModel) Update(msg tea.Msg) (ECRModel, tea.Cmd) {
 	return m, cmd
 }
 
+// ecrResourceNames names the resources listed in each table state, for the empty-state message
+var ecrResourceNames = map[ECRState]string{
+	ECRStateRepositories: "ECR repositories",
+	ECRStateImages:       "images",
+}
+
+// columns are the columns of the table on screen
+func (m ECRModel) columns() []Column {
+	if m.state == ECRStateRepositories {
+		return ecrRepoColumns
+	}
+	return ecrImageColumns
+}
+
 func (m ECRModel) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
+	}
+
+	if resource, ok := ecrResourceNames[m.state]; ok && m.loaded && len(m.list.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.list, resource, m.profile, m.table)
 	}
 
 	return m.renderHeader() + "\n" + m.list.View()
 }
 
 func (m ECRModel) renderHeader() string {
-	var columns []Column
-	if m.state == ECRStateRepositories {
-		columns = ecrRepoColumns
-	} else {
-		columns = ecrImageColumns
-	}
-	_, header := RenderTableHelpers(m.list, m.styles, columns)
+	_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
 	return header
 }
 
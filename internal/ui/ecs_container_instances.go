@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+var ecsContainerInstanceColumns = []Column{
+	{Title: "EC2 Instance", Width: 0.2},
+	{Title: "Status", Width: 0.12},
+	{Title: "Agent", Width: 0.13},
+	{Title: "Running", Width: 0.1},
+	{Title: "Pending", Width: 0.1},
+	{Title: "CPU Free", Width: 0.15},
+	{Title: "Memory Free", Width: 0.2},
+}
+
+// ECSContainerInstancesMsg carries the container instances of the selected
+// cluster
+type ECSContainerInstancesMsg []aws.ContainerInstanceInfo
+
+func (m ECSModel) fetchContainerInstances(cluster string) tea.Cmd {
+	return func() tea.Msg {
+		client, err := aws.NewECSClient(context.Background(), m.profile)
+		if err != nil {
+			return ECSErrorMsg(err)
+		}
+		arns, err := client.ListContainerInstances(context.Background(), cluster)
+		if err != nil {
+			return ECSErrorMsg(err)
+		}
+		instances, err := client.DescribeContainerInstances(context.Background(), cluster, arns)
+		if err != nil {
+			return ECSErrorMsg(err)
+		}
+		return ECSContainerInstancesMsg(instances)
+	}
+}
+
+func (m *ECSModel) setContainerInstances(msg ECSContainerInstancesMsg) {
+	m.loaded = true
+	items := make([]list.Item, len(msg))
+	for i, v := range msg {
+		agent := m.styles.Success.Render("connected")
+		if !v.AgentConnected {
+			// Tasks are not placed on an instance whose agent is disconnected
+			agent = m.styles.Error.Render("disconnected")
+		}
+		items[i] = ecsItem{
+			title:       v.EC2InstanceID,
+			description: v.ARN,
+			id:          v.ID,
+			arn:         v.ARN,
+			values: []string{
+				v.EC2InstanceID,
+				renderStatus(m.styles, v.Status),
+				agent,
+				fmt.Sprintf("%d", v.RunningTasks),
+				fmt.Sprintf("%d", v.PendingTasks),
+				m.renderCapacity(v.RemainingCPU, v.RegisteredCPU, ""),
+				m.renderCapacity(v.RemainingMemory, v.RegisteredMemory, " MiB"),
+			},
+		}
+	}
+	m.list.SetItems(items)
+	m.list.ResetSelected()
+	m.state = ECSStateContainerInstances
+}
+
+// renderCapacity shows what is left of a resource out of what the instance
+// registered, warning once less than a tenth is free
+func (m ECSModel) renderCapacity(remaining, registered int32, unit string) string {
+	s := fmt.Sprintf("%d/%d%s", remaining, registered, unit)
+	if registered > 0 && remaining*10 < registered {
+		return m.styles.Warning.Render(s)
+	}
+	return s
+}
@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+// ecsDeploymentPollInterval is how often the watcher describes the service
+const ecsDeploymentPollInterval = 5 * time.Second
+
+// ecsDeploymentEvents is how many of the latest service events are shown
+const ecsDeploymentEvents = 8
+
+// ECSDeploymentMsg carries one poll of the watched service. Polls from an
+// earlier watch carry a stale id and do not schedule another.
+type ECSDeploymentMsg struct {
+	watch  int
+	status *aws.ECSDeploymentStatus
+}
+
+// ecsDeploymentTickMsg polls the service again. Ticks from an earlier watch
+// carry a stale id and are dropped.
+type ecsDeploymentTickMsg int
+
+// watchDeployment opens the watcher for the selected service
+func (m *ECSModel) watchDeployment() tea.Cmd {
+	m.state = ECSStateDeployment
+	m.deployment = nil
+	m.deploymentWatch++
+	return m.fetchDeployment()
+}
+
+func (m ECSModel) fetchDeployment() tea.Cmd {
+	cluster, service, watch := m.selectedCluster, m.selectedService, m.deploymentWatch
+	return func() tea.Msg {
+		client, err := aws.NewECSClient(context.Background(), m.profile)
+		if err != nil {
+			return ECSErrorMsg(err)
+		}
+		status, err := client.GetDeploymentStatus(context.Background(), cluster, service)
+		if err != nil {
+			return ECSErrorMsg(err)
+		}
+		return ECSDeploymentMsg{watch: watch, status: status}
+	}
+}
+
+// pollDeployment schedules the next poll unless the rollout is over
+func (m ECSModel) pollDeployment() tea.Cmd {
+	if m.deployment == nil || m.deployment.Stable || m.deployment.Failed {
+		return nil
+	}
+	id := m.deploymentWatch
+	return tea.Tick(ecsDeploymentPollInterval, func(time.Time) tea.Msg {
+		return ecsDeploymentTickMsg(id)
+	})
+}
+
+func (m ECSModel) renderDeployment() string {
+	title := lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true)
+	if m.deployment == nil {
+		return "\n  " + title.Render("󱎯 Loading deployment...")
+	}
+	d := m.deployment
+
+	var s strings.Builder
+	switch {
+	case d.Stable:
+		s.WriteString(m.styles.Success.Bold(true).Render("✔ Deployment complete"))
+	case d.Failed:
+		s.WriteString(m.styles.Error.Bold(true).Render("✘ Deployment failed"))
+	default:
+		s.WriteString(m.styles.Warning.Bold(true).Render("… Deployment in progress") + " " +
+			m.styles.StatusMuted.Render(fmt.Sprintf("(polling every %s)", ecsDeploymentPollInterval)))
+	}
+	s.WriteString("\n\n")
+	// How far the primary deployment has come towards its desired tasks
+	for _, dep := range d.Deployments {
+		if dep.Status == "PRIMARY" && dep.Desired > 0 {
+			s.WriteString(renderProgressBar(m.styles, float64(dep.Running)/float64(dep.Desired), progressBarWidth) + " " +
+				m.styles.StatusMuted.Render(fmt.Sprintf("%d of %d tasks running", dep.Running, dep.Desired)) + "\n\n")
+		}
+	}
+
+	row := func(cells ...string) string {
+		widths := []int{10, 14, 28, 9, 9, 9, 14}
+		out := make([]string, len(cells))
+		for i, c := range cells {
+			out[i] = lipgloss.NewStyle().Width(widths[i]).MaxWidth(widths[i]).Render(c)
+		}
+		return lipgloss.JoinHorizontal(lipgloss.Top, out...)
+	}
+	s.WriteString(lipgloss.NewStyle().Foreground(m.styles.Muted).Bold(true).Render(
+		row("STATUS", "ROLLOUT", "TASK DEFINITION", "RUNNING", "PENDING", "DESIRED", "UPDATED")) + "\n")
+	for _, dep := range d.Deployments {
+		rollout := dep.RolloutState
+		if rollout == "" {
+			rollout = "-"
+		}
+		s.WriteString(row(
+			dep.Status,
+			renderStatus(m.styles, rollout),
+			dep.TaskDefinition,
+			fmt.Sprintf("%d", dep.Running),
+			fmt.Sprintf("%d", dep.Pending),
+			fmt.Sprintf("%d", dep.Desired),
+			humanizeTime(dep.UpdatedAt),
+		) + "\n")
+		if dep.RolloutReason != "" && dep.Status == "PRIMARY" {
+			s.WriteString(m.styles.StatusMuted.Render("  "+dep.RolloutReason) + "\n")
+		}
+	}
+
+	s.WriteString("\n" + title.Render("Recent events") + "\n")
+	events := d.Events[:min(len(d.Events), ecsDeploymentEvents)]
+	if len(events) == 0 {
+		s.WriteString(m.styles.StatusMuted.Render("No events") + "\n")
+	}
+	for _, e := range events {
+		s.WriteString(m.styles.StatusMuted.Render(e.CreatedAt) + "  " + e.Message + "\n")
+	}
+
+	w, _ := GetMainContainerSize(m.width, m.height)
+	return lipgloss.NewStyle().Padding(1, 2).Width(w - 2).Render(s.String())
+}
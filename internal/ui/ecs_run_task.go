@@ -0,0 +1,490 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+type runTaskStep int
+
+const (
+	runTaskCluster runTaskStep = iota
+	runTaskTaskDef
+	runTaskLaunchType
+	runTaskSubnets
+	runTaskSecurityGroups
+	runTaskPublicIP
+	runTaskConfirm
+	runTaskLaunched
+)
+
+// runTaskStepTitles name the choice asked for at each step
+var runTaskStepTitles = map[runTaskStep]string{
+	runTaskCluster:        "Cluster",
+	runTaskTaskDef:        "Task definition",
+	runTaskLaunchType:     "Launch type",
+	runTaskSubnets:        "Subnets",
+	runTaskSecurityGroups: "Security groups",
+	runTaskPublicIP:       "Public IP",
+}
+
+// runTaskForm walks through the choices needed to run a one-off task. Steps
+// already answered by where the form was opened from are skipped.
+type runTaskForm struct {
+	step           runTaskStep
+	cluster        string
+	taskDef        string // Family (latest active revision) or revision ARN
+	launchType     string
+	subnets        []string
+	vpcID          string
+	securityGroups []string
+	publicIP       bool
+	// skipCluster and skipTaskDef remember the answers given on opening, so
+	// going back does not ask for them
+	skipCluster bool
+	skipTaskDef bool
+	options     list.Model
+	loading     bool
+	running     bool
+	launched    *aws.LaunchedTask
+	err         error
+}
+
+// runTaskOption is a choice in the form; multi-select steps check several
+type runTaskOption struct {
+	title       string
+	description string
+	value       string
+	vpcID       string
+	checked     bool
+}
+
+func (o runTaskOption) Title() string       { return o.title }
+func (o runTaskOption) Description() string { return o.description }
+func (o runTaskOption) FilterValue() string { return o.title + " " + o.value + " " + o.description }
+
+type runTaskOptionDelegate struct {
+	styles Styles
+	multi  bool
+}
+
+func (d runTaskOptionDelegate) Height() int                               { return 1 }
+func (d runTaskOptionDelegate) Spacing() int                              { return 0 }
+func (d runTaskOptionDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd { return nil }
+func (d runTaskOptionDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	o, ok := listItem.(runTaskOption)
+	if !ok {
+		return
+	}
+	str := o.title
+	if d.multi {
+		box := "[ ] "
+		if o.checked {
+			box = "[x] "
+		}
+		str = box + str
+	}
+	if o.description != "" {
+		str += "  " + d.styles.StatusMuted.Render(o.description)
+	}
+	str = lipgloss.NewStyle().MaxWidth(max(m.Width()-2, 10)).Render(str)
+	if index == m.Index() {
+		str = d.styles.SelectedMenuItem.Render("➜ " + str)
+	} else {
+		str = d.styles.MenuItem.Render("  " + str)
+	}
+	fmt.Fprint(w, str)
+}
+
+// ecsRunTaskOptionsMsg carries the choices of a step loaded from the account
+type ecsRunTaskOptionsMsg struct {
+	step    runTaskStep
+	options []runTaskOption
+}
+
+// ECSTaskLaunchedMsg carries the task started from the run task form
+type ECSTaskLaunchedMsg aws.LaunchedTask
+
+// openRunTask starts the form; cluster and taskDef may be empty to be asked for
+func (m *ECSModel) openRunTask(cluster, taskDef string) tea.Cmd {
+	m.runTask = runTaskForm{
+		cluster:     cluster,
+		taskDef:     taskDef,
+		skipCluster: cluster != "",
+		skipTaskDef: taskDef != "",
+	}
+	m.state = ECSStateRunTask
+	step := runTaskCluster
+	if cluster != "" {
+		step = runTaskTaskDef
+	}
+	if taskDef != "" && cluster != "" {
+		step = runTaskLaunchType
+	}
+	return m.gotoRunTaskStep(step)
+}
+
+// gotoRunTaskStep shows the choices of a step, loading them when they come
+// from the account
+func (m *ECSModel) gotoRunTaskStep(step runTaskStep) tea.Cmd {
+	f := &m.runTask
+	f.step = step
+	f.err = nil
+	f.options = list.New(nil, runTaskOptionDelegate{styles: m.styles, multi: step == runTaskSubnets || step == runTaskSecurityGroups}, 64, 12)
+	f.options.SetShowTitle(false)
+	f.options.SetShowStatusBar(false)
+	f.options.SetShowHelp(false)
+	f.options.KeyMap.Quit.SetEnabled(false)
+
+	switch step {
+	case runTaskLaunchType:
+		f.options.SetItems([]list.Item{
+			runTaskOption{title: "FARGATE", value: "FARGATE", description: "Serverless, needs subnets"},
+			runTaskOption{title: "EC2", value: "EC2", description: "On the cluster's container instances"},
+		})
+	case runTaskPublicIP:
+		f.options.SetItems([]list.Item{
+			runTaskOption{title: "Disabled", value: "no", description: "Private subnets with a NAT gateway or endpoints"},
+			runTaskOption{title: "Enabled", value: "yes", description: "Public subnets without a NAT gateway"},
+		})
+	case runTaskConfirm, runTaskLaunched:
+	default:
+		f.loading = true
+		return m.fetchRunTaskOptions(step, f.vpcID)
+	}
+	return nil
+}
+
+func (m ECSModel) fetchRunTaskOptions(step runTaskStep, vpcID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		var options []runTaskOption
+		switch step {
+		case runTaskCluster:
+			client, err := aws.NewECSClient(ctx, m.profile)
+			if err != nil {
+				return ECSErrorMsg(err)
+			}
+			clusters, err := client.ListClusters(ctx)
+			if err != nil {
+				return ECSErrorMsg(err)
+			}
+			for _, c := range clusters {
+				options = append(options, runTaskOption{title: c.Name, value: c.Name, description: c.Status})
+			}
+		case runTaskTaskDef:
+			client, err := aws.NewECSClient(ctx, m.profile)
+			if err != nil {
+				return ECSErrorMsg(err)
+			}
+			families, err := client.ListTaskDefinitionFamilies(ctx)
+			if err != nil {
+				return ECSErrorMsg(err)
+			}
+			for _, f := range families {
+				options = append(options, runTaskOption{title: f, value: f, description: "latest revision"})
+			}
+		case runTaskSubnets:
+			client, err := aws.NewEC2Client(ctx, m.profile)
+			if err != nil {
+				return ECSErrorMsg(err)
+			}
+			subnets, err := client.ListSubnets(ctx)
+			if err != nil {
+				return ECSErrorMsg(err)
+			}
+			for _, s := range subnets {
+				title := s.ID
+				if s.Name != "" {
+					title = s.Name + " (" + s.ID + ")"
+				}
+				options = append(options, runTaskOption{title: title, value: s.ID, vpcID: s.VpcID, description: s.AvailabilityZone + " " + s.CidrBlock})
+			}
+		case runTaskSecurityGroups:
+			client, err := aws.NewEC2ResourcesClient(ctx, m.profile)
+			if err != nil {
+				return ECSErrorMsg(err)
+			}
+			groups, err := client.ListSecurityGroups(ctx)
+			if err != nil {
+				return ECSErrorMsg(err)
+			}
+			for _, g := range groups {
+				if g.VpcID != vpcID {
+					continue
+				}
+				options = append(options, runTaskOption{title: g.Name + " (" + g.ID + ")", value: g.ID, vpcID: g.VpcID, description: g.Description})
+			}
+		}
+		return ecsRunTaskOptionsMsg{step: step, options: options}
+	}
+}
+
+func (m ECSModel) launchTask(f runTaskForm) tea.Cmd {
+	return armed(func() tea.Msg {
+		client, err := aws.NewECSClient(context.Background(), m.profile)
+		if err != nil {
+			return ECSErrorMsg(err)
+		}
+		var network *aws.TaskNetworkConfig
+		if len(f.subnets) > 0 {
+			network = &aws.TaskNetworkConfig{Subnets: f.subnets, SecurityGroups: f.securityGroups, AssignPublicIP: f.publicIP}
+		}
+		task, err := client.RunTask(context.Background(), f.cluster, f.taskDef, f.launchType, network)
+		if err != nil {
+			return ECSErrorMsg(err)
+		}
+		return ECSTaskLaunchedMsg(*task)
+	})
+}
+
+func (m *ECSModel) setRunTaskOptions(msg ecsRunTaskOptionsMsg) {
+	f := &m.runTask
+	if m.state != ECSStateRunTask || msg.step != f.step {
+		return
+	}
+	f.loading = false
+	items := make([]list.Item, len(msg.options))
+	for i, o := range msg.options {
+		items[i] = o
+	}
+	f.options.SetItems(items)
+}
+
+// checked returns the values of the options checked in a multi-select step
+func (f runTaskForm) checked() []runTaskOption {
+	var checked []runTaskOption
+	for _, item := range f.options.Items() {
+		if o, ok := item.(runTaskOption); ok && o.checked {
+			checked = append(checked, o)
+		}
+	}
+	return checked
+}
+
+// previousStep is the step esc goes back to; the first step closes the form
+func (f runTaskForm) previousStep() (runTaskStep, bool) {
+	switch f.step {
+	case runTaskTaskDef:
+		return runTaskCluster, !f.skipCluster
+	case runTaskLaunchType:
+		if !f.skipTaskDef {
+			return runTaskTaskDef, true
+		}
+		return runTaskCluster, !f.skipCluster
+	case runTaskSubnets:
+		return runTaskLaunchType, true
+	case runTaskSecurityGroups:
+		return runTaskSubnets, true
+	case runTaskPublicIP:
+		return runTaskSecurityGroups, true
+	case runTaskConfirm:
+		switch {
+		case len(f.subnets) == 0:
+			return runTaskSubnets, true
+		case f.launchType == "FARGATE":
+			return runTaskPublicIP, true
+		default:
+			return runTaskSecurityGroups, true
+		}
+	}
+	return 0, false
+}
+
+func (m ECSModel) updateRunTask(msg tea.KeyMsg) (ECSModel, tea.Cmd) {
+	f := &m.runTask
+	if f.running {
+		return m, nil
+	}
+	filtering := f.options.FilterState() == list.Filtering
+
+	if f.step == runTaskLaunched {
+		switch msg.String() {
+		case "l":
+			return m, m.fetchLogGroup(f.launched.TaskDefinitionArn)
+		case "y":
+			return m, copyToClipboard(f.launched.TaskArn, "task ARN")
+		case "esc", "q", "enter":
+			m.state = m.runTaskReturnState
+		}
+		return m, nil
+	}
+
+	if f.step == runTaskConfirm {
+		switch msg.String() {
+		case "enter", "y", "Y":
+			f.running = true
+			return m, m.launchTask(*f)
+		case "esc", "backspace", "n":
+			step, _ := f.previousStep()
+			return m, m.gotoRunTaskStep(step)
+		}
+		return m, nil
+	}
+
+	switch {
+	case msg.String() == "esc" && !filtering && f.options.FilterState() == list.Unfiltered:
+		if step, ok := f.previousStep(); ok {
+			return m, m.gotoRunTaskStep(step)
+		}
+		m.state = m.runTaskReturnState
+		return m, nil
+
+	case msg.String() == " " && !filtering && (f.step == runTaskSubnets || f.step == runTaskSecurityGroups):
+		if o, ok := f.options.SelectedItem().(runTaskOption); ok {
+			o.checked = !o.checked
+			f.options.SetItem(f.options.Index(), o)
+		}
+		return m, nil
+
+	case msg.String() == "enter" && !filtering:
+		if f.loading {
+			return m, nil
+		}
+		return m, m.submitRunTaskStep()
+	}
+
+	var cmd tea.Cmd
+	f.options, cmd = f.options.Update(msg)
+	return m, cmd
+}
+
+// submitRunTaskStep records the choice of the step and moves to the next
+func (m *ECSModel) submitRunTaskStep() tea.Cmd {
+	f := &m.runTask
+	selected, _ := f.options.SelectedItem().(runTaskOption)
+
+	switch f.step {
+	case runTaskCluster:
+		if selected.value == "" {
+			return nil
+		}
+		f.cluster = selected.value
+		if f.skipTaskDef {
+			return m.gotoRunTaskStep(runTaskLaunchType)
+		}
+		return m.gotoRunTaskStep(runTaskTaskDef)
+
+	case runTaskTaskDef:
+		if selected.value == "" {
+			return nil
+		}
+		f.taskDef = selected.value
+		return m.gotoRunTaskStep(runTaskLaunchType)
+
+	case runTaskLaunchType:
+		if selected.value == "" {
+			return nil
+		}
+		f.launchType = selected.value
+		return m.gotoRunTaskStep(runTaskSubnets)
+
+	case runTaskSubnets:
+		checked := f.checked()
+		f.subnets, f.vpcID = nil, ""
+		for _, o := range checked {
+			if f.vpcID != "" && o.vpcID != f.vpcID {
+				f.err = fmt.Errorf("subnets must all be in one VPC")
+				return nil
+			}
+			f.vpcID = o.vpcID
+			f.subnets = append(f.subnets, o.value)
+		}
+		if len(f.subnets) == 0 {
+			if f.launchType == "FARGATE" {
+				f.err = fmt.Errorf("Fargate tasks need at least one subnet (space to select)")
+				return nil
+			}
+			// EC2 tasks outside awsvpc take no network configuration
+			f.securityGroups = nil
+			return m.gotoRunTaskStep(runTaskConfirm)
+		}
+		return m.gotoRunTaskStep(runTaskSecurityGroups)
+
+	case runTaskSecurityGroups:
+		f.securityGroups = nil
+		for _, o := range f.checked() {
+			f.securityGroups = append(f.securityGroups, o.value)
+		}
+		if f.launchType == "FARGATE" {
+			return m.gotoRunTaskStep(runTaskPublicIP)
+		}
+		return m.gotoRunTaskStep(runTaskConfirm)
+
+	case runTaskPublicIP:
+		f.publicIP = selected.value == "yes"
+		return m.gotoRunTaskStep(runTaskConfirm)
+	}
+	return nil
+}
+
+func (m ECSModel) renderRunTask() string {
+	f := m.runTask
+	title := lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(m.styles.Primary).Width(18)
+
+	var s strings.Builder
+	s.WriteString(" " + title.Render("Run task") + "\n\n")
+
+	// What has been chosen so far
+	summary := []detailField{
+		{"Cluster", f.cluster},
+		{"Task definition", f.taskDef},
+		{"Launch type", f.launchType},
+		{"Subnets", strings.Join(f.subnets, ", ")},
+		{"Security groups", strings.Join(f.securityGroups, ", ")},
+	}
+	if f.launchType == "FARGATE" && f.step >= runTaskConfirm {
+		summary = append(summary, detailField{"Public IP", map[bool]string{true: "Enabled", false: "Disabled"}[f.publicIP]})
+	}
+	for _, field := range summary {
+		if field.value != "" {
+			s.WriteString(" " + labelStyle.Render(field.label) + lipgloss.NewStyle().MaxWidth(60).Render(field.value) + "\n")
+		}
+	}
+
+	help := ""
+	switch f.step {
+	case runTaskConfirm:
+		if f.running {
+			s.WriteString("\n " + m.styles.StatusMuted.Render("Starting task...") + "\n")
+		} else {
+			s.WriteString("\n " + m.styles.Warning.Render("Run one task with these settings?") + "\n")
+			help = "(enter to run, esc to go back)"
+		}
+	case runTaskLaunched:
+		s.WriteString("\n " + m.styles.Success.Bold(true).Render("✔ Task started") + "\n")
+		s.WriteString(" " + lipgloss.NewStyle().MaxWidth(78).Render(f.launched.TaskArn) + "\n")
+		help = "(l to open its logs, y to copy the ARN, esc to close)"
+	default:
+		s.WriteString("\n " + title.Render(runTaskStepTitles[f.step]) + "\n")
+		if f.loading {
+			s.WriteString(" " + m.styles.StatusMuted.Render("loading...") + "\n")
+		} else if len(f.options.Items()) == 0 {
+			s.WriteString(" " + m.styles.StatusMuted.Render("Nothing to choose from") + "\n")
+		} else {
+			s.WriteString(f.options.View() + "\n")
+		}
+		help = "(enter to choose, / to filter, esc to go back)"
+		if f.step == runTaskSubnets || f.step == runTaskSecurityGroups {
+			help = "(space to select, enter to continue, esc to go back)"
+		}
+	}
+	if f.err != nil {
+		s.WriteString("\n " + m.styles.Error.Render("✘ "+f.err.Error()) + "\n")
+	}
+	if help != "" {
+		s.WriteString("\n " + m.styles.StatusMuted.Render(help))
+	}
+
+	popup := m.styles.Popup.Width(84).Render(s.String())
+	w, h := GetMainContainerSize(m.width, m.height)
+	return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, popup)
+}
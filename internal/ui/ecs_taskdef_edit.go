@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+// ECSTaskDefRegisteredMsg carries the ARN of the revision registered from an
+// edited task definition
+type ECSTaskDefRegisteredMsg string
+
+// ecsTaskDefUnchangedMsg reports that the editor was closed without changes
+type ecsTaskDefUnchangedMsg struct{}
+
+// getTaskDefEditCommand writes the task definition on screen to a temp file
+// and opens it in $EDITOR. The caller removes the file once it is registered.
+func (m ECSModel) getTaskDefEditCommand() (*exec.Cmd, string, error) {
+	return editTempFile("aws-tui-taskdef-*.json", m.selectedTaskDefJSON)
+}
+
+// registerEditedTaskDef registers the edited file as a new revision of the
+// task definition
+func (m ECSModel) registerEditedTaskDef(path string) tea.Msg {
+	defer os.Remove(path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ECSErrorMsg(err)
+	}
+	if bytes.Equal(bytes.TrimSpace(data), bytes.TrimSpace([]byte(m.selectedTaskDefJSON))) {
+		return ecsTaskDefUnchangedMsg{}
+	}
+
+	// Catch syntax errors before the round trip, with the position of the error
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		if syntaxErr, ok := err.(*json.SyntaxError); ok {
+			return ECSErrorMsg(fmt.Errorf("edited task definition is not valid JSON at byte %d: %w", syntaxErr.Offset, err))
+		}
+		return ECSErrorMsg(fmt.Errorf("edited task definition is not valid JSON: %w", err))
+	}
+
+	client, err := aws.NewECSClient(context.Background(), m.profile)
+	if err != nil {
+		return ECSErrorMsg(err)
+	}
+	arn, err := client.RegisterTaskDefinitionJSON(context.Background(), data)
+	if err != nil {
+		return ECSErrorMsg(err)
+	}
+
+	// The new revision is missing from the cached listings
+	m.cache.Delete(m.cacheKeys.ECSResources("all-task-defs"))
+	m.cache.Delete(m.cacheKeys.ECSResources("task-def-families"))
+	return ECSTaskDefRegisteredMsg(arn)
+}
@@ -32,6 +32,10 @@ const (
 	ECSStateSubMenu
 	ECSStateTaskActions
 	ECSStateServiceActions
+	ECSStateTaskDetail
+	ECSStateDeployment
+	ECSStateRunTask
+	ECSStateContainerInstances
 )
 
 type ecsItem struct {
@@ -43,9 +47,12 @@ type ecsItem struct {
 	values      []string
 }
 
-func (i ecsItem) Title() string       { return i.title }
-func (i ecsItem) Description() string { return i.description }
-func (i ecsItem) FilterValue() string { return i.title + " " + i.description + " " + i.id }
+func (i ecsItem) Title() string               { return i.title }
+func (i ecsItem) Description() string         { return i.description }
+func (i ecsItem) FilterValue() string         { return i.title + " " + i.description + " " + i.id }
+func (i ecsItem) rowID() string               { return i.id }
+func (i ecsItem) rowValues() []string         { return i.values }
+func (i ecsItem) relatedLinks() []relatedLink { return linksTo("task-definition", i.taskDef) }
 
 type ECSModel struct {
 	client                 *aws.ECSClient
@@ -53,13 +60,16 @@ type ECSModel struct {
 	actionList             list.Model
 	serviceActionList      list.Model
 	viewport               viewport.Model
+	search                 viewportSearch
 	delegate               ecsItemDelegate
 	styles                 Styles
+	table                  *tableLayout
 	state                  ECSState
 	width                  int
 	height                 int
 	profile                string
 	err                    error
+	loaded                 bool
 	cache                  *cache.Cache
 	cacheKeys              *cache.KeyBuilder
 	selectedCluster        string
@@ -69,12 +79,31 @@ type ECSModel struct {
 	selectedTaskDefFamily  string
 	selectedTaskDefJSON    string
 	allTaskDefs            []aws.TaskDefinitionInfo
+	clusterSummary         *aws.ECSClusterSummary
+	clusterSummaryErr      error
+	servicesRunning        int32
+	servicesDesired        int32
+
+	// taskDetail is the task whose containers are listed in ECSStateTaskDetail
+	taskDetail *aws.ECSTaskDetail
+	// showStopped lists stopped tasks instead of running ones
+	showStopped bool
+	// deployment is the rollout shown in ECSStateDeployment; deploymentWatch
+	// tells the polls of the current watch from earlier ones
+	deployment      *aws.ECSDeploymentStatus
+	deploymentWatch int
+	// runTask is the form shown in ECSStateRunTask, opened from
+	// runTaskReturnState
+	runTask            runTaskForm
+	runTaskReturnState ECSState
 }
 
 type ecsItemDelegate struct {
 	list.DefaultDelegate
-	styles Styles
-	state  ECSState
+	styles  Styles
+	table   *tableLayout
+	state   ECSState
+	stopped bool
 }
 
 var ecsMenuColumns = []Column{
@@ -105,6 +134,22 @@ var ecsTaskColumns = []Column{
 	{Title: "Created", Width: 0.25},
 }
 
+var ecsStoppedTaskColumns = []Column{
+	{Title: "Task ID", Width: 0.2},
+	{Title: "Status", Width: 0.1},
+	{Title: "Stopped", Width: 0.15},
+	{Title: "Stopped Reason", Width: 0.55},
+}
+
+var ecsContainerColumns = []Column{
+	{Title: "Container", Width: 0.2},
+	{Title: "Image", Width: 0.3},
+	{Title: "Status", Width: 0.1},
+	{Title: "Health", Width: 0.1},
+	{Title: "Exit Code", Width: 0.1},
+	{Title: "Reason", Width: 0.2},
+}
+
 var ecsEventColumns = []Column{
 	{Title: "Time", Width: 0.15},
 	{Title: "Message", Width: 0.85},
@@ -142,6 +187,11 @@ func (d ecsItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 		columns = ecsServiceColumns
 	case ECSStateTasks:
 		columns = ecsTaskColumns
+		if d.stopped {
+			columns = ecsStoppedTaskColumns
+		}
+	case ECSStateTaskDetail:
+		columns = ecsContainerColumns
 	case ECSStateEvents:
 		columns = ecsEventColumns
 	case ECSStateTaskDefFamilies:
@@ -150,22 +200,28 @@ func (d ecsItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 		columns = ecsTaskDefRevisionColumns
 	case ECSStateSubMenu:
 		columns = ecsMenuColumns
+	case ECSStateContainerInstances:
+		columns = ecsContainerInstanceColumns
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, columns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, columns, d.table)
 	isSelected := index == m.Index()
 
-	RenderTableRow(w, m, d.styles, colStyles, i.values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, i.values, isSelected)
 }
 
 func (d ecsItemDelegate) Height() int {
 	return 1
 }
 
-func NewECSModel(profile string, styles Styles, appCache *cache.Cache) ECSModel {
+func (d ecsItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewECSModel(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) ECSModel {
+	table := &tableLayout{display: display}
 	d := ecsItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 		state:           ECSStateMenu,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
@@ -181,7 +237,9 @@ func NewECSModel(profile string, styles Styles, appCache *cache.Cache) ECSModel
 		list:      l,
 		delegate:  d,
 		viewport:  viewport.New(0, 0),
+		search:    newViewportSearch(),
 		styles:    styles,
+		table:     table,
 		state:     ECSStateMenu,
 		profile:   profile,
 		cache:     appCache,
@@ -196,7 +254,7 @@ func (m *ECSModel) loadMenu() {
 		ecsItem{title: "Clusters", id: "clusters", values: []string{"Clusters"}},
 		ecsItem{title: "Task Definitions", id: "task-def-families", values: []string{"Task Definitions"}},
 	}
-	m.list.SetItems(items)
+	m.table.setItems(&m.list, items)
 	m.list.ResetSelected()
 	m.state = ECSStateMenu
 }
@@ -206,8 +264,9 @@ func (m *ECSModel) loadServiceSubMenu(serviceName string) {
 		ecsItem{title: "Tasks", id: "tasks", values: []string{"Tasks"}},
 		ecsItem{title: "Logs", id: "logs", values: []string{"Logs"}},
 		ecsItem{title: "Events", id: "events", values: []string{"Events"}},
+		ecsItem{title: "Deployment", id: "deployment", values: []string{"Deployment"}},
 	}
-	m.list.SetItems(items)
+	m.table.setItems(&m.list, items)
 	m.list.ResetSelected()
 	// Use menu columns for this submenu
 }
@@ -243,7 +302,13 @@ func (m *ECSModel) loadServiceActionMenu() {
 
 type ECSClustersMsg []aws.ECSClusterInfo
 type ECSServicesMsg []aws.ServiceInfo
+type ECSClusterSummaryMsg *aws.ECSClusterSummary
+
+// ECSClusterSummaryErrorMsg reports a summary that could not be fetched; it
+// is shown in place of the summary, keeping the services listed below it
+type ECSClusterSummaryErrorMsg struct{ Err error }
 type ECSTasksMsg []aws.ECSTaskInfo
+type ECSTaskDetailMsg *aws.ECSTaskDetail
 type ECSEventsMsg []aws.ECSEventInfo
 type ECSTaskDefsMsg []aws.TaskDefinitionInfo
 type ECSTaskDefFamiliesMsg []string
@@ -291,6 +356,20 @@ func (m ECSModel) fetchServices(cluster string) tea.Cmd {
 	}
 }
 
+func (m ECSModel) fetchClusterSummary(cluster string) tea.Cmd {
+	return func() tea.Msg {
+		client, err := aws.NewECSClient(context.Background(), m.profile)
+		if err != nil {
+			return ECSErrorMsg(err)
+		}
+		summary, err := client.GetClusterSummary(context.Background(), cluster)
+		if err != nil {
+			return ECSClusterSummaryErrorMsg{Err: err}
+		}
+		return ECSClusterSummaryMsg(summary)
+	}
+}
+
 func (m ECSModel) fetchTasks(cluster, service string) tea.Cmd {
 	return func() tea.Msg {
 		client, err := aws.NewECSClient(context.Background(), m.profile)
@@ -301,7 +380,11 @@ func (m ECSModel) fetchTasks(cluster, service string) tea.Cmd {
 		if service != "" {
 			svc = &service
 		}
-		tasks, err := client.ListTasks(context.Background(), cluster, svc)
+		status := "RUNNING"
+		if m.showStopped {
+			status = "STOPPED"
+		}
+		tasks, err := client.ListTasks(context.Background(), cluster, svc, status)
 		if err != nil {
 			return ECSErrorMsg(err)
 		}
@@ -309,6 +392,20 @@ func (m ECSModel) fetchTasks(cluster, service string) tea.Cmd {
 	}
 }
 
+func (m ECSModel) fetchTaskDetail(taskArn string) tea.Cmd {
+	return func() tea.Msg {
+		client, err := aws.NewECSClient(context.Background(), m.profile)
+		if err != nil {
+			return ECSErrorMsg(err)
+		}
+		detail, err := client.DescribeTask(context.Background(), m.selectedCluster, taskArn)
+		if err != nil {
+			return ECSErrorMsg(err)
+		}
+		return ECSTaskDetailMsg(detail)
+	}
+}
+
 func (m ECSModel) fetchEvents(cluster, service string) tea.Cmd {
 	return func() tea.Msg {
 		client, err := aws.NewECSClient(context.Background(), m.profile)
@@ -408,7 +505,7 @@ func (m ECSModel) fetchLogGroup(taskDefArn string) tea.Cmd {
 }
 
 func (m ECSModel) restartTask() tea.Cmd {
-	return func() tea.Msg {
+	return armed(func() tea.Msg {
 		client, err := aws.NewECSClient(context.Background(), m.profile)
 		if err != nil {
 			return ECSErrorMsg(err)
@@ -418,11 +515,11 @@ func (m ECSModel) restartTask() tea.Cmd {
 			return ECSErrorMsg(err)
 		}
 		return ECSSuccessMsg("Task stopped successfully")
-	}
+	})
 }
 
 func (m ECSModel) stopServiceAction() tea.Cmd {
-	return func() tea.Msg {
+	return armed(func() tea.Msg {
 		client, err := aws.NewECSClient(context.Background(), m.profile)
 		if err != nil {
 			return ECSErrorMsg(err)
@@ -432,11 +529,11 @@ func (m ECSModel) stopServiceAction() tea.Cmd {
 			return ECSErrorMsg(err)
 		}
 		return ECSSuccessMsg("Service stopped successfully")
-	}
+	})
 }
 
 func (m ECSModel) restartServiceAction() tea.Cmd {
-	return func() tea.Msg {
+	return armed(func() tea.Msg {
 		client, err := aws.NewECSClient(context.Background(), m.profile)
 		if err != nil {
 			return ECSErrorMsg(err)
@@ -446,25 +543,38 @@ func (m ECSModel) restartServiceAction() tea.Cmd {
 			return ECSErrorMsg(err)
 		}
 		return ECSSuccessMsg("Service restart initiated successfully")
-	}
+	})
 }
 
 func (m ECSModel) Update(msg tea.Msg) (ECSModel, tea.Cmd) {
+	m, cmd := m.update(msg)
+	m.sizeList()
+	return m, cmd
+}
+
+// sizeList fits the table to the view, less the summary line shown above the
+// services of a cluster and the containers of a task
+func (m *ECSModel) sizeList() {
+	w, h := GetInnerListSize(m.width, m.height)
+	if m.state == ECSStateServices || m.state == ECSStateTaskDetail {
+		h--
+	}
+	m.list.SetSize(w, h)
+}
+
+func (m ECSModel) update(msg tea.Msg) (ECSModel, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
 	case ECSClustersMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
-			status := v.Status
-			if status == "ACTIVE" {
-				status = lipgloss.NewStyle().Foreground(m.styles.Success.GetForeground()).Render(status)
-			}
+			status := renderStatus(m.styles, v.Status)
 			items[i] = ecsItem{
 				title:       v.Name,
 				description: v.ARN,
@@ -479,17 +589,18 @@ func (m ECSModel) Update(msg tea.Msg) (ECSModel, tea.Cmd) {
 				},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = ECSStateClusters
 
 	case ECSServicesMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
+		m.servicesRunning, m.servicesDesired = 0, 0
 		for i, v := range msg {
-			status := v.Status
-			if status == "ACTIVE" {
-				status = lipgloss.NewStyle().Foreground(m.styles.Success.GetForeground()).Render(status)
-			}
+			m.servicesRunning += v.RunningTasks
+			m.servicesDesired += v.DesiredTasks
+			status := renderStatus(m.styles, v.Status)
 			items[i] = ecsItem{
 				title:       v.Name,
 				description: v.ARN,
@@ -504,19 +615,25 @@ func (m ECSModel) Update(msg tea.Msg) (ECSModel, tea.Cmd) {
 				},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = ECSStateServices
 
+	case ECSClusterSummaryMsg:
+		m.clusterSummary = msg
+		m.clusterSummaryErr = nil
+
+	case ECSClusterSummaryErrorMsg:
+		m.clusterSummaryErr = msg.Err
+
+	case ECSContainerInstancesMsg:
+		m.setContainerInstances(msg)
+
 	case ECSTasksMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
-			status := v.LastStatus
-			if status == "RUNNING" {
-				status = lipgloss.NewStyle().Foreground(m.styles.Success.GetForeground()).Render(status)
-			} else if status == "STOPPED" {
-				status = lipgloss.NewStyle().Foreground(m.styles.Error.GetForeground()).Render(status)
-			}
+			status := renderStatus(m.styles, v.LastStatus)
 			items[i] = ecsItem{
 				title:       v.ID,
 				description: v.ARN,
@@ -529,15 +646,67 @@ func (m ECSModel) Update(msg tea.Msg) (ECSModel, tea.Cmd) {
 					v.DesiredStatus,
 					v.CPU,
 					v.Memory,
-					v.CreatedAt,
+					humanizeTime(v.CreatedAt),
 				},
 			}
+			if m.showStopped {
+				items[i] = ecsItem{
+					title:       v.ID,
+					description: v.StoppedReason,
+					id:          v.ID,
+					arn:         v.ARN,
+					taskDef:     v.TaskDefinition,
+					values: []string{
+						v.ID,
+						status,
+						humanizeTime(v.StoppedAt),
+						v.StoppedReason,
+					},
+				}
+			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = ECSStateTasks
 
+	case ECSTaskDetailMsg:
+		m.loaded = true
+		m.taskDetail = msg
+		items := make([]list.Item, len(msg.Containers))
+		for i, v := range msg.Containers {
+			exitCode := "-"
+			if v.ExitCode != nil {
+				exitCode = fmt.Sprintf("%d", *v.ExitCode)
+				if *v.ExitCode == 0 {
+					exitCode = m.styles.Success.Render(exitCode)
+				} else {
+					exitCode = m.styles.Error.Render(exitCode)
+				}
+			}
+			health := v.HealthStatus
+			if health == "" {
+				health = "-"
+			}
+			items[i] = ecsItem{
+				title:       v.Name,
+				description: v.Image,
+				id:          v.Name,
+				values: []string{
+					v.Name,
+					v.Image,
+					renderStatus(m.styles, v.LastStatus),
+					renderStatus(m.styles, health),
+					exitCode,
+					v.Reason,
+				},
+			}
+		}
+		m.table.setItems(&m.list, items)
+		m.list.ResetSelected()
+		m.state = ECSStateTaskDetail
+
 	case ECSEventsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			items[i] = ecsItem{
@@ -550,12 +719,15 @@ func (m ECSModel) Update(msg tea.Msg) (ECSModel, tea.Cmd) {
 				},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = ECSStateEvents
 
 	case ECSTaskDefsMsg:
-		m.allTaskDefs = msg
+		m.loaded = true
+		// Copy before sorting: msg is the slice held by the cache and may be
+		// read concurrently by other fetches or the persistence flush
+		m.allTaskDefs = append([]aws.TaskDefinitionInfo(nil), msg...)
 		// Sort revisions globally by revision number descending
 		sort.Slice(m.allTaskDefs, func(i, j int) bool {
 			return m.allTaskDefs[i].Revision > m.allTaskDefs[j].Revision
@@ -579,7 +751,7 @@ func (m ECSModel) Update(msg tea.Msg) (ECSModel, tea.Cmd) {
 					values: []string{f},
 				}
 			}
-			m.list.SetItems(items)
+			m.table.setItems(&m.list, items)
 			m.list.ResetSelected()
 			m.state = ECSStateTaskDefFamilies
 		} else if m.state == ECSStateTaskDefRevisions {
@@ -600,13 +772,14 @@ func (m ECSModel) Update(msg tea.Msg) (ECSModel, tea.Cmd) {
 					})
 				}
 			}
-			m.list.SetItems(items)
+			m.table.setItems(&m.list, items)
 			// Don't reset selected if we are refreshing?
 			// But here we usually want to show the list
 			m.list.ResetSelected()
 		}
 
 	case ECSTaskDefFamiliesMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			items[i] = ecsItem{
@@ -615,26 +788,65 @@ func (m ECSModel) Update(msg tea.Msg) (ECSModel, tea.Cmd) {
 				values: []string{v},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = ECSStateTaskDefFamilies
 
 	case ECSTaskDefJSONMsg:
 		m.selectedTaskDefJSON = string(msg)
 		m.state = ECSStateTaskDefJSON
-		m.viewport.SetContent(m.highlightTaskDef(string(msg)))
+		m.search.reset()
+		m.search.setContent(&m.viewport, m.styles, m.highlightTaskDef(string(msg)))
 		m.viewport.YOffset = 0
 
+	case ECSTaskDefRegisteredMsg:
+		// Show the new revision in place of the edited one
+		return m, m.fetchTaskDefJSON(string(msg))
+
 	case ECSSuccessMsg:
 		m.err = nil
 		if m.state == ECSStateServiceActions {
-			m.state = ECSStateServices
-			return m, m.fetchServices(m.selectedCluster)
+			// Follow the rollout the restart or scale-down started
+			cmd := m.watchDeployment()
+			return m, cmd
 		}
 		m.state = ECSStateTasks
 		return m, m.fetchTasks(m.selectedCluster, m.selectedService)
 
+	case ECSDeploymentMsg:
+		if m.state == ECSStateDeployment && msg.watch == m.deploymentWatch {
+			m.deployment = msg.status
+			return m, m.pollDeployment()
+		}
+		return m, nil
+
+	case ecsDeploymentTickMsg:
+		if m.state == ECSStateDeployment && int(msg) == m.deploymentWatch {
+			return m, m.fetchDeployment()
+		}
+		return m, nil
+
+	case ecsRunTaskOptionsMsg:
+		m.setRunTaskOptions(msg)
+		return m, nil
+
+	case ECSTaskLaunchedMsg:
+		if m.state == ECSStateRunTask {
+			m.runTask.running = false
+			task := aws.LaunchedTask(msg)
+			m.runTask.launched = &task
+			m.runTask.step = runTaskLaunched
+		}
+		return m, nil
+
 	case ECSErrorMsg:
+		// Errors of the run task form are shown in it, so the choices are kept
+		if m.state == ECSStateRunTask {
+			m.runTask.loading = false
+			m.runTask.running = false
+			m.runTask.err = msg
+			return m, nil
+		}
 		m.err = msg
 
 	case tea.KeyMsg:
@@ -643,11 +855,21 @@ func (m ECSModel) Update(msg tea.Msg) (ECSModel, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.state == ECSStateRunTask {
+			return m.updateRunTask(msg)
+		}
+
 		if m.state == ECSStateTaskDefJSON {
+			if handled, cmd := m.search.update(&m.viewport, m.styles, msg); handled {
+				return m, cmd
+			}
 			switch msg.String() {
 			case "esc", "backspace", "q":
 				m.state = ECSStateTaskDefRevisions
 			default:
+				if scrollViewport(&m.viewport, msg.String()) {
+					return m, nil
+				}
 				m.viewport, cmd = m.viewport.Update(msg)
 				return m, cmd
 			}
@@ -684,6 +906,35 @@ func (m ECSModel) Update(msg tea.Msg) (ECSModel, tea.Cmd) {
 		}
 
 		switch msg.String() {
+		case "n":
+			if m.list.FilterState() == list.Filtering {
+				break
+			}
+			item, _ := m.list.SelectedItem().(ecsItem)
+			switch m.state {
+			case ECSStateClusters:
+				m.runTaskReturnState = m.state
+				cmd := m.openRunTask(item.id, "")
+				return m, cmd
+			case ECSStateTaskDefFamilies:
+				m.runTaskReturnState = m.state
+				cmd := m.openRunTask("", item.id)
+				return m, cmd
+			case ECSStateTaskDefRevisions:
+				m.runTaskReturnState = m.state
+				cmd := m.openRunTask("", item.arn)
+				return m, cmd
+			}
+		case "i":
+			// The EC2 capacity tasks of the cluster are placed on
+			if m.state == ECSStateServices && m.list.FilterState() != list.Filtering {
+				return m, m.fetchContainerInstances(m.selectedCluster)
+			}
+		case "f":
+			if m.state == ECSStateTasks && m.list.FilterState() != list.Filtering {
+				m.showStopped = !m.showStopped
+				return m, m.fetchTasks(m.selectedCluster, m.selectedService)
+			}
 		case "o":
 			if m.state == ECSStateTasks {
 				if item, ok := m.list.SelectedItem().(ecsItem); ok {
@@ -707,11 +958,18 @@ func (m ECSModel) Update(msg tea.Msg) (ECSModel, tea.Cmd) {
 				m.cache.Delete(m.cacheKeys.ECSResources("clusters"))
 				return m, m.fetchClusters()
 			case ECSStateServices:
-				return m, m.fetchServices(m.selectedCluster)
+				return m, tea.Batch(m.fetchServices(m.selectedCluster), m.fetchClusterSummary(m.selectedCluster))
+			case ECSStateContainerInstances:
+				return m, m.fetchContainerInstances(m.selectedCluster)
 			case ECSStateTasks:
 				return m, m.fetchTasks(m.selectedCluster, m.selectedService)
+			case ECSStateTaskDetail:
+				return m, m.fetchTaskDetail(m.selectedTask)
 			case ECSStateEvents:
 				return m, m.fetchEvents(m.selectedCluster, m.selectedService)
+			case ECSStateDeployment:
+				m.deploymentWatch++
+				return m, m.fetchDeployment()
 			case ECSStateTaskDefFamilies:
 				m.cache.Delete(m.cacheKeys.ECSResources("all-task-defs"))
 				return m, m.fetchAllTaskDefs()
@@ -729,10 +987,13 @@ func (m ECSModel) Update(msg tea.Msg) (ECSModel, tea.Cmd) {
 					}
 				case ECSStateClusters:
 					m.selectedCluster = item.id
-					return m, m.fetchServices(m.selectedCluster)
+					m.clusterSummary = nil
+					m.clusterSummaryErr = nil
+					return m, tea.Batch(m.fetchServices(m.selectedCluster), m.fetchClusterSummary(m.selectedCluster))
 				case ECSStateServices:
 					m.selectedService = item.id
 					m.selectedServiceTaskDef = item.taskDef
+					m.showStopped = false
 					m.loadServiceSubMenu(item.id)
 					m.state = ECSStateSubMenu
 					return m, nil
@@ -744,7 +1005,10 @@ func (m ECSModel) Update(msg tea.Msg) (ECSModel, tea.Cmd) {
 				case ECSStateTaskDefRevisions:
 					return m, m.fetchTaskDefJSON(item.arn)
 				case ECSStateTasks:
-					// Options handled by 'o'
+					m.selectedTask = item.arn
+					return m, m.fetchTaskDetail(item.arn)
+				case ECSStateTaskDetail:
+					// Just list
 				case ECSStateEvents:
 					// Just list
 				case ECSStateTaskDefJSON:
@@ -759,6 +1023,9 @@ func (m ECSModel) Update(msg tea.Msg) (ECSModel, tea.Cmd) {
 							return m, m.fetchLogGroup(m.selectedServiceTaskDef)
 						case "events":
 							return m, m.fetchEvents(m.selectedCluster, m.selectedService)
+						case "deployment":
+							cmd := m.watchDeployment()
+							return m, cmd
 						}
 					}
 				}
@@ -769,9 +1036,13 @@ func (m ECSModel) Update(msg tea.Msg) (ECSModel, tea.Cmd) {
 				m.loadMenu()
 			case ECSStateServices:
 				return m, m.fetchClusters()
-			case ECSStateTasks, ECSStateEvents:
+			case ECSStateContainerInstances:
+				return m, m.fetchServices(m.selectedCluster)
+			case ECSStateTasks, ECSStateEvents, ECSStateDeployment:
 				m.loadServiceSubMenu(m.selectedService)
 				m.state = ECSStateSubMenu
+			case ECSStateTaskDetail:
+				return m, m.fetchTasks(m.selectedCluster, m.selectedService)
 			case ECSStateTaskDefRevisions:
 				m.state = ECSStateTaskDefFamilies
 				return m, func() tea.Msg { return ECSTaskDefsMsg(m.allTaskDefs) }
@@ -796,6 +1067,7 @@ func (m ECSModel) Update(msg tea.Msg) (ECSModel, tea.Cmd) {
 	m.list, cmd = m.list.Update(msg)
 	// Update delegate state
 	m.delegate.state = m.state
+	m.delegate.stopped = m.showStopped
 	m.list.SetDelegate(m.delegate)
 	return m, cmd
 }
@@ -806,12 +1078,15 @@ func (m ECSModel) highlightTaskDef(content string) string {
 		lexer = lexers.Fallback
 	}
 
-	style := styles.Get("monokai")
+	style := styles.Get(m.styles.ChromaStyle)
 	if style == nil {
 		style = styles.Fallback
 	}
 
 	formatter := formatters.Get("terminal256")
+	if m.styles.NoColor {
+		formatter = formatters.NoOp
+	}
 	if formatter == nil {
 		formatter = formatters.Fallback
 	}
@@ -841,15 +1116,54 @@ func (m ECSModel) highlightTaskDef(content string) string {
 	return strings.Join(numberedLines, "\n")
 }
 
+// ecsResourceNames names the resources listed in each table state, for the empty-state message
+var ecsResourceNames = map[ECSState]string{
+	ECSStateClusters:           "ECS clusters",
+	ECSStateServices:           "ECS services",
+	ECSStateTasks:              "ECS tasks",
+	ECSStateTaskDetail:         "containers",
+	ECSStateEvents:             "service events",
+	ECSStateTaskDefFamilies:    "task definition families",
+	ECSStateTaskDefRevisions:   "task definition revisions",
+	ECSStateContainerInstances: "container instances",
+}
+
+// columns are the columns of the table on screen
+func (m ECSModel) columns() []Column {
+	switch m.state {
+	case ECSStateClusters:
+		return ecsClusterColumns
+	case ECSStateServices:
+		return ecsServiceColumns
+	case ECSStateTasks:
+		if m.showStopped {
+			return ecsStoppedTaskColumns
+		}
+		return ecsTaskColumns
+	case ECSStateTaskDetail:
+		return ecsContainerColumns
+	case ECSStateEvents:
+		return ecsEventColumns
+	case ECSStateTaskDefFamilies:
+		return ecsTaskDefFamilyColumns
+	case ECSStateTaskDefRevisions:
+		return ecsTaskDefRevisionColumns
+	case ECSStateContainerInstances:
+		return ecsContainerInstanceColumns
+	}
+	// The menu, a submenu or an unknown state
+	return ecsMenuColumns
+}
+
 func (m ECSModel) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
 	}
 
 	if m.state == ECSStateTaskDefJSON {
 		return lipgloss.NewStyle().
 			Padding(1, 2).
-			Render(m.viewport.View())
+			Render(m.viewport.View() + "\n" + renderScrollIndicator(m.styles, m.viewport, m.search.view(m.styles)))
 	}
 
 	if m.state == ECSStateTaskActions {
@@ -868,34 +1182,88 @@ func (m ECSModel) View() string {
 		return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, popup)
 	}
 
-	var columns []Column
-	switch m.state {
-	case ECSStateMenu:
-		columns = ecsMenuColumns
-	case ECSStateClusters:
-		columns = ecsClusterColumns
-	case ECSStateServices:
-		columns = ecsServiceColumns
-	case ECSStateTasks:
-		columns = ecsTaskColumns
-	case ECSStateEvents:
-		columns = ecsEventColumns
-	case ECSStateTaskDefFamilies:
-		columns = ecsTaskDefFamilyColumns
-	case ECSStateTaskDefRevisions:
-		columns = ecsTaskDefRevisionColumns
-	default:
-		// Submenu or unknown state
-		columns = ecsMenuColumns
+	if m.state == ECSStateDeployment {
+		return m.renderDeployment()
+	}
+
+	if m.state == ECSStateRunTask {
+		return m.renderRunTask()
+	}
+
+	if resource, ok := ecsResourceNames[m.state]; ok && m.loaded && len(m.list.Items()) == 0 {
+		if m.state == ECSStateTasks && m.showStopped {
+			resource = "stopped ECS tasks"
+		}
+		return RenderEmptyState(m.styles, m.list, resource, m.profile, m.table)
+	}
+
+	_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
+	if m.state == ECSStateServices {
+		return m.renderClusterSummary() + "\n" + header + "\n" + m.list.View()
+	}
+	if m.state == ECSStateTaskDetail {
+		return m.renderTaskSummary() + "\n" + header + "\n" + m.list.View()
 	}
-	_, header := RenderTableHelpers(m.list, m.styles, columns)
 	return header + "\n" + m.list.View()
 }
 
+func (m ECSModel) renderClusterSummary() string {
+	if m.clusterSummaryErr != nil {
+		return m.styles.Error.Render("  ✘ Cluster summary unavailable: " + m.clusterSummaryErr.Error())
+	}
+	if m.clusterSummary == nil {
+		return m.styles.StatusMuted.Render("  Loading cluster summary...")
+	}
+
+	tasks := fmt.Sprintf("%d/%d", m.servicesRunning, m.servicesDesired)
+	if m.servicesRunning >= m.servicesDesired {
+		tasks = m.styles.Success.Render(tasks)
+	} else {
+		tasks = m.styles.Warning.Render(tasks)
+	}
+
+	providers := "none"
+	if len(m.clusterSummary.CapacityProviders) > 0 {
+		providers = strings.Join(m.clusterSummary.CapacityProviders, ", ")
+	}
+
+	sep := m.styles.StatusMuted.Render(" • ")
+	return "  " + strings.Join([]string{
+		m.styles.StatusMuted.Render("Running/Desired: ") + tasks,
+		m.styles.StatusMuted.Render("Pending: ") + fmt.Sprintf("%d", m.clusterSummary.PendingTasks),
+		m.styles.StatusMuted.Render("Container Instances: ") + fmt.Sprintf("%d", m.clusterSummary.ContainerInstances),
+		m.styles.StatusMuted.Render("Capacity Providers: ") + providers,
+	}, sep)
+}
+
+// renderTaskSummary shows the task status above its containers, with the
+// stop reason once the task has stopped
+func (m ECSModel) renderTaskSummary() string {
+	if m.taskDetail == nil {
+		return ""
+	}
+
+	sep := m.styles.StatusMuted.Render(" • ")
+	parts := []string{
+		m.styles.StatusMuted.Render("Status: ") + renderStatus(m.styles, m.taskDetail.LastStatus),
+		m.styles.StatusMuted.Render("Desired: ") + m.taskDetail.DesiredStatus,
+	}
+	if m.taskDetail.StoppedReason != "" {
+		reason := m.taskDetail.StoppedReason
+		if m.taskDetail.StopCode != "" {
+			reason = m.taskDetail.StopCode + ": " + reason
+		}
+		parts = append(parts,
+			m.styles.StatusMuted.Render("Stopped: ")+humanizeTime(m.taskDetail.StoppedAt),
+			m.styles.StatusMuted.Render("Reason: ")+m.styles.Error.Render(reason))
+	}
+	return "  " + strings.Join(parts, sep)
+}
+
 func (m *ECSModel) SetSize(width, height int) {
 	m.width = width
 	m.height = height
-	m.list.SetSize(GetInnerListSize(width, height))
+	m.sizeList()
 	m.viewport.Width = width - InnerContentWidthOffset
-	m.viewport.Height = height - AppInternalFooterHeight - 4
+	m.viewport.Height = height - AppInternalFooterHeight - 5
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
@@ -25,22 +26,39 @@ type efsItem struct {
 	description  string
 	fileSystemId string
 	isMount      bool
+	target       aws.MountTargetInfo
 }
 
 func (i efsItem) Title() string       { return i.title }
 func (i efsItem) Description() string { return i.description }
-func (i efsItem) FilterValue() string { return i.title }
+func (i efsItem) FilterValue() string { return i.title + " " + i.description }
+
+func (i efsItem) rowID() string {
+	if i.isMount {
+		return i.target.MountTargetId
+	}
+	return i.fileSystemId
+}
+
+func (i efsItem) source() any {
+	if !i.isMount {
+		return nil
+	}
+	return i.target
+}
 
 type EFSModel struct {
 	client            *aws.EFSClient
 	list              list.Model
 	styles            Styles
+	table             *tableLayout
 	state             EFSState
 	currentFileSystem string
 	width             int
 	height            int
 	profile           string
 	err               error
+	loaded            bool
 	cache             *cache.Cache
 	cacheKeys         *cache.KeyBuilder
 }
@@ -48,22 +66,27 @@ type EFSModel struct {
 type efsItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 	state  EFSState
 }
 
 var efsFileSystemColumns = []Column{
-	{Title: "File System ID", Width: 0.25},
-	{Title: "Name", Width: 0.25},
-	{Title: "State", Width: 0.15},
-	{Title: "Size", Width: 0.15},
-	{Title: "Mount Targets", Width: 0.2},
+	{Title: "File System ID", Width: 0.18},
+	{Title: "Name", Width: 0.18},
+	{Title: "State", Width: 0.1},
+	{Title: "Performance", Width: 0.14},
+	{Title: "Throughput", Width: 0.12},
+	{Title: "Size", Width: 0.12},
+	{Title: "Mount Targets", Width: 0.16},
 }
 
 var efsMountTargetColumns = []Column{
-	{Title: "Mount Target ID", Width: 0.25},
-	{Title: "Subnet ID", Width: 0.25},
-	{Title: "IP Address", Width: 0.25},
-	{Title: "State", Width: 0.25},
+	{Title: "Mount Target ID", Width: 0.18},
+	{Title: "Subnet ID", Width: 0.18},
+	{Title: "AZ", Width: 0.1},
+	{Title: "IP Address", Width: 0.12},
+	{Title: "Security Groups", Width: 0.3},
+	{Title: "State", Width: 0.12},
 }
 
 func (d efsItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
@@ -79,7 +102,7 @@ func (d efsItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 		columns = efsMountTargetColumns
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, columns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, columns, d.table)
 	isSelected := index == m.Index()
 
 	var values []string
@@ -87,52 +110,68 @@ func (d efsItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 		parts := strings.Split(i.description, " | ")
 		name := ""
 		state := ""
+		performance := ""
+		throughput := ""
 		size := ""
 		targets := ""
-		if len(parts) >= 4 {
+		if len(parts) >= 6 {
 			name = strings.TrimPrefix(parts[0], "Name: ")
 			state = strings.TrimPrefix(parts[1], "State: ")
-			size = strings.TrimPrefix(parts[2], "Size: ")
-			targets = strings.TrimPrefix(parts[3], "Targets: ")
+			performance = strings.TrimPrefix(parts[2], "Performance: ")
+			throughput = strings.TrimPrefix(parts[3], "Throughput: ")
+			size = strings.TrimPrefix(parts[4], "Size: ")
+			targets = strings.TrimPrefix(parts[5], "Targets: ")
 		}
 		values = []string{
 			"📁 " + i.title,
 			name,
-			state,
+			renderStatus(d.styles, state),
+			performance,
+			throughput,
 			size,
 			targets,
 		}
 	} else {
 		if i.title == ".." {
-			values = []string{"..", "", "", ""}
+			values = []string{"..", "", "", "", "", ""}
 		} else {
 			parts := strings.Split(i.description, " | ")
 			subnetId := ""
+			az := ""
 			ip := ""
+			groups := ""
 			state := ""
-			if len(parts) >= 3 {
+			if len(parts) >= 5 {
 				subnetId = strings.TrimPrefix(parts[0], "Subnet: ")
-				ip = strings.TrimPrefix(parts[1], "IP: ")
-				state = strings.TrimPrefix(parts[2], "State: ")
+				az = strings.TrimPrefix(parts[1], "AZ: ")
+				ip = strings.TrimPrefix(parts[2], "IP: ")
+				groups = strings.TrimPrefix(parts[3], "SGs: ")
+				state = strings.TrimPrefix(parts[4], "State: ")
 			}
 			values = []string{
 				"📍 " + i.title,
 				subnetId,
+				az,
 				ip,
-				state,
+				groups,
+				renderStatus(d.styles, state),
 			}
 		}
 	}
 
-	RenderTableRow(w, m, d.styles, colStyles, values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, values, isSelected)
 }
 
 func (d efsItemDelegate) Height() int { return 1 }
 
-func NewEFSModel(profile string, styles Styles, appCache *cache.Cache) EFSModel {
+func (d efsItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewEFSModel(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) EFSModel {
+	table := &tableLayout{display: display}
 	d := efsItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 		state:           EFSStateFileSystems,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
@@ -150,6 +189,7 @@ func NewEFSModel(profile string, styles Styles, appCache *cache.Cache) EFSModel
 	return EFSModel{
 		list:      l,
 		styles:    styles,
+		table:     table,
 		state:     EFSStateFileSystems,
 		profile:   profile,
 		cache:     appCache,
@@ -158,7 +198,13 @@ func NewEFSModel(profile string, styles Styles, appCache *cache.Cache) EFSModel
 }
 
 type EFSFileSystemsMsg []aws.FileSystemInfo
-type EFSMountTargetsMsg []aws.MountTargetInfo
+
+// EFSMountTargetsMsg carries the mount targets of a file system with the names
+// of their security groups, when they could be resolved
+type EFSMountTargetsMsg struct {
+	Targets    []aws.MountTargetInfo
+	GroupNames map[string]string
+}
 type EFSErrorMsg error
 
 func (m EFSModel) Init() tea.Cmd {
@@ -192,8 +238,8 @@ func (m EFSModel) fetchMountTargets() tea.Cmd {
 	return func() tea.Msg {
 		cacheKey := m.cacheKeys.EFSMountTargets(m.currentFileSystem)
 		if cached, ok := m.cache.Get(cacheKey); ok {
-			if targets, ok := cached.([]aws.MountTargetInfo); ok {
-				return EFSMountTargetsMsg(targets)
+			if msg, ok := cached.(EFSMountTargetsMsg); ok {
+				return msg
 			}
 		}
 
@@ -206,8 +252,21 @@ func (m EFSModel) fetchMountTargets() tea.Cmd {
 			return EFSErrorMsg(err)
 		}
 
-		m.cache.Set(cacheKey, targets, cache.TTLEFSResources)
-		return EFSMountTargetsMsg(targets)
+		msg := EFSMountTargetsMsg{Targets: targets}
+
+		// Names are a convenience; without EC2 access the IDs are still shown
+		var ids []string
+		for _, mt := range targets {
+			ids = append(ids, mt.SecurityGroups...)
+		}
+		if ec2Client, err := aws.NewEC2ResourcesClient(context.Background(), m.profile); err == nil {
+			if names, err := ec2Client.GetSecurityGroupNames(context.Background(), slices.Compact(slices.Sorted(slices.Values(ids)))); err == nil {
+				msg.GroupNames = names
+			}
+		}
+
+		m.cache.Set(cacheKey, msg, cache.TTLEFSResources)
+		return msg
 	}
 }
 
@@ -221,22 +280,24 @@ func (m EFSModel) Update(msg tea.Msg) (EFSModel, tea.Cmd) {
 		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
 	case EFSFileSystemsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, fs := range msg {
-			sizeMB := float64(fs.SizeInBytes) / 1024 / 1024
 			items[i] = efsItem{
-				title:        fs.FileSystemId,
-				description:  fmt.Sprintf("Name: %s | State: %s | Size: %.2f MB | Targets: %d", fs.Name, fs.LifeCycleState, sizeMB, fs.NumberOfMountTargets),
+				title: fs.FileSystemId,
+				description: fmt.Sprintf("Name: %s | State: %s | Performance: %s | Throughput: %s | Size: %s | Targets: %d",
+					fs.Name, fs.LifeCycleState, fs.PerformanceMode, fs.ThroughputMode, humanizeBytes(fs.SizeInBytes), fs.NumberOfMountTargets),
 				fileSystemId: fs.FileSystemId,
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.state = EFSStateFileSystems
 		m.list.Title = "EFS File Systems"
 
 		d := efsItemDelegate{
 			DefaultDelegate: list.NewDefaultDelegate(),
 			styles:          m.styles,
+			table:           m.table,
 			state:           EFSStateFileSystems,
 		}
 		d.Styles.SelectedTitle = m.styles.ListSelectedTitle
@@ -244,23 +305,34 @@ func (m EFSModel) Update(msg tea.Msg) (EFSModel, tea.Cmd) {
 		m.list.SetDelegate(d)
 
 	case EFSMountTargetsMsg:
+		m.loaded = true
 		items := make([]list.Item, 0)
 		items = append(items, efsItem{title: "..", description: "Back"})
 
-		for _, mt := range msg {
+		for _, mt := range msg.Targets {
+			groups := make([]string, len(mt.SecurityGroups))
+			for i, id := range mt.SecurityGroups {
+				groups[i] = id
+				if name := msg.GroupNames[id]; name != "" {
+					groups[i] = fmt.Sprintf("%s (%s)", id, name)
+				}
+			}
 			items = append(items, efsItem{
-				title:       mt.MountTargetId,
-				description: fmt.Sprintf("Subnet: %s | IP: %s | State: %s", mt.SubnetId, mt.IpAddress, mt.LifeCycleState),
-				isMount:     true,
+				title: mt.MountTargetId,
+				description: fmt.Sprintf("Subnet: %s | AZ: %s | IP: %s | SGs: %s | State: %s",
+					mt.SubnetId, mt.AvailabilityZoneName, mt.IpAddress, strings.Join(groups, ", "), mt.LifeCycleState),
+				isMount: true,
+				target:  mt,
 			})
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.state = EFSStateMountTargets
 		m.list.Title = fmt.Sprintf("EFS Mount Targets: %s", m.currentFileSystem)
 
 		d := efsItemDelegate{
 			DefaultDelegate: list.NewDefaultDelegate(),
 			styles:          m.styles,
+			table:           m.table,
 			state:           EFSStateMountTargets,
 		}
 		d.Styles.SelectedTitle = m.styles.ListSelectedTitle
@@ -309,22 +381,34 @@ func (m EFSModel) Update(msg tea.Msg) (EFSModel, tea.Cmd) {
 	return m, cmd
 }
 
+// efsResourceNames names the resources listed in each table state, for the empty-state message
+var efsResourceNames = map[EFSState]string{
+	EFSStateFileSystems:  "EFS file systems",
+	EFSStateMountTargets: "mount targets",
+}
+
+// columns are the columns of the table on screen
+func (m EFSModel) columns() []Column {
+	if m.state == EFSStateFileSystems {
+		return efsFileSystemColumns
+	}
+	return efsMountTargetColumns
+}
+
 func (m EFSModel) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
+	}
+
+	if resource, ok := efsResourceNames[m.state]; ok && m.loaded && len(m.list.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.list, resource, m.profile, m.table)
 	}
 
 	return m.renderHeader() + "\n" + m.list.View()
 }
 
 func (m EFSModel) renderHeader() string {
-	var columns []Column
-	if m.state == EFSStateFileSystems {
-		columns = efsFileSystemColumns
-	} else {
-		columns = efsMountTargetColumns
-	}
-	_, header := RenderTableHelpers(m.list, m.styles, columns)
+	_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
 	return header
 }
 
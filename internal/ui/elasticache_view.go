@@ -24,22 +24,32 @@ type elasticacheItem struct {
 	description string
 	id          string
 	category    string
+	engine      string
+	endpoint    string
+	port        int32
+	tls         bool
 	values      []string
+	info        any
 }
 
 func (i elasticacheItem) Title() string       { return i.title }
+func (i elasticacheItem) source() any         { return i.info }
 func (i elasticacheItem) Description() string { return i.description }
 func (i elasticacheItem) FilterValue() string { return i.title + " " + i.description + " " + i.id }
+func (i elasticacheItem) rowID() string       { return i.id }
+func (i elasticacheItem) rowValues() []string { return i.values }
 
 type ElastiCacheModel struct {
 	client    *aws.ElastiCacheClient
 	list      list.Model
 	styles    Styles
+	table     *tableLayout
 	state     ElastiCacheState
 	width     int
 	height    int
 	profile   string
 	err       error
+	loaded    bool
 	cache     *cache.Cache
 	cacheKeys *cache.KeyBuilder
 }
@@ -47,6 +57,7 @@ type ElastiCacheModel struct {
 type elasticacheItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 	state  ElastiCacheState
 }
 
@@ -75,7 +86,7 @@ func (d elasticacheItemDelegate) Render(w io.Writer, m list.Model, index int, li
 	}
 
 	if d.state == ElastiCacheStateMenu {
-		d.DefaultDelegate.Render(w, m, index, listItem)
+		d.table.renderMenuItem(d.DefaultDelegate, w, m, index, listItem)
 		return
 	}
 
@@ -87,23 +98,27 @@ func (d elasticacheItemDelegate) Render(w io.Writer, m list.Model, index int, li
 		columns = cacheClusterColumns
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, columns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, columns, d.table)
 	isSelected := index == m.Index()
 
-	RenderTableRow(w, m, d.styles, colStyles, i.values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, i.values, isSelected)
 }
 
 func (d elasticacheItemDelegate) Height() int {
 	if d.state == ElastiCacheStateMenu {
-		return 2
+		return d.table.menuItemHeight()
 	}
 	return 1
 }
 
-func NewElastiCacheModel(profile string, styles Styles, appCache *cache.Cache) ElastiCacheModel {
+func (d elasticacheItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewElastiCacheModel(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) ElastiCacheModel {
+	table := &tableLayout{display: display}
 	d := elasticacheItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 		state:           ElastiCacheStateMenu,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
@@ -118,6 +133,7 @@ func NewElastiCacheModel(profile string, styles Styles, appCache *cache.Cache) E
 	return ElastiCacheModel{
 		list:      l,
 		styles:    styles,
+		table:     table,
 		state:     ElastiCacheStateMenu,
 		profile:   profile,
 		cache:     appCache,
@@ -196,39 +212,51 @@ func (m ElastiCacheModel) Update(msg tea.Msg) (ElastiCacheModel, tea.Cmd) {
 		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
 	case ElastiCacheMenuMsg:
-		m.list.SetItems(msg)
+		m.table.setItems(&m.list, msg)
 		m.list.ResetSelected()
 		m.state = ElastiCacheStateMenu
 		m.updateDelegate()
 
 	case ReplicationGroupsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			items[i] = elasticacheItem{
+				info:        v,
 				title:       v.ID,
 				description: v.Description,
 				id:          v.ID,
 				category:    "replication-group",
-				values:      []string{v.ID, v.Status, v.Engine, v.CacheNodeType, fmt.Sprintf("%d", v.Nodes), v.Description},
+				engine:      v.Engine,
+				endpoint:    v.Endpoint,
+				port:        v.Port,
+				tls:         v.TLS,
+				values:      []string{v.ID, renderStatus(m.styles, v.Status), v.Engine, v.CacheNodeType, fmt.Sprintf("%d", v.Nodes), v.Description},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = ElastiCacheStateReplicationGroups
 		m.updateDelegate()
 
 	case CacheClustersMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			items[i] = elasticacheItem{
+				info:        v,
 				title:       v.ID,
 				description: v.Status,
 				id:          v.ID,
 				category:    "cache-cluster",
-				values:      []string{v.ID, v.Status, v.Engine, v.EngineVersion, v.CacheNodeType, v.AZ},
+				engine:      v.Engine,
+				endpoint:    v.Endpoint,
+				port:        v.Port,
+				tls:         v.TLS,
+				values:      []string{v.ID, renderStatus(m.styles, v.Status), v.Engine, v.EngineVersion, v.CacheNodeType, v.AZ},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = ElastiCacheStateCacheClusters
 		m.updateDelegate()
@@ -243,6 +271,10 @@ func (m ElastiCacheModel) Update(msg tea.Msg) (ElastiCacheModel, tea.Cmd) {
 		}
 
 		switch msg.String() {
+		case "y":
+			if item, ok := m.list.SelectedItem().(elasticacheItem); ok && item.endpoint != "" && !m.list.SettingFilter() {
+				return m, copyToClipboard(cacheConnectionString(item.engine, item.endpoint, item.port, item.tls), "connection string")
+			}
 		case "r":
 			switch m.state {
 			case ElastiCacheStateReplicationGroups:
@@ -278,6 +310,7 @@ func (m *ElastiCacheModel) updateDelegate() {
 	d := elasticacheItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          m.styles,
+		table:           m.table,
 		state:           m.state,
 	}
 	d.Styles.SelectedTitle = m.styles.ListSelectedTitle
@@ -285,20 +318,34 @@ func (m *ElastiCacheModel) updateDelegate() {
 	m.list.SetDelegate(d)
 }
 
+// elastiCacheResourceNames names the resources listed in each table state, for the empty-state message
+var elastiCacheResourceNames = map[ElastiCacheState]string{
+	ElastiCacheStateReplicationGroups: "replication groups",
+	ElastiCacheStateCacheClusters:     "cache clusters",
+}
+
+// columns are the columns of the table on screen
+func (m ElastiCacheModel) columns() []Column {
+	switch m.state {
+	case ElastiCacheStateReplicationGroups:
+		return replicationGroupColumns
+	case ElastiCacheStateCacheClusters:
+		return cacheClusterColumns
+	}
+	return nil
+}
+
 func (m ElastiCacheModel) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
+	}
+
+	if resource, ok := elastiCacheResourceNames[m.state]; ok && m.loaded && len(m.list.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.list, resource, m.profile, m.table)
 	}
 
 	if m.state != ElastiCacheStateMenu {
-		var columns []Column
-		switch m.state {
-		case ElastiCacheStateReplicationGroups:
-			columns = replicationGroupColumns
-		case ElastiCacheStateCacheClusters:
-			columns = cacheClusterColumns
-		}
-		_, header := RenderTableHelpers(m.list, m.styles, columns)
+		_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
 		return header + "\n" + m.list.View()
 	}
 
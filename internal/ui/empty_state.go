@@ -0,0 +1,25 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// RenderEmptyState renders a centered message in place of a table whose data
+// has loaded but contains no items. resource is the plural noun, e.g. "EC2 instances".
+// The message names the region the table lists, or the profile for the
+// global services, and the key bound to refresh.
+func RenderEmptyState(styles Styles, l list.Model, resource, profile string, t *tableLayout) string {
+	scope := "in " + t.region
+	if t.region == "" {
+		scope = "for profile " + profile
+	}
+	message := lipgloss.JoinVertical(lipgloss.Center,
+		styles.StatusMuted.Render(fmt.Sprintf("No %s found %s", resource, scope)),
+		"",
+		styles.StatusKey.Render(t.refreshKey)+" "+styles.StatusMuted.Render("to refresh"),
+	)
+	return lipgloss.Place(l.Width(), l.Height()+TableColumnHeaderHeight, lipgloss.Center, lipgloss.Center, message)
+}
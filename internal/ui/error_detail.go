@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+// viewError is the error the current service view is showing, if any
+func (m Model) viewError() error {
+	switch m.view {
+	case viewS3:
+		return m.s3Model.err
+	case viewIAM:
+		return m.iamModel.err
+	case viewVPC:
+		return m.vpcModel.err
+	case viewLambda:
+		return m.lambdaModel.err
+	case viewEC2:
+		return m.ec2Model.err
+	case viewRDS:
+		return m.rdsModel.err
+	case viewCW:
+		return m.cwModel.err
+	case viewCF:
+		return m.cfModel.err
+	case viewElastiCache:
+		return m.elasticacheModel.err
+	case viewMSK:
+		return m.mskModel.err
+	case viewSQS:
+		return m.sqsModel.err
+	case viewSM:
+		return m.smModel.err
+	case viewRoute53:
+		return m.route53Model.err
+	case viewACM:
+		return m.acmModel.err
+	case viewSNS:
+		return m.snsModel.err
+	case viewKMS:
+		return m.kmsModel.err
+	case viewDMS:
+		return m.dmsModel.err
+	case viewECS:
+		return m.ecsModel.err
+	case viewBilling:
+		return m.billingModel.err
+	case viewSecurityHub:
+		return m.securityhubModel.err
+	case viewWAF:
+		return m.wafModel.err
+	case viewECR:
+		return m.ecrModel.err
+	case viewEFS:
+		return m.efsModel.err
+	case viewBackup:
+		return m.backupModel.err
+	case viewDynamoDB:
+		return m.dynamodbModel.err
+	case viewTransfer:
+		return m.transferModel.err
+	case viewAPIGateway:
+		return m.apiGatewayModel.err
+	}
+	return nil
+}
+
+// handleErrorKeyPress lets e expand the error on screen into everything AWS
+// reported about the failed call, and y copy that for a support case. Other
+// keys collapse it and go on to the view, which dismisses the error.
+func (m *Model) handleErrorKeyPress(msg tea.KeyMsg) (handled bool, cmd tea.Cmd) {
+	err := m.viewError()
+	if err == nil {
+		m.errorExpanded = false
+		return false, nil
+	}
+	switch msg.String() {
+	case "e":
+		m.errorExpanded = !m.errorExpanded
+		return true, nil
+	case "y":
+		if m.errorExpanded {
+			return true, copyToClipboard(errorDetailText(err), "error details")
+		}
+	}
+	m.errorExpanded = false
+	return false, nil
+}
+
+// errorDetailFields lists what is known about err, in the order a support
+// case asks for it
+func errorDetailFields(err error) []detailField {
+	detail, ok := aws.DescribeError(err)
+	if !ok {
+		return nil
+	}
+	status := ""
+	if detail.StatusCode != 0 {
+		status = fmt.Sprintf("%d", detail.StatusCode)
+	}
+	return []detailField{
+		{"Service", detail.Service},
+		{"Operation", detail.Operation},
+		{"Error code", detail.Code},
+		{"Message", detail.Message},
+		{"Fault", detail.Fault},
+		{"HTTP status", status},
+		{"Request ID", detail.RequestID},
+		{"Host ID", detail.HostID},
+	}
+}
+
+// errorDetailText is the expanded error as plain text, for the clipboard
+func errorDetailText(err error) string {
+	var s strings.Builder
+	for _, f := range errorDetailFields(err) {
+		if f.value != "" {
+			s.WriteString(fmt.Sprintf("%s: %s\n", f.label, f.value))
+		}
+	}
+	s.WriteString(fmt.Sprintf("Error: %v\n", err))
+	return s.String()
+}
+
+func (m Model) renderErrorDetail(err error) string {
+	w, h := GetMainContainerSize(m.width, m.height)
+	labelStyle := lipgloss.NewStyle().Foreground(m.styles.Primary).Width(16)
+	valueStyle := lipgloss.NewStyle().Width(max(w-24, 20))
+
+	var s strings.Builder
+	s.WriteString(" " + m.styles.Error.Bold(true).Render("✘ Error details") + "\n\n")
+
+	fields := errorDetailFields(err)
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		s.WriteString(" " + lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render(f.label), valueStyle.Render(f.value)) + "\n")
+	}
+	if fields == nil {
+		s.WriteString(" " + m.styles.StatusMuted.Render("Not an AWS API error, no request details to show") + "\n")
+	}
+
+	s.WriteString("\n " + m.styles.Error.Width(max(w-4, 20)).Render(err.Error()) + "\n")
+	s.WriteString("\n " + m.styles.StatusMuted.Render("(y to copy, e to collapse, any other key to continue)"))
+
+	return lipgloss.NewStyle().MaxHeight(h).Render(s.String())
+}
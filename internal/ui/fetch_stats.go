@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+// fetchStats describes a listing fetched from AWS: the pages it took, how
+// many of their calls were retried and how long it ran. It is shown in the
+// footer so an expensive listing stands out in a big account.
+type fetchStats struct {
+	pages   int
+	retries int
+	items   int
+	took    time.Duration
+}
+
+// measureFetch returns a context that counts the calls made with it, and a
+// function that reports them once the listing of items is complete
+func measureFetch() (context.Context, func(items int) *fetchStats) {
+	ctx, stats := aws.WithFetchStats(context.Background())
+	start := time.Now()
+	return ctx, func(items int) *fetchStats {
+		return &fetchStats{pages: stats.Calls(), retries: stats.Retries(), items: items, took: time.Since(start)}
+	}
+}
+
+// add accumulates the stats of a listing loaded a page at a time. The items
+// are those loaded so far.
+func (s *fetchStats) add(next *fetchStats) *fetchStats {
+	if s == nil || next == nil {
+		return next
+	}
+	return &fetchStats{
+		pages:   s.pages + next.pages,
+		retries: s.retries + next.retries,
+		items:   next.items,
+		took:    s.took + next.took,
+	}
+}
+
+func (s fetchStats) String() string {
+	text := fmt.Sprintf("%d items in %d page", s.items, s.pages)
+	if s.pages != 1 {
+		text += "s"
+	}
+	text += fmt.Sprintf(", %s", s.took.Round(10*time.Millisecond))
+	switch s.retries {
+	case 0:
+	case 1:
+		text += ", 1 retry"
+	default:
+		text += fmt.Sprintf(", %d retries", s.retries)
+	}
+	return text
+}
+
+// fetchStatsNote is the footer note of the listing on screen. Listings read
+// from the cache have no stats and show none.
+func (m Model) fetchStatsNote() string {
+	var stats *fetchStats
+	switch {
+	case m.view == viewS3 && m.s3Model.state == S3StateObjects:
+		stats = m.s3Model.fetchStats
+	case m.view == viewIAM && m.iamModel.state == IAMStateUsers:
+		stats = m.iamModel.usersStats
+	case m.view == viewCW && m.cwModel.state == CWStateLogGroups:
+		stats = m.cwModel.groupsStats
+	}
+	if stats == nil {
+		return ""
+	}
+	return "⏱ " + stats.String()
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// absoluteTimeFormat is used wherever the exact time matters, e.g. detail views
+const absoluteTimeFormat = "2006-01-02 15:04"
+
+// humanizeTime formats t relative to now, e.g. "3m ago" or "2d ago", for list
+// columns where recency matters more than the exact time. The zero time renders
+// as an empty string and future times fall back to the absolute format.
+func humanizeTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	d := time.Since(t)
+	switch {
+	case d < 0:
+		return t.Format(absoluteTimeFormat)
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	case d < 365*24*time.Hour:
+		return fmt.Sprintf("%dmo ago", int(d.Hours()/(24*30)))
+	default:
+		return fmt.Sprintf("%dy ago", int(d.Hours()/(24*365)))
+	}
+}
+
+// humanizeBytes formats a size with binary units, e.g. "512 B" or "3.2 GiB"
+func humanizeBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// shortenARN returns the last segment of an ARN's resource part, e.g.
+// my-topic, alice for an IAM user or family:3 for an ECS task definition
+func shortenARN(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) < 6 {
+		return arn
+	}
+	resource := parts[5]
+	if i := strings.LastIndex(resource, "/"); i != -1 {
+		return resource[i+1:]
+	}
+	return resource[strings.LastIndex(resource, ":")+1:]
+}
+
+// formatARN shortens value if it is an ARN, unless full is set
+func formatARN(value string, full bool) string {
+	if full || !strings.HasPrefix(value, "arn:") {
+		return value
+	}
+	return shortenARN(value)
+}
@@ -0,0 +1,325 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+	"github.com/giovannirossini/aws-tui/internal/cache"
+)
+
+// IAMGroupsMsg carries the groups of the account
+type IAMGroupsMsg []aws.IAMGroupInfo
+
+// IAMGroupMembersMsg carries the users in a group
+type IAMGroupMembersMsg struct {
+	group   string
+	members []aws.IAMUserInfo
+}
+
+type iamGroupItem struct {
+	info aws.IAMGroupInfo
+}
+
+func (i iamGroupItem) Title() string       { return i.info.GroupName }
+func (i iamGroupItem) Description() string { return i.info.Arn }
+func (i iamGroupItem) FilterValue() string { return i.info.GroupName + " " + i.info.Arn }
+func (i iamGroupItem) rowID() string       { return i.info.Arn }
+
+type iamGroupDelegate struct {
+	list.DefaultDelegate
+	styles Styles
+	table  *tableLayout
+}
+
+var iamGroupColumns = []Column{
+	{Title: "Group Name", Width: 0.3},
+	{Title: "Path", Width: 0.15},
+	{Title: "Created", Width: 0.15},
+	{Title: "Arn", Width: 0.4},
+}
+
+func (d iamGroupDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(iamGroupItem)
+	if !ok {
+		return
+	}
+
+	colStyles, _ := RenderTableHelpers(m, d.styles, iamGroupColumns, d.table)
+	values := []string{
+		"👥 " + i.info.GroupName,
+		i.info.Path,
+		i.info.CreateDate.Format("2006-01-02 15:04"),
+		i.info.Arn,
+	}
+	RenderTableRow(w, m, listItem, d.styles, colStyles, values, index == m.Index())
+}
+
+func (d iamGroupDelegate) Height() int { return 1 }
+
+func (d iamGroupDelegate) Spacing() int { return d.table.rowSpacing() }
+
+type iamMemberItem struct {
+	info aws.IAMUserInfo
+}
+
+func (i iamMemberItem) Title() string       { return i.info.UserName }
+func (i iamMemberItem) Description() string { return i.info.Arn }
+func (i iamMemberItem) FilterValue() string { return i.info.UserName + " " + i.info.UserID }
+func (i iamMemberItem) rowID() string       { return i.info.UserID }
+
+type iamMemberDelegate struct {
+	list.DefaultDelegate
+	styles Styles
+	table  *tableLayout
+}
+
+var iamMemberColumns = []Column{
+	{Title: "Username", Width: 0.25},
+	{Title: "User ID", Width: 0.25},
+	{Title: "Created", Width: 0.15},
+	{Title: "Arn", Width: 0.35},
+}
+
+func (d iamMemberDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(iamMemberItem)
+	if !ok {
+		return
+	}
+
+	colStyles, _ := RenderTableHelpers(m, d.styles, iamMemberColumns, d.table)
+	values := []string{
+		"👤 " + i.info.UserName,
+		i.info.UserID,
+		i.info.CreateDate.Format("2006-01-02 15:04"),
+		i.info.Arn,
+	}
+	RenderTableRow(w, m, listItem, d.styles, colStyles, values, index == m.Index())
+}
+
+func (d iamMemberDelegate) Height() int { return 1 }
+
+func (d iamMemberDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func newIAMGroupLists(styles Styles, table *tableLayout) (groups, members list.Model) {
+	gd := iamGroupDelegate{DefaultDelegate: list.NewDefaultDelegate(), styles: styles, table: table}
+	gd.Styles.SelectedTitle = styles.ListSelectedTitle
+	groups = list.New([]list.Item{}, gd, 0, 0)
+	groups.SetShowStatusBar(false)
+	groups.SetShowHelp(false)
+	groups.SetShowTitle(false)
+
+	md := iamMemberDelegate{DefaultDelegate: list.NewDefaultDelegate(), styles: styles, table: table}
+	md.Styles.SelectedTitle = styles.ListSelectedTitle
+	members = list.New([]list.Item{}, md, 0, 0)
+	members.SetShowStatusBar(false)
+	members.SetShowHelp(false)
+	members.SetShowTitle(false)
+	return groups, members
+}
+
+func (m IAMModel) fetchGroups() tea.Cmd {
+	return func() tea.Msg {
+		if cached, ok := m.cache.Get(m.cacheKeys.IAMGroups()); ok {
+			if groups, ok := cached.([]aws.IAMGroupInfo); ok {
+				return IAMGroupsMsg(groups)
+			}
+		}
+
+		client, err := aws.NewIAMClient(context.Background(), m.profile)
+		if err != nil {
+			return IAMErrorMsg(err)
+		}
+		groups, err := client.ListGroups(context.Background())
+		if err != nil {
+			return IAMErrorMsg(err)
+		}
+
+		m.cache.Set(m.cacheKeys.IAMGroups(), groups, cache.TTLIAMGroups)
+		return IAMGroupsMsg(groups)
+	}
+}
+
+func (m IAMModel) fetchGroupMembers(group string) tea.Cmd {
+	return func() tea.Msg {
+		cacheKey := m.cacheKeys.IAMGroupMembers(group)
+		if cached, ok := m.cache.Get(cacheKey); ok {
+			if members, ok := cached.([]aws.IAMUserInfo); ok {
+				return IAMGroupMembersMsg{group: group, members: members}
+			}
+		}
+
+		client, err := aws.NewIAMClient(context.Background(), m.profile)
+		if err != nil {
+			return IAMErrorMsg(err)
+		}
+		members, err := client.GetGroupMembers(context.Background(), group)
+		if err != nil {
+			return IAMErrorMsg(err)
+		}
+
+		m.cache.Set(cacheKey, members, cache.TTLIAMGroups)
+		return IAMGroupMembersMsg{group: group, members: members}
+	}
+}
+
+func (m IAMModel) addUserToGroup(group, userName string) tea.Cmd {
+	return armed(func() tea.Msg {
+		client, err := aws.NewIAMClient(context.Background(), m.profile)
+		if err != nil {
+			return IAMErrorMsg(err)
+		}
+		if err := client.AddUserToGroup(context.Background(), group, userName); err != nil {
+			return IAMErrorMsg(err)
+		}
+
+		m.cache.Delete(m.cacheKeys.IAMGroupMembers(group))
+		return IAMSuccessMsg(fmt.Sprintf("Added %s to %s", userName, group))
+	})
+}
+
+func (m IAMModel) removeUserFromGroup(group, userName string) tea.Cmd {
+	return armed(func() tea.Msg {
+		client, err := aws.NewIAMClient(context.Background(), m.profile)
+		if err != nil {
+			return IAMErrorMsg(err)
+		}
+		if err := client.RemoveUserFromGroup(context.Background(), group, userName); err != nil {
+			return IAMErrorMsg(err)
+		}
+
+		m.cache.Delete(m.cacheKeys.IAMGroupMembers(group))
+		return IAMSuccessMsg(fmt.Sprintf("Removed %s from %s", userName, group))
+	})
+}
+
+func (m *IAMModel) setGroups(groups []aws.IAMGroupInfo) {
+	items := make([]list.Item, len(groups))
+	for i, g := range groups {
+		items[i] = iamGroupItem{info: g}
+	}
+	m.table.setItems(&m.groupList, items)
+	m.groupsLoaded = true
+}
+
+func (m *IAMModel) setGroupMembers(msg IAMGroupMembersMsg) {
+	// Members of a group the user has already left
+	if msg.group != m.selectedGroup.GroupName {
+		return
+	}
+	items := make([]list.Item, len(msg.members))
+	for i, u := range msg.members {
+		items[i] = iamMemberItem{info: u}
+	}
+	m.table.setItems(&m.memberList, items)
+	m.membersLoaded = true
+}
+
+func (m IAMModel) updateGroups(msg tea.KeyMsg) (IAMModel, tea.Cmd) {
+	if m.groupList.FilterState() != list.Filtering {
+		switch msg.String() {
+		case "r":
+			m.cache.Delete(m.cacheKeys.IAMGroups())
+			m.groupsLoaded = false
+			return m, m.fetchGroups()
+		case "enter":
+			if item, ok := m.groupList.SelectedItem().(iamGroupItem); ok {
+				m.selectedGroup = item.info
+				m.table.setItems(&m.memberList, nil)
+				m.memberList.ResetSelected()
+				m.membersLoaded = false
+				m.state = IAMStateGroupMembers
+				return m, m.fetchGroupMembers(item.info.GroupName)
+			}
+			return m, nil
+		case "esc", "backspace":
+			if m.groupList.FilterState() == list.Unfiltered {
+				m.state = IAMStateMenu
+				return m, nil
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.groupList, cmd = m.groupList.Update(msg)
+	return m, cmd
+}
+
+func (m IAMModel) updateGroupMembers(msg tea.KeyMsg) (IAMModel, tea.Cmd) {
+	if m.memberList.FilterState() != list.Filtering {
+		switch msg.String() {
+		case "a":
+			m.state = IAMStateInput
+			m.action = IAMActionAddToGroup
+			m.input.Placeholder = "Username"
+			m.input.Focus()
+			return m, nil
+		case "d":
+			if item, ok := m.memberList.SelectedItem().(iamMemberItem); ok {
+				group, user := m.selectedGroup.GroupName, item.info.UserName
+				m.openConfirm(IAMStateConfirmRemoveMember, IAMActionRemoveFromGroup, NewConfirmDialog("Confirm Removal", fmt.Sprintf("Remove %s from %s?",
+					lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render(user),
+					lipgloss.NewStyle().Foreground(m.styles.Accent).Bold(true).Render(group),
+				), true, func() tea.Cmd { return m.removeUserFromGroup(group, user) }))
+			}
+			return m, nil
+		case "r":
+			m.cache.Delete(m.cacheKeys.IAMGroupMembers(m.selectedGroup.GroupName))
+			m.membersLoaded = false
+			return m, m.fetchGroupMembers(m.selectedGroup.GroupName)
+		case "esc", "backspace":
+			if m.memberList.FilterState() == list.Unfiltered {
+				m.state = IAMStateGroups
+				return m, nil
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.memberList, cmd = m.memberList.Update(msg)
+	return m, cmd
+}
+
+func (m IAMModel) renderGroups() string {
+	if !m.groupsLoaded {
+		return "\n  " + lipgloss.NewStyle().Foreground(m.styles.Primary).Render("󱎯 Loading...")
+	}
+	if len(m.groupList.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.groupList, "IAM groups", m.profile, m.table)
+	}
+	_, header := RenderTableHelpers(m.groupList, m.styles, iamGroupColumns, m.table)
+	return header + "\n" + m.groupList.View()
+}
+
+// renderGroupMembers renders the members table with the add/remove popups on top
+func (m IAMModel) renderGroupMembers() string {
+	var base string
+	if !m.membersLoaded {
+		_, header := RenderTableHelpers(m.memberList, m.styles, iamMemberColumns, m.table)
+		base = header + "\n\n  " + lipgloss.NewStyle().Foreground(m.styles.Primary).Render("󱎯 Loading...")
+	} else if len(m.memberList.Items()) == 0 {
+		base = RenderEmptyState(m.styles, m.memberList, "group members", m.profile, m.table)
+	} else {
+		_, header := RenderTableHelpers(m.memberList, m.styles, iamMemberColumns, m.table)
+		base = header + "\n" + m.memberList.View()
+	}
+
+	switch m.state {
+	case IAMStateInput:
+		return RenderOverlay(base, m.styles.Popup.Width(50).Render(fmt.Sprintf(
+			" %s\n\n %s\n\n %s",
+			lipgloss.NewStyle().Foreground(m.styles.Primary).Render("Add user to "+m.selectedGroup.GroupName),
+			m.input.View(),
+			m.styles.StatusMuted.Render("(esc to cancel)"),
+		)), m.width, m.height)
+
+	case IAMStateConfirmRemoveMember:
+		return RenderOverlay(base, m.confirm.View(m.styles), m.width, m.height)
+	}
+
+	return base
+}
@@ -0,0 +1,247 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+	"github.com/giovannirossini/aws-tui/internal/cache"
+	"github.com/giovannirossini/aws-tui/internal/logging"
+)
+
+// IAMRolesMsg carries the roles of the account with when each was last used
+type IAMRolesMsg []aws.IAMRoleInfo
+
+type iamRoleItem struct {
+	info       aws.IAMRoleInfo
+	principals string
+}
+
+func (i iamRoleItem) Title() string       { return i.info.RoleName }
+func (i iamRoleItem) Description() string { return i.principals }
+func (i iamRoleItem) FilterValue() string {
+	return i.info.RoleName + " " + i.info.Arn + " " + i.principals
+}
+
+func (i iamRoleItem) rowID() string { return i.info.Arn }
+
+type iamRoleDelegate struct {
+	list.DefaultDelegate
+	styles Styles
+	table  *tableLayout
+}
+
+var iamRoleColumns = []Column{
+	{Title: "Role Name", Width: 0.3},
+	{Title: "Trusted Principals", Width: 0.35},
+	{Title: "Max Session", Width: 0.1},
+	{Title: "Last Used", Width: 0.25},
+}
+
+func (d iamRoleDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(iamRoleItem)
+	if !ok {
+		return
+	}
+
+	colStyles, _ := RenderTableHelpers(m, d.styles, iamRoleColumns, d.table)
+	values := []string{
+		"🎭 " + i.info.RoleName,
+		i.principals,
+		formatSessionDuration(i.info.MaxSessionDuration),
+		roleLastUsed(i.info),
+	}
+	RenderTableRow(w, m, listItem, d.styles, colStyles, values, index == m.Index())
+}
+
+func (d iamRoleDelegate) Height() int { return 1 }
+
+func (d iamRoleDelegate) Spacing() int { return d.table.rowSpacing() }
+
+// formatSessionDuration renders a role's maximum session, given in seconds
+func formatSessionDuration(seconds int32) string {
+	if seconds%3600 == 0 {
+		return fmt.Sprintf("%dh", seconds/3600)
+	}
+	return fmt.Sprintf("%dm", seconds/60)
+}
+
+// roleLastUsed renders when and where a role was last assumed
+func roleLastUsed(r aws.IAMRoleInfo) string {
+	if r.LastUsed == nil {
+		return "Never"
+	}
+	if r.LastUsedRegion == "" {
+		return humanizeTime(*r.LastUsed)
+	}
+	return humanizeTime(*r.LastUsed) + " (" + r.LastUsedRegion + ")"
+}
+
+func newIAMRoleList(styles Styles, table *tableLayout) list.Model {
+	d := iamRoleDelegate{
+		DefaultDelegate: list.NewDefaultDelegate(),
+		styles:          styles,
+		table:           table,
+	}
+	d.Styles.SelectedTitle = styles.ListSelectedTitle
+
+	l := list.New([]list.Item{}, d, 0, 0)
+	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)
+	l.SetShowTitle(false)
+	return l
+}
+
+func (m IAMModel) fetchRoles() tea.Cmd {
+	return func() tea.Msg {
+		if cached, ok := m.cache.Get(m.cacheKeys.IAMRoles()); ok {
+			if roles, ok := cached.([]aws.IAMRoleInfo); ok {
+				return IAMRolesMsg(roles)
+			}
+		}
+
+		ctx := context.Background()
+		client, err := aws.NewIAMClient(ctx, m.profile)
+		if err != nil {
+			return IAMErrorMsg(err)
+		}
+		roles, err := client.ListRoles(ctx)
+		if err != nil {
+			return IAMErrorMsg(err)
+		}
+
+		// Last use takes a GetRole call per role. A role whose lookup fails
+		// is shown as never used rather than failing the whole list.
+		sem := make(chan struct{}, iamSummaryWorkers)
+		var wg sync.WaitGroup
+		for i := range roles {
+			wg.Add(1)
+			go func(r *aws.IAMRoleInfo) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				lastUsed, region, err := client.GetRoleLastUsed(ctx, r.RoleName)
+				if err != nil {
+					logging.Printf("role last used: %v", err)
+					return
+				}
+				r.LastUsed, r.LastUsedRegion = lastUsed, region
+			}(&roles[i])
+		}
+		wg.Wait()
+
+		m.cache.Set(m.cacheKeys.IAMRoles(), roles, cache.TTLIAMRoles)
+		return IAMRolesMsg(roles)
+	}
+}
+
+func (m *IAMModel) setRoles(roles []aws.IAMRoleInfo) {
+	items := make([]list.Item, len(roles))
+	for i, r := range roles {
+		items[i] = iamRoleItem{
+			info:       r,
+			principals: strings.Join(aws.TrustedPrincipals(r.TrustPolicy), ", "),
+		}
+	}
+	m.table.setItems(&m.roleList, items)
+	m.rolesLoaded = true
+}
+
+func (m IAMModel) updateRoles(msg tea.KeyMsg) (IAMModel, tea.Cmd) {
+	if m.roleList.FilterState() != list.Filtering {
+		switch msg.String() {
+		case "r":
+			m.cache.Delete(m.cacheKeys.IAMRoles())
+			m.rolesLoaded = false
+			return m, m.fetchRoles()
+		case "enter":
+			if item, ok := m.roleList.SelectedItem().(iamRoleItem); ok {
+				m.openRoleDetail(item.info)
+			}
+			return m, nil
+		case "esc", "backspace":
+			if m.roleList.FilterState() == list.Unfiltered {
+				m.state = IAMStateMenu
+				return m, nil
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.roleList, cmd = m.roleList.Update(msg)
+	return m, cmd
+}
+
+// openRoleDetail shows the full trust policy of a role
+func (m *IAMModel) openRoleDetail(role aws.IAMRoleInfo) {
+	m.selectedRole = role
+	policy := role.TrustPolicy
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, []byte(policy), "", "  "); err == nil {
+		policy = pretty.String()
+	}
+
+	w, h := GetMainContainerSize(m.width, m.height)
+	m.roleViewport = viewport.New(max(w-6, 20), max(h-AppInternalFooterHeight-12, 3))
+	m.roleViewport.SetContent(highlightLog(m.styles, policy))
+	m.state = IAMStateRoleDetail
+}
+
+func (m IAMModel) updateRoleDetail(msg tea.KeyMsg) (IAMModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "backspace", "q":
+		m.state = IAMStateRoles
+		return m, nil
+	case "y":
+		return m, copyToClipboard(m.selectedRole.TrustPolicy, "trust policy")
+	}
+
+	if scrollViewport(&m.roleViewport, msg.String()) {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.roleViewport, cmd = m.roleViewport.Update(msg)
+	return m, cmd
+}
+
+func (m IAMModel) renderRoles() string {
+	if !m.rolesLoaded {
+		return "\n  " + lipgloss.NewStyle().Foreground(m.styles.Primary).Render("󱎯 Loading...")
+	}
+	if len(m.roleList.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.roleList, "IAM roles", m.profile, m.table)
+	}
+	_, header := RenderTableHelpers(m.roleList, m.styles, iamRoleColumns, m.table)
+	return header + "\n" + m.roleList.View()
+}
+
+func (m IAMModel) renderRoleDetail() string {
+	r := m.selectedRole
+	labelStyle := lipgloss.NewStyle().Foreground(m.styles.Primary).Width(16)
+	fields := []detailField{
+		{"ARN", r.Arn},
+		{"Path", r.Path},
+		{"Created", r.CreateDate.Local().Format(absoluteTimeFormat)},
+		{"Max session", formatSessionDuration(r.MaxSessionDuration)},
+		{"Last used", roleLastUsed(r)},
+	}
+
+	var s strings.Builder
+	for _, f := range fields {
+		s.WriteString(" " + labelStyle.Render(f.label) + f.value + "\n")
+	}
+	s.WriteString("\n " + lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render("Trust policy") + "\n")
+	s.WriteString(m.roleViewport.View() + "\n\n")
+	s.WriteString(renderScrollIndicator(m.styles, m.roleViewport, m.styles.StatusMuted.Render(" (↑/↓ to scroll, y to copy, esc to go back)")))
+	return s.String()
+}
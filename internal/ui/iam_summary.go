@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+	"github.com/giovannirossini/aws-tui/internal/cache"
+)
+
+// iamSummaryWorkers bounds the concurrent user detail lookups
+const iamSummaryWorkers = 5
+
+// IAMSummaryMsg carries the account security posture shown on the summary screen
+type IAMSummaryMsg struct {
+	Policy            *aws.PasswordPolicy
+	Users             int
+	WithoutMFA        []string
+	ConsoleWithoutMFA int
+}
+
+func (m IAMModel) fetchSummary() tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		client, err := aws.NewIAMClient(ctx, m.profile)
+		if err != nil {
+			return IAMErrorMsg(err)
+		}
+
+		policy, err := client.GetAccountPasswordPolicy(ctx)
+		if err != nil {
+			return IAMErrorMsg(err)
+		}
+
+		var users []aws.IAMUserInfo
+		if cached, ok := m.cache.Get(m.cacheKeys.IAMUsers()); ok {
+			users, _ = cached.([]aws.IAMUserInfo)
+		}
+		if users == nil {
+			if users, err = client.ListUsers(ctx); err != nil {
+				return IAMErrorMsg(err)
+			}
+			m.cache.Set(m.cacheKeys.IAMUsers(), users, cache.TTLIAMUsers)
+		}
+
+		details := make([]*aws.IAMUserInfo, len(users))
+		errs := make([]error, len(users))
+		sem := make(chan struct{}, iamSummaryWorkers)
+		var wg sync.WaitGroup
+		for i, u := range users {
+			wg.Add(1)
+			go func(i int, userName string) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				// Reuse the per-user details the actions popup caches
+				cacheKey := m.cacheKeys.IAMUserDetails(userName)
+				if cached, ok := m.cache.Get(cacheKey); ok {
+					if d, ok := cached.(IAMUserDetailsMsg); ok {
+						details[i] = d.Info
+						return
+					}
+				}
+				info, keys, err := client.GetUserDetails(ctx, userName)
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				m.cache.Set(cacheKey, IAMUserDetailsMsg{Info: info, Keys: keys}, cache.TTLIAMUserDetails)
+				details[i] = info
+			}(i, u.UserName)
+		}
+		wg.Wait()
+
+		for _, err := range errs {
+			if err != nil {
+				return IAMErrorMsg(err)
+			}
+		}
+
+		summary := IAMSummaryMsg{Policy: policy, Users: len(users)}
+		for _, d := range details {
+			if d.MFAEnabled {
+				continue
+			}
+			summary.WithoutMFA = append(summary.WithoutMFA, d.UserName)
+			if d.PasswordExists {
+				summary.ConsoleWithoutMFA++
+			}
+		}
+		sort.Strings(summary.WithoutMFA)
+
+		return summary
+	}
+}
+
+func (m IAMModel) renderSummary() string {
+	if m.summary == nil {
+		return "\n  " + lipgloss.NewStyle().Foreground(m.styles.Primary).Render("󱎯 Loading...")
+	}
+
+	sectionStyle := lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(m.styles.Muted).Width(28)
+	valueStyle := lipgloss.NewStyle().Foreground(m.styles.Snow).Bold(true)
+
+	check := func(ok bool) string {
+		if ok {
+			return m.styles.Success.Render("✔")
+		}
+		return m.styles.Error.Render("✘")
+	}
+	row := func(label, value string) string {
+		return "  " + labelStyle.Render(label) + value + "\n"
+	}
+
+	var s strings.Builder
+	s.WriteString("\n" + sectionStyle.Render("PASSWORD POLICY") + "\n")
+	if p := m.summary.Policy; p == nil {
+		s.WriteString("  " + m.styles.Error.Render("✘ No password policy set, AWS defaults apply") + "\n")
+	} else {
+		expiry := "Never"
+		if p.ExpirePasswords {
+			expiry = fmt.Sprintf("%d days", p.MaxPasswordAge)
+		}
+		reuse := "Off"
+		if p.PasswordReusePrevention > 0 {
+			reuse = fmt.Sprintf("Last %d passwords", p.PasswordReusePrevention)
+		}
+		s.WriteString(row("Minimum length", valueStyle.Render(fmt.Sprintf("%d", p.MinimumLength))))
+		s.WriteString(row("Require uppercase", check(p.RequireUppercase)))
+		s.WriteString(row("Require lowercase", check(p.RequireLowercase)))
+		s.WriteString(row("Require numbers", check(p.RequireNumbers)))
+		s.WriteString(row("Require symbols", check(p.RequireSymbols)))
+		s.WriteString(row("Password expiry", valueStyle.Render(expiry)))
+		s.WriteString(row("Reuse prevention", valueStyle.Render(reuse)))
+		s.WriteString(row("Users can change password", check(p.AllowUsersToChangePassword)))
+	}
+
+	noMFA := len(m.summary.WithoutMFA)
+	countStyle := m.styles.Success.Bold(true)
+	if noMFA > 0 {
+		countStyle = m.styles.Error.Bold(true)
+	}
+
+	s.WriteString("\n" + sectionStyle.Render("MFA") + "\n")
+	s.WriteString(row("Users", valueStyle.Render(fmt.Sprintf("%d", m.summary.Users))))
+	s.WriteString(row("Users without MFA", countStyle.Render(fmt.Sprintf("%d", noMFA))))
+	if noMFA > 0 {
+		s.WriteString(row("  with console access", countStyle.Render(fmt.Sprintf("%d", m.summary.ConsoleWithoutMFA))))
+		s.WriteString("\n  " + m.styles.StatusMuted.Render(strings.Join(m.summary.WithoutMFA, ", ")) + "\n")
+	}
+
+	return lipgloss.NewStyle().Width(m.width - InnerContentWidthOffset).Render(s.String())
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/giovannirossini/aws-tui/internal/aws"
@@ -23,6 +24,19 @@ const (
 	IAMStateInput
 	IAMStateConfirmDelete
 	IAMStateConfirmConsoleToggle
+	IAMStatePolicies
+	IAMStateConfirmDetach
+	IAMStateAccessKeys
+	IAMStateConfirmKeyAction
+	IAMStateNewAccessKey
+	IAMStateNewPassword
+	IAMStateMenu
+	IAMStateSummary
+	IAMStateRoles
+	IAMStateRoleDetail
+	IAMStateGroups
+	IAMStateGroupMembers
+	IAMStateConfirmRemoveMember
 )
 
 type IAMAction int
@@ -34,8 +48,25 @@ const (
 	IAMActionResetPassword
 	IAMActionEnableConsole
 	IAMActionDisableConsole
+	IAMActionAttachPolicy
+	IAMActionDetachPolicy
+	IAMActionCreateKey
+	IAMActionActivateKey
+	IAMActionDeactivateKey
+	IAMActionDeleteKey
+	IAMActionAddToGroup
+	IAMActionRemoveFromGroup
 )
 
+type iamMenuItem struct {
+	title       string
+	description string
+}
+
+func (i iamMenuItem) Title() string       { return i.title }
+func (i iamMenuItem) Description() string { return i.description }
+func (i iamMenuItem) FilterValue() string { return i.title }
+
 type iamActionItem struct {
 	title string
 	key   string
@@ -52,6 +83,7 @@ type iamItem struct {
 	path             string
 	createDate       string
 	passwordLastUsed string
+	lastLogin        string
 }
 
 func (i iamItem) Title() string { return i.userName }
@@ -62,11 +94,16 @@ func (i iamItem) Description() string {
 	}
 	return desc
 }
-func (i iamItem) FilterValue() string { return i.userName }
+func (i iamItem) FilterValue() string {
+	return i.userName + " " + i.userID + " " + i.arn + " " + i.path
+}
+
+func (i iamItem) rowID() string { return i.userID }
 
 type iamItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 }
 
 var iamColumns = []Column{
@@ -84,10 +121,10 @@ func (d iamItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 		return
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, iamColumns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, iamColumns, d.table)
 	isSelected := index == m.Index()
 
-	lastLogin := i.passwordLastUsed
+	lastLogin := i.lastLogin
 	if lastLogin == "" {
 		lastLogin = "Never"
 	}
@@ -101,11 +138,119 @@ func (d iamItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 		i.arn,
 	}
 
-	RenderTableRow(w, m, d.styles, colStyles, values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, values, isSelected)
 }
 
 func (d iamItemDelegate) Height() int { return 1 }
 
+func (d iamItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+type iamPolicyItem struct {
+	name   string
+	arn    string
+	inline bool
+}
+
+func (i iamPolicyItem) Title() string       { return i.name }
+func (i iamPolicyItem) Description() string { return i.arn }
+func (i iamPolicyItem) FilterValue() string { return i.name + " " + i.arn }
+func (i iamPolicyItem) rowID() string       { return i.arn }
+
+type iamPolicyDelegate struct {
+	list.DefaultDelegate
+	styles Styles
+	table  *tableLayout
+}
+
+var iamPolicyColumns = []Column{
+	{Title: "Policy Name", Width: 0.35},
+	{Title: "Type", Width: 0.1},
+	{Title: "Arn", Width: 0.55},
+}
+
+func (d iamPolicyDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(iamPolicyItem)
+	if !ok {
+		return
+	}
+
+	colStyles, _ := RenderTableHelpers(m, d.styles, iamPolicyColumns, d.table)
+	isSelected := index == m.Index()
+
+	policyType := "Managed"
+	if i.inline {
+		policyType = "Inline"
+	}
+
+	values := []string{
+		"📜 " + i.name,
+		policyType,
+		i.arn,
+	}
+
+	RenderTableRow(w, m, listItem, d.styles, colStyles, values, isSelected)
+}
+
+func (d iamPolicyDelegate) Height() int { return 1 }
+
+func (d iamPolicyDelegate) Spacing() int { return d.table.rowSpacing() }
+
+type iamKeyItem struct {
+	id         string
+	status     string
+	createDate string
+}
+
+func (i iamKeyItem) Title() string       { return i.id }
+func (i iamKeyItem) Description() string { return i.status }
+func (i iamKeyItem) FilterValue() string { return i.id + " " + i.status }
+
+type iamKeyDelegate struct {
+	list.DefaultDelegate
+	styles Styles
+	table  *tableLayout
+}
+
+var iamKeyColumns = []Column{
+	{Title: "Access Key ID", Width: 0.4},
+	{Title: "Status", Width: 0.2},
+	{Title: "Created", Width: 0.4},
+}
+
+func (d iamKeyDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(iamKeyItem)
+	if !ok {
+		return
+	}
+
+	colStyles, _ := RenderTableHelpers(m, d.styles, iamKeyColumns, d.table)
+	isSelected := index == m.Index()
+
+	values := []string{
+		"🔑 " + i.id,
+		renderStatus(d.styles, i.status),
+		i.createDate,
+	}
+
+	RenderTableRow(w, m, listItem, d.styles, colStyles, values, isSelected)
+}
+
+func (d iamKeyDelegate) Height() int { return 1 }
+
+func (d iamKeyDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func iamKeyItems(keys []aws.AccessKeyInfo) []list.Item {
+	items := make([]list.Item, len(keys))
+	for i, k := range keys {
+		items[i] = iamKeyItem{
+			id:         k.AccessKeyId,
+			status:     k.Status,
+			createDate: k.CreateDate.Format("2006-01-02 15:04"),
+		}
+	}
+	return items
+}
+
 type actionDelegate struct {
 	styles Styles
 }
@@ -131,27 +276,57 @@ func (d actionDelegate) Render(w io.Writer, m list.Model, index int, listItem li
 }
 
 type IAMModel struct {
-	list         list.Model
-	actionList   list.Model
-	input        textinput.Model
-	styles       Styles
-	state        IAMState
-	action       IAMAction
-	selectedUser iamItem
-	userDetail   *aws.IAMUserInfo
-	userKeys     []aws.AccessKeyInfo
-	width        int
-	height       int
-	profile      string
-	err          error
-	cache        *cache.Cache
-	cacheKeys    *cache.KeyBuilder
-}
-
-func NewIAMModel(profile string, styles Styles, appCache *cache.Cache) IAMModel {
+	menuList       list.Model
+	list           list.Model
+	actionList     list.Model
+	policyList     list.Model
+	policiesLoaded bool
+	keyList        list.Model
+	newKey         *aws.NewAccessKey
+	newPassword    string
+	summary        *IAMSummaryMsg
+	input          textinput.Model
+	styles         Styles
+	table          *tableLayout
+	state          IAMState
+	action         IAMAction
+	selectedUser   iamItem
+	userDetail     *aws.IAMUserInfo
+	userKeys       []aws.AccessKeyInfo
+	deleteSummary  deleteSummary
+	confirm        ConfirmDialog
+	width          int
+	height         int
+	profile        string
+	err            error
+	loaded         bool
+	loadingMore    bool
+	cache          *cache.Cache
+	cacheKeys      *cache.KeyBuilder
+	// How the users were fetched so far, nil when cached
+	usersStats *fetchStats
+	// usersLoad numbers the loads of the users, so the pages of an earlier
+	// load still in flight are dropped
+	usersLoad int
+	// Roles and the one whose trust policy is shown
+	roleList     list.Model
+	rolesLoaded  bool
+	selectedRole aws.IAMRoleInfo
+	roleViewport viewport.Model
+	// Groups and the members of the one opened
+	groupList     list.Model
+	groupsLoaded  bool
+	selectedGroup aws.IAMGroupInfo
+	memberList    list.Model
+	membersLoaded bool
+}
+
+func NewIAMModel(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) IAMModel {
+	table := &tableLayout{display: display}
 	d := iamItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
 
@@ -170,16 +345,58 @@ func NewIAMModel(profile string, styles Styles, appCache *cache.Cache) IAMModel
 	al.SetShowPagination(false)
 	al.KeyMap.Quit.SetEnabled(false) // Don't let the list handle quit, let the model do it
 
+	pd := iamPolicyDelegate{
+		DefaultDelegate: list.NewDefaultDelegate(),
+		styles:          styles,
+		table:           table,
+	}
+	pl := list.New([]list.Item{}, pd, 0, 0)
+	pl.SetShowStatusBar(false)
+	pl.SetShowHelp(false)
+	pl.SetShowTitle(false)
+
+	kd := iamKeyDelegate{
+		DefaultDelegate: list.NewDefaultDelegate(),
+		styles:          styles,
+		table:           table,
+	}
+	kl := list.New([]list.Item{}, kd, 0, 0)
+	kl.SetShowStatusBar(false)
+	kl.SetShowHelp(false)
+	kl.SetShowTitle(false)
+
+	md := list.NewDefaultDelegate()
+	md.Styles.SelectedTitle = styles.ListSelectedTitle
+	md.Styles.SelectedDesc = styles.ListSelectedDesc
+	ml := list.New([]list.Item{
+		iamMenuItem{title: "Users", description: "IAM Users, Policies and Access Keys"},
+		iamMenuItem{title: "Roles", description: "IAM Roles and their Trust Policies"},
+		iamMenuItem{title: "Groups", description: "IAM Groups and their Members"},
+		iamMenuItem{title: "Security Summary", description: "Password Policy and MFA Coverage"},
+	}, md, 0, 0)
+	ml.SetShowStatusBar(false)
+	ml.SetShowHelp(false)
+	ml.SetShowTitle(false)
+
+	gl, gml := newIAMGroupLists(styles, table)
+
 	ti := textinput.New()
 	ti.Placeholder = "Username..."
 	ti.Focus()
 
 	return IAMModel{
+		menuList:   ml,
 		list:       l,
 		actionList: al,
+		policyList: pl,
+		keyList:    kl,
+		roleList:   newIAMRoleList(styles, table),
+		groupList:  gl,
+		memberList: gml,
 		input:      ti,
 		styles:     styles,
-		state:      IAMStateLoading,
+		table:      table,
+		state:      IAMStateMenu,
 		profile:    profile,
 		cache:      appCache,
 		cacheKeys:  cache.NewKeyBuilder(profile),
@@ -187,39 +404,68 @@ func NewIAMModel(profile string, styles Styles, appCache *cache.Cache) IAMModel
 }
 
 type IAMUsersMsg []aws.IAMUserInfo
+
+// IAMUsersPageMsg carries the users loaded so far and the marker for the next
+// page, with the stats of the pages fetched for them. Profile and Load tell
+// which load the page belongs to.
+type IAMUsersPageMsg struct {
+	Users   []aws.IAMUserInfo
+	Marker  *string
+	Stats   *fetchStats
+	Profile string
+	Load    int
+}
 type IAMUserDetailsMsg struct {
 	Info *aws.IAMUserInfo
 	Keys []aws.AccessKeyInfo
 }
+type IAMUserPoliciesMsg []aws.UserPolicyInfo
+type IAMAccessKeyCreatedMsg *aws.NewAccessKey
+type IAMPasswordSetMsg string
 type IAMErrorMsg error
 type IAMSuccessMsg string
 
 func (m IAMModel) Init() tea.Cmd {
-	return m.fetchUsers()
+	return nil
 }
 
-func (m IAMModel) fetchUsers() tea.Cmd {
+func (m *IAMModel) fetchUsers() tea.Cmd {
+	// A new load orphans the pages of any load still in flight
+	m.usersLoad++
+	c := *m
 	return func() tea.Msg {
 		// Check cache first
-		if cached, ok := m.cache.Get(m.cacheKeys.IAMUsers()); ok {
+		if cached, ok := c.cache.Get(c.cacheKeys.IAMUsers()); ok {
 			if users, ok := cached.([]aws.IAMUserInfo); ok {
 				return IAMUsersMsg(users)
 			}
 		}
 
+		return c.fetchUsersPage(nil, nil, nil)()
+	}
+}
+
+// fetchUsersPage loads the page after marker and appends it to the users loaded so far
+func (m IAMModel) fetchUsersPage(loaded []aws.IAMUserInfo, marker *string, stats *fetchStats) tea.Cmd {
+	return func() tea.Msg {
 		client, err := aws.NewIAMClient(context.Background(), m.profile)
 		if err != nil {
 			return IAMErrorMsg(err)
 		}
-		users, err := client.ListUsers(context.Background())
+		ctx, done := measureFetch()
+		page, next, err := client.ListUsersPage(ctx, marker)
 		if err != nil {
 			return IAMErrorMsg(err)
 		}
 
-		// Cache the result
-		m.cache.Set(m.cacheKeys.IAMUsers(), users, cache.TTLIAMUsers)
+		users := append(append([]aws.IAMUserInfo(nil), loaded...), page...)
 
-		return IAMUsersMsg(users)
+		// Only cache the complete list
+		if next == nil {
+			m.cache.Set(m.cacheKeys.IAMUsers(), users, cache.TTLIAMUsers)
+		}
+
+		return IAMUsersPageMsg{Users: users, Marker: next, Stats: stats.add(done(len(users))), Profile: m.profile, Load: m.usersLoad}
 	}
 }
 
@@ -251,12 +497,131 @@ func (m IAMModel) fetchUserDetails(userName string) tea.Cmd {
 	}
 }
 
-func (m IAMModel) resetPassword(userName, password string) tea.Cmd {
+func (m IAMModel) fetchUserPolicies(userName string) tea.Cmd {
 	return func() tea.Msg {
+		cacheKey := m.cacheKeys.IAMUserPolicies(userName)
+		if cached, ok := m.cache.Get(cacheKey); ok {
+			if policies, ok := cached.([]aws.UserPolicyInfo); ok {
+				return IAMUserPoliciesMsg(policies)
+			}
+		}
+
+		client, err := aws.NewIAMClient(context.Background(), m.profile)
+		if err != nil {
+			return IAMErrorMsg(err)
+		}
+		managed, err := client.ListAttachedUserPolicies(context.Background(), userName)
+		if err != nil {
+			return IAMErrorMsg(err)
+		}
+		inline, err := client.ListUserInlinePolicies(context.Background(), userName)
+		if err != nil {
+			return IAMErrorMsg(err)
+		}
+		policies := append(managed, inline...)
+
+		m.cache.Set(cacheKey, policies, cache.TTLIAMUserDetails)
+		return IAMUserPoliciesMsg(policies)
+	}
+}
+
+func (m IAMModel) attachPolicy(userName, policyArn string) tea.Cmd {
+	return armed(func() tea.Msg {
+		client, err := aws.NewIAMClient(context.Background(), m.profile)
+		if err != nil {
+			return IAMErrorMsg(err)
+		}
+		if err := client.AttachUserPolicy(context.Background(), userName, policyArn); err != nil {
+			return IAMErrorMsg(err)
+		}
+
+		m.cache.Delete(m.cacheKeys.IAMUserPolicies(userName))
+		return IAMSuccessMsg("Policy attached")
+	})
+}
+
+func (m IAMModel) detachPolicy(userName, policyArn string) tea.Cmd {
+	return armed(func() tea.Msg {
+		client, err := aws.NewIAMClient(context.Background(), m.profile)
+		if err != nil {
+			return IAMErrorMsg(err)
+		}
+		if err := client.DetachUserPolicy(context.Background(), userName, policyArn); err != nil {
+			return IAMErrorMsg(err)
+		}
+
+		m.cache.Delete(m.cacheKeys.IAMUserPolicies(userName))
+		return IAMSuccessMsg("Policy detached")
+	})
+}
+
+func (m IAMModel) createAccessKey(userName string) tea.Cmd {
+	return armed(func() tea.Msg {
 		client, err := aws.NewIAMClient(context.Background(), m.profile)
 		if err != nil {
 			return IAMErrorMsg(err)
 		}
+		key, err := client.CreateAccessKey(context.Background(), userName)
+		if err != nil {
+			return IAMErrorMsg(err)
+		}
+
+		m.cache.Delete(m.cacheKeys.IAMUserDetails(userName))
+		return IAMAccessKeyCreatedMsg(key)
+	})
+}
+
+func (m IAMModel) updateAccessKey(userName, accessKeyID string, action IAMAction) tea.Cmd {
+	return armed(func() tea.Msg {
+		client, err := aws.NewIAMClient(context.Background(), m.profile)
+		if err != nil {
+			return IAMErrorMsg(err)
+		}
+
+		msg := "Access key deleted"
+		switch action {
+		case IAMActionActivateKey:
+			err = client.UpdateAccessKeyStatus(context.Background(), userName, accessKeyID, true)
+			msg = "Access key activated"
+		case IAMActionDeactivateKey:
+			err = client.UpdateAccessKeyStatus(context.Background(), userName, accessKeyID, false)
+			msg = "Access key deactivated"
+		default:
+			err = client.DeleteAccessKey(context.Background(), userName, accessKeyID)
+		}
+		if err != nil {
+			return IAMErrorMsg(err)
+		}
+
+		m.cache.Delete(m.cacheKeys.IAMUserDetails(userName))
+		return IAMSuccessMsg(msg)
+	})
+}
+
+// generatePassword creates a password that satisfies the account password policy
+func generatePassword(client *aws.IAMClient) (string, error) {
+	policy, err := client.GetAccountPasswordPolicy(context.Background())
+	if err != nil {
+		return "", err
+	}
+	return aws.GeneratePassword(policy)
+}
+
+// resetPassword sets a new password, generating one when password is empty
+func (m IAMModel) resetPassword(userName, password string) tea.Cmd {
+	return armed(func() tea.Msg {
+		client, err := aws.NewIAMClient(context.Background(), m.profile)
+		if err != nil {
+			return IAMErrorMsg(err)
+		}
+
+		generated := password == ""
+		if generated {
+			if password, err = generatePassword(client); err != nil {
+				return IAMErrorMsg(err)
+			}
+		}
+
 		err = client.UpdateLoginProfile(context.Background(), userName, password)
 		if err != nil {
 			return IAMErrorMsg(err)
@@ -265,19 +630,26 @@ func (m IAMModel) resetPassword(userName, password string) tea.Cmd {
 		// Invalidate user details cache
 		m.cache.Delete(m.cacheKeys.IAMUserDetails(userName))
 
+		if generated {
+			return IAMPasswordSetMsg(password)
+		}
 		return IAMSuccessMsg("Password reset successfully")
-	}
+	})
 }
 
 func (m IAMModel) toggleConsoleAccess(userName string, enable bool) tea.Cmd {
-	return func() tea.Msg {
+	return armed(func() tea.Msg {
 		client, err := aws.NewIAMClient(context.Background(), m.profile)
 		if err != nil {
 			return IAMErrorMsg(err)
 		}
+		var password string
 		if enable {
-			// Using a default temporary password for enabling
-			err = client.CreateLoginProfile(context.Background(), userName, "TempPass123!")
+			if password, err = generatePassword(client); err != nil {
+				return IAMErrorMsg(err)
+			}
+			// The user has to pick their own password on first login
+			err = client.CreateLoginProfile(context.Background(), userName, password)
 		} else {
 			err = client.DeleteLoginProfile(context.Background(), userName)
 		}
@@ -288,16 +660,15 @@ func (m IAMModel) toggleConsoleAccess(userName string, enable bool) tea.Cmd {
 		// Invalidate user details cache
 		m.cache.Delete(m.cacheKeys.IAMUserDetails(userName))
 
-		msg := "Console access disabled"
 		if enable {
-			msg = "Console access enabled (TempPass123!)"
+			return IAMPasswordSetMsg(password)
 		}
-		return IAMSuccessMsg(msg)
-	}
+		return IAMSuccessMsg("Console access disabled")
+	})
 }
 
 func (m IAMModel) createUser(name string) tea.Cmd {
-	return func() tea.Msg {
+	return armed(func() tea.Msg {
 		client, err := aws.NewIAMClient(context.Background(), m.profile)
 		if err != nil {
 			return IAMErrorMsg(err)
@@ -311,11 +682,11 @@ func (m IAMModel) createUser(name string) tea.Cmd {
 		m.cache.Delete(m.cacheKeys.IAMUsers())
 
 		return IAMSuccessMsg("User created")
-	}
+	})
 }
 
 func (m IAMModel) deleteUser(name string) tea.Cmd {
-	return func() tea.Msg {
+	return armed(func() tea.Msg {
 		client, err := aws.NewIAMClient(context.Background(), m.profile)
 		if err != nil {
 			return IAMErrorMsg(err)
@@ -330,13 +701,90 @@ func (m IAMModel) deleteUser(name string) tea.Cmd {
 		m.cache.Delete(m.cacheKeys.IAMUserDetails(name))
 
 		return IAMSuccessMsg("User deleted")
-	}
+	})
 }
 
 func (m *IAMModel) SetSize(width, height int) {
 	m.width = width
 	m.height = height
+	m.menuList.SetSize(GetInnerListSize(width, height))
 	m.list.SetSize(GetInnerListSize(width, height))
+	m.policyList.SetSize(GetInnerListSize(width, height))
+	m.keyList.SetSize(GetInnerListSize(width, height))
+	m.roleList.SetSize(GetInnerListSize(width, height))
+	m.groupList.SetSize(GetInnerListSize(width, height))
+	m.memberList.SetSize(GetInnerListSize(width, height))
+}
+
+// inputReturnState is the state to go back to when the input popup is dismissed
+func (m IAMModel) inputReturnState() IAMState {
+	switch {
+	case m.action == IAMActionAttachPolicy:
+		return IAMStatePolicies
+	case m.action == IAMActionAddToGroup:
+		return IAMStateGroupMembers
+	case m.userDetail != nil:
+		return IAMStateActions
+	default:
+		return IAMStateUsers
+	}
+}
+
+// confirmReturnState is the state a cancelled confirmation goes back to
+func (m IAMModel) confirmReturnState() IAMState {
+	switch m.state {
+	case IAMStateConfirmDetach:
+		return IAMStatePolicies
+	case IAMStateConfirmKeyAction:
+		return IAMStateAccessKeys
+	case IAMStateConfirmRemoveMember:
+		return IAMStateGroupMembers
+	default:
+		return IAMStateActions
+	}
+}
+
+// openConfirm asks before the action in the given confirmation state
+func (m *IAMModel) openConfirm(state IAMState, action IAMAction, dialog ConfirmDialog) {
+	m.state = state
+	m.action = action
+	m.confirm = dialog
+}
+
+// openDeleteUser asks to delete the selected user while counting what it owns
+func (m *IAMModel) openDeleteUser() tea.Cmd {
+	user := m.selectedUser.userName
+	m.openConfirm(IAMStateConfirmDelete, IAMActionDeleteUser, NewConfirmDialog("Confirm Deletion",
+		"Are you sure you want to delete user "+lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render(user),
+		true, func() tea.Cmd { return m.deleteUser(user) }))
+	m.deleteSummary = deleteSummary{loading: true}
+	return m.fetchUserDeleteSummary(user)
+}
+
+// setUsers fills the users table
+func (m *IAMModel) setUsers(users []aws.IAMUserInfo) {
+	items := make([]list.Item, len(users))
+	for i, u := range users {
+		lastUsed, lastLogin := "", ""
+		if u.PasswordLastUsed != nil {
+			lastUsed = u.PasswordLastUsed.Format(absoluteTimeFormat)
+			lastLogin = humanizeTime(*u.PasswordLastUsed)
+		}
+		items[i] = iamItem{
+			userName:         u.UserName,
+			userID:           u.UserID,
+			arn:              u.Arn,
+			path:             u.Path,
+			createDate:       u.CreateDate.Format("2006-01-02 15:04"),
+			passwordLastUsed: lastUsed,
+			lastLogin:        lastLogin,
+		}
+	}
+	m.table.setItems(&m.list, items)
+	// The user may have gone back to the menu while the list was loading
+	if m.state == IAMStateLoading {
+		m.state = IAMStateUsers
+	}
 }
 
 func (m IAMModel) Update(msg tea.Msg) (IAMModel, tea.Cmd) {
@@ -347,27 +795,28 @@ func (m IAMModel) Update(msg tea.Msg) (IAMModel, tea.Cmd) {
 		m.SetSize(msg.Width, msg.Height)
 
 	case IAMUsersMsg:
-		items := make([]list.Item, len(msg))
-		for i, u := range msg {
-			lastUsed := ""
-			if u.PasswordLastUsed != nil {
-				lastUsed = u.PasswordLastUsed.Format("2006-01-02 15:04")
-			}
-			items[i] = iamItem{
-				userName:         u.UserName,
-				userID:           u.UserID,
-				arn:              u.Arn,
-				path:             u.Path,
-				createDate:       u.CreateDate.Format("2006-01-02 15:04"),
-				passwordLastUsed: lastUsed,
-			}
+		m.loaded = true
+		m.loadingMore = false
+		m.usersStats = nil
+		m.setUsers(msg)
+
+	case IAMUsersPageMsg:
+		if msg.Profile != m.profile || msg.Load != m.usersLoad {
+			return m, nil
 		}
-		m.list.SetItems(items)
-		m.state = IAMStateUsers
+		m.setUsers(msg.Users)
+		m.usersStats = msg.Stats
+		if msg.Marker != nil {
+			m.loadingMore = true
+			return m, m.fetchUsersPage(msg.Users, msg.Marker, msg.Stats)
+		}
+		m.loaded = true
+		m.loadingMore = false
 
 	case IAMUserDetailsMsg:
 		m.userDetail = msg.Info
 		m.userKeys = msg.Keys
+		m.table.setItems(&m.keyList, iamKeyItems(msg.Keys))
 
 		actions := []list.Item{
 			iamActionItem{title: "Reset Password", key: "reset"},
@@ -377,14 +826,63 @@ func (m IAMModel) Update(msg tea.Msg) (IAMModel, tea.Cmd) {
 		} else {
 			actions = append(actions, iamActionItem{title: "Enable Console Access", key: "enable_console"})
 		}
-		actions = append(actions, iamActionItem{title: "Delete User", key: "delete"})
+		actions = append(actions,
+			iamActionItem{title: "Manage Policies", key: "policies"},
+			iamActionItem{title: "Manage Access Keys", key: "keys"},
+			iamActionItem{title: "Delete User", key: "delete"},
+		)
 
 		m.actionList.SetItems(actions)
 		m.actionList.SetSize(36, len(actions))
 		return m, nil
 
+	case IAMUserPoliciesMsg:
+		items := make([]list.Item, len(msg))
+		for i, p := range msg {
+			items[i] = iamPolicyItem{
+				name:   p.Name,
+				arn:    p.Arn,
+				inline: p.Inline,
+			}
+		}
+		m.table.setItems(&m.policyList, items)
+		m.policyList.ResetSelected()
+		m.policiesLoaded = true
+		return m, nil
+
+	case IAMAccessKeyCreatedMsg:
+		m.newKey = msg
+		m.action = IAMActionNone
+		m.state = IAMStateNewAccessKey
+		return m, m.fetchUserDetails(m.selectedUser.userName)
+
+	case IAMSummaryMsg:
+		m.summary = &msg
+		return m, nil
+
+	case IAMPasswordSetMsg:
+		m.newPassword = string(msg)
+		m.action = IAMActionNone
+		m.state = IAMStateNewPassword
+		return m, m.fetchUserDetails(m.selectedUser.userName)
+
 	case IAMSuccessMsg:
 		m.err = nil
+		if m.action == IAMActionActivateKey || m.action == IAMActionDeactivateKey || m.action == IAMActionDeleteKey {
+			m.action = IAMActionNone
+			m.state = IAMStateAccessKeys
+			return m, m.fetchUserDetails(m.selectedUser.userName)
+		}
+		if m.action == IAMActionAttachPolicy || m.action == IAMActionDetachPolicy {
+			m.action = IAMActionNone
+			m.state = IAMStatePolicies
+			return m, m.fetchUserPolicies(m.selectedUser.userName)
+		}
+		if m.action == IAMActionAddToGroup || m.action == IAMActionRemoveFromGroup {
+			m.action = IAMActionNone
+			m.state = IAMStateGroupMembers
+			return m, m.fetchGroupMembers(m.selectedGroup.GroupName)
+		}
 		if m.action == IAMActionResetPassword || m.action == IAMActionEnableConsole || m.action == IAMActionDisableConsole {
 			m.action = IAMActionNone
 			// Stay in Actions state while refreshing details
@@ -392,7 +890,22 @@ func (m IAMModel) Update(msg tea.Msg) (IAMModel, tea.Cmd) {
 		}
 		// Return to user list and refresh
 		m.state = IAMStateUsers
-		return m, m.fetchUsers()
+		cmd := m.fetchUsers()
+		return m, cmd
+
+	case IAMDeleteSummaryMsg:
+		if m.state == IAMStateConfirmDelete && m.selectedUser.userName == msg.UserName {
+			m.deleteSummary = userDeleteSummary(msg)
+		}
+
+	case IAMRolesMsg:
+		m.setRoles(msg)
+
+	case IAMGroupsMsg:
+		m.setGroups(msg)
+
+	case IAMGroupMembersMsg:
+		m.setGroupMembers(msg)
 
 	case IAMErrorMsg:
 		m.err = msg
@@ -407,11 +920,8 @@ func (m IAMModel) Update(msg tea.Msg) (IAMModel, tea.Cmd) {
 			switch msg.String() {
 			case "enter":
 				name := m.input.Value()
-				if name == "" {
-					m.state = IAMStateUsers
-					if m.userDetail != nil {
-						m.state = IAMStateActions
-					}
+				if name == "" && m.action != IAMActionResetPassword {
+					m.state = m.inputReturnState()
 					return m, nil
 				}
 				var actionCmd tea.Cmd
@@ -419,39 +929,152 @@ func (m IAMModel) Update(msg tea.Msg) (IAMModel, tea.Cmd) {
 					actionCmd = m.createUser(name)
 				} else if m.action == IAMActionResetPassword {
 					actionCmd = m.resetPassword(m.selectedUser.userName, name)
+				} else if m.action == IAMActionAttachPolicy {
+					actionCmd = m.attachPolicy(m.selectedUser.userName, name)
+				} else if m.action == IAMActionAddToGroup {
+					actionCmd = m.addUserToGroup(m.selectedGroup.GroupName, name)
 				}
 				m.input.Reset()
 				return m, actionCmd
 			case "esc":
-				m.state = IAMStateUsers
-				if m.userDetail != nil {
-					m.state = IAMStateActions
-				}
+				m.state = m.inputReturnState()
+				m.input.Reset()
 				return m, nil
 			}
 			m.input, cmd = m.input.Update(msg)
 			return m, cmd
 		}
 
-		if m.state == IAMStateConfirmDelete {
+		switch m.state {
+		case IAMStateConfirmDelete, IAMStateConfirmConsoleToggle, IAMStateConfirmDetach, IAMStateConfirmKeyAction, IAMStateConfirmRemoveMember:
+			result, cmd := m.confirm.Update(msg)
+			if result == confirmCancelled {
+				m.state = m.confirmReturnState()
+				m.action = IAMActionNone
+			}
+			return m, cmd
+		}
+
+		if m.state == IAMStateMenu {
+			if msg.String() == "enter" {
+				if item, ok := m.menuList.SelectedItem().(iamMenuItem); ok {
+					switch item.title {
+					case "Users":
+						m.state = IAMStateLoading
+						cmd := m.fetchUsers()
+						return m, cmd
+					case "Roles":
+						m.state = IAMStateRoles
+						return m, m.fetchRoles()
+					case "Groups":
+						m.state = IAMStateGroups
+						return m, m.fetchGroups()
+					case "Security Summary":
+						m.state = IAMStateSummary
+						m.summary = nil
+						return m, m.fetchSummary()
+					}
+				}
+				return m, nil
+			}
+			m.menuList, cmd = m.menuList.Update(msg)
+			return m, cmd
+		}
+
+		if m.state == IAMStateRoles {
+			return m.updateRoles(msg)
+		}
+
+		if m.state == IAMStateRoleDetail {
+			return m.updateRoleDetail(msg)
+		}
+
+		if m.state == IAMStateGroups {
+			return m.updateGroups(msg)
+		}
+
+		if m.state == IAMStateGroupMembers {
+			return m.updateGroupMembers(msg)
+		}
+
+		if m.state == IAMStateSummary {
+			switch msg.String() {
+			case "r":
+				m.summary = nil
+				return m, m.fetchSummary()
+			case "esc", "backspace":
+				m.state = IAMStateMenu
+			}
+			return m, nil
+		}
+
+		if m.state == IAMStateNewPassword || m.state == IAMStateNewAccessKey {
+			// Drop the credential as soon as the user has seen it
+			m.clearCredentials()
+			return m, nil
+		}
+
+		if m.state == IAMStateAccessKeys {
 			switch msg.String() {
-			case "y", "Y":
-				return m, m.deleteUser(m.selectedUser.userName)
-			default:
+			case "n":
+				m.action = IAMActionCreateKey
+				return m, m.createAccessKey(m.selectedUser.userName)
+			case "t":
+				if item, ok := m.keyList.SelectedItem().(iamKeyItem); ok {
+					if item.status == "Active" {
+						m.openConfirm(IAMStateConfirmKeyAction, IAMActionDeactivateKey, m.keyActionDialog(item.id, IAMActionDeactivateKey))
+						return m, nil
+					}
+					m.action = IAMActionActivateKey
+					return m, m.updateAccessKey(m.selectedUser.userName, item.id, m.action)
+				}
+				return m, nil
+			case "d":
+				if item, ok := m.keyList.SelectedItem().(iamKeyItem); ok {
+					m.openConfirm(IAMStateConfirmKeyAction, IAMActionDeleteKey, m.keyActionDialog(item.id, IAMActionDeleteKey))
+				}
+				return m, nil
+			case "r":
+				m.cache.Delete(m.cacheKeys.IAMUserDetails(m.selectedUser.userName))
+				return m, m.fetchUserDetails(m.selectedUser.userName)
+			case "esc", "backspace":
 				m.state = IAMStateActions
 				return m, nil
 			}
+			m.keyList, cmd = m.keyList.Update(msg)
+			return m, cmd
 		}
 
-		if m.state == IAMStateConfirmConsoleToggle {
+		if m.state == IAMStatePolicies {
 			switch msg.String() {
-			case "y", "Y":
-				enable := m.action == IAMActionEnableConsole
-				return m, m.toggleConsoleAccess(m.selectedUser.userName, enable)
-			default:
+			case "a":
+				m.state = IAMStateInput
+				m.action = IAMActionAttachPolicy
+				m.input.Placeholder = "Policy ARN"
+				m.input.Focus()
+				return m, nil
+			case "d":
+				if item, ok := m.policyList.SelectedItem().(iamPolicyItem); ok {
+					if item.inline {
+						m.err = fmt.Errorf("inline policy %s can't be detached", item.name)
+						return m, nil
+					}
+					user, arn := m.selectedUser.userName, item.arn
+					m.openConfirm(IAMStateConfirmDetach, IAMActionDetachPolicy, NewConfirmDialog("Confirm Detach", fmt.Sprintf("Detach %s from %s?",
+						lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render(item.name),
+						lipgloss.NewStyle().Foreground(m.styles.Accent).Bold(true).Render(user),
+					), true, func() tea.Cmd { return m.detachPolicy(user, arn) }))
+				}
+				return m, nil
+			case "r":
+				m.cache.Delete(m.cacheKeys.IAMUserPolicies(m.selectedUser.userName))
+				return m, m.fetchUserPolicies(m.selectedUser.userName)
+			case "esc", "backspace":
 				m.state = IAMStateActions
 				return m, nil
 			}
+			m.policyList, cmd = m.policyList.Update(msg)
+			return m, cmd
 		}
 
 		if m.state == IAMStateActions {
@@ -462,17 +1085,23 @@ func (m IAMModel) Update(msg tea.Msg) (IAMModel, tea.Cmd) {
 					case "reset":
 						m.state = IAMStateInput
 						m.action = IAMActionResetPassword
-						m.input.Placeholder = "New password"
+						m.input.Placeholder = "New password (empty to generate)"
 						m.input.Focus()
 					case "enable_console":
-						m.state = IAMStateConfirmConsoleToggle
-						m.action = IAMActionEnableConsole
+						m.openConfirm(IAMStateConfirmConsoleToggle, IAMActionEnableConsole, m.consoleToggleDialog(true))
 					case "disable_console":
-						m.state = IAMStateConfirmConsoleToggle
-						m.action = IAMActionDisableConsole
+						m.openConfirm(IAMStateConfirmConsoleToggle, IAMActionDisableConsole, m.consoleToggleDialog(false))
+					case "policies":
+						m.state = IAMStatePolicies
+						m.table.setItems(&m.policyList, nil)
+						m.policiesLoaded = false
+						return m, m.fetchUserPolicies(m.selectedUser.userName)
+					case "keys":
+						m.state = IAMStateAccessKeys
+						m.table.setItems(&m.keyList, iamKeyItems(m.userKeys))
 					case "delete":
-						m.state = IAMStateConfirmDelete
-						m.action = IAMActionDeleteUser
+						cmd := m.openDeleteUser()
+						return m, cmd
 					}
 					return m, nil
 				}
@@ -489,7 +1118,8 @@ func (m IAMModel) Update(msg tea.Msg) (IAMModel, tea.Cmd) {
 		case "r": // Manual refresh for IAM
 			if m.state == IAMStateUsers {
 				m.cache.Delete(m.cacheKeys.IAMUsers())
-				return m, m.fetchUsers()
+				cmd := m.fetchUsers()
+				return m, cmd
 			} else if m.state == IAMStateActions && m.userDetail != nil {
 				m.cache.Delete(m.cacheKeys.IAMUserDetails(m.selectedUser.userName))
 				return m, m.fetchUserDetails(m.selectedUser.userName)
@@ -504,6 +1134,8 @@ func (m IAMModel) Update(msg tea.Msg) (IAMModel, tea.Cmd) {
 					actions := []list.Item{
 						iamActionItem{title: "Reset Password", key: "reset"},
 						iamActionItem{title: "Toggle Console Access", key: "toggle_console"},
+						iamActionItem{title: "Manage Policies", key: "policies"},
+						iamActionItem{title: "Manage Access Keys", key: "keys"},
 						iamActionItem{title: "Delete User", key: "delete"},
 					}
 					m.actionList.SetItems(actions)
@@ -518,6 +1150,10 @@ func (m IAMModel) Update(msg tea.Msg) (IAMModel, tea.Cmd) {
 				m.userDetail = nil
 				return m, nil
 			}
+			if m.state == IAMStateUsers || m.state == IAMStateLoading {
+				m.state = IAMStateMenu
+				return m, nil
+			}
 		case "n":
 			if m.state == IAMStateUsers {
 				m.state = IAMStateInput
@@ -529,9 +1165,8 @@ func (m IAMModel) Update(msg tea.Msg) (IAMModel, tea.Cmd) {
 		case "d":
 			if item, ok := m.list.SelectedItem().(iamItem); ok {
 				m.selectedUser = item
-				m.state = IAMStateConfirmDelete
-				m.action = IAMActionDeleteUser
-				return m, nil
+				cmd := m.openDeleteUser()
+				return m, cmd
 			}
 		}
 	}
@@ -540,12 +1175,88 @@ func (m IAMModel) Update(msg tea.Msg) (IAMModel, tea.Cmd) {
 	return m, cmd
 }
 
+// iamResourceNames names the resources listed in each table state, for the empty-state message
+var iamResourceNames = map[IAMState]string{
+	IAMStateUsers: "IAM users",
+}
+
+// clearCredentials drops a generated password or access key secret and
+// closes the popup showing it
+func (m *IAMModel) clearCredentials() {
+	switch m.state {
+	case IAMStateNewPassword:
+		m.state = IAMStateActions
+	case IAMStateNewAccessKey:
+		m.state = IAMStateAccessKeys
+	}
+	m.newPassword = ""
+	m.newKey = nil
+}
+
+// columns are the columns of the table on screen, following the states View
+// renders each table in
+func (m IAMModel) columns() []Column {
+	switch m.state {
+	case IAMStateMenu, IAMStateSummary, IAMStateRoleDetail:
+		return nil
+	case IAMStateRoles:
+		return iamRoleColumns
+	case IAMStateGroups:
+		return iamGroupColumns
+	case IAMStateGroupMembers, IAMStateConfirmRemoveMember:
+		return iamMemberColumns
+	case IAMStatePolicies, IAMStateConfirmDetach:
+		return iamPolicyColumns
+	case IAMStateAccessKeys, IAMStateConfirmKeyAction, IAMStateNewAccessKey:
+		return iamKeyColumns
+	case IAMStateInput:
+		switch m.action {
+		case IAMActionAddToGroup:
+			return iamMemberColumns
+		case IAMActionAttachPolicy:
+			return iamPolicyColumns
+		}
+	}
+	return iamColumns
+}
+
 func (m IAMModel) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
 	}
 
-	_, header := RenderTableHelpers(m.list, m.styles, iamColumns)
+	if resource, ok := iamResourceNames[m.state]; ok && m.loaded && len(m.list.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.list, resource, m.profile, m.table)
+	}
+
+	switch m.state {
+	case IAMStateMenu:
+		return m.menuList.View()
+	case IAMStateSummary:
+		return m.renderSummary()
+	case IAMStateRoles:
+		return m.renderRoles()
+	case IAMStateRoleDetail:
+		return m.renderRoleDetail()
+	case IAMStateGroups:
+		return m.renderGroups()
+	}
+
+	if m.state == IAMStateGroupMembers || m.state == IAMStateConfirmRemoveMember ||
+		(m.state == IAMStateInput && m.action == IAMActionAddToGroup) {
+		return m.renderGroupMembers()
+	}
+
+	if m.state == IAMStatePolicies || m.state == IAMStateConfirmDetach ||
+		(m.state == IAMStateInput && m.action == IAMActionAttachPolicy) {
+		return m.renderPolicies()
+	}
+
+	if m.state == IAMStateAccessKeys || m.state == IAMStateConfirmKeyAction || m.state == IAMStateNewAccessKey {
+		return m.renderAccessKeys()
+	}
+
+	_, header := RenderTableHelpers(m.list, m.styles, iamColumns, m.table)
 
 	switch m.state {
 	case IAMStateLoading:
@@ -563,25 +1274,22 @@ func (m IAMModel) View() string {
 		)), m.width, m.height)
 
 	case IAMStateConfirmDelete:
-		return RenderOverlay(header+"\n"+m.list.View(), m.styles.Popup.Width(40).BorderForeground(ErrorColor).Render(fmt.Sprintf(
-			" %s\n\n %s %s\n\n %s",
-			m.styles.Error.Bold(true).Render("⚠ Confirm Deletion"),
-			"Are you sure you want to delete user",
-			lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render(m.selectedUser.userName),
-			m.styles.StatusMuted.Render("(y/n)"),
-		)), m.width, m.height)
+		confirm := m.confirm
+		confirm.Detail = renderDeleteSummary(m.styles, m.deleteSummary)
+		return RenderOverlay(header+"\n"+m.list.View(), confirm.View(m.styles), m.width, m.height)
 
 	case IAMStateConfirmConsoleToggle:
-		action := "enable"
-		if m.action == IAMActionDisableConsole {
-			action = "disable"
-		}
-		return RenderOverlay(header+"\n"+m.list.View(), m.styles.Popup.Width(40).Render(fmt.Sprintf(
-			" %s\n\n Are you sure you want to %s console access for %s?\n\n %s",
-			lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render("⚠ Confirm Console Access Toggle"),
-			action,
-			lipgloss.NewStyle().Foreground(m.styles.Accent).Bold(true).Render(m.selectedUser.userName),
-			m.styles.StatusMuted.Render("(y/n)"),
+		return RenderOverlay(header+"\n"+m.list.View(), m.confirm.View(m.styles), m.width, m.height)
+
+	case IAMStateNewPassword:
+		return RenderOverlay(header+"\n"+m.list.View(), m.styles.Popup.Width(50).BorderForeground(m.styles.WarningColor).Render(fmt.Sprintf(
+			" %s\n\n %s\n %s\n\n %s\n %s\n\n %s",
+			m.styles.Success.Bold(true).Render("✔ Password set for "+m.selectedUser.userName),
+			lipgloss.NewStyle().Foreground(m.styles.Muted).Render("Temporary password"),
+			lipgloss.NewStyle().Foreground(m.styles.Snow).Bold(true).Render(m.newPassword),
+			m.styles.Warning.Bold(true).Render("⚠ It will NOT be shown again."),
+			m.styles.StatusMuted.Render("A new password is required at first sign-in."),
+			m.styles.StatusMuted.Render("(press any key to close)"),
 		)), m.width, m.height)
 
 	case IAMStateActions:
@@ -619,6 +1327,94 @@ func (m IAMModel) View() string {
 	}
 }
 
+// renderPolicies renders the policies table with the attach/detach popups on top
+func (m IAMModel) renderPolicies() string {
+	var base string
+	if !m.policiesLoaded {
+		_, header := RenderTableHelpers(m.policyList, m.styles, iamPolicyColumns, m.table)
+		base = header + "\n\n  " + lipgloss.NewStyle().Foreground(m.styles.Primary).Render("󱎯 Loading...")
+	} else if len(m.policyList.Items()) == 0 {
+		base = RenderEmptyState(m.styles, m.policyList, "attached policies", m.profile, m.table)
+	} else {
+		_, header := RenderTableHelpers(m.policyList, m.styles, iamPolicyColumns, m.table)
+		base = header + "\n" + m.policyList.View()
+	}
+
+	switch m.state {
+	case IAMStateInput:
+		return RenderOverlay(base, m.styles.Popup.Width(60).Render(fmt.Sprintf(
+			" %s\n\n %s\n\n %s",
+			lipgloss.NewStyle().Foreground(m.styles.Primary).Render("Attach managed policy"),
+			m.input.View(),
+			m.styles.StatusMuted.Render("(esc to cancel)"),
+		)), m.width, m.height)
+
+	case IAMStateConfirmDetach:
+		return RenderOverlay(base, m.confirm.View(m.styles), m.width, m.height)
+	}
+
+	return base
+}
+
+// keyActionDialog asks before deactivating or deleting an access key
+func (m *IAMModel) keyActionDialog(keyID string, action IAMAction) ConfirmDialog {
+	user := m.selectedUser.userName
+	title, verb := "Confirm Deletion", "Delete"
+	if action == IAMActionDeactivateKey {
+		title, verb = "Confirm Deactivation", "Deactivate"
+	}
+	return NewConfirmDialog(title, fmt.Sprintf("%s access key %s?", verb, lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render(keyID)),
+		true, func() tea.Cmd { return m.updateAccessKey(user, keyID, action) })
+}
+
+// consoleToggleDialog asks before enabling or disabling console access;
+// disabling cuts the user off, so only that is marked dangerous
+func (m *IAMModel) consoleToggleDialog(enable bool) ConfirmDialog {
+	user := m.selectedUser.userName
+	verb := "enable"
+	if !enable {
+		verb = "disable"
+	}
+	return NewConfirmDialog("Confirm Console Access Toggle", fmt.Sprintf("Are you sure you want to %s console access for %s?",
+		verb, lipgloss.NewStyle().Foreground(m.styles.Accent).Bold(true).Render(user),
+	), !enable, func() tea.Cmd { return m.toggleConsoleAccess(user, enable) })
+}
+
+// renderAccessKeys renders the access keys table with confirmations and the one-time secret popup
+func (m IAMModel) renderAccessKeys() string {
+	var base string
+	if len(m.keyList.Items()) == 0 {
+		base = RenderEmptyState(m.styles, m.keyList, "access keys", m.profile, m.table)
+	} else {
+		_, header := RenderTableHelpers(m.keyList, m.styles, iamKeyColumns, m.table)
+		base = header + "\n" + m.keyList.View()
+	}
+
+	switch m.state {
+	case IAMStateNewAccessKey:
+		if m.newKey == nil {
+			return base
+		}
+		labelStyle := lipgloss.NewStyle().Foreground(m.styles.Muted)
+		valueStyle := lipgloss.NewStyle().Foreground(m.styles.Snow).Bold(true)
+		return RenderOverlay(base, m.styles.Popup.Width(64).BorderForeground(m.styles.WarningColor).Render(fmt.Sprintf(
+			" %s\n\n %s\n %s\n\n %s\n %s\n\n %s\n\n %s",
+			m.styles.Success.Bold(true).Render("✔ Access key created"),
+			labelStyle.Render("Access Key ID"),
+			valueStyle.Render(m.newKey.AccessKeyId),
+			labelStyle.Render("Secret Access Key"),
+			valueStyle.Render(m.newKey.SecretAccessKey),
+			m.styles.Warning.Bold(true).Render("⚠ Copy the secret now. It will NOT be shown again."),
+			m.styles.StatusMuted.Render("(press any key to close)"),
+		)), m.width, m.height)
+
+	case IAMStateConfirmKeyAction:
+		return RenderOverlay(base, m.confirm.View(m.styles), m.width, m.height)
+	}
+
+	return base
+}
+
 func (m IAMModel) renderUserDetails() string {
 	var s strings.Builder
 	// Header moved to global header
@@ -646,7 +1442,7 @@ func (m IAMModel) renderUserDetails() string {
 	s.WriteString(labelStyle.Render("Console Access:  ") + consoleStatus + "\n")
 
 	if m.userDetail.PasswordLastUsed != nil {
-		s.WriteString(labelStyle.Render("Last Login:      ") + valueStyle.Render(m.userDetail.PasswordLastUsed.Format("2006-01-02 15:04")) + "\n")
+		s.WriteString(labelStyle.Render("Last Login:      ") + valueStyle.Render(m.userDetail.PasswordLastUsed.Format(absoluteTimeFormat)) + "\n")
 	} else {
 		s.WriteString(labelStyle.Render("Last Login:      ") + valueStyle.Render("Never") + "\n")
 	}
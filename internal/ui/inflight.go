@@ -0,0 +1,32 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+// inFlightMsg carries the number of AWS requests currently running
+type inFlightMsg int
+
+// waitForInFlight blocks until the number of running AWS requests changes.
+// It is re-issued after every change, so exactly one is waiting at a time.
+func waitForInFlight() tea.Cmd {
+	return func() tea.Msg {
+		<-aws.InFlightChanged()
+		return inFlightMsg(aws.InFlight())
+	}
+}
+
+// renderInFlight renders the outstanding request counter for the header
+func (m Model) renderInFlight() string {
+	if m.inFlight == 0 {
+		return ""
+	}
+	label := "requests"
+	if m.inFlight == 1 {
+		label = "request"
+	}
+	return m.styles.StatusMuted.Render(" | ") + m.styles.Warning.Render(fmt.Sprintf("⟳ %d %s", m.inFlight, label))
+}
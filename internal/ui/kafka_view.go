@@ -14,29 +14,36 @@ import (
 type MSKState int
 
 const (
-	MSKStateClusters MSKState = iota
+	MSKStateMenu MSKState = iota
+	MSKStateClusters
+	MSKStateConfigurations
 )
 
 type mskItem struct {
 	title       string
 	description string
 	id          string
+	category    string
 	values      []string
 }
 
 func (i mskItem) Title() string       { return i.title }
 func (i mskItem) Description() string { return i.description }
 func (i mskItem) FilterValue() string { return i.title + " " + i.description + " " + i.id }
+func (i mskItem) rowID() string       { return i.id }
+func (i mskItem) rowValues() []string { return i.values }
 
 type MSKModel struct {
 	client    *aws.MSKClient
 	list      list.Model
 	styles    Styles
+	table     *tableLayout
 	state     MSKState
 	width     int
 	height    int
 	profile   string
 	err       error
+	loaded    bool
 	cache     *cache.Cache
 	cacheKeys *cache.KeyBuilder
 }
@@ -44,6 +51,7 @@ type MSKModel struct {
 type mskItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 	state  MSKState
 }
 
@@ -55,39 +63,60 @@ var mskClusterColumns = []Column{
 	{Title: "ARN", Width: 0.3},
 }
 
+var mskConfigurationColumns = []Column{
+	{Title: "Name", Width: 0.3},
+	{Title: "Description", Width: 0.4},
+	{Title: "Latest Revision", Width: 0.15},
+	{Title: "State", Width: 0.15},
+}
+
 func (d mskItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
 	i, ok := listItem.(mskItem)
 	if !ok {
 		return
 	}
 
+	if d.state == MSKStateMenu {
+		d.table.renderMenuItem(d.DefaultDelegate, w, m, index, listItem)
+		return
+	}
+
 	var columns []Column
 	switch d.state {
 	case MSKStateClusters:
 		columns = mskClusterColumns
+	case MSKStateConfigurations:
+		columns = mskConfigurationColumns
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, columns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, columns, d.table)
 	isSelected := index == m.Index()
 
-	RenderTableRow(w, m, d.styles, colStyles, i.values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, i.values, isSelected)
 }
 
 func (d mskItemDelegate) Height() int {
+	if d.state == MSKStateMenu {
+		return d.table.menuItemHeight()
+	}
 	return 1
 }
 
-func NewMSKModel(profile string, styles Styles, appCache *cache.Cache) MSKModel {
+func (d mskItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewMSKModel(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) MSKModel {
+	table := &tableLayout{display: display}
 	d := mskItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
-		state:           MSKStateClusters,
+		table:           table,
+		state:           MSKStateMenu,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
 	d.Styles.SelectedDesc = styles.ListSelectedDesc
 
 	l := list.New([]list.Item{}, d, 0, 0)
-	l.Title = "MSK Clusters"
+	l.Title = "MSK Resources"
 	l.SetShowStatusBar(false)
 	l.SetShowHelp(false)
 	l.SetShowTitle(false)
@@ -95,7 +124,8 @@ func NewMSKModel(profile string, styles Styles, appCache *cache.Cache) MSKModel
 	return MSKModel{
 		list:      l,
 		styles:    styles,
-		state:     MSKStateClusters,
+		table:     table,
+		state:     MSKStateMenu,
 		profile:   profile,
 		cache:     appCache,
 		cacheKeys: cache.NewKeyBuilder(profile),
@@ -103,10 +133,22 @@ func NewMSKModel(profile string, styles Styles, appCache *cache.Cache) MSKModel
 }
 
 type MSKClustersMsg []aws.ClusterInfo
+type MSKConfigurationsMsg []aws.MSKConfigurationInfo
 type MSKErrorMsg error
+type MSKMenuMsg []list.Item
 
 func (m MSKModel) Init() tea.Cmd {
-	return m.fetchClusters()
+	return m.showMenu()
+}
+
+func (m MSKModel) showMenu() tea.Cmd {
+	return func() tea.Msg {
+		items := []list.Item{
+			mskItem{title: "Clusters", description: "Provisioned and Serverless Clusters", category: "menu"},
+			mskItem{title: "Configurations", description: "Broker Configurations", category: "menu"},
+		}
+		return MSKMenuMsg(items)
+	}
 }
 
 func (m MSKModel) fetchClusters() tea.Cmd {
@@ -130,6 +172,27 @@ func (m MSKModel) fetchClusters() tea.Cmd {
 	}
 }
 
+func (m MSKModel) fetchConfigurations() tea.Cmd {
+	return func() tea.Msg {
+		if cached, ok := m.cache.Get(m.cacheKeys.MSKResources("configurations")); ok {
+			if configurations, ok := cached.([]aws.MSKConfigurationInfo); ok {
+				return MSKConfigurationsMsg(configurations)
+			}
+		}
+
+		client, err := aws.NewMSKClient(context.Background(), m.profile)
+		if err != nil {
+			return MSKErrorMsg(err)
+		}
+		configurations, err := client.ListConfigurations(context.Background())
+		if err != nil {
+			return MSKErrorMsg(err)
+		}
+		m.cache.Set(m.cacheKeys.MSKResources("configurations"), configurations, cache.TTLMSKResources)
+		return MSKConfigurationsMsg(configurations)
+	}
+}
+
 func (m MSKModel) Update(msg tea.Msg) (MSKModel, tea.Cmd) {
 	var cmd tea.Cmd
 
@@ -139,19 +202,48 @@ func (m MSKModel) Update(msg tea.Msg) (MSKModel, tea.Cmd) {
 		m.height = msg.Height
 		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
+	case MSKMenuMsg:
+		m.table.setItems(&m.list, msg)
+		m.list.ResetSelected()
+		m.state = MSKStateMenu
+		m.updateDelegate()
+
 	case MSKClustersMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			items[i] = mskItem{
 				title:       v.Name,
 				description: v.ARN,
 				id:          v.ARN,
-				values:      []string{v.Name, v.Status, v.EngineVersion, fmt.Sprintf("%d", v.Nodes), v.ARN},
+				category:    "cluster",
+				values:      []string{v.Name, renderStatus(m.styles, v.Status), v.EngineVersion, fmt.Sprintf("%d", v.Nodes), v.ARN},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = MSKStateClusters
+		m.updateDelegate()
+
+	case MSKConfigurationsMsg:
+		m.loaded = true
+		items := make([]list.Item, len(msg))
+		for i, v := range msg {
+			items[i] = mskItem{
+				title:       v.Name,
+				description: v.Description,
+				id:          v.ARN,
+				category:    "configuration",
+				values:      []string{v.Name, v.Description, fmt.Sprintf("%d", v.LatestRevision), renderStatus(m.styles, v.State)},
+			}
+		}
+		m.table.setItems(&m.list, items)
+		m.list.ResetSelected()
+		m.state = MSKStateConfigurations
+		m.updateDelegate()
+
+	case MSKBootstrapMsg:
+		return m, copyToClipboard(string(msg), "bootstrap brokers")
 
 	case MSKErrorMsg:
 		m.err = msg
@@ -163,9 +255,34 @@ func (m MSKModel) Update(msg tea.Msg) (MSKModel, tea.Cmd) {
 		}
 
 		switch msg.String() {
+		case "y":
+			if item, ok := m.list.SelectedItem().(mskItem); ok && m.state == MSKStateClusters && !m.list.SettingFilter() {
+				return m, m.fetchBootstrapBrokers(item.id)
+			}
 		case "r":
-			m.cache.Delete(m.cacheKeys.MSKResources("clusters"))
-			return m, m.fetchClusters()
+			switch m.state {
+			case MSKStateClusters:
+				m.cache.Delete(m.cacheKeys.MSKResources("clusters"))
+				return m, m.fetchClusters()
+			case MSKStateConfigurations:
+				m.cache.Delete(m.cacheKeys.MSKResources("configurations"))
+				return m, m.fetchConfigurations()
+			}
+		case "enter":
+			if item, ok := m.list.SelectedItem().(mskItem); ok {
+				if m.state == MSKStateMenu {
+					switch item.title {
+					case "Clusters":
+						return m, m.fetchClusters()
+					case "Configurations":
+						return m, m.fetchConfigurations()
+					}
+				}
+			}
+		case "backspace", "esc":
+			if m.state != MSKStateMenu {
+				return m, m.showMenu()
+			}
 		}
 	}
 
@@ -173,18 +290,50 @@ func (m MSKModel) Update(msg tea.Msg) (MSKModel, tea.Cmd) {
 	return m, cmd
 }
 
-func (m MSKModel) View() string {
-	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+func (m *MSKModel) updateDelegate() {
+	d := mskItemDelegate{
+		DefaultDelegate: list.NewDefaultDelegate(),
+		styles:          m.styles,
+		table:           m.table,
+		state:           m.state,
 	}
+	d.Styles.SelectedTitle = m.styles.ListSelectedTitle
+	d.Styles.SelectedDesc = m.styles.ListSelectedDesc
+	m.list.SetDelegate(d)
+}
 
-	var columns []Column
+// mskResourceNames names the resources listed in each table state, for the empty-state message
+var mskResourceNames = map[MSKState]string{
+	MSKStateClusters:       "MSK clusters",
+	MSKStateConfigurations: "MSK configurations",
+}
+
+// columns are the columns of the table on screen
+func (m MSKModel) columns() []Column {
 	switch m.state {
 	case MSKStateClusters:
-		columns = mskClusterColumns
+		return mskClusterColumns
+	case MSKStateConfigurations:
+		return mskConfigurationColumns
 	}
-	_, header := RenderTableHelpers(m.list, m.styles, columns)
-	return header + "\n" + m.list.View()
+	return nil
+}
+
+func (m MSKModel) View() string {
+	if m.err != nil {
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
+	}
+
+	if resource, ok := mskResourceNames[m.state]; ok && m.loaded && len(m.list.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.list, resource, m.profile, m.table)
+	}
+
+	if m.state != MSKStateMenu {
+		_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
+		return header + "\n" + m.list.View()
+	}
+
+	return m.list.View()
 }
 
 func (m *MSKModel) SetSize(width, height int) {
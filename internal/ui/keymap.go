@@ -0,0 +1,193 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultKeys are the remappable global actions and the keys the handlers
+// compare against. Remapped keys are translated to these before dispatch, so
+// the handlers keep matching their own key strings.
+var defaultKeys = map[string]string{
+	"command":      ":",
+	"refresh":      "r",
+	"auto_refresh": "a",
+	"clear_cache":  "R",
+	"profile":      "p",
+	"recent":       "H",
+	"ops_log":      "L",
+	"columns":      "C",
+	"tag_filter":   "T",
+	"full_arns":    "A",
+	"pin":          "P",
+	"jump":         "J",
+	"compact":      "z",
+	"scroll_left":  "[",
+	"scroll_right": "]",
+	"quit":         "q",
+}
+
+// viewKeys are the remappable actions of the views. Each keeps its meaning
+// across the views that have it, and only applies where isMutatingKey (or
+// the tag editor) takes its default key as the action: n still cancels a
+// confirmation and moves to the next search match.
+var viewKeys = map[string]string{
+	"new":    "n",
+	"delete": "d",
+	"edit":   "e",
+	"add":    "a",
+}
+
+// keyHandlers are the handlers a key pressed may skip
+type keyHandlers int
+
+const (
+	globalHandlers keyHandlers = 1 << iota
+	viewHandlers
+)
+
+// keymap translates the keys pressed into the default keys of their actions
+type keymap struct {
+	// translate maps a key bound to a global action to its default key
+	translate map[string]string
+	// translateView maps a key bound to a view action to its default key
+	translateView map[string]string
+	// freed holds the default keys of remapped global actions, which the
+	// global handlers skip
+	freed map[string]bool
+	// freedView holds the default keys of remapped view actions, which the
+	// views skip where the action applies
+	freedView map[string]bool
+	// keys maps each action to the key it is bound to
+	keys map[string]string
+}
+
+// newKeymap builds the keymap from the action-to-key bindings of the config
+func newKeymap(bindings map[string]string) (keymap, error) {
+	km := keymap{
+		translate:     make(map[string]string),
+		translateView: make(map[string]string),
+		freed:         make(map[string]bool),
+		freedView:     make(map[string]bool),
+		keys:          make(map[string]string, len(defaultKeys)+len(viewKeys)),
+	}
+	maps.Copy(km.keys, defaultKeys)
+	maps.Copy(km.keys, viewKeys)
+
+	bound := make(map[string]string)
+	for _, action := range slices.Sorted(maps.Keys(bindings)) {
+		key := bindings[action]
+		translate := km.translate
+		def, ok := defaultKeys[action]
+		if !ok {
+			translate = km.translateView
+			if def, ok = viewKeys[action]; !ok {
+				return keymap{}, fmt.Errorf("unknown action %q in keys config (known: %s)", action, strings.Join(slices.Sorted(maps.Keys(km.keys)), ", "))
+			}
+		}
+		if key == "" {
+			return keymap{}, fmt.Errorf("no key given for action %q in keys config", action)
+		}
+		if other, ok := bound[key]; ok {
+			return keymap{}, fmt.Errorf("key %q is bound to both %q and %q", key, other, action)
+		}
+		bound[key] = action
+		km.keys[action] = key
+		translate[key] = def
+	}
+
+	// The default key of a remapped action no longer triggers it, unless it
+	// was bound to another action of the same kind
+	for action, def := range defaultKeys {
+		if _, ok := km.translate[def]; !ok && km.keys[action] != def {
+			km.freed[def] = true
+		}
+	}
+	for action, def := range viewKeys {
+		if _, ok := km.translateView[def]; !ok && km.keys[action] != def {
+			km.freedView[def] = true
+		}
+	}
+	return km, nil
+}
+
+// key returns the key bound to the action, for footer hints
+func (km keymap) key(action string) string {
+	if key, ok := km.keys[action]; ok {
+		return key
+	}
+	return defaultKeys[action]
+}
+
+// remapKey translates a remapped key to the default key of its action and
+// tells which handlers skip it. The freed default key of a remapped global
+// action is left untranslated for the views: moving auto_refresh off a keeps
+// a attaching policies in IAM. A view action is translated only for the
+// views, where it applies, and its freed default key then only reaches the
+// global handlers. Keys typed into inputs are never translated.
+func (m *Model) remapKey(msg tea.KeyMsg) (tea.KeyMsg, keyHandlers) {
+	if m.typing() {
+		return msg, 0
+	}
+	key := msg.String()
+	if def, ok := m.keys.translateView[key]; ok && m.viewActionApplies(def) {
+		return keyMsgFor(def), globalHandlers
+	}
+	if def, ok := m.keys.translate[key]; ok {
+		return keyMsgFor(def), 0
+	}
+
+	var skip keyHandlers
+	if m.keys.freed[key] {
+		skip |= globalHandlers
+	}
+	if m.keys.freedView[key] && m.viewActionApplies(key) {
+		skip |= viewHandlers
+	}
+	return msg, skip
+}
+
+// viewActionApplies reports whether the default key of a view action runs
+// the action in the view or tag editor on screen
+func (m *Model) viewActionApplies(def string) bool {
+	if m.tagEditorActive {
+		return m.tagEditor.mode == tagEditorBrowse && (def == "a" || def == "e" || def == "d")
+	}
+	return m.isMutatingKey(keyMsgFor(def))
+}
+
+// globalKey is the key the global handlers match: the key pressed, or none
+// for a key that only reaches the views
+func (m *Model) globalKey(msg tea.KeyMsg) string {
+	if m.keySkips&globalHandlers != 0 {
+		return ""
+	}
+	return msg.String()
+}
+
+// typing reports whether keys are going to a text input
+func (m *Model) typing() bool {
+	if m.isInputFocused() || m.paletteActive || m.tagFilterActive || m.testEventActive {
+		return true
+	}
+	if m.tagEditorActive && m.tagEditor.typing() {
+		return true
+	}
+	if m.profileSelector.active && m.profileSelector.list.FilterState() == list.Filtering {
+		return true
+	}
+	if l := m.activeList(); l != nil && l.FilterState() == list.Filtering {
+		return true
+	}
+	return false
+}
+
+// keyMsgFor builds the key message of a default key; all of them are runes
+func keyMsgFor(key string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
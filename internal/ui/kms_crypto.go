@@ -0,0 +1,159 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+// KMSCryptoMsg carries the outcome of an encrypt or decrypt. Plaintext is only
+// ever held by the model while the result is on screen, never cached.
+type KMSCryptoMsg struct {
+	decrypted bool
+	value     string
+}
+
+// openCrypto prompts for the text to encrypt or the ciphertext to decrypt
+// under the selected key
+func (m *KMSModel) openCrypto(decrypt bool) {
+	item, ok := m.list.SelectedItem().(kmsItem)
+	if !ok {
+		return
+	}
+	m.cryptoKey = item
+	m.input = textinput.New()
+	m.input.CharLimit = 8192
+	m.input.Width = 60
+	m.input.Focus()
+	m.cryptoErr = ""
+	if decrypt {
+		m.input.Placeholder = "base64 ciphertext"
+		m.state = KMSStateDecryptInput
+	} else {
+		m.input.Placeholder = "text to encrypt (up to 4 KB)"
+		m.input.EchoMode = textinput.EchoPassword
+		m.state = KMSStateEncryptInput
+	}
+}
+
+func (m KMSModel) updateCryptoInput(msg tea.KeyMsg) (KMSModel, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "esc":
+		m.input.Reset()
+		m.state = KMSStateKeys
+		return m, nil
+	case "enter":
+		value := m.input.Value()
+		if strings.TrimSpace(value) == "" {
+			m.cryptoErr = "enter a value"
+			return m, nil
+		}
+		decrypt := m.state == KMSStateDecryptInput
+		m.input.Reset()
+		m.state = KMSStateKeys
+		return m, m.runCrypto(m.cryptoKey.info.ID, value, decrypt)
+	}
+
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m KMSModel) runCrypto(keyID, value string, decrypt bool) tea.Cmd {
+	return func() tea.Msg {
+		client, err := aws.NewKMSClient(context.Background(), m.profile)
+		if err != nil {
+			return KMSErrorMsg(err)
+		}
+		if decrypt {
+			plaintext, err := client.Decrypt(context.Background(), keyID, value)
+			if err != nil {
+				return KMSErrorMsg(err)
+			}
+			return KMSCryptoMsg{decrypted: true, value: plaintext}
+		}
+		ciphertext, err := client.Encrypt(context.Background(), keyID, value)
+		if err != nil {
+			return KMSErrorMsg(err)
+		}
+		return KMSCryptoMsg{value: ciphertext}
+	}
+}
+
+func (m KMSModel) updateCryptoResult(msg tea.KeyMsg) (KMSModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "enter":
+		m.clearCryptoResult()
+	case "v":
+		if m.cryptoResult.decrypted {
+			m.revealed = !m.revealed
+		}
+	case "y":
+		what := "ciphertext"
+		if m.cryptoResult.decrypted {
+			what = "plaintext"
+		}
+		return m, copyToClipboard(m.cryptoResult.value, what)
+	}
+	return m, nil
+}
+
+// clearCryptoResult drops the result, so plaintext does not outlive the popup
+func (m *KMSModel) clearCryptoResult() {
+	m.cryptoResult = KMSCryptoMsg{}
+	m.revealed = false
+	m.state = KMSStateKeys
+}
+
+func (m KMSModel) renderCryptoInput() string {
+	title := "Encrypt with " + m.cryptoKey.title
+	help := "The text is sent to KMS and not kept."
+	if m.state == KMSStateDecryptInput {
+		title = "Decrypt with " + m.cryptoKey.title
+		help = "Paste ciphertext produced under this key."
+	}
+	content := fmt.Sprintf(
+		" %s\n %s\n\n %s\n",
+		lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render(title),
+		m.styles.StatusMuted.Render(help),
+		m.input.View(),
+	)
+	if m.cryptoErr != "" {
+		content += "\n " + m.styles.Error.Render("✘ "+m.cryptoErr) + "\n"
+	}
+	content += "\n " + m.styles.StatusMuted.Render("(enter to run, esc to cancel)")
+	return m.renderPopup(m.styles.Popup.Width(72).Render(content))
+}
+
+func (m KMSModel) renderCryptoResult() string {
+	title := "Ciphertext (base64)"
+	value := m.cryptoResult.value
+	hint := "(y to copy, esc to close)"
+	if m.cryptoResult.decrypted {
+		title = "Plaintext"
+		hint = "(v to reveal, y to copy, esc to close)"
+		if !m.revealed {
+			value = strings.Repeat("•", min(len([]rune(value)), 32))
+		} else {
+			hint = "(v to hide, y to copy, esc to close)"
+		}
+	}
+	content := fmt.Sprintf(
+		" %s\n\n %s\n\n %s",
+		lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render(title),
+		lipgloss.NewStyle().Width(66).Render(value),
+		m.styles.StatusMuted.Render(hint),
+	)
+	return m.renderPopup(m.styles.Popup.Width(72).Render(content))
+}
+
+func (m KMSModel) renderPopup(popup string) string {
+	w, h := GetMainContainerSize(m.width, m.height)
+	return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, popup)
+}
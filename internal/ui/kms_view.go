@@ -7,38 +7,62 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/giovannirossini/aws-tui/internal/aws"
 	"github.com/giovannirossini/aws-tui/internal/cache"
 )
 
+type KMSState int
+
+const (
+	KMSStateKeys KMSState = iota
+	KMSStateEncryptInput
+	KMSStateDecryptInput
+	KMSStateCryptoResult
+)
+
 type kmsItem struct {
 	title       string
 	description string
 	id          string
 	values      []string
+	info        aws.KMSKeyInfo
 }
 
 func (i kmsItem) Title() string       { return i.title }
 func (i kmsItem) Description() string { return i.description }
 func (i kmsItem) FilterValue() string { return i.title + " " + i.description + " " + i.id }
+func (i kmsItem) rowID() string       { return i.id }
+func (i kmsItem) rowValues() []string { return i.values }
+func (i kmsItem) source() any         { return i.info }
 
 type KMSModel struct {
 	client    *aws.KMSClient
 	list      list.Model
 	styles    Styles
+	table     *tableLayout
+	state     KMSState
 	width     int
 	height    int
 	profile   string
 	err       error
+	loaded    bool
 	cache     *cache.Cache
 	cacheKeys *cache.KeyBuilder
+	// Encrypt/decrypt of a short payload under the selected key
+	input        textinput.Model
+	cryptoKey    kmsItem
+	cryptoErr    string
+	cryptoResult KMSCryptoMsg
+	revealed     bool
 }
 
 type kmsItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 }
 
 var kmsColumns = []Column{
@@ -54,20 +78,24 @@ func (d kmsItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 		return
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, kmsColumns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, kmsColumns, d.table)
 	isSelected := index == m.Index()
 
-	RenderTableRow(w, m, d.styles, colStyles, i.values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, i.values, isSelected)
 }
 
 func (d kmsItemDelegate) Height() int {
 	return 1
 }
 
-func NewKMSModel(profile string, styles Styles, appCache *cache.Cache) KMSModel {
+func (d kmsItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewKMSModel(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) KMSModel {
+	table := &tableLayout{display: display}
 	d := kmsItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
 	d.Styles.SelectedDesc = styles.ListSelectedDesc
@@ -81,6 +109,7 @@ func NewKMSModel(profile string, styles Styles, appCache *cache.Cache) KMSModel
 	return KMSModel{
 		list:      l,
 		styles:    styles,
+		table:     table,
 		profile:   profile,
 		cache:     appCache,
 		cacheKeys: cache.NewKeyBuilder(profile),
@@ -125,6 +154,7 @@ func (m KMSModel) Update(msg tea.Msg) (KMSModel, tea.Cmd) {
 		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
 	case KMSKeysMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			name := v.Alias
@@ -134,15 +164,7 @@ func (m KMSModel) Update(msg tea.Msg) (KMSModel, tea.Cmd) {
 				name = strings.TrimPrefix(name, "alias/")
 			}
 
-			status := v.State
-			switch status {
-			case "Enabled":
-				status = lipgloss.NewStyle().Foreground(m.styles.Success.GetForeground()).Render(status)
-			case "Disabled":
-				status = lipgloss.NewStyle().Foreground(m.styles.Error.GetForeground()).Render(status)
-			case "PendingDeletion":
-				status = lipgloss.NewStyle().Foreground(m.styles.Warning.GetForeground()).Render(status)
-			}
+			status := renderStatus(m.styles, v.State)
 
 			manager := v.Manager
 			if manager == "AWS" {
@@ -152,6 +174,7 @@ func (m KMSModel) Update(msg tea.Msg) (KMSModel, tea.Cmd) {
 			}
 
 			items[i] = kmsItem{
+				info:        v,
 				title:       name,
 				description: v.ARN,
 				id:          v.ID,
@@ -163,9 +186,14 @@ func (m KMSModel) Update(msg tea.Msg) (KMSModel, tea.Cmd) {
 				},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 
+	case KMSCryptoMsg:
+		m.cryptoResult = msg
+		m.revealed = false
+		m.state = KMSStateCryptoResult
+
 	case KMSErrorMsg:
 		m.err = msg
 
@@ -175,10 +203,26 @@ func (m KMSModel) Update(msg tea.Msg) (KMSModel, tea.Cmd) {
 			return m, nil
 		}
 
+		switch m.state {
+		case KMSStateEncryptInput, KMSStateDecryptInput:
+			return m.updateCryptoInput(msg)
+		case KMSStateCryptoResult:
+			return m.updateCryptoResult(msg)
+		}
+
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
 		switch msg.String() {
 		case "r":
 			m.cache.Delete(m.cacheKeys.KMSResources("keys"))
 			return m, m.fetchKeys()
+		case "e":
+			m.openCrypto(false)
+			return m, textinput.Blink
+		case "d":
+			m.openCrypto(true)
+			return m, textinput.Blink
 		}
 	}
 
@@ -186,12 +230,28 @@ func (m KMSModel) Update(msg tea.Msg) (KMSModel, tea.Cmd) {
 	return m, cmd
 }
 
+// columns are the columns of the table on screen
+func (m KMSModel) columns() []Column {
+	return kmsColumns
+}
+
 func (m KMSModel) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
+	}
+
+	if m.loaded && len(m.list.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.list, "KMS keys", m.profile, m.table)
+	}
+
+	switch m.state {
+	case KMSStateEncryptInput, KMSStateDecryptInput:
+		return m.renderCryptoInput()
+	case KMSStateCryptoResult:
+		return m.renderCryptoResult()
 	}
 
-	_, header := RenderTableHelpers(m.list, m.styles, kmsColumns)
+	_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
 	return header + "\n" + m.list.View()
 }
 
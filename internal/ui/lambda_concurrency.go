@@ -0,0 +1,224 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+// LambdaConcurrencyMsg carries the concurrency of a function and of the account
+type LambdaConcurrencyMsg struct {
+	function    string
+	concurrency *aws.FunctionConcurrency
+	account     *aws.AccountConcurrency
+}
+
+// LambdaSuccessMsg reports a change made to a function
+type LambdaSuccessMsg string
+
+// openConcurrency shows the concurrency of the selected function. It is
+// never cached: it is read during incidents, when it must be current.
+func (m *LambdaModel) openConcurrency(function string) tea.Cmd {
+	m.selectedFunction = function
+	m.concurrency = nil
+	m.account = nil
+	m.state = LambdaStateConcurrency
+	return m.fetchConcurrency(function)
+}
+
+func (m LambdaModel) fetchConcurrency(function string) tea.Cmd {
+	return func() tea.Msg {
+		client, err := aws.NewLambdaClient(context.Background(), m.profile)
+		if err != nil {
+			return LambdaErrorMsg(err)
+		}
+		concurrency, err := client.GetFunctionConcurrency(context.Background(), function)
+		if err != nil {
+			return LambdaErrorMsg(err)
+		}
+		account, err := client.GetAccountConcurrency(context.Background())
+		if err != nil {
+			return LambdaErrorMsg(err)
+		}
+		return LambdaConcurrencyMsg{function: function, concurrency: concurrency, account: account}
+	}
+}
+
+func (m LambdaModel) putConcurrency(function string, reserved int32) tea.Cmd {
+	return armed(func() tea.Msg {
+		client, err := aws.NewLambdaClient(context.Background(), m.profile)
+		if err != nil {
+			return LambdaErrorMsg(err)
+		}
+		if err := client.PutFunctionConcurrency(context.Background(), function, reserved); err != nil {
+			return LambdaErrorMsg(err)
+		}
+		return LambdaSuccessMsg(fmt.Sprintf("Reserved concurrency of %s set to %d", function, reserved))
+	})
+}
+
+func (m LambdaModel) deleteConcurrency(function string) tea.Cmd {
+	return armed(func() tea.Msg {
+		client, err := aws.NewLambdaClient(context.Background(), m.profile)
+		if err != nil {
+			return LambdaErrorMsg(err)
+		}
+		if err := client.DeleteFunctionConcurrency(context.Background(), function); err != nil {
+			return LambdaErrorMsg(err)
+		}
+		return LambdaSuccessMsg(fmt.Sprintf("Reserved concurrency of %s cleared", function))
+	})
+}
+
+// reservable is the most concurrency the function can reserve: what it holds
+// now plus the unreserved pool, less the part Lambda keeps unreserved
+func (m LambdaModel) reservable() int32 {
+	if m.account == nil {
+		return 0
+	}
+	reservable := m.account.Unreserved - aws.MinUnreservedConcurrency
+	if m.concurrency != nil && m.concurrency.Reserved != nil {
+		reservable += *m.concurrency.Reserved
+	}
+	return max(reservable, 0)
+}
+
+func (m LambdaModel) updateConcurrency(msg tea.KeyMsg) (LambdaModel, tea.Cmd) {
+	switch m.state {
+	case LambdaStateConcurrencyInput:
+		switch msg.String() {
+		case "esc":
+			m.state = LambdaStateConcurrency
+			m.inputErr = ""
+			return m, nil
+		case "enter":
+			n, err := strconv.ParseInt(strings.TrimSpace(m.input.Value()), 10, 32)
+			switch {
+			case err != nil || n < 0:
+				m.inputErr = "Enter a whole number, 0 or more"
+			case int32(n) > m.reservable():
+				m.inputErr = fmt.Sprintf("At most %d can be reserved without going below the %d Lambda keeps unreserved", m.reservable(), aws.MinUnreservedConcurrency)
+			default:
+				m.inputErr = ""
+				m.state = LambdaStateConcurrency
+				return m, m.putConcurrency(m.selectedFunction, int32(n))
+			}
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+
+	case LambdaStateConfirmClearConcurrency:
+		result, cmd := m.confirm.Update(msg)
+		if result != confirmPending {
+			m.state = LambdaStateConcurrency
+		}
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "esc", "backspace":
+		m.state = LambdaStateFunctions
+	case "r":
+		m.concurrency = nil
+		m.account = nil
+		return m, m.fetchConcurrency(m.selectedFunction)
+	case "e":
+		if m.account == nil {
+			return m, nil
+		}
+		m.input = textinput.New()
+		m.input.Placeholder = "Reserved concurrency"
+		m.input.CharLimit = 10
+		m.input.Width = 20
+		if m.concurrency != nil && m.concurrency.Reserved != nil {
+			m.input.SetValue(strconv.Itoa(int(*m.concurrency.Reserved)))
+		}
+		m.input.Focus()
+		m.inputErr = ""
+		m.state = LambdaStateConcurrencyInput
+		return m, textinput.Blink
+	case "d":
+		if m.concurrency != nil && m.concurrency.Reserved != nil {
+			function := m.selectedFunction
+			m.state = LambdaStateConfirmClearConcurrency
+			m.confirm = NewConfirmDialog("Clear Reserved Concurrency", fmt.Sprintf("Return %s to the unreserved pool?",
+				lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render(function),
+			), true, func() tea.Cmd { return m.deleteConcurrency(function) })
+			m.confirm.Width = 48
+		}
+	}
+	return m, nil
+}
+
+func (m LambdaModel) renderConcurrency() string {
+	w, h := GetMainContainerSize(m.width, m.height)
+	if m.concurrency == nil || m.account == nil {
+		return "\n  " + lipgloss.NewStyle().Foreground(m.styles.Primary).Render("󱎯 Loading...")
+	}
+
+	reserved := m.styles.StatusMuted.Render("None (uses the unreserved pool)")
+	if r := m.concurrency.Reserved; r != nil {
+		reserved = lipgloss.NewStyle().Bold(true).Render(strconv.Itoa(int(*r)))
+		if *r == 0 {
+			reserved += " " + m.styles.Error.Render("✘ throttled: every invocation is rejected")
+		}
+	}
+	unreserved := fmt.Sprintf("%d of %d", m.account.Unreserved, m.account.Limit)
+	if m.account.Unreserved <= aws.MinUnreservedConcurrency {
+		unreserved = m.styles.Warning.Render("⚠ " + unreserved + " (at the minimum)")
+	}
+
+	fields := []detailField{
+		{"Reserved", reserved},
+		{"Unreserved", unreserved},
+		{"Reservable", fmt.Sprintf("%d", m.reservable())},
+	}
+	if len(m.concurrency.Provisioned) == 0 {
+		fields = append(fields, detailField{"Provisioned", m.styles.StatusMuted.Render("None")})
+	}
+	for _, p := range m.concurrency.Provisioned {
+		value := fmt.Sprintf("%d requested, %d allocated, %d available  %s", p.Requested, p.Allocated, p.Available, renderStatus(m.styles, p.Status))
+		if p.StatusReason != "" {
+			value += " " + m.styles.StatusMuted.Render(p.StatusReason)
+		}
+		fields = append(fields, detailField{"Provisioned " + p.Qualifier, value})
+	}
+
+	labelStyle := lipgloss.NewStyle().Foreground(m.styles.Primary).Width(24)
+	valueStyle := lipgloss.NewStyle().Width(max(w-32, 20))
+
+	var s strings.Builder
+	for _, f := range fields {
+		s.WriteString(" " + lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render(f.label), valueStyle.Render(f.value)) + "\n")
+	}
+	s.WriteString("\n " + m.styles.StatusMuted.Render("(r to refresh, esc to go back)"))
+	base := lipgloss.NewStyle().Height(h - AppInternalFooterHeight - 2).MaxHeight(h - AppInternalFooterHeight - 2).Render(s.String())
+
+	switch m.state {
+	case LambdaStateConcurrencyInput:
+		content := fmt.Sprintf(
+			" %s\n %s\n\n %s\n",
+			lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render("Reserved concurrency for "+m.selectedFunction),
+			m.styles.StatusMuted.Render(fmt.Sprintf("0 to %d; 0 throttles the function", m.reservable())),
+			m.input.View(),
+		)
+		if m.inputErr != "" {
+			content += "\n " + m.styles.Error.Render(m.inputErr) + "\n"
+		}
+		content += "\n " + m.styles.StatusMuted.Render("(enter to save, esc to cancel)")
+		return RenderOverlay(base, m.styles.Popup.Width(56).Render(content), m.width, m.height)
+
+	case LambdaStateConfirmClearConcurrency:
+		return RenderOverlay(base, m.confirm.View(m.styles), m.width, m.height)
+	}
+
+	return base
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/giovannirossini/aws-tui/internal/aws"
 	"github.com/giovannirossini/aws-tui/internal/cache"
@@ -15,34 +16,50 @@ type LambdaState int
 
 const (
 	LambdaStateFunctions LambdaState = iota
+	LambdaStateConcurrency
+	LambdaStateConcurrencyInput
+	LambdaStateConfirmClearConcurrency
 )
 
 type lambdaItem struct {
 	title       string
 	description string
 	values      []string
+	info        aws.FunctionInfo
 }
 
 func (i lambdaItem) Title() string       { return i.title }
 func (i lambdaItem) Description() string { return i.description }
 func (i lambdaItem) FilterValue() string { return i.title + " " + i.description }
+func (i lambdaItem) rowValues() []string { return i.values }
+func (i lambdaItem) source() any         { return i.info }
 
 type LambdaModel struct {
 	client    *aws.LambdaClient
 	list      list.Model
 	styles    Styles
+	table     *tableLayout
 	state     LambdaState
 	width     int
 	height    int
 	profile   string
 	err       error
+	loaded    bool
 	cache     *cache.Cache
 	cacheKeys *cache.KeyBuilder
+	// Concurrency of the selected function and the form to reserve it
+	selectedFunction string
+	concurrency      *aws.FunctionConcurrency
+	account          *aws.AccountConcurrency
+	input            textinput.Model
+	inputErr         string
+	confirm          ConfirmDialog
 }
 
 type lambdaItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 	state  LambdaState
 }
 
@@ -60,18 +77,22 @@ func (d lambdaItemDelegate) Render(w io.Writer, m list.Model, index int, listIte
 		return
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, lambdaColumns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, lambdaColumns, d.table)
 	isSelected := index == m.Index()
 
-	RenderTableRow(w, m, d.styles, colStyles, i.values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, i.values, isSelected)
 }
 
 func (d lambdaItemDelegate) Height() int { return 1 }
 
-func NewLambdaModel(profile string, styles Styles, appCache *cache.Cache) LambdaModel {
+func (d lambdaItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewLambdaModel(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) LambdaModel {
+	table := &tableLayout{display: display}
 	d := lambdaItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 		state:           LambdaStateFunctions,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
@@ -86,6 +107,7 @@ func NewLambdaModel(profile string, styles Styles, appCache *cache.Cache) Lambda
 	return LambdaModel{
 		list:      l,
 		styles:    styles,
+		table:     table,
 		state:     LambdaStateFunctions,
 		profile:   profile,
 		cache:     appCache,
@@ -132,9 +154,11 @@ func (m LambdaModel) Update(msg tea.Msg) (LambdaModel, tea.Cmd) {
 		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
 	case LambdaFunctionsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, f := range msg {
 			items[i] = lambdaItem{
+				info:        f,
 				title:       f.Name,
 				description: f.Description,
 				values: []string{
@@ -146,8 +170,20 @@ func (m LambdaModel) Update(msg tea.Msg) (LambdaModel, tea.Cmd) {
 				},
 			}
 		}
-		m.list.SetItems(items)
-		m.state = LambdaStateFunctions
+		m.table.setItems(&m.list, items)
+
+	case LambdaConcurrencyMsg:
+		if msg.function == m.selectedFunction {
+			m.concurrency = msg.concurrency
+			m.account = msg.account
+		}
+
+	case LambdaSuccessMsg:
+		m.err = nil
+		if m.state == LambdaStateConcurrency {
+			return m, m.fetchConcurrency(m.selectedFunction)
+		}
+		return m, nil
 
 	case LambdaErrorMsg:
 		m.err = msg
@@ -158,10 +194,18 @@ func (m LambdaModel) Update(msg tea.Msg) (LambdaModel, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.state != LambdaStateFunctions {
+			return m.updateConcurrency(msg)
+		}
+
 		switch msg.String() {
 		case "r":
 			m.cache.Delete(m.cacheKeys.LambdaFunctions())
 			return m, m.fetchFunctions()
+		case "c":
+			if item, ok := m.list.SelectedItem().(lambdaItem); ok && !m.list.SettingFilter() {
+				return m, m.openConcurrency(item.title)
+			}
 		}
 	}
 
@@ -169,12 +213,25 @@ func (m LambdaModel) Update(msg tea.Msg) (LambdaModel, tea.Cmd) {
 	return m, cmd
 }
 
+// columns are the columns of the table on screen
+func (m LambdaModel) columns() []Column {
+	return lambdaColumns
+}
+
 func (m LambdaModel) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
+	}
+
+	if m.state != LambdaStateFunctions {
+		return m.renderConcurrency()
+	}
+
+	if m.loaded && len(m.list.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.list, "Lambda functions", m.profile, m.table)
 	}
 
-	_, header := RenderTableHelpers(m.list, m.styles, lambdaColumns)
+	_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
 	return header + "\n" + m.list.View()
 }
 
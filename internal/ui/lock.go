@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// lockMsg fires when the inactivity timeout elapses. Ticks carrying a stale
+// id were scheduled before the last key press and are dropped.
+type lockMsg int
+
+func (m Model) scheduleLock() tea.Cmd {
+	if m.lockAfter <= 0 {
+		return nil
+	}
+	id := m.lockID
+	return tea.Tick(m.lockAfter, func(time.Time) tea.Msg {
+		return lockMsg(id)
+	})
+}
+
+// resetLock restarts the inactivity timer; bumping the id orphans the tick
+// already in flight
+func (m *Model) resetLock() tea.Cmd {
+	m.lockID++
+	return m.scheduleLock()
+}
+
+func (m *Model) handleLock(msg lockMsg) (tea.Model, tea.Cmd) {
+	if int(msg) != m.lockID || m.lockAfter <= 0 {
+		return *m, nil
+	}
+	m.lock()
+	return *m, nil
+}
+
+// lock returns to the home screen and drops anything sensitive still held by
+// the service views: revealed secret values, KMS results, new IAM passwords
+// and access keys, and viewport documents such as object previews, task
+// definitions and log events
+func (m *Model) lock() {
+	m.stopAutoRefresh()
+	m.profileSelector.active = false
+	m.profileCheckActive = false
+	m.paletteActive = false
+	m.testEventActive = false
+	m.recentActive = false
+	m.opsLogActive = false
+	m.columnPickerActive = false
+	m.describeActive = false
+	m.relatedActive = false
+	m.tagEditorActive = false
+	m.tagFilterActive = false
+
+	m.smModel.selectedValue = ""
+	m.smModel.state = SMStateSecrets
+	m.kmsModel.clearCryptoResult()
+	m.iamModel.clearCredentials()
+
+	m.s3Model.viewport.SetContent("")
+	m.ecsModel.search.reset()
+	m.ecsModel.search.content = ""
+	m.ecsModel.viewport.SetContent("")
+	m.cwModel.search.reset()
+	m.cwModel.search.content = ""
+	m.cwModel.viewport.SetContent("")
+
+	m.view = viewHome
+	m.locked = true
+}
+
+func (m Model) renderLocked() string {
+	popup := m.styles.Popup.Width(44).Render(
+		m.styles.StatusMuted.Render("Locked due to inactivity") + "\n\n" +
+			"Press any key to continue",
+	)
+	w, h := GetMainContainerSize(m.width, m.height)
+	return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, popup)
+}
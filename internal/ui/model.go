@@ -1,11 +1,17 @@
 package ui
 
 import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/giovannirossini/aws-tui/internal/aws"
 	"github.com/giovannirossini/aws-tui/internal/cache"
+	"github.com/giovannirossini/aws-tui/internal/config"
+	"github.com/giovannirossini/aws-tui/internal/logging"
 )
 
 type focus int
@@ -94,30 +100,200 @@ type Model struct {
 	searching        bool
 	filteredServices []string
 	selectedFiltered int
+	searchOffset     int
 	width            int
 	height           int
 	ready            bool
 	identity         *aws.IdentityInfo
+	profileRegion    string // configured region of the selected profile, read on switch
 	cache            *cache.Cache
 	cacheKeys        *cache.KeyBuilder
+	cachePath        string
+	readOnly         bool
+	display          *tableDisplay // Shared with the tables of every view
+	// Toast
+	toast      string
+	toastID    int
+	toastMuted bool
+	// Command palette
+	paletteInput       textinput.Model
+	paletteActive      bool
+	paletteSuggestions []string
+	paletteSelected    int
+	// Auto-refresh
+	autoRefresh          time.Duration
+	autoRefreshID        int
+	autoRefreshView      viewState
+	autoRefreshViewState int
+	// Startup credentials check
+	preflightChecking bool
+	preflightErr      error
+	// Number of AWS requests currently running
+	inFlight int
+	// Test event popup
+	testEventActive bool
+	testEventTarget testEventTarget
+	testEventList   list.Model
+	// Resources viewed in detail, most recent first
+	recent       []recentResource
+	recentActive bool
+	recentList   list.Model
+	// Mutations run during the session, oldest first
+	opsLog           []operation
+	opsLogActive     bool
+	opsLogViewport   viewport.Model
+	pendingOperation string
+	// JSON of the selected row, for views without a detail screen
+	describeActive   bool
+	describeTitle    string
+	describeJSON     string
+	describeViewport viewport.Model
+	// Resources the selected row references, and the ID of the row to
+	// select once the list jumped to has loaded
+	relatedActive bool
+	relatedList   list.Model
+	jumpTarget    string
+	// Configuration of the selected function in this and another profile,
+	// which is picked first
+	compareActive   bool
+	comparePicking  bool
+	compareList     list.Model
+	compareName     string
+	compareProfiles [2]string
+	compareSame     bool
+	compareViewport viewport.Model
+	// Tags of the selected resource
+	tagEditorActive bool
+	tagEditor       tagEditor
+	// Preferences saved to the config file; an empty configPath keeps them
+	// for the session only
+	config     *config.Config
+	configPath string
+	keys       keymap
+	// The handlers that skip the key being handled, see remapKey
+	keySkips keyHandlers
+	// Column picker for the table on screen
+	columnPickerActive  bool
+	columnPickerService string
+	columnPickerColumns []Column
+	columnPickerFocus   int
+	// Global tag filter applied to the listings that support it
+	tagFilter       aws.TagFilter
+	tagFilterActive bool
+	tagFilterInput  textinput.Model
+	// The error on screen is expanded into the details of the failed call
+	errorExpanded bool
+	// Mouse reporting is on, so the header's profile and region are clickable
+	mouse bool
+	// Inactivity lock; zero lockAfter disables it
+	lockAfter time.Duration
+	lockID    int
+	locked    bool
+	// Credentials check across every profile
+	profileCheck         []aws.ProfileStatus
+	profileCheckActive   bool
+	profileCheckRunning  bool
+	profileCheckSelected int
+	// Rows before the pending refresh, diffed when the new ones arrive
+	rowSnapshot  *rowSnapshot
+	rowChangesID int
+	// Rows pinned to the top, by view title and rowKey
+	pins map[string]map[string]bool
+	// Home screen count badges by service name; only fetched when enabled
+	resourceCountsEnabled bool
+	resourceCounts        map[string]int
+	// Results of the "needs attention" checks by ID; only fetched when
+	// enabled in the config
+	attention map[string]attentionResult
+	// The multi-step operation shown in the progress overlay, if any
+	progress *progressState
 }
 
 type IdentityMsg *aws.IdentityInfo
 
 func (m Model) Init() tea.Cmd {
-	return m.fetchIdentity()
+	return tea.Batch(m.preflight(), waitForInFlight(), m.scheduleLock())
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.update(msg)
+	if next, ok := model.(Model); ok {
+		next.syncTable()
+		return next, cmd
+	}
+	return model, cmd
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if err, ok := msg.(error); ok {
+		logging.Printf("error view=%d: %v", m.view, err)
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		return m.handleWindowSize(msg)
 	case tea.KeyMsg:
-		return m.handleKeyPress(msg)
+		lock := m.resetLock()
+		if m.locked {
+			// The key that dismisses the lock note does nothing else
+			m.locked = false
+			return m, lock
+		}
+		key, skips := m.remapKey(msg)
+		m.keySkips = skips
+		// A jump whose row never showed up is dropped once the user moves on
+		m.jumpTarget = ""
+		model, cmd := m.handleKeyPress(key)
+		return model, tea.Batch(cmd, lock)
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
 	case ProfileSelectedMsg:
 		return m.handleProfileChange(string(msg))
+	case clearToastMsg:
+		if int(msg) == m.toastID {
+			m.toast = ""
+		}
+		return m, nil
+	case autoRefreshMsg:
+		return m.handleAutoRefresh(msg)
+	case lockMsg:
+		return m.handleLock(msg)
+	case profileCheckMsg:
+		return m.handleProfileCheck(msg)
+	case clearRowChangesMsg:
+		if msg.id == m.rowChangesID {
+			msg.table.changes = nil
+		}
+		return m, nil
+	case preflightMsg:
+		return m.handlePreflight(msg)
+	case resourceCountMsg:
+		return m.handleResourceCount(msg)
+	case attentionMsg:
+		return m.handleAttention(msg)
+	case ProfileCompareMsg:
+		return m.handleProfileCompare(msg)
+	case ProgressMsg:
+		return m.handleProgress(msg)
+	case inFlightMsg:
+		m.inFlight = int(msg)
+		return m, waitForInFlight()
 	default:
-		return m.handleViewMessages(msg)
+		bell := m.bellFor(msg)
+		model, cmd := m.handleViewMessages(msg)
+		cmd = tea.Batch(cmd, bell)
+		next, ok := model.(Model)
+		if !ok {
+			return model, cmd
+		}
+		next.sortPinned()
+		if next.jumpTarget != "" {
+			next.selectJumpTarget()
+		}
+		if next.rowSnapshot != nil {
+			return next, tea.Batch(cmd, next.diffRows())
+		}
+		return next, cmd
 	}
 }
 
@@ -128,7 +304,7 @@ func (m Model) View() string {
 
 	header := m.renderHeader()
 	footer := m.renderFooter()
-	boxContent := m.renderMainContent()
+	boxContent := m.renderProgress(m.renderMainContent())
 
 	mainBox := RenderBoxedContainer(m.styles, boxContent, footer, m.width, m.height)
 
@@ -2,24 +2,74 @@ package ui
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/giovannirossini/aws-tui/internal/aws"
 	"github.com/giovannirossini/aws-tui/internal/cache"
+	"github.com/giovannirossini/aws-tui/internal/config"
+	"github.com/giovannirossini/aws-tui/internal/logging"
 )
 
-func NewModel() (Model, error) {
+// Options holds startup settings parsed from the command line
+type Options struct {
+	// ReadOnly disables every create/delete/restart/stop action
+	ReadOnly bool
+	// PersistCache keeps cached responses on disk between runs
+	PersistCache bool
+	// RequestTimeout bounds each AWS operation (zero keeps the default)
+	RequestTimeout time.Duration
+	// Theme names the color theme (empty selects DefaultTheme)
+	Theme string
+	// LockAfter returns to the home screen and clears revealed values after
+	// this long without key input (zero disables the lock)
+	LockAfter time.Duration
+	// ResourceCounts shows how many resources each service has on the home
+	// screen, at the cost of one list call per service
+	ResourceCounts bool
+	// FullARNs shows ARNs in full instead of by their resource name until
+	// toggled with A
+	FullARNs bool
+	// FitColumns sizes table columns to their content instead of fixed
+	// fractions of the width
+	FitColumns bool
+	// Mouse marks the header's profile and region as clickable; the program
+	// must be started with mouse reporting for clicks to arrive
+	Mouse bool
+	// Profile starts with this profile instead of AWS_PROFILE or "default";
+	// it must exist in the AWS config or credentials file
+	Profile string
+}
+
+func NewModel(opts Options) (Model, error) {
+	styles, err := ThemeStyles(opts.Theme)
+	if err != nil {
+		return Model{}, err
+	}
+
 	profiles, err := aws.GetProfiles()
 	if err != nil {
 		return Model{}, err
 	}
 
 	selected := ""
+	// 0. A profile given on the command line must exist
+	if opts.Profile != "" {
+		if !slices.Contains(profiles, opts.Profile) {
+			configPath, _ := aws.ConfigFilePath()
+			credsPath, _ := aws.CredentialsFilePath()
+			return Model{}, fmt.Errorf("profile %q not found in %s or %s (available: %s)", opts.Profile, configPath, credsPath, strings.Join(profiles, ", "))
+		}
+		selected = opts.Profile
+	}
+
 	// 1. Try to use AWS_PROFILE if set
-	if p := os.Getenv("AWS_PROFILE"); p != "" {
+	if p := os.Getenv("AWS_PROFILE"); selected == "" && p != "" {
 		for _, profile := range profiles {
 			if profile == p {
 				selected = p
@@ -48,16 +98,50 @@ func NewModel() (Model, error) {
 		selected = "default"
 	}
 
-	styles := DefaultStyles()
+	if opts.RequestTimeout > 0 {
+		aws.SetRequestTimeout(opts.RequestTimeout)
+	}
+
+	logging.Printf("starting with profile=%s read-only=%t theme=%s", selected, opts.ReadOnly, opts.Theme)
+
 	ps := NewProfileSelector(profiles, selected, styles)
 	appCache := cache.New()
 
+	cachePath := ""
+	if opts.PersistCache {
+		if path, err := cache.DefaultPath(); err != nil {
+			logging.Printf("cache persistence disabled: %v", err)
+		} else {
+			cachePath = path
+			if err := appCache.Load(cachePath); err != nil {
+				logging.Printf("could not load cache: %v", err)
+			}
+		}
+	}
+
+	cfg := &config.Config{}
+	configPath, err := config.DefaultPath()
+	if err != nil {
+		logging.Printf("config disabled: %v", err)
+	} else if cfg, err = config.Load(configPath); err != nil {
+		return Model{}, err
+	}
+	keys, err := newKeymap(cfg.Keys)
+	if err != nil {
+		return Model{}, fmt.Errorf("%s: %w", configPath, err)
+	}
+
 	// Start background cache cleanup goroutine
 	go func() {
 		ticker := time.NewTicker(5 * time.Minute)
 		defer ticker.Stop()
 		for range ticker.C {
 			appCache.CleanExpired()
+			if cachePath != "" {
+				if err := appCache.Save(cachePath); err != nil {
+					logging.Printf("could not save cache: %v", err)
+				}
+			}
 		}
 	}()
 
@@ -68,21 +152,54 @@ func NewModel() (Model, error) {
 	ti.Width = 30
 
 	return Model{
-		profiles:         profiles,
-		selectedProfile:  selected,
-		profileSelector:  ps,
-		styles:           styles,
-		focus:            focusContent,
-		view:             viewHome,
-		categories:       getServiceCategories(),
-		selectedCategory: 0,
-		selectedService:  0,
-		searchInput:      ti,
-		cache:            appCache,
-		cacheKeys:        cache.NewKeyBuilder(selected),
+		profiles:              profiles,
+		selectedProfile:       selected,
+		profileSelector:       ps,
+		styles:                styles,
+		focus:                 focusContent,
+		view:                  viewHome,
+		categories:            getServiceCategories(),
+		selectedCategory:      0,
+		selectedService:       0,
+		searchInput:           ti,
+		paletteInput:          newPaletteInput(),
+		cache:                 appCache,
+		cacheKeys:             cache.NewKeyBuilder(selected),
+		cachePath:             cachePath,
+		config:                cfg,
+		configPath:            configPath,
+		keys:                  keys,
+		readOnly:              opts.ReadOnly,
+		display:               &tableDisplay{fullARNs: opts.FullARNs, fitColumns: opts.FitColumns, compact: cfg.Compact},
+		lockAfter:             opts.LockAfter,
+		resourceCountsEnabled: opts.ResourceCounts,
+		mouse:                 opts.Mouse,
+		// The home view stays hidden until the credentials check passes
+		preflightChecking: true,
 	}, nil
 }
 
+// SaveCache flushes the cache to disk when persistence is enabled
+func (m Model) SaveCache() error {
+	if m.cachePath == "" {
+		return nil
+	}
+	return m.cache.Save(m.cachePath)
+}
+
+// region returns the region of the session. Until the identity of a newly
+// selected profile arrives, the profile's configured region is used so views
+// never target the previous profile's region.
+func (m Model) region() string {
+	if m.identity != nil && m.identity.Region != "" {
+		return m.identity.Region
+	}
+	if m.profileRegion != "" {
+		return m.profileRegion
+	}
+	return "us-east-1"
+}
+
 func (m Model) fetchIdentity() tea.Cmd {
 	return func() tea.Msg {
 		// Check cache first
@@ -111,6 +228,8 @@ func (m Model) fetchIdentity() tea.Cmd {
 			}
 		}
 
+		id.Profile = m.selectedProfile
+
 		// Cache the identity
 		m.cache.Set(m.cacheKeys.Identity(), id, cache.TTLIdentity)
 
@@ -0,0 +1,299 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// rowPrefixLength is how much of an item's title is looked for on screen to
+// find its row. Short enough to survive column truncation.
+const rowPrefixLength = 8
+
+// handleMouse maps mouse events onto the keyboard model: the wheel scrolls
+// like the arrow keys and a left click selects what is under the pointer
+func (m *Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return *m, nil
+	}
+	// The arrow keys are never remapped
+	m.keySkips = 0
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return m.handleKeyPress(tea.KeyMsg{Type: tea.KeyUp})
+	case tea.MouseButtonWheelDown:
+		return m.handleKeyPress(tea.KeyMsg{Type: tea.KeyDown})
+	case tea.MouseButtonLeft:
+		if m.profileSelector.active || m.profileCheckActive || m.paletteActive || m.testEventActive || m.recentActive || m.opsLogActive || m.columnPickerActive || m.describeActive || m.relatedActive || m.tagEditorActive || m.tagFilterActive || m.preflightBlocking() || m.isInputFocused() {
+			return *m, nil
+		}
+		if msg.Y < lipgloss.Height(m.renderHeader()) {
+			return *m, m.clickHeader(msg.X, msg.Y)
+		}
+		if m.view == viewHome {
+			return m.clickHomeService(msg.X, msg.Y)
+		}
+		m.clickListRow(msg.Y)
+	}
+	return *m, nil
+}
+
+// screenLines returns the current screen as plain text, one entry per row
+func (m Model) screenLines() []string {
+	return strings.Split(sgrPattern.ReplaceAllString(m.View(), ""), "\n")
+}
+
+// clickHeader opens the profile selector or the region command when the
+// profile or region of the header is under the pointer. The segments are
+// found in the rendered header, as it is centered and its width varies.
+func (m *Model) clickHeader(x, y int) tea.Cmd {
+	lines := strings.Split(sgrPattern.ReplaceAllString(m.renderHeader(), ""), "\n")
+	if y < 0 || y >= len(lines) {
+		return nil
+	}
+	line := lines[y]
+
+	hit := func(label, value string) bool {
+		idx := strings.Index(line, label+value)
+		if idx < 0 || value == "" {
+			return false
+		}
+		start := lipgloss.Width(line[:idx]) + lipgloss.Width(label)
+		return x >= start && x < start+lipgloss.Width(value)
+	}
+
+	if hit("profile: ", m.selectedProfile) {
+		m.profileSelector.active = true
+		m.profileSelector.list.FilterInput.Focus()
+		return nil
+	}
+	if m.identity != nil && hit("region: ", m.identity.Region) {
+		return m.openPaletteWith("region ")
+	}
+	return nil
+}
+
+// clickHomeService selects the service under the pointer on the home grid.
+// Clicking the service that is already selected opens it.
+func (m *Model) clickHomeService(x, y int) (tea.Model, tea.Cmd) {
+	lines := m.screenLines()
+	if y < 0 || y >= len(lines) {
+		return *m, nil
+	}
+	line := lines[y]
+
+	// Prefer the longest name so "EC2" never shadows a longer label
+	catIdx, svcIdx, longest := -1, -1, 0
+	for ci, cat := range m.categories {
+		for si, service := range cat.Services {
+			icon := featureIcons[service]
+			if icon == "" {
+				icon = "• "
+			}
+			label := icon + service
+			idx := strings.Index(line, label)
+			if idx < 0 || len(service) <= longest {
+				continue
+			}
+			start := lipgloss.Width(line[:idx])
+			if x < start || x >= start+lipgloss.Width(label) {
+				continue
+			}
+			catIdx, svcIdx, longest = ci, si, len(service)
+		}
+	}
+	if catIdx < 0 {
+		return *m, nil
+	}
+
+	if catIdx == m.selectedCategory && svcIdx == m.selectedService && m.focus == focusContent {
+		return m.handleServiceSelection(m.categories[catIdx].Services[svcIdx])
+	}
+	m.selectedCategory, m.selectedService = catIdx, svcIdx
+	m.focus = focusContent
+	return *m, nil
+}
+
+// clickListRow selects the row under the pointer in the current view's list.
+// Rows are found by looking for the start of each visible item's title on
+// screen, in order, which keeps working whatever the view renders above the
+// table.
+func (m *Model) clickListRow(y int) {
+	l := m.activeList()
+	if l == nil {
+		return
+	}
+
+	lines := m.screenLines()
+	items := l.VisibleItems()
+	start, end := l.Paginator.GetSliceBounds(len(items))
+
+	// Skip the header, whose title repeats names from the list
+	line := lipgloss.Height(m.renderHeader()) + 1
+	var rows, indexes []int
+	for i := start; i < end; i++ {
+		item, ok := items[i].(list.DefaultItem)
+		if !ok {
+			continue
+		}
+		prefix := []rune(strings.TrimSpace(item.Title()))
+		if len(prefix) == 0 {
+			continue
+		}
+		prefix = prefix[:min(len(prefix), rowPrefixLength)]
+		for row := line; row < len(lines); row++ {
+			if strings.Contains(lines[row], string(prefix)) {
+				rows = append(rows, row)
+				indexes = append(indexes, i)
+				line = row + 1
+				break
+			}
+		}
+	}
+
+	// A row owns the lines down to the next one, e.g. a menu item's description
+	for i, row := range rows {
+		next := row + 2
+		if i+1 < len(rows) {
+			next = rows[i+1]
+		}
+		if y >= row && y < next {
+			l.Select(indexes[i])
+			return
+		}
+	}
+}
+
+// activeList returns the main list of the current view
+func (m *Model) activeList() *list.Model {
+	switch m.view {
+	case viewS3:
+		return &m.s3Model.list
+	case viewIAM:
+		switch m.iamModel.state {
+		case IAMStateRoles:
+			return &m.iamModel.roleList
+		case IAMStateGroups:
+			return &m.iamModel.groupList
+		case IAMStateGroupMembers:
+			return &m.iamModel.memberList
+		}
+		return &m.iamModel.list
+	case viewVPC:
+		return &m.vpcModel.list
+	case viewLambda:
+		return &m.lambdaModel.list
+	case viewEC2:
+		return &m.ec2Model.list
+	case viewRDS:
+		return &m.rdsModel.list
+	case viewCW:
+		return &m.cwModel.list
+	case viewCF:
+		return &m.cfModel.list
+	case viewElastiCache:
+		return &m.elasticacheModel.list
+	case viewMSK:
+		return &m.mskModel.list
+	case viewSQS:
+		return &m.sqsModel.list
+	case viewSM:
+		return &m.smModel.list
+	case viewRoute53:
+		return &m.route53Model.list
+	case viewACM:
+		return &m.acmModel.list
+	case viewSNS:
+		return &m.snsModel.list
+	case viewKMS:
+		return &m.kmsModel.list
+	case viewDMS:
+		return &m.dmsModel.list
+	case viewECS:
+		return &m.ecsModel.list
+	case viewBilling:
+		return &m.billingModel.list
+	case viewSecurityHub:
+		return &m.securityhubModel.list
+	case viewWAF:
+		return &m.wafModel.list
+	case viewECR:
+		return &m.ecrModel.list
+	case viewEFS:
+		return &m.efsModel.list
+	case viewBackup:
+		return &m.backupModel.list
+	case viewDynamoDB:
+		return &m.dynamodbModel.list
+	case viewTransfer:
+		return &m.transferModel.list
+	case viewAPIGateway:
+		return &m.apiGatewayModel.list
+	}
+	return nil
+}
+
+// activeTable returns the table layout of the current view and the columns
+// of the table it shows, or a nil layout when it shows none
+func (m *Model) activeTable() (*tableLayout, []Column) {
+	switch m.view {
+	case viewS3:
+		return m.s3Model.table, m.s3Model.columns()
+	case viewIAM:
+		return m.iamModel.table, m.iamModel.columns()
+	case viewVPC:
+		return m.vpcModel.table, m.vpcModel.columns()
+	case viewLambda:
+		return m.lambdaModel.table, m.lambdaModel.columns()
+	case viewEC2:
+		return m.ec2Model.table, m.ec2Model.columns()
+	case viewRDS:
+		return m.rdsModel.table, m.rdsModel.columns()
+	case viewCW:
+		return m.cwModel.table, m.cwModel.columns()
+	case viewCF:
+		return m.cfModel.table, m.cfModel.columns()
+	case viewElastiCache:
+		return m.elasticacheModel.table, m.elasticacheModel.columns()
+	case viewMSK:
+		return m.mskModel.table, m.mskModel.columns()
+	case viewSQS:
+		return m.sqsModel.table, m.sqsModel.columns()
+	case viewSM:
+		return m.smModel.table, m.smModel.columns()
+	case viewRoute53:
+		return m.route53Model.table, m.route53Model.columns()
+	case viewACM:
+		return m.acmModel.table, m.acmModel.columns()
+	case viewSNS:
+		return m.snsModel.table, m.snsModel.columns()
+	case viewKMS:
+		return m.kmsModel.table, m.kmsModel.columns()
+	case viewDMS:
+		return m.dmsModel.table, m.dmsModel.columns()
+	case viewECS:
+		return m.ecsModel.table, m.ecsModel.columns()
+	case viewBilling:
+		return m.billingModel.table, m.billingModel.columns()
+	case viewSecurityHub:
+		return m.securityhubModel.table, m.securityhubModel.columns()
+	case viewWAF:
+		return m.wafModel.table, m.wafModel.columns()
+	case viewECR:
+		return m.ecrModel.table, m.ecrModel.columns()
+	case viewEFS:
+		return m.efsModel.table, m.efsModel.columns()
+	case viewBackup:
+		return m.backupModel.table, m.backupModel.columns()
+	case viewDynamoDB:
+		return m.dynamodbModel.table, m.dynamodbModel.columns()
+	case viewTransfer:
+		return m.transferModel.table, m.transferModel.columns()
+	case viewAPIGateway:
+		return m.apiGatewayModel.table, m.apiGatewayModel.columns()
+	}
+	return nil, nil
+}
@@ -66,6 +66,39 @@ func (m Model) isInputFocused() bool {
 	if m.view == viewIAM && m.iamModel.state == IAMStateInput {
 		return true
 	}
+	if m.view == viewLambda && m.lambdaModel.state == LambdaStateConcurrencyInput {
+		return true
+	}
+	if m.view == viewEC2 && m.ec2Model.state == EC2StatePortForwardInput {
+		return true
+	}
+	if m.view == viewRDS && m.rdsModel.state == RDSStateConnectInput {
+		return true
+	}
+	if m.view == viewACM && m.acmModel.state == ACMStateRequestInput {
+		return true
+	}
+	if m.view == viewBackup && m.backupModel.state == BackupStateRestoreInput {
+		return true
+	}
+	if m.view == viewDynamoDB && m.dynamodbModel.state == DynamoDBStateBackupInput {
+		return true
+	}
+	if m.view == viewSM && m.smModel.state == SMStateNameInput {
+		return true
+	}
+	if m.view == viewSQS && m.sqsModel.state == SQSStateEditAttributes {
+		return true
+	}
+	if m.view == viewKMS && (m.kmsModel.state == KMSStateEncryptInput || m.kmsModel.state == KMSStateDecryptInput) {
+		return true
+	}
+	if m.view == viewECS && m.ecsModel.search.typing {
+		return true
+	}
+	if m.view == viewCW && (m.cwModel.search.typing || m.cwModel.prefixing) {
+		return true
+	}
 	return false
 }
 
@@ -86,12 +119,6 @@ func (m *Model) updateFilter() {
 		}
 	}
 
-	// Limit to max 10 items
-	maxItems := 10
-	if len(m.filteredServices) > maxItems {
-		m.filteredServices = m.filteredServices[:maxItems]
-	}
-
 	if m.selectedFiltered >= len(m.filteredServices) {
 		m.selectedFiltered = len(m.filteredServices) - 1
 		if m.selectedFiltered < 0 {
@@ -101,6 +128,22 @@ func (m *Model) updateFilter() {
 	if m.selectedFiltered < 0 && len(m.filteredServices) > 0 {
 		m.selectedFiltered = 0
 	}
+	m.scrollSearchResults()
+}
+
+// searchPageSize is how many search results the home menu shows at once
+const searchPageSize = 10
+
+// scrollSearchResults moves the visible window of search results so that
+// the selected one stays in view
+func (m *Model) scrollSearchResults() {
+	if m.selectedFiltered < m.searchOffset {
+		m.searchOffset = m.selectedFiltered
+	}
+	if m.selectedFiltered >= m.searchOffset+searchPageSize {
+		m.searchOffset = m.selectedFiltered - searchPageSize + 1
+	}
+	m.searchOffset = max(0, min(m.searchOffset, len(m.filteredServices)-searchPageSize))
 }
 
 func (m *Model) handleServiceSelection(selectedService string) (tea.Model, tea.Cmd) {
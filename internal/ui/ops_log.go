@@ -0,0 +1,190 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// opsLogLimit is how many operations the log keeps
+const opsLogLimit = 200
+
+// operation is a mutation run during the session
+type operation struct {
+	at     time.Time
+	target string // Breadcrumb of the view the action was started from
+	action string
+	err    error
+}
+
+// armOperation remembers where a mutating action was dispatched so its result
+// can be logged against it. Only an error that follows an armed action is
+// logged, so failed listings stay out of the log.
+func (m *Model) armOperation() {
+	m.pendingOperation = m.getViewTitle()
+}
+
+// operationArmedMsg arms the log for the mutation a view dispatches after it
+type operationArmedMsg struct{}
+
+// armed wraps the Cmd of a mutation so the log is armed when it is dispatched,
+// once any confirmation is behind it, rather than when its key is pressed
+func armed(cmd tea.Cmd) tea.Cmd {
+	return tea.Sequence(func() tea.Msg { return operationArmedMsg{} }, cmd)
+}
+
+func (m *Model) logOperation(action string, err error) {
+	target := m.pendingOperation
+	if target == "" {
+		target = m.getViewTitle()
+	}
+	m.pendingOperation = ""
+
+	m.opsLog = append(m.opsLog, operation{at: time.Now(), target: target, action: action, err: err})
+	if len(m.opsLog) > opsLogLimit {
+		m.opsLog = m.opsLog[len(m.opsLog)-opsLogLimit:]
+	}
+	if m.opsLogActive {
+		m.setOpsLogContent()
+	}
+}
+
+// trackOperation records the outcome of a mutation. Like trackRecent it runs
+// before the message is routed, while the model still shows the target.
+func (m *Model) trackOperation(msg tea.Msg) {
+	switch msg := msg.(type) {
+	case operationArmedMsg:
+		m.armOperation()
+	case smValueUnchangedMsg, ecsTaskDefUnchangedMsg, dynamoItemUnchangedMsg:
+		// An edit closed without changes dispatched nothing
+		m.pendingOperation = ""
+	case S3SuccessMsg:
+		m.logOperation(string(msg), nil)
+	case IAMSuccessMsg:
+		m.logOperation(string(msg), nil)
+	case LambdaSuccessMsg:
+		m.logOperation(string(msg), nil)
+	case DMSSuccessMsg:
+		m.logOperation(string(msg), nil)
+	case ECSSuccessMsg:
+		m.logOperation(string(msg), nil)
+	case IAMAccessKeyCreatedMsg:
+		m.logOperation("Access key created", nil)
+	case IAMPasswordSetMsg:
+		m.logOperation("Console password set", nil)
+	case ACMRequestedMsg:
+		m.logOperation("Certificate requested "+string(msg), nil)
+	case ACMRecordsCreatedMsg:
+		m.logOperation(fmt.Sprintf("Created %d validation records", int(msg)), nil)
+	case ACMDeletedMsg:
+		m.logOperation("Certificate deleted "+string(msg), nil)
+	case BackupRestoreStartedMsg:
+		m.logOperation(fmt.Sprintf("Restore job %s started", string(msg)), nil)
+	case DynamoBackupCreatedMsg:
+		m.logOperation(fmt.Sprintf("Backup %s created", string(msg)), nil)
+	case DynamoItemSavedMsg:
+		if msg.Created {
+			m.logOperation("Added item "+msg.Key, nil)
+		} else {
+			m.logOperation("Saved item "+msg.Key, nil)
+		}
+	case DynamoItemDeletedMsg:
+		m.logOperation("Deleted item "+string(msg), nil)
+	case SMSecretCreatedMsg:
+		m.logOperation("Created secret "+string(msg), nil)
+	case SMSecretValuePutMsg:
+		m.logOperation("Put a new version of "+string(msg), nil)
+	case SMSecretRotatedMsg:
+		m.logOperation("Started rotating "+string(msg), nil)
+	case TransferServerActionMsg:
+		m.logOperation(fmt.Sprintf("Server %s %s", msg.ServerId, msg.Action), nil)
+	case SQSAttributesUpdatedMsg:
+		m.logOperation(fmt.Sprintf("Updated attributes of %s", string(msg)), nil)
+	case ECSTaskDefRegisteredMsg:
+		m.logOperation("Registered "+string(msg), nil)
+	case ECSTaskLaunchedMsg:
+		m.logOperation("Started task "+msg.TaskArn, nil)
+	case CFFunctionPublishedMsg:
+		m.logOperation(fmt.Sprintf("Published %s to LIVE", string(msg)), nil)
+	case TagsSavedMsg:
+		m.logOperation(msg.action, msg.err)
+	case TestEventSentMsg:
+		action := fmt.Sprintf("Sent %q to %s", msg.template, msg.target.name)
+		switch {
+		case msg.err != nil:
+			m.logOperation(action, msg.err)
+		case msg.failed:
+			m.logOperation(action, fmt.Errorf("function failed with %s", msg.result))
+		default:
+			m.logOperation(action, nil)
+		}
+	case error:
+		// Every service reports failures as an XxxErrorMsg error
+		if m.pendingOperation != "" {
+			m.logOperation("Action failed", msg)
+		}
+	}
+}
+
+// openOpsLog shows the operations log; L closes it again
+func (m *Model) openOpsLog() tea.Cmd {
+	if len(m.opsLog) == 0 {
+		return m.showToast("No operations run yet")
+	}
+	w, h := GetMainContainerSize(m.width, m.height)
+	m.opsLogViewport = viewport.New(min(w-8, 110), max(min(len(m.opsLog), h-AppInternalFooterHeight-10), 1))
+	m.opsLogActive = true
+	m.setOpsLogContent()
+	return nil
+}
+
+// setOpsLogContent renders the log, oldest first, and scrolls to the latest
+func (m *Model) setOpsLogContent() {
+	var s strings.Builder
+	for i, op := range m.opsLog {
+		if i > 0 {
+			s.WriteString("\n")
+		}
+		outcome := m.styles.Success.Render("✔")
+		action := op.action
+		if op.err != nil {
+			outcome = m.styles.Error.Render("✘")
+			action += ": " + m.styles.Error.Render(op.err.Error())
+		}
+		s.WriteString(m.styles.StatusMuted.Render(op.at.Format("15:04:05")) + " " + outcome + " " +
+			lipgloss.NewStyle().Foreground(m.styles.Primary).Render(op.target) + "  " + action)
+	}
+	m.opsLogViewport.SetContent(lipgloss.NewStyle().Width(m.opsLogViewport.Width).Render(s.String()))
+	m.opsLogViewport.GotoBottom()
+}
+
+func (m *Model) handleOpsLogKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return *m, tea.Quit
+	case "esc", "q", "L":
+		m.opsLogActive = false
+		return *m, nil
+	}
+
+	if scrollViewport(&m.opsLogViewport, msg.String()) {
+		return *m, nil
+	}
+	var cmd tea.Cmd
+	m.opsLogViewport, cmd = m.opsLogViewport.Update(msg)
+	return *m, cmd
+}
+
+func (m Model) renderOpsLog() string {
+	title := lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render(fmt.Sprintf("Operations Log (%d)", len(m.opsLog)))
+	popup := m.styles.Popup.Width(m.opsLogViewport.Width + 4).Render(
+		title + "\n\n" + m.opsLogViewport.View() + "\n\n" +
+			renderScrollIndicator(m.styles, m.opsLogViewport, m.styles.StatusMuted.Render("(↑/↓ to scroll, esc to close)")),
+	)
+	w, h := GetMainContainerSize(m.width, m.height)
+	return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, popup)
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"slices"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// togglePin pins the selected row of the view on screen, or unpins it
+func (m *Model) togglePin() tea.Cmd {
+	l := m.activeList()
+	if m.view == viewHome || l == nil || l.SelectedItem() == nil {
+		return nil
+	}
+	key := rowKey(l.SelectedItem())
+	if key == ".." {
+		return nil
+	}
+
+	name := key
+	if item, ok := l.SelectedItem().(list.DefaultItem); ok {
+		name = item.Title()
+	}
+
+	view := m.getViewTitle()
+	if m.pins == nil {
+		m.pins = make(map[string]map[string]bool)
+	}
+	if m.pins[view] == nil {
+		m.pins[view] = make(map[string]bool)
+	}
+	toast := "Pinned " + name
+	if m.pins[view][key] {
+		delete(m.pins[view], key)
+		toast = "Unpinned " + name
+	} else {
+		m.pins[view][key] = true
+	}
+	m.sortPinned()
+
+	// Keep the cursor on the row that moved
+	for i, item := range l.Items() {
+		if rowKey(item) == key {
+			l.Select(i)
+			break
+		}
+	}
+	return m.showMutedToast(toast)
+}
+
+// sortPinned moves the pinned rows of the view on screen to the top, keeping
+// the order of the rest. The list is only replaced when the order changes,
+// so it is cheap to call after every message.
+func (m *Model) sortPinned() {
+	pins := m.pins[m.getViewTitle()]
+	l := m.activeList()
+	if len(pins) == 0 || l == nil || l.FilterState() == list.Filtering {
+		return
+	}
+
+	items := l.Items()
+	// A ".." row stays first so the way back is where it always is
+	var back []list.Item
+	if len(items) > 0 && rowKey(items[0]) == ".." {
+		back, items = items[:1], items[1:]
+	}
+
+	var pinned, rest []list.Item
+	sorted := true
+	for _, item := range items {
+		if pins[rowKey(item)] {
+			if len(rest) > 0 {
+				sorted = false
+			}
+			pinned = append(pinned, item)
+		} else {
+			rest = append(rest, item)
+		}
+	}
+	if sorted {
+		return
+	}
+	l.SetItems(slices.Concat(back, pinned, rest))
+}
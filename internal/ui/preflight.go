@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+	"github.com/giovannirossini/aws-tui/internal/cache"
+	"github.com/giovannirossini/aws-tui/internal/logging"
+)
+
+// preflightMsg reports whether the selected profile can call STS
+type preflightMsg struct {
+	identity *aws.IdentityInfo
+	err      error
+}
+
+// preflight validates the selected profile's credentials with an uncached
+// GetCallerIdentity, so broken credentials are caught before any view loads
+func (m Model) preflight() tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		stsClient, err := aws.NewSTSClient(ctx, m.selectedProfile)
+		if err != nil {
+			return preflightMsg{err: err}
+		}
+		id, err := stsClient.GetCallerIdentity(ctx)
+		if err != nil {
+			return preflightMsg{err: err}
+		}
+		m.cache.Set(m.cacheKeys.Identity(), id, cache.TTLIdentity)
+		return preflightMsg{identity: id}
+	}
+}
+
+func (m *Model) handlePreflight(msg preflightMsg) (tea.Model, tea.Cmd) {
+	m.preflightChecking = false
+	m.preflightErr = msg.err
+	if msg.err != nil {
+		logging.Printf("preflight failed for profile=%s: %v", m.selectedProfile, msg.err)
+		return *m, nil
+	}
+	m.identity = msg.identity
+	// Fill in the account alias in the background
+	m.cache.Delete(m.cacheKeys.Identity())
+	return *m, tea.Batch(m.fetchIdentity(), m.fetchResourceCounts(), m.fetchAttention())
+}
+
+func (m *Model) retryPreflight() tea.Cmd {
+	m.preflightChecking = true
+	m.preflightErr = nil
+	return m.preflight()
+}
+
+// preflightBlocking reports whether the preflight screen replaces the UI
+func (m Model) preflightBlocking() bool {
+	return m.preflightChecking || m.preflightErr != nil
+}
+
+// handlePreflightKeyPress only allows retrying, switching profile and quitting
+// until the credentials check passes
+func (m *Model) handlePreflightKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return *m, tea.Quit
+	case "p":
+		m.profileSelector.active = true
+		m.profileSelector.list.FilterInput.Focus()
+	case "r":
+		if m.preflightErr != nil {
+			return *m, m.retryPreflight()
+		}
+	}
+	return *m, nil
+}
+
+// preflightDiagnosis explains a credentials failure and how to fix it, based
+// on the messages the SDK returns for the common cases
+func preflightDiagnosis(err error, profile string) (problem string, hints []string) {
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "failed to get shared config profile"):
+		configPath, _ := aws.ConfigFilePath()
+		credsPath, _ := aws.CredentialsFilePath()
+		return fmt.Sprintf("Profile %s is not defined", profile), []string{
+			fmt.Sprintf("Check the profile name in %s and %s", configPath, credsPath),
+			"Press p to pick another profile",
+		}
+	case strings.Contains(msg, "sso") && (strings.Contains(msg, "expired") || strings.Contains(msg, "token")):
+		return "The SSO session has expired", []string{
+			fmt.Sprintf("Run: aws sso login --profile %s", profile),
+		}
+	case strings.Contains(msg, "expiredtoken"), strings.Contains(msg, "token included in the request is expired"):
+		return "The session credentials have expired", []string{
+			"Refresh the temporary credentials for this profile",
+			fmt.Sprintf("For SSO profiles run: aws sso login --profile %s", profile),
+		}
+	case strings.Contains(msg, "failed to retrieve credentials"), strings.Contains(msg, "no ec2 imds role found"),
+		strings.Contains(msg, "anonymous credentials"):
+		return "No credentials were found for this profile", []string{
+			fmt.Sprintf("Run: aws configure --profile %s", profile),
+			"Or export AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY",
+		}
+	case strings.Contains(msg, "invalidclienttokenid"), strings.Contains(msg, "signaturedoesnotmatch"):
+		return "The credentials were rejected", []string{
+			"Check that the access key is active and the secret key is correct",
+			"Make sure the system clock is accurate",
+		}
+	case strings.Contains(msg, "dial tcp"), strings.Contains(msg, "no such host"), strings.Contains(msg, "i/o timeout"),
+		strings.Contains(msg, "connection refused"), strings.Contains(msg, "deadline exceeded"):
+		return "AWS could not be reached", []string{
+			"Check the network connection, proxy and VPN settings",
+			"Use --timeout to allow slower connections",
+		}
+	default:
+		return "The credentials check failed", []string{
+			"Check the profile configuration",
+			"Run with --debug for details",
+		}
+	}
+}
+
+func (m Model) renderPreflight() string {
+	w, h := GetMainContainerSize(m.width, m.height)
+	h -= AppInternalFooterHeight + 2
+
+	if m.preflightErr == nil {
+		message := lipgloss.NewStyle().Foreground(m.styles.Primary).
+			Render(fmt.Sprintf("󱎯 Checking credentials for profile %s...", m.selectedProfile))
+		return lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, message)
+	}
+
+	problem, hints := preflightDiagnosis(m.preflightErr, m.selectedProfile)
+
+	var s strings.Builder
+	s.WriteString(m.styles.Error.Bold(true).Render("✘ "+problem) + "\n\n")
+	for _, hint := range hints {
+		s.WriteString("  • " + hint + "\n")
+	}
+	s.WriteString("\n" + m.styles.StatusMuted.Render(m.preflightErr.Error()) + "\n\n")
+	s.WriteString(m.styles.StatusKey.Render("r") + " " + m.styles.StatusMuted.Render("Retry") + m.styles.StatusMuted.Render(" • ") +
+		m.styles.StatusKey.Render("p") + " " + m.styles.StatusMuted.Render("Switch profile") + m.styles.StatusMuted.Render(" • ") +
+		m.styles.StatusKey.Render("q") + " " + m.styles.StatusMuted.Render("Quit"))
+
+	popup := m.styles.Popup.Width(min(w-4, 80)).BorderForeground(m.styles.ErrorColor).Render(s.String())
+	return lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, popup)
+}
@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+const (
+	// profileCheckWorkers bounds how many profiles are checked at once
+	profileCheckWorkers = 8
+	// profileCheckTimeout bounds each profile, so one hanging SSO or
+	// credential process does not hold up the table
+	profileCheckTimeout = 10 * time.Second
+)
+
+// profileCheckMsg carries the identity of every profile
+type profileCheckMsg []aws.ProfileStatus
+
+// openProfileCheck shows the profile diagnostics screen and starts checking
+// every profile
+func (m *Model) openProfileCheck() tea.Cmd {
+	m.profileCheckActive = true
+	m.profileCheckRunning = true
+	m.profileCheckSelected = 0
+	profiles := m.profiles
+	return func() tea.Msg {
+		return profileCheckMsg(aws.CheckProfiles(context.Background(), profiles, profileCheckWorkers, profileCheckTimeout))
+	}
+}
+
+func (m *Model) handleProfileCheck(msg profileCheckMsg) (tea.Model, tea.Cmd) {
+	m.profileCheckRunning = false
+	m.profileCheck = msg
+	if m.profileCheckSelected >= len(msg) {
+		m.profileCheckSelected = 0
+	}
+	return *m, nil
+}
+
+func (m *Model) handleProfileCheckKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return *m, tea.Quit
+	case "esc", "q":
+		m.profileCheckActive = false
+	case "r":
+		if !m.profileCheckRunning {
+			return *m, m.openProfileCheck()
+		}
+	case "up", "k":
+		if m.profileCheckSelected > 0 {
+			m.profileCheckSelected--
+		}
+	case "down", "j":
+		if m.profileCheckSelected < len(m.profileCheck)-1 {
+			m.profileCheckSelected++
+		}
+	case "enter":
+		if m.profileCheckRunning || len(m.profileCheck) == 0 {
+			return *m, nil
+		}
+		m.profileCheckActive = false
+		profile := m.profileCheck[m.profileCheckSelected].Profile
+		m.profileSelector.selected = profile
+		return m.handleProfileChange(profile)
+	}
+	return *m, nil
+}
+
+func (m Model) renderProfileCheck() string {
+	w, h := GetMainContainerSize(m.width, m.height)
+	h -= AppInternalFooterHeight + 2
+	width := min(96, w-4)
+
+	cols := []lipgloss.Style{
+		lipgloss.NewStyle().Width(24).MaxWidth(24).PaddingRight(2),
+		lipgloss.NewStyle().Width(15).MaxWidth(15).PaddingRight(2),
+		lipgloss.NewStyle().Width(16).MaxWidth(16).PaddingRight(2),
+		lipgloss.NewStyle().Width(max(width-59, 10)).MaxWidth(max(width-59, 10)),
+	}
+	row := func(values ...string) string {
+		cells := make([]string, len(values))
+		for i, v := range values {
+			cells[i] = cols[i].Render(v)
+		}
+		return lipgloss.JoinHorizontal(lipgloss.Top, cells...)
+	}
+
+	var s strings.Builder
+	s.WriteString(m.styles.ViewTitle.Render("Profile Diagnostics") + "\n\n")
+	s.WriteString("  " + lipgloss.NewStyle().Foreground(m.styles.Muted).Bold(true).
+		Render(row("PROFILE", "ACCOUNT", "REGION", "STATUS")) + "\n")
+
+	if m.profileCheckRunning {
+		s.WriteString(lipgloss.NewStyle().Foreground(m.styles.Primary).
+			Render(fmt.Sprintf("󱎯 Checking %d profiles...", len(m.profiles))) + "\n")
+	} else {
+		// Keep the selected row on screen
+		visible := max(h-10, 3)
+		start := max(0, m.profileCheckSelected-visible+1)
+		end := min(len(m.profileCheck), start+visible)
+
+		ok := 0
+		for _, p := range m.profileCheck {
+			if p.Err == nil {
+				ok++
+			}
+		}
+
+		for i := start; i < end; i++ {
+			p := m.profileCheck[i]
+			// The principal, e.g. user/alice or assumed-role/Admin/session
+			status := m.styles.Success.Render("✔ " + p.Arn[strings.LastIndex(p.Arn, ":")+1:])
+			if p.Err != nil {
+				problem, _ := preflightDiagnosis(p.Err, p.Profile)
+				status = m.styles.Error.Render("✘ " + problem)
+			}
+			line := row(p.Profile, p.Account, p.Region, status)
+			if i == m.profileCheckSelected {
+				line = lipgloss.NewStyle().Foreground(m.styles.Primary).Render("> ") + line
+			} else {
+				line = "  " + line
+			}
+			s.WriteString(line + "\n")
+		}
+		s.WriteString("\n" + m.styles.StatusMuted.Render(
+			fmt.Sprintf(" %d of %d profiles working", ok, len(m.profileCheck))) + "\n")
+	}
+
+	s.WriteString(m.styles.StatusMuted.Render(" (enter to switch profile, r to re-check, esc to close)"))
+	popup := m.styles.Popup.Width(width).Render(s.String())
+	return lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, popup)
+}
@@ -0,0 +1,265 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+// compareProfile is a profile offered to compare the selected resource with
+type compareProfile string
+
+func (p compareProfile) Title() string       { return string(p) }
+func (p compareProfile) Description() string { return "" }
+func (p compareProfile) FilterValue() string { return string(p) }
+
+// ProfileCompareMsg carries the configuration of the same Lambda function in
+// two profiles, the current one first
+type ProfileCompareMsg struct {
+	Name     string
+	Profiles [2]string
+	Configs  [2]string
+	Err      error
+}
+
+// openCompare offers the other profiles to compare a Lambda function's
+// configuration with
+func (m *Model) openCompare(name string) tea.Cmd {
+	var items []list.Item
+	for _, profile := range m.profiles {
+		if profile != m.selectedProfile {
+			items = append(items, compareProfile(profile))
+		}
+	}
+	if len(items) == 0 {
+		return m.showMutedToast("No other profile to compare with")
+	}
+
+	d := list.NewDefaultDelegate()
+	d.ShowDescription = false
+	d.Styles.SelectedTitle = m.styles.ListSelectedTitle
+
+	_, h := GetMainContainerSize(m.width, m.height)
+	m.compareList = list.New(items, d, 56, min(len(items)+6, h-6))
+	m.compareList.Title = "Compare " + name + " with"
+	m.compareList.SetShowStatusBar(false)
+	m.compareList.SetShowHelp(false)
+	m.compareName = name
+	m.comparePicking = true
+	m.compareActive = true
+	return nil
+}
+
+// fetchCompare gets the configuration from both profiles at once, each with a
+// client of its own
+func fetchCompare(name string, profiles [2]string) tea.Cmd {
+	return func() tea.Msg {
+		msg := ProfileCompareMsg{Name: name, Profiles: profiles}
+		errs := make([]error, 2)
+		var wg sync.WaitGroup
+		for i, profile := range profiles {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				client, err := aws.NewLambdaClient(context.Background(), profile)
+				if err != nil {
+					errs[i] = fmt.Errorf("%s: %w", profile, err)
+					return
+				}
+				msg.Configs[i], err = client.GetFunctionConfigJSON(context.Background(), name)
+				if err != nil {
+					errs[i] = fmt.Errorf("%s: %w", profile, err)
+				}
+			}()
+		}
+		wg.Wait()
+		for _, err := range errs {
+			if err != nil {
+				msg.Err = err
+				break
+			}
+		}
+		return msg
+	}
+}
+
+func (m *Model) handleCompareKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.comparePicking {
+		switch msg.String() {
+		case "ctrl+c":
+			return *m, tea.Quit
+		case "esc", "q":
+			m.compareActive = false
+			return *m, nil
+		case "enter":
+			other, ok := m.compareList.SelectedItem().(compareProfile)
+			if !ok {
+				return *m, nil
+			}
+			m.comparePicking = false
+			m.compareSame = false
+			m.compareProfiles = [2]string{m.selectedProfile, string(other)}
+			m.compareViewport = viewport.New(m.compareWidth(), 1)
+			m.compareViewport.SetContent(m.styles.StatusMuted.Render("Fetching from both profiles..."))
+			return *m, fetchCompare(m.compareName, m.compareProfiles)
+		}
+		var cmd tea.Cmd
+		m.compareList, cmd = m.compareList.Update(msg)
+		return *m, cmd
+	}
+
+	switch msg.String() {
+	case "ctrl+c":
+		return *m, tea.Quit
+	case "esc", "q":
+		m.compareActive = false
+		return *m, nil
+	}
+	if scrollViewport(&m.compareViewport, msg.String()) {
+		return *m, nil
+	}
+	var cmd tea.Cmd
+	m.compareViewport, cmd = m.compareViewport.Update(msg)
+	return *m, cmd
+}
+
+func (m *Model) handleProfileCompare(msg ProfileCompareMsg) (tea.Model, tea.Cmd) {
+	if !m.compareActive || m.comparePicking || msg.Name != m.compareName || msg.Profiles != m.compareProfiles {
+		return *m, nil
+	}
+	if msg.Err != nil {
+		m.compareActive = false
+		return *m, m.showToast("✘ Could not compare: " + msg.Err.Error())
+	}
+
+	content := renderSideBySide(m.styles,
+		strings.Split(msg.Configs[0], "\n"), strings.Split(msg.Configs[1], "\n"), m.compareWidth())
+	_, h := GetMainContainerSize(m.width, m.height)
+	m.compareViewport.Height = max(min(strings.Count(content, "\n")+1, h-AppInternalFooterHeight-12), 1)
+	m.compareViewport.SetContent(content)
+	m.compareSame = msg.Configs[0] == msg.Configs[1]
+	return *m, nil
+}
+
+// compareWidth is the width of the side-by-side view, both columns included
+func (m Model) compareWidth() int {
+	w, _ := GetMainContainerSize(m.width, m.height)
+	return max(w-8, 40)
+}
+
+// diffOp is a line of a line-by-line diff: in both sides, or in one only
+type diffOp struct {
+	left, right string
+	inLeft      bool
+	inRight     bool
+}
+
+// diffLines aligns two documents line by line on their longest common
+// subsequence, which is plenty for configs of a few hundred lines
+func diffLines(a, b []string) []diffOp {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, diffOp{left: a[i], right: b[j], inLeft: true, inRight: true})
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			ops = append(ops, diffOp{right: b[j], inRight: true})
+			j++
+		default:
+			ops = append(ops, diffOp{left: a[i], inLeft: true})
+			i++
+		}
+	}
+	return ops
+}
+
+// renderSideBySide lays two documents out in columns. Lines only on the left
+// are red, lines only on the right green, and runs of both facing each other
+// are shown as changed lines in yellow.
+func renderSideBySide(styles Styles, a, b []string, width int) string {
+	colWidth := (width - 3) / 2
+	cell := func(style lipgloss.Style, s string) string {
+		if r := []rune(s); len(r) > colWidth {
+			s = string(r[:colWidth-1]) + "…"
+		}
+		return style.Width(colWidth).Render(s)
+	}
+	sep := styles.StatusMuted.Render(" │ ")
+	plain := lipgloss.NewStyle()
+
+	ops := diffLines(a, b)
+	var rows []string
+	for k := 0; k < len(ops); {
+		if ops[k].inLeft && ops[k].inRight {
+			rows = append(rows, cell(plain, ops[k].left)+sep+cell(plain, ops[k].right))
+			k++
+			continue
+		}
+		// Gather the run of differing lines and pair its two sides up
+		var left, right []string
+		for ; k < len(ops) && !(ops[k].inLeft && ops[k].inRight); k++ {
+			if ops[k].inLeft {
+				left = append(left, ops[k].left)
+			} else {
+				right = append(right, ops[k].right)
+			}
+		}
+		for n := 0; n < max(len(left), len(right)); n++ {
+			switch {
+			case n < len(left) && n < len(right):
+				rows = append(rows, cell(styles.Warning, left[n])+sep+cell(styles.Warning, right[n]))
+			case n < len(left):
+				rows = append(rows, cell(styles.Error, left[n])+sep+cell(plain, ""))
+			default:
+				rows = append(rows, cell(plain, "")+sep+cell(styles.Success, right[n]))
+			}
+		}
+	}
+	return strings.Join(rows, "\n")
+}
+
+func (m Model) renderCompare() string {
+	w, h := GetMainContainerSize(m.width, m.height)
+	if m.comparePicking {
+		return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center,
+			m.styles.Popup.Render(m.compareList.View()+"\n\n "+m.styles.StatusMuted.Render("(enter to compare, esc to cancel)")))
+	}
+
+	title := lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render("Lambda / " + m.compareName)
+	colWidth := (m.compareViewport.Width - 3) / 2
+	header := lipgloss.NewStyle().Width(colWidth).Bold(true).Render(m.compareProfiles[0]) + "   " +
+		lipgloss.NewStyle().Width(colWidth).Bold(true).Render(m.compareProfiles[1])
+	summary := m.styles.StatusMuted.Render("(↑/↓ to scroll, esc to close)")
+	if m.compareSame {
+		summary = m.styles.Success.Render("✔ Identical") + "  " + summary
+	}
+	popup := m.styles.Popup.Width(m.compareViewport.Width + 4).Render(
+		title + "\n\n" + header + "\n" + m.compareViewport.View() + "\n\n" +
+			renderScrollIndicator(m.styles, m.compareViewport, summary),
+	)
+	return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, popup)
+}
@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// progressBarWidth is the width of the bar in the progress overlay
+const progressBarWidth = 40
+
+// renderProgressBar draws fraction (0–1) of width cells filled, followed by
+// the percentage. It is drawn with lipgloss rather than bubbles/progress,
+// whose animation this needs none of.
+func renderProgressBar(styles Styles, fraction float64, width int) string {
+	fraction = min(max(fraction, 0), 1)
+	filled := int(fraction * float64(width))
+	return lipgloss.NewStyle().Foreground(styles.Primary).Render(strings.Repeat("━", filled)) +
+		lipgloss.NewStyle().Foreground(styles.Muted).Render(strings.Repeat("━", width-filled)) +
+		styles.StatusMuted.Render(fmt.Sprintf(" %3.0f%%", fraction*100))
+}
+
+// progressReport is what a running operation reports of itself; fraction is
+// how much of it is done, from 0 to 1
+type progressReport func(fraction float64, detail string)
+
+// ProgressMsg carries a step of the operation started by startProgress. The
+// first one carries the operation's label and opens the overlay; the last one
+// has done set and carries the operation's own result, which is handled as
+// if the operation had been a plain command.
+type ProgressMsg struct {
+	label    string
+	fraction float64
+	detail   string
+	done     bool
+	result   tea.Msg
+	updates  <-chan ProgressMsg // Identifies the operation
+}
+
+// progressState is the operation the overlay is showing
+type progressState struct {
+	updates  <-chan ProgressMsg
+	label    string
+	fraction float64
+	detail   string
+}
+
+// startProgress returns a command that runs a multi-step operation in the
+// background and shows its progress in an overlay until it returns, so views
+// can start one like any other command. run reports each step; reports that
+// arrive faster than they are drawn are dropped, but the result never is.
+func startProgress(label string, run func(report progressReport) tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		updates := make(chan ProgressMsg, 1)
+		go func() {
+			report := func(fraction float64, detail string) {
+				select {
+				case updates <- ProgressMsg{fraction: fraction, detail: detail, updates: updates}:
+				default:
+				}
+			}
+			result := run(report)
+			// Drain an undrawn step so the result cannot be dropped
+			select {
+			case <-updates:
+			default:
+			}
+			updates <- ProgressMsg{fraction: 1, done: true, result: result, updates: updates}
+		}()
+		return ProgressMsg{label: label, updates: updates}
+	}
+}
+
+// waitForProgress blocks until the operation reports its next step
+func waitForProgress(updates <-chan ProgressMsg) tea.Cmd {
+	return func() tea.Msg {
+		return <-updates
+	}
+}
+
+func (m *Model) handleProgress(msg ProgressMsg) (tea.Model, tea.Cmd) {
+	current := m.progress != nil && m.progress.updates == msg.updates
+	switch {
+	case msg.label != "":
+		m.progress = &progressState{updates: msg.updates, label: msg.label}
+	case msg.done:
+		if current {
+			m.progress = nil
+		}
+		result := msg.result
+		return *m, func() tea.Msg { return result }
+	case current:
+		m.progress.fraction = msg.fraction
+		m.progress.detail = msg.detail
+	}
+	return *m, waitForProgress(msg.updates)
+}
+
+// renderProgress draws the running operation over the main content
+func (m Model) renderProgress(base string) string {
+	if m.progress == nil {
+		return base
+	}
+	content := fmt.Sprintf(" %s\n\n %s",
+		lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render(m.progress.label),
+		renderProgressBar(m.styles, m.progress.fraction, progressBarWidth))
+	if m.progress.detail != "" {
+		content += "\n\n " + m.styles.StatusMuted.Render(m.progress.detail)
+	}
+	w, h := GetMainContainerSize(m.width, m.height)
+	return RenderOverlay(base, m.styles.Popup.Width(progressBarWidth+10).Render(content), w, h-AppInternalFooterHeight-2)
+}
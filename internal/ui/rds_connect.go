@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// openConnect shows the bastion prompt for tunnelling to the selected instance
+func (m *RDSModel) openConnect(item rdsItem) {
+	m.connectTarget = item
+	m.bastionInput = textinput.New()
+	m.bastionInput.Placeholder = "i-0123456789abcdef0"
+	m.bastionInput.CharLimit = 32
+	m.bastionInput.Width = 22
+	m.bastionInput.Focus()
+	m.localPortInput = newPortInput(strconv.Itoa(int(item.port)))
+	m.connectErr = ""
+	m.state = RDSStateConnectInput
+}
+
+func (m RDSModel) updateConnect(msg tea.KeyMsg) (RDSModel, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "esc":
+		m.state = RDSStateInstances
+		return m, nil
+	case "tab", "shift+tab", "up", "down":
+		if m.bastionInput.Focused() {
+			m.bastionInput.Blur()
+			m.localPortInput.Focus()
+		} else {
+			m.localPortInput.Blur()
+			m.bastionInput.Focus()
+		}
+		return m, nil
+	case "enter":
+		bastion := strings.TrimSpace(m.bastionInput.Value())
+		if !strings.HasPrefix(bastion, "i-") && !strings.HasPrefix(bastion, "mi-") {
+			m.connectErr = "enter the instance ID of the bastion"
+			return m, nil
+		}
+		remote := strconv.Itoa(int(m.connectTarget.port))
+		local := remote
+		if m.localPortInput.Value() != "" {
+			var err error
+			if local, err = parsePort(m.localPortInput.Value()); err != nil {
+				m.connectErr = err.Error()
+				return m, nil
+			}
+		}
+		m.state = RDSStateInstances
+		target := m.connectTarget
+		return m, func() tea.Msg {
+			return SSMPortForwardMsg{Target: bastion, Host: target.endpoint, RemotePort: remote, LocalPort: local}
+		}
+	}
+
+	if m.bastionInput.Focused() {
+		m.bastionInput, cmd = m.bastionInput.Update(msg)
+	} else {
+		m.localPortInput, cmd = m.localPortInput.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m RDSModel) renderConnect() string {
+	labelStyle := lipgloss.NewStyle().Foreground(m.styles.Primary).Width(14)
+	content := fmt.Sprintf(
+		" %s\n %s\n\n %s%s\n %s%s\n",
+		lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render("Connect to "+m.connectTarget.id+" via bastion"),
+		m.styles.StatusMuted.Render(fmt.Sprintf("%s:%d", m.connectTarget.endpoint, m.connectTarget.port)),
+		labelStyle.Render("Bastion ID"), m.bastionInput.View(),
+		labelStyle.Render("Local port"), m.localPortInput.View(),
+	)
+	if m.connectErr != "" {
+		content += "\n " + m.styles.Error.Render("✘ "+m.connectErr) + "\n"
+	}
+	content += "\n " + m.styles.StatusMuted.Render("(tab to switch, esc to cancel)")
+
+	popup := m.styles.Popup.Width(60).Render(content)
+	w, h := GetMainContainerSize(m.width, m.height)
+	return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, popup)
+}
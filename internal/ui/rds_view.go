@@ -6,6 +6,7 @@ import (
 	"io"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/giovannirossini/aws-tui/internal/aws"
 	"github.com/giovannirossini/aws-tui/internal/cache"
@@ -19,6 +20,7 @@ const (
 	RDSStateClusters
 	RDSStateSnapshots
 	RDSStateSubnetGroups
+	RDSStateConnectInput
 )
 
 type rdsItem struct {
@@ -26,29 +28,51 @@ type rdsItem struct {
 	description string
 	id          string
 	category    string
+	endpoint    string
+	port        int32
+	dbName      string
+	arn         string
 	values      []string
+	info        any
 }
 
 func (i rdsItem) Title() string       { return i.title }
+func (i rdsItem) source() any         { return i.info }
 func (i rdsItem) Description() string { return i.description }
 func (i rdsItem) FilterValue() string { return i.title + " " + i.description + " " + i.id }
+func (i rdsItem) rowID() string       { return i.id }
+func (i rdsItem) rowValues() []string { return i.values }
+func (i rdsItem) tagTarget() (tagTarget, bool) {
+	if i.arn == "" {
+		return tagTarget{}, false
+	}
+	return tagTarget{kind: tagResourceRDS, id: i.arn, name: i.title}, true
+}
 
 type RDSModel struct {
 	client    *aws.RDSClient
 	list      list.Model
 	styles    Styles
+	table     *tableLayout
 	state     RDSState
 	width     int
 	height    int
 	profile   string
 	err       error
+	loaded    bool
 	cache     *cache.Cache
 	cacheKeys *cache.KeyBuilder
+	// Connect via bastion prompt
+	connectTarget  rdsItem
+	bastionInput   textinput.Model
+	localPortInput textinput.Model
+	connectErr     string
 }
 
 type rdsItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 	state  RDSState
 }
 
@@ -89,7 +113,7 @@ func (d rdsItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 	}
 
 	if d.state == RDSStateMenu {
-		d.DefaultDelegate.Render(w, m, index, listItem)
+		d.table.renderMenuItem(d.DefaultDelegate, w, m, index, listItem)
 		return
 	}
 
@@ -105,23 +129,27 @@ func (d rdsItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 		columns = rdsSubnetColumns
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, columns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, columns, d.table)
 	isSelected := index == m.Index()
 
-	RenderTableRow(w, m, d.styles, colStyles, i.values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, i.values, isSelected)
 }
 
 func (d rdsItemDelegate) Height() int {
 	if d.state == RDSStateMenu {
-		return 2
+		return d.table.menuItemHeight()
 	}
 	return 1
 }
 
-func NewRDSModel(profile string, styles Styles, appCache *cache.Cache) RDSModel {
+func (d rdsItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewRDSModel(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) RDSModel {
+	table := &tableLayout{display: display}
 	d := rdsItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 		state:           RDSStateMenu,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
@@ -136,6 +164,7 @@ func NewRDSModel(profile string, styles Styles, appCache *cache.Cache) RDSModel
 	return RDSModel{
 		list:      l,
 		styles:    styles,
+		table:     table,
 		state:     RDSStateMenu,
 		profile:   profile,
 		cache:     appCache,
@@ -260,71 +289,88 @@ func (m RDSModel) Update(msg tea.Msg) (RDSModel, tea.Cmd) {
 		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
 	case RDSMenuMsg:
-		m.list.SetItems(msg)
+		m.table.setItems(&m.list, msg)
 		m.list.ResetSelected()
 		m.state = RDSStateMenu
 		m.updateDelegate()
 
 	case RDSInstancesMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			items[i] = rdsItem{
+				info:        v,
 				title:       v.ID,
 				description: v.Engine,
 				id:          v.ID,
 				category:    "instance",
-				values:      []string{v.ID, v.Engine, v.Status, v.Class, v.Endpoint},
+				arn:         v.Arn,
+				endpoint:    v.Endpoint,
+				port:        v.Port,
+				dbName:      v.DBName,
+				values:      []string{v.ID, v.Engine, renderStatus(m.styles, v.Status), v.Class, v.Endpoint},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = RDSStateInstances
 		m.updateDelegate()
 
 	case RDSClustersMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			items[i] = rdsItem{
+				info:        v,
 				title:       v.ID,
 				description: v.Engine,
 				id:          v.ID,
 				category:    "cluster",
-				values:      []string{v.ID, v.Engine, v.Status, v.VpcID},
+				arn:         v.Arn,
+				endpoint:    v.Endpoint,
+				port:        v.Port,
+				dbName:      v.DBName,
+				values:      []string{v.ID, v.Engine, renderStatus(m.styles, v.Status), v.VpcID},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = RDSStateClusters
 		m.updateDelegate()
 
 	case RDSSnapshotsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			items[i] = rdsItem{
+				info:        v,
 				title:       v.ID,
 				description: v.InstanceID,
 				id:          v.ID,
 				category:    "snapshot",
-				values:      []string{v.ID, v.InstanceID, v.Status, v.Type, v.CreateTime},
+				arn:         v.Arn,
+				values:      []string{v.ID, v.InstanceID, renderStatus(m.styles, v.Status), v.Type, humanizeTime(v.CreateTime)},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = RDSStateSnapshots
 		m.updateDelegate()
 
 	case RDSSubnetGroupsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			items[i] = rdsItem{
+				info:        v,
 				title:       v.Name,
 				description: v.VpcID,
 				id:          v.Name,
 				category:    "subnet-group",
-				values:      []string{v.Name, v.Description, v.VpcID, v.Status},
+				values:      []string{v.Name, v.Description, v.VpcID, renderStatus(m.styles, v.Status)},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = RDSStateSubnetGroups
 		m.updateDelegate()
@@ -338,7 +384,20 @@ func (m RDSModel) Update(msg tea.Msg) (RDSModel, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.state == RDSStateConnectInput {
+			return m.updateConnect(msg)
+		}
+
 		switch msg.String() {
+		case "c":
+			if item, ok := m.list.SelectedItem().(rdsItem); ok && m.state == RDSStateInstances && item.endpoint != "" {
+				m.openConnect(item)
+				return m, textinput.Blink
+			}
+		case "y":
+			if item, ok := m.list.SelectedItem().(rdsItem); ok && (m.state == RDSStateInstances || m.state == RDSStateClusters) && item.endpoint != "" && !m.list.SettingFilter() {
+				return m, copyToClipboard(rdsConnectionString(item.description, item.endpoint, item.port, item.dbName), "connection string")
+			}
 		case "r":
 			switch m.state {
 			case RDSStateInstances:
@@ -384,6 +443,7 @@ func (m *RDSModel) updateDelegate() {
 	d := rdsItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          m.styles,
+		table:           m.table,
 		state:           m.state,
 	}
 	d.Styles.SelectedTitle = m.styles.ListSelectedTitle
@@ -391,24 +451,44 @@ func (m *RDSModel) updateDelegate() {
 	m.list.SetDelegate(d)
 }
 
+// rdsResourceNames names the resources listed in each table state, for the empty-state message
+var rdsResourceNames = map[RDSState]string{
+	RDSStateInstances:    "RDS instances",
+	RDSStateClusters:     "RDS clusters",
+	RDSStateSnapshots:    "RDS snapshots",
+	RDSStateSubnetGroups: "DB subnet groups",
+}
+
+// columns are the columns of the table on screen
+func (m RDSModel) columns() []Column {
+	switch m.state {
+	case RDSStateInstances:
+		return rdsInstanceColumns
+	case RDSStateClusters:
+		return rdsClusterColumns
+	case RDSStateSnapshots:
+		return rdsSnapshotColumns
+	case RDSStateSubnetGroups:
+		return rdsSubnetColumns
+	}
+	return nil
+}
+
 func (m RDSModel) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
+	}
+
+	if m.state == RDSStateConnectInput {
+		return m.renderConnect()
+	}
+
+	if resource, ok := rdsResourceNames[m.state]; ok && m.loaded && len(m.list.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.list, resource, m.profile, m.table)
 	}
 
 	if m.state != RDSStateMenu {
-		var columns []Column
-		switch m.state {
-		case RDSStateInstances:
-			columns = rdsInstanceColumns
-		case RDSStateClusters:
-			columns = rdsClusterColumns
-		case RDSStateSnapshots:
-			columns = rdsSnapshotColumns
-		case RDSStateSubnetGroups:
-			columns = rdsSubnetColumns
-		}
-		_, header := RenderTableHelpers(m.list, m.styles, columns)
+		_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
 		return header + "\n" + m.list.View()
 	}
 
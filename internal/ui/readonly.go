@@ -0,0 +1,104 @@
+package ui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+const readOnlyToast = "🔒 read-only mode: action disabled"
+
+// isMutatingKey reports whether the key would trigger a create/delete/restart/stop
+// action in the current view and state. Used to enforce read-only mode.
+func (m Model) isMutatingKey(msg tea.KeyMsg) bool {
+	key := msg.String()
+
+	switch m.view {
+	case viewS3:
+		switch m.s3Model.state {
+		case S3StateBuckets:
+			return key == "n" || key == "d"
+		case S3StateObjects:
+			return key == "n" || key == "d" || key == "u" || key == "e"
+		case S3StateVersions:
+			return key == "d"
+		}
+	case viewIAM:
+		switch m.iamModel.state {
+		case IAMStateUsers:
+			return key == "n" || key == "d"
+		case IAMStateActions:
+			item, ok := m.iamModel.actionList.SelectedItem().(iamActionItem)
+			return key == "enter" && !(ok && (item.key == "policies" || item.key == "keys"))
+		case IAMStatePolicies, IAMStateGroupMembers:
+			return key == "a" || key == "d"
+		case IAMStateAccessKeys:
+			return key == "n" || key == "t" || key == "d"
+		}
+	case viewECS:
+		if m.ecsModel.state == ECSStateTasks || m.ecsModel.state == ECSStateServices {
+			return key == "o"
+		}
+		if m.ecsModel.state == ECSStateTaskDefJSON && !m.ecsModel.search.typing {
+			return key == "e"
+		}
+		switch m.ecsModel.state {
+		case ECSStateClusters, ECSStateTaskDefFamilies, ECSStateTaskDefRevisions:
+			return key == "n"
+		}
+	case viewDMS:
+		if m.dmsModel.state == DMSStateTasks {
+			return key == "o"
+		}
+	case viewSQS:
+		if m.sqsModel.state == SQSStateEditAttributes {
+			return key == "enter"
+		}
+		return key == "t" || key == "e"
+	case viewLambda:
+		if m.lambdaModel.state == LambdaStateConcurrency {
+			return key == "e" || key == "d"
+		}
+		return key == "t"
+	case viewSNS:
+		return key == "t"
+	case viewBackup:
+		switch m.backupModel.state {
+		case BackupStateJobs, BackupStateJobDetail:
+			return key == "s"
+		case BackupStateRestoreInput:
+			return key == "enter"
+		}
+	case viewTransfer:
+		return m.transferModel.state == TransferStateServers && key == "o"
+	case viewCF:
+		switch m.cfModel.state {
+		case CFStateFunctionDetail:
+			return key == "u"
+		case CFStateConfirmPublish:
+			return key == "y" || key == "Y"
+		}
+	case viewDynamoDB:
+		switch m.dynamodbModel.state {
+		case DynamoDBStateBackups:
+			return key == "n"
+		case DynamoDBStateItems:
+			return !m.dynamodbModel.list.SettingFilter() && (key == "enter" || key == "n" || key == "d")
+		case DynamoDBStateConfirmDeleteItem:
+			return key == "y" || key == "Y"
+		}
+	case viewSM:
+		switch m.smModel.state {
+		case SMStateSecrets:
+			return key == "n" && !m.smModel.list.SettingFilter()
+		case SMStateValue:
+			return key == "e" || key == "o"
+		case SMStateConfirmPut, SMStateConfirmRotate:
+			return key == "y" || key == "Y"
+		}
+	case viewACM:
+		switch m.acmModel.state {
+		case ACMStateList:
+			return key == "n" || key == "d"
+		case ACMStateConfirmRecords, ACMStateConfirmDelete:
+			return key == "y" || key == "Y"
+		}
+	}
+	return false
+}
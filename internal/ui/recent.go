@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// recentLimit is how many resources the history keeps
+const recentLimit = 20
+
+// recentResource is a resource viewed in detail during the session
+type recentResource struct {
+	service     string // Service name as listed on the home screen
+	state       int    // State of the service model that shows the resource
+	id          string
+	displayName string
+}
+
+func (r recentResource) Title() string       { return featureIcons[r.service] + r.displayName }
+func (r recentResource) Description() string { return r.service }
+func (r recentResource) FilterValue() string { return r.displayName + " " + r.service }
+
+// addRecent puts r at the front of the history, dropping an earlier visit
+func (m *Model) addRecent(r recentResource) {
+	m.recent = slices.DeleteFunc(m.recent, func(e recentResource) bool {
+		return e.service == r.service && e.state == r.state && e.id == r.id
+	})
+	m.recent = append([]recentResource{r}, m.recent...)
+	if len(m.recent) > recentLimit {
+		m.recent = m.recent[:recentLimit]
+	}
+}
+
+// trackRecent records the resource a detail message is about. It runs before
+// the message is routed, while the model still holds what was requested.
+func (m *Model) trackRecent(msg tea.Msg) {
+	switch msg := msg.(type) {
+	case S3ObjectsMsg:
+		if bucket := m.s3Model.currentBucket; bucket != "" {
+			m.addRecent(recentResource{service: "Simple Storage Service (S3)", state: int(S3StateObjects), id: bucket, displayName: "s3://" + bucket})
+		}
+	case S3BucketDetailsMsg:
+		if msg != nil {
+			m.addRecent(recentResource{service: "Simple Storage Service (S3)", state: int(S3StateBucketDetails), id: msg.Name, displayName: msg.Name + " (details)"})
+		}
+	case CWLogStreamsMsg:
+		if group := m.cwModel.selectedGroup; group != "" {
+			m.addRecent(recentResource{service: "CloudWatch", state: int(CWStateLogStreams), id: group, displayName: group})
+		}
+	case ECSTaskDetailMsg:
+		if msg != nil {
+			m.addRecent(recentResource{service: "Elastic Container Service (ECS)", state: int(ECSStateTaskDetail), id: msg.ARN, displayName: "task " + shortID(msg.ARN)})
+		}
+	}
+}
+
+// trackRecentInstance records the EC2 instance whose actions were opened
+func (m *Model) trackRecentInstance() {
+	if m.ec2Model.state != EC2StateInstanceActions {
+		return
+	}
+	name := m.ec2Model.selectedInstance
+	if item, ok := m.ec2Model.list.SelectedItem().(ec2Item); ok && item.title != "" {
+		name = item.title + " (" + item.id + ")"
+	}
+	m.addRecent(recentResource{service: "Elastic Compute Cloud (EC2)", state: int(EC2StateInstances), id: m.ec2Model.selectedInstance, displayName: name})
+}
+
+// shortID returns the last segment of an ARN
+func shortID(arn string) string {
+	return arn[strings.LastIndex(arn, "/")+1:]
+}
+
+// ecsClusterFromTaskARN extracts the cluster from a task ARN in the
+// arn:aws:ecs:region:account:task/cluster/id format
+func ecsClusterFromTaskARN(arn string) string {
+	parts := strings.Split(arn, "/")
+	if len(parts) < 3 {
+		return ""
+	}
+	return parts[1]
+}
+
+// openRecent shows the history as a jump list
+func (m *Model) openRecent() tea.Cmd {
+	if len(m.recent) == 0 {
+		return m.showToast("No resources viewed yet")
+	}
+
+	items := make([]list.Item, len(m.recent))
+	for i, r := range m.recent {
+		items[i] = r
+	}
+
+	d := list.NewDefaultDelegate()
+	d.Styles.SelectedTitle = m.styles.ListSelectedTitle
+	d.Styles.SelectedDesc = m.styles.ListSelectedDesc
+
+	_, h := GetMainContainerSize(m.width, m.height)
+	m.recentList = list.New(items, d, 56, min(len(items)*3+2, h-6))
+	m.recentList.Title = "Recent Resources"
+	m.recentList.SetShowStatusBar(false)
+	m.recentList.SetShowHelp(false)
+	m.recentList.SetFilteringEnabled(false)
+	m.recentActive = true
+	return nil
+}
+
+func (m *Model) handleRecentKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return *m, tea.Quit
+	case "esc", "q", "H":
+		m.recentActive = false
+		return *m, nil
+	case "enter":
+		m.recentActive = false
+		if r, ok := m.recentList.SelectedItem().(recentResource); ok {
+			return m.reopenRecent(r)
+		}
+		return *m, nil
+	}
+
+	var cmd tea.Cmd
+	m.recentList, cmd = m.recentList.Update(msg)
+	return *m, cmd
+}
+
+// reopenRecent opens the service and goes straight to the resource. Like the
+// palette subcommands, the service's own initial fetch is skipped.
+func (m *Model) reopenRecent(r recentResource) (tea.Model, tea.Cmd) {
+	m.handleServiceSelection(r.service)
+
+	switch m.view {
+	case viewS3:
+		if S3State(r.state) == S3StateBucketDetails {
+			m.s3Model.state = S3StateBucketDetails
+			return *m, m.s3Model.fetchBucketDetails(r.id)
+		}
+		m.s3Model.currentBucket = r.id
+		m.s3Model.state = S3StateObjects
+		return *m, tea.Batch(m.s3Model.fetchObjects(), m.s3Model.fetchVersioning(r.id))
+	case viewCW:
+		m.cwModel.selectedGroup = r.id
+		m.cwModel.state = CWStateLogStreams
+		return *m, m.cwModel.fetchLogStreams(r.id)
+	case viewECS:
+		m.ecsModel.selectedCluster = ecsClusterFromTaskARN(r.id)
+		m.ecsModel.selectedTask = r.id
+		return *m, m.ecsModel.fetchTaskDetail(r.id)
+	case viewEC2:
+		m.ec2Model.selectID = r.id
+		return *m, m.ec2Model.fetchInstances()
+	}
+	return *m, nil
+}
+
+func (m Model) renderRecent() string {
+	popup := m.styles.Popup.Width(60).Render(
+		m.recentList.View() + "\n" + m.styles.StatusMuted.Render(" (enter to open, esc to close)"),
+	)
+	w, h := GetMainContainerSize(m.width, m.height)
+	return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, popup)
+}
@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+// relatedLink points from a row to another resource it references
+type relatedLink struct {
+	kind string
+	id   string
+}
+
+func (l relatedLink) Title() string       { return relatedLabels[l.kind] }
+func (l relatedLink) Description() string { return l.id }
+func (l relatedLink) FilterValue() string { return l.id }
+
+// linkedItem is implemented by items that reference other resources
+type linkedItem interface {
+	relatedLinks() []relatedLink
+}
+
+// linksTo links to a single resource; an empty ID links to nothing
+func linksTo(kind, id string) []relatedLink {
+	if id == "" {
+		return nil
+	}
+	return []relatedLink{{kind: kind, id: id}}
+}
+
+// instanceLinks links an instance to its network and security groups
+func instanceLinks(i aws.InstanceInfo) []relatedLink {
+	links := slices.Concat(linksTo("vpc", i.VpcID), linksTo("subnet", i.SubnetID))
+	for _, sg := range i.SecurityGroups {
+		links = append(links, relatedLink{kind: "security-group", id: sg})
+	}
+	return links
+}
+
+// relatedLabels name the kinds of resources rows can link to
+var relatedLabels = map[string]string{
+	"vpc":             "VPC",
+	"subnet":          "Subnet",
+	"instance":        "Instance",
+	"security-group":  "Security group",
+	"task-definition": "Task definition",
+}
+
+// openLink opens the list holding the resource of a link. The row with its ID
+// is selected once the list has loaded.
+func (m *Model) openLink(link relatedLink) tea.Cmd {
+	var command string
+	switch link.kind {
+	case "vpc":
+		command = "vpc vpcs"
+	case "subnet":
+		command = "vpc subnets"
+	case "instance":
+		command = "ec2 instances"
+	case "security-group":
+		command = "ec2 security-groups"
+	case "task-definition":
+		// The revisions of the family, from the ARN's family:revision
+		m.handleServiceSelection(paletteAliases["ecs"])
+		family := link.id[strings.LastIndex(link.id, "/")+1:]
+		if i := strings.LastIndex(family, ":"); i >= 0 {
+			family = family[:i]
+		}
+		m.ecsModel.selectedTaskDefFamily = family
+		m.ecsModel.state = ECSStateTaskDefRevisions
+		return m.ecsModel.fetchAllTaskDefs()
+	default:
+		return nil
+	}
+	_, cmd := m.runPaletteCommand(command)
+	return cmd
+}
+
+// selectedLinks returns the links of the selected row of the list on screen
+func (m *Model) selectedLinks() []relatedLink {
+	l := m.activeList()
+	if m.view == viewHome || l == nil || l.FilterState() == list.Filtering {
+		return nil
+	}
+	if item, ok := l.SelectedItem().(linkedItem); ok {
+		return item.relatedLinks()
+	}
+	return nil
+}
+
+// hasRelated reports whether the selected row links to other resources
+func (m *Model) hasRelated() bool {
+	return len(m.selectedLinks()) > 0
+}
+
+// openRelated offers the resources the selected row references
+func (m *Model) openRelated() tea.Cmd {
+	links := m.selectedLinks()
+	if len(links) == 0 {
+		return m.showMutedToast("No related resources for this row")
+	}
+
+	items := make([]list.Item, len(links))
+	for i, link := range links {
+		items[i] = link
+	}
+	d := list.NewDefaultDelegate()
+	d.Styles.SelectedTitle = m.styles.ListSelectedTitle
+	d.Styles.SelectedDesc = m.styles.ListSelectedDesc
+
+	_, h := GetMainContainerSize(m.width, m.height)
+	m.relatedList = list.New(items, d, 56, min(len(items)*3+2, h-6))
+	m.relatedList.Title = "Jump to"
+	m.relatedList.SetShowStatusBar(false)
+	m.relatedList.SetShowHelp(false)
+	m.relatedList.SetFilteringEnabled(false)
+	m.relatedActive = true
+	return nil
+}
+
+func (m *Model) handleRelatedKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return *m, tea.Quit
+	case "esc", "q":
+		m.relatedActive = false
+		return *m, nil
+	case "enter":
+		m.relatedActive = false
+		link, ok := m.relatedList.SelectedItem().(relatedLink)
+		if !ok {
+			return *m, nil
+		}
+		m.jumpTarget = link.id
+		cmd := m.openLink(link)
+		return *m, cmd
+	}
+
+	var cmd tea.Cmd
+	m.relatedList, cmd = m.relatedList.Update(msg)
+	return *m, cmd
+}
+
+// selectJumpTarget selects the row of the resource jumped to once it shows
+// up in the list on screen
+func (m *Model) selectJumpTarget() {
+	l := m.activeList()
+	if l == nil {
+		return
+	}
+	for i, item := range l.Items() {
+		if slices.Contains(strings.Fields(item.FilterValue()), m.jumpTarget) {
+			l.Select(i)
+			m.jumpTarget = ""
+			return
+		}
+	}
+}
+
+func (m Model) renderRelated() string {
+	popup := m.styles.Popup.Width(60).Render(
+		m.relatedList.View() + "\n" + m.styles.StatusMuted.Render(" (enter to jump, esc to close)"),
+	)
+	w, h := GetMainContainerSize(m.width, m.height)
+	return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, popup)
+}
@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+	"github.com/giovannirossini/aws-tui/internal/cache"
+	"github.com/giovannirossini/aws-tui/internal/logging"
+)
+
+// countFunc counts the main resource of a service with a fresh client
+type countFunc func(ctx context.Context, profile string) (int, error)
+
+// counter adapts a client constructor and one of its list methods to a
+// countFunc
+func counter[C, T any](newClient func(context.Context, string) (C, error), list func(C, context.Context) ([]T, error)) countFunc {
+	return func(ctx context.Context, profile string) (int, error) {
+		client, err := newClient(ctx, profile)
+		if err != nil {
+			return 0, err
+		}
+		items, err := list(client, ctx)
+		return len(items), err
+	}
+}
+
+// resourceCounters are the services that get a count badge on the home
+// screen. Services whose listing needs several calls per item, or whose main
+// view is not a list (Billing, Security Hub), are left out.
+var resourceCounters = map[string]countFunc{
+	"Simple Storage Service (S3)":        counter(aws.NewS3Client, (*aws.S3Client).ListBuckets),
+	"Identity & Access (IAM)":            counter(aws.NewIAMClient, (*aws.IAMClient).ListUsers),
+	"Virtual Private Cloud (VPC)":        counter(aws.NewEC2Client, (*aws.EC2Client).ListVpcs),
+	"Lambda Functions":                   counter(aws.NewLambdaClient, (*aws.LambdaClient).ListFunctions),
+	"Elastic Compute Cloud (EC2)":        counter(aws.NewEC2ResourcesClient, (*aws.EC2ResourcesClient).ListInstances),
+	"Relational Database Service (RDS)":  counter(aws.NewRDSClient, (*aws.RDSClient).ListInstances),
+	"CloudFront":                         counter(aws.NewCloudFrontClient, (*aws.CloudFrontClient).ListDistributions),
+	"ElastiCache (Redis)":                counter(aws.NewElastiCacheClient, (*aws.ElastiCacheClient).ListReplicationGroups),
+	"Managed Streaming for Kakfa (MSK)":  counter(aws.NewMSKClient, (*aws.MSKClient).ListClustersV2),
+	"Simple Queue Service (SQS)":         counter(aws.NewSQSClient, (*aws.SQSClient).ListQueues),
+	"Secrets Manager":                    counter(aws.NewSecretsManagerClient, (*aws.SecretsManagerClient).ListSecrets),
+	"Route 53":                           counter(aws.NewRoute53Client, (*aws.Route53Client).ListHostedZones),
+	"Certificate Manager (ACM)":          counter(aws.NewACMClient, (*aws.ACMClient).ListCertificates),
+	"Simple Notification Service (SNS)":  counter(aws.NewSNSClient, (*aws.SNSClient).ListTopics),
+	"KMS Keys":                           counter(aws.NewKMSClient, (*aws.KMSClient).ListKeys),
+	"Data Migration Service (DMS)":       counter(aws.NewDMSClient, (*aws.DMSClient).ListReplicationTasks),
+	"Elastic Container Service (ECS)":    counter(aws.NewECSClient, (*aws.ECSClient).ListClusters),
+	"Elastic Container Repository (ECR)": counter(aws.NewECRClient, (*aws.ECRClient).ListRepositories),
+	"Elastic File System (EFS)":          counter(aws.NewEFSClient, (*aws.EFSClient).ListFileSystems),
+	"DynamoDB":                           counter(aws.NewDynamoDBClient, (*aws.DynamoDBClient).ListTables),
+	"AWS Transfer":                       counter(aws.NewTransferClient, (*aws.TransferClient).ListServers),
+	"API Gateway":                        counter(aws.NewAPIGatewayClient, (*aws.APIGatewayClient).ListRestAPIs),
+}
+
+// resourceCountMsg carries one service's count. The profile lets counts that
+// were still running when the profile changed be dropped.
+type resourceCountMsg struct {
+	profile string
+	service string
+	count   int
+}
+
+// fetchResourceCounts counts every service concurrently. Each count is its own
+// command, so the badges fill in as they arrive and a slow or denied service
+// does not hold up the others; failures just leave the badge out.
+func (m *Model) fetchResourceCounts() tea.Cmd {
+	if !m.resourceCountsEnabled {
+		return nil
+	}
+	m.resourceCounts = make(map[string]int, len(resourceCounters))
+
+	profile, appCache := m.selectedProfile, m.cache
+	cmds := make([]tea.Cmd, 0, len(resourceCounters))
+	for service, count := range resourceCounters {
+		key := m.cacheKeys.ResourceCount(service)
+		cmds = append(cmds, func() tea.Msg {
+			if cached, ok := appCache.Get(key); ok {
+				if n, ok := cached.(int); ok {
+					return resourceCountMsg{profile: profile, service: service, count: n}
+				}
+			}
+			n, err := count(context.Background(), profile)
+			if err != nil {
+				logging.Printf("resource count service=%q profile=%s: %v", service, profile, err)
+				return nil
+			}
+			appCache.Set(key, n, cache.TTLResourceCounts)
+			return resourceCountMsg{profile: profile, service: service, count: n}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *Model) handleResourceCount(msg resourceCountMsg) (tea.Model, tea.Cmd) {
+	if msg.profile == m.selectedProfile && m.resourceCounts != nil {
+		m.resourceCounts[msg.service] = msg.count
+	}
+	return *m, nil
+}
+
+// resourceCountBadge is the muted " (12)" shown after a service name, or
+// nothing while the count is unknown
+func (m Model) resourceCountBadge(service string) string {
+	n, ok := m.resourceCounts[service]
+	if !ok {
+		return ""
+	}
+	return m.styles.StatusMuted.Render(fmt.Sprintf(" (%d)", n))
+}
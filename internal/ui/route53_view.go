@@ -25,31 +25,45 @@ type route53Item struct {
 	description string
 	id          string
 	values      []string
+	info        any
 }
 
 func (i route53Item) Title() string       { return i.title }
+func (i route53Item) source() any         { return i.info }
 func (i route53Item) Description() string { return i.description }
 func (i route53Item) FilterValue() string { return i.title + " " + i.description + " " + i.id }
+func (i route53Item) rowID() string       { return i.title + " " + i.description }
+func (i route53Item) rowValues() []string { return i.values }
 
 type Route53Model struct {
 	client           *aws.Route53Client
 	list             list.Model
 	delegate         route53ItemDelegate
 	styles           Styles
+	table            *tableLayout
 	state            Route53State
 	width            int
 	height           int
 	profile          string
 	err              error
+	loaded           bool
 	cache            *cache.Cache
 	cacheKeys        *cache.KeyBuilder
 	selectedZone     string
 	selectedZoneName string
+	// Header of the records table; nil until loaded
+	zoneDetails    *aws.HostedZoneDetails
+	zoneDetailsErr error
 }
 
+// route53ZoneHeaderHeight is the number of lines the zone header takes above
+// the records table
+const route53ZoneHeaderHeight = 3
+
 type route53ItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 	state  Route53State
 }
 
@@ -82,20 +96,24 @@ func (d route53ItemDelegate) Render(w io.Writer, m list.Model, index int, listIt
 		columns = route53RecordColumns
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, columns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, columns, d.table)
 	isSelected := index == m.Index()
 
-	RenderTableRow(w, m, d.styles, colStyles, i.values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, i.values, isSelected)
 }
 
 func (d route53ItemDelegate) Height() int {
 	return 1
 }
 
-func NewRoute53Model(profile string, styles Styles, appCache *cache.Cache) Route53Model {
+func (d route53ItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewRoute53Model(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) Route53Model {
+	table := &tableLayout{display: display}
 	d := route53ItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 		state:           Route53StateZones,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
@@ -111,6 +129,7 @@ func NewRoute53Model(profile string, styles Styles, appCache *cache.Cache) Route
 		list:      l,
 		delegate:  d,
 		styles:    styles,
+		table:     table,
 		state:     Route53StateZones,
 		profile:   profile,
 		cache:     appCache,
@@ -122,6 +141,13 @@ type HostedZonesMsg []aws.HostedZoneInfo
 type RecordSetsMsg []aws.ResourceRecordSetInfo
 type Route53ErrorMsg error
 
+// Route53ZoneDetailsMsg carries the header of a zone's records. A failure
+// only affects the header, the records still load.
+type Route53ZoneDetailsMsg struct {
+	Details aws.HostedZoneDetails
+	Err     error
+}
+
 func (m Route53Model) Init() tea.Cmd {
 	return m.fetchHostedZones()
 }
@@ -161,16 +187,26 @@ func (m Route53Model) fetchRecordSets(zoneID string) tea.Cmd {
 	}
 }
 
+func (m Route53Model) fetchZoneDetails(zoneID string) tea.Cmd {
+	return func() tea.Msg {
+		client, err := aws.NewRoute53Client(context.Background(), m.profile)
+		if err != nil {
+			return Route53ZoneDetailsMsg{Err: err}
+		}
+		details, err := client.GetHostedZoneDetails(context.Background(), zoneID)
+		return Route53ZoneDetailsMsg{Details: details, Err: err}
+	}
+}
+
 func (m Route53Model) Update(msg tea.Msg) (Route53Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		m.width = msg.Width
-		m.height = msg.Height
-		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
+		m.SetSize(msg.Width, msg.Height)
 
 	case HostedZonesMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			zoneType := "Public"
@@ -190,13 +226,15 @@ func (m Route53Model) Update(msg tea.Msg) (Route53Model, tea.Cmd) {
 				},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.delegate.state = Route53StateZones
 		m.list.SetDelegate(m.delegate)
 		m.state = Route53StateZones
+		m.SetSize(m.width, m.height)
 
 	case RecordSetsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			recordType := v.Type
@@ -213,6 +251,7 @@ func (m Route53Model) Update(msg tea.Msg) (Route53Model, tea.Cmd) {
 				ttl = "-"
 			}
 			items[i] = route53Item{
+				info:        v,
 				title:       v.Name,
 				description: v.Type,
 				id:          v.Name,
@@ -224,11 +263,20 @@ func (m Route53Model) Update(msg tea.Msg) (Route53Model, tea.Cmd) {
 				},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.delegate.state = Route53StateRecords
 		m.list.SetDelegate(m.delegate)
 		m.state = Route53StateRecords
+		m.SetSize(m.width, m.height)
+
+	case Route53ZoneDetailsMsg:
+		if msg.Err != nil {
+			m.zoneDetailsErr = msg.Err
+		} else {
+			m.zoneDetails = &msg.Details
+		}
+		return m, nil
 
 	case Route53ErrorMsg:
 		m.err = msg
@@ -245,16 +293,22 @@ func (m Route53Model) Update(msg tea.Msg) (Route53Model, tea.Cmd) {
 				m.cache.Delete(m.cacheKeys.Route53Resources("hosted-zones"))
 				return m, m.fetchHostedZones()
 			} else if m.state == Route53StateRecords {
-				return m, m.fetchRecordSets(m.selectedZone)
+				return m, tea.Batch(m.fetchRecordSets(m.selectedZone), m.fetchZoneDetails(m.selectedZone))
 			}
 		case "enter":
 			if m.state == Route53StateZones {
 				if item, ok := m.list.SelectedItem().(route53Item); ok {
 					m.selectedZone = item.id
 					m.selectedZoneName = item.title
-					return m, m.fetchRecordSets(m.selectedZone)
+					m.zoneDetails = nil
+					m.zoneDetailsErr = nil
+					return m, tea.Batch(m.fetchRecordSets(m.selectedZone), m.fetchZoneDetails(m.selectedZone))
 				}
 			}
+		case "y":
+			if m.state == Route53StateRecords && m.zoneDetails != nil && len(m.zoneDetails.NameServers) > 0 && !m.list.SettingFilter() {
+				return m, copyToClipboard(strings.Join(m.zoneDetails.NameServers, "\n"), "name servers")
+			}
 		case "backspace", "esc":
 			if m.state == Route53StateRecords {
 				return m, m.fetchHostedZones()
@@ -266,24 +320,77 @@ func (m Route53Model) Update(msg tea.Msg) (Route53Model, tea.Cmd) {
 	return m, cmd
 }
 
-func (m Route53Model) View() string {
-	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
-	}
+// route53ResourceNames names the resources listed in each table state, for the empty-state message
+var route53ResourceNames = map[Route53State]string{
+	Route53StateZones:   "hosted zones",
+	Route53StateRecords: "DNS records",
+}
 
-	var columns []Column
+// columns are the columns of the table on screen
+func (m Route53Model) columns() []Column {
 	switch m.state {
 	case Route53StateZones:
-		columns = hostedZoneColumns
+		return hostedZoneColumns
 	case Route53StateRecords:
-		columns = route53RecordColumns
+		return route53RecordColumns
 	}
-	_, header := RenderTableHelpers(m.list, m.styles, columns)
-	return header + "\n" + m.list.View()
+	return nil
+}
+
+func (m Route53Model) View() string {
+	if m.err != nil {
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
+	}
+
+	zoneHeader := ""
+	if m.state == Route53StateRecords {
+		zoneHeader = m.renderZoneHeader() + "\n\n"
+	}
+
+	if resource, ok := route53ResourceNames[m.state]; ok && m.loaded && len(m.list.Items()) == 0 {
+		return zoneHeader + RenderEmptyState(m.styles, m.list, resource, m.profile, m.table)
+	}
+
+	_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
+	return zoneHeader + header + "\n" + m.list.View()
+}
+
+// renderZoneHeader summarizes the selected zone above its records: the name
+// servers to hand to a registrar, then visibility, size and DNSSEC status
+func (m Route53Model) renderZoneHeader() string {
+	label := lipgloss.NewStyle().Foreground(m.styles.Primary)
+	switch {
+	case m.zoneDetailsErr != nil:
+		return " " + m.styles.StatusMuted.Render(fmt.Sprintf("Zone details unavailable: %v", m.zoneDetailsErr)) + "\n"
+	case m.zoneDetails == nil:
+		return " " + m.styles.StatusMuted.Render("Loading zone details...") + "\n"
+	}
+
+	z := m.zoneDetails
+	nameServers := strings.Join(z.NameServers, ", ")
+	if nameServers == "" {
+		nameServers = "-"
+	}
+	zoneType, dnssec := "Public", renderStatus(m.styles, z.DNSSECStatus)
+	if z.IsPrivate {
+		zoneType, dnssec = "Private", m.styles.StatusMuted.Render("not supported")
+	}
+
+	w, _ := GetInnerListSize(m.width, m.height)
+	line := lipgloss.NewStyle().MaxWidth(w)
+	return line.Render(" "+label.Render("Name servers ")+nameServers) + "\n" +
+		line.Render(fmt.Sprintf(" %s%s   %s%d   %s%s",
+			label.Render("Type "), zoneType,
+			label.Render("Records "), z.RecordCount,
+			label.Render("DNSSEC "), dnssec))
 }
 
 func (m *Route53Model) SetSize(width, height int) {
 	m.width = width
 	m.height = height
-	m.list.SetSize(GetInnerListSize(width, height))
+	w, h := GetInnerListSize(width, height)
+	if m.state == Route53StateRecords {
+		h -= route53ZoneHeaderHeight
+	}
+	m.list.SetSize(w, h)
 }
@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// rowChangeDuration is how long the rows changed by a refresh stay highlighted
+const rowChangeDuration = 2 * time.Second
+
+type rowChange int
+
+const (
+	rowUnchanged rowChange = iota
+	rowAdded
+	rowChanged
+)
+
+// clearRowChangesMsg ends the highlight started with the same id on table
+type clearRowChangesMsg struct {
+	id    int
+	table *tableLayout
+}
+
+// rowSnapshot is the active list as it was when a refresh was requested
+type rowSnapshot struct {
+	title string // View title, so that navigating away drops the comparison
+	items []list.Item
+}
+
+// identifiedItem is implemented by items with an ID of their own, such as an
+// ARN or instance ID, which tells apart rows that share a title
+type identifiedItem interface {
+	rowID() string
+}
+
+// rowKey identifies a row across refreshes: by its ID, or by its title for
+// items without one
+func rowKey(item list.Item) string {
+	if i, ok := item.(identifiedItem); ok && i.rowID() != "" {
+		return i.rowID()
+	}
+	if t, ok := item.(interface{ Title() string }); ok {
+		return t.Title()
+	}
+	return item.FilterValue()
+}
+
+// rowContent is what a refresh compares to tell a changed row: the cell
+// values of the row, or its title and description for items without them
+func rowContent(item list.Item) string {
+	if row, ok := item.(tableRow); ok {
+		return strings.Join(row.rowValues(), "\x1f")
+	}
+	if d, ok := item.(list.DefaultItem); ok {
+		return d.Title() + "\x1f" + d.Description()
+	}
+	return item.FilterValue()
+}
+
+// snapshotRows remembers the active list before a refresh
+func (m *Model) snapshotRows() {
+	l := m.activeList()
+	if l == nil || len(l.Items()) == 0 {
+		m.rowSnapshot = nil
+		return
+	}
+	m.rowSnapshot = &rowSnapshot{title: m.getViewTitle(), items: l.Items()}
+}
+
+// diffRows compares the active list with the snapshot once the refreshed
+// items have replaced it, highlights added and changed rows and reports
+// removed ones in a toast
+func (m *Model) diffRows() tea.Cmd {
+	snap := m.rowSnapshot
+	l := m.activeList()
+	t, _ := m.activeTable()
+	if l == nil || t == nil || m.getViewTitle() != snap.title {
+		m.rowSnapshot = nil
+		return nil
+	}
+	items := l.Items()
+	if len(items) == len(snap.items) && (len(items) == 0 || &items[0] == &snap.items[0]) {
+		// Still the same slice: the refresh has not arrived yet
+		return nil
+	}
+	m.rowSnapshot = nil
+
+	before := make(map[string]string, len(snap.items))
+	for _, item := range snap.items {
+		before[rowKey(item)] = rowContent(item)
+	}
+	changes := make(map[string]rowChange)
+	added, changed := 0, 0
+	for _, item := range items {
+		key := rowKey(item)
+		prev, ok := before[key]
+		switch {
+		case !ok:
+			changes[key] = rowAdded
+			added++
+		case prev != rowContent(item):
+			changes[key] = rowChanged
+			changed++
+		}
+		delete(before, key)
+	}
+	removed := len(before)
+	if added+changed+removed == 0 {
+		return nil
+	}
+
+	t.changes = changes
+	m.rowChangesID++
+	id := m.rowChangesID
+
+	var summary []string
+	for _, c := range []struct {
+		n    int
+		verb string
+	}{{added, "added"}, {changed, "changed"}, {removed, "removed"}} {
+		if c.n > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", c.n, c.verb))
+		}
+	}
+	return tea.Batch(
+		m.showMutedToast("Refreshed: "+strings.Join(summary, ", ")),
+		tea.Tick(rowChangeDuration, func(time.Time) tea.Msg { return clearRowChangesMsg{id: id, table: t} }),
+	)
+}
@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2/formatters"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+	"github.com/giovannirossini/aws-tui/internal/cache"
+)
+
+// S3BucketDetailsMsg carries the settings shown on the bucket detail screen
+type S3BucketDetailsMsg *aws.BucketDetails
+
+func (m S3Model) fetchBucketDetails(bucket string) tea.Cmd {
+	return func() tea.Msg {
+		cacheKey := m.cacheKeys.S3BucketDetails(bucket)
+		if cached, ok := m.cache.Get(cacheKey); ok {
+			if details, ok := cached.(*aws.BucketDetails); ok {
+				return S3BucketDetailsMsg(details)
+			}
+		}
+
+		client, err := aws.NewS3Client(context.Background(), m.profile)
+		if err != nil {
+			return S3ErrorMsg(err)
+		}
+		details, err := client.GetBucketDetails(context.Background(), bucket)
+		if err != nil {
+			return S3ErrorMsg(err)
+		}
+		m.cache.Set(cacheKey, details, cache.TTLS3Buckets)
+		return S3BucketDetailsMsg(details)
+	}
+}
+
+func (m S3Model) renderBucketDetails() string {
+	d := m.bucketDetails
+	sectionStyle := lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(m.styles.Muted).Width(28)
+	valueStyle := lipgloss.NewStyle().Foreground(m.styles.Snow).Bold(true)
+
+	check := func(ok bool) string {
+		if ok {
+			return m.styles.Success.Render("✔")
+		}
+		return m.styles.Error.Render("✘")
+	}
+	row := func(label, value string) string {
+		return "  " + labelStyle.Render(label) + value + "\n"
+	}
+	failed := func(section string) string {
+		return "  " + m.styles.Error.Render("✘ "+d.Errors[section]) + "\n"
+	}
+
+	var s strings.Builder
+	s.WriteString(sectionStyle.Render("BUCKET") + "\n")
+	s.WriteString(row("Name", valueStyle.Render(d.Name)))
+	if _, ok := d.Errors["region"]; ok {
+		s.WriteString(failed("region"))
+	} else {
+		s.WriteString(row("Region", valueStyle.Render(d.Region)))
+	}
+	if _, ok := d.Errors["versioning"]; ok {
+		s.WriteString(failed("versioning"))
+	} else {
+		s.WriteString(row("Versioning", renderStatus(m.styles, d.Versioning)))
+		s.WriteString(row("MFA delete", renderStatus(m.styles, d.MFADelete)))
+	}
+
+	s.WriteString("\n" + sectionStyle.Render("ENCRYPTION") + "\n")
+	switch _, ok := d.Errors["encryption"]; {
+	case ok:
+		s.WriteString(failed("encryption"))
+	case d.Encryption == "":
+		s.WriteString("  " + m.styles.Error.Render("✘ No default encryption configured") + "\n")
+	default:
+		s.WriteString(row("Algorithm", valueStyle.Render(d.Encryption)))
+		if d.KMSKeyID != "" {
+			s.WriteString(row("KMS key", valueStyle.Render(d.KMSKeyID)))
+		}
+		s.WriteString(row("Bucket key", check(d.BucketKeyEnabled)))
+	}
+
+	s.WriteString("\n" + sectionStyle.Render("PUBLIC ACCESS BLOCK") + "\n")
+	switch _, ok := d.Errors["public-access-block"]; {
+	case ok:
+		s.WriteString(failed("public-access-block"))
+	case d.PublicAccessBlock == nil:
+		s.WriteString("  " + m.styles.Error.Render("✘ Not configured on the bucket") + "\n")
+	default:
+		s.WriteString(row("Block public ACLs", check(d.PublicAccessBlock.BlockPublicAcls)))
+		s.WriteString(row("Ignore public ACLs", check(d.PublicAccessBlock.IgnorePublicAcls)))
+		s.WriteString(row("Block public policy", check(d.PublicAccessBlock.BlockPublicPolicy)))
+		s.WriteString(row("Restrict public buckets", check(d.PublicAccessBlock.RestrictPublicBuckets)))
+	}
+
+	s.WriteString("\n" + sectionStyle.Render("BUCKET POLICY") + "\n")
+	switch _, ok := d.Errors["policy"]; {
+	case ok:
+		s.WriteString(failed("policy"))
+	case d.Policy == "":
+		s.WriteString("  " + m.styles.StatusMuted.Render("No bucket policy") + "\n")
+	default:
+		s.WriteString(m.highlightPolicy(d.Policy) + "\n")
+	}
+
+	return s.String()
+}
+
+func (m S3Model) highlightPolicy(content string) string {
+	var policy interface{}
+	if err := json.Unmarshal([]byte(content), &policy); err == nil {
+		if pretty, err := json.MarshalIndent(policy, "", "  "); err == nil {
+			content = string(pretty)
+		}
+	}
+
+	lexer := lexers.Get("json")
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+
+	style := styles.Get(m.styles.ChromaStyle)
+	if style == nil {
+		style = styles.Fallback
+	}
+
+	formatter := formatters.Get("terminal256")
+	if m.styles.NoColor {
+		formatter = formatters.NoOp
+	}
+	if formatter == nil {
+		formatter = formatters.Fallback
+	}
+
+	iterator, err := lexer.Tokenise(nil, content)
+	if err != nil {
+		return content
+	}
+
+	var sb strings.Builder
+	if err := formatter.Format(&sb, style, iterator); err != nil {
+		return content
+	}
+	return sb.String()
+}
@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+// s3ShellExitedMsg reports that the subshell opened on a prefix has exited
+type s3ShellExitedMsg struct{}
+
+// getShellCommand starts $SHELL with the environment of the AWS CLI set to
+// the session's profile and region, and the bucket and prefix on screen in
+// S3_BUCKET, S3_PREFIX and S3_URI, e.g. for `aws s3 ls $S3_URI`
+func (m S3Model) getShellCommand(region string) *exec.Cmd {
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+
+	c := exec.Command(shell)
+	c.Env = append(os.Environ(),
+		"AWS_PROFILE="+m.profile,
+		"AWS_REGION="+region,
+		"AWS_DEFAULT_REGION="+region,
+		"S3_BUCKET="+m.currentBucket,
+		"S3_PREFIX="+m.currentPrefix,
+		"S3_URI=s3://"+m.currentBucket+"/"+m.currentPrefix,
+	)
+	c.Env = append(c.Env, aws.EndpointEnv()...)
+	return c
+}
+
+// shellExited reports why the shell could not run. The exit status of the
+// shell is that of the last command run in it, so it is not an error here.
+func shellExited(err error) tea.Msg {
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return S3ErrorMsg(err)
+	}
+	return s3ShellExitedMsg{}
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+type S3VersionsMsg []aws.ObjectVersionInfo
+
+// S3VersioningMsg carries the versioning status of the bucket being browsed
+type S3VersioningMsg string
+
+var s3VersionColumns = []Column{
+	{Title: "Version ID", Width: 0.4},
+	{Title: "Size", Width: 0.15},
+	{Title: "Last Modified", Width: 0.25},
+	{Title: "Latest", Width: 0.1},
+	{Title: "Delete Marker", Width: 0.1},
+}
+
+func (m S3Model) fetchVersioning(bucket string) tea.Cmd {
+	return func() tea.Msg {
+		// Reuse the status from the bucket detail screen when it is cached
+		if cached, ok := m.cache.Get(m.cacheKeys.S3BucketDetails(bucket)); ok {
+			if details, ok := cached.(*aws.BucketDetails); ok && details.Versioning != "" {
+				return S3VersioningMsg(details.Versioning)
+			}
+		}
+
+		client, err := aws.NewS3Client(context.Background(), m.profile)
+		if err != nil {
+			return S3VersioningMsg("")
+		}
+		// Versions are simply not offered when the status cannot be read
+		status, err := client.GetBucketVersioning(context.Background(), bucket)
+		if err != nil {
+			return S3VersioningMsg("")
+		}
+		return S3VersioningMsg(status)
+	}
+}
+
+// versioningEnabled reports whether the current bucket can hold object
+// versions. Suspended buckets keep the versions created while it was enabled.
+func (m S3Model) versioningEnabled() bool {
+	return m.currentVersioning == "Enabled" || m.currentVersioning == "Suspended"
+}
+
+func (m S3Model) fetchVersions() tea.Cmd {
+	return func() tea.Msg {
+		client, err := aws.NewS3Client(context.Background(), m.profile)
+		if err != nil {
+			return S3ErrorMsg(err)
+		}
+		versions, err := client.ListObjectVersions(context.Background(), m.currentBucket, m.versionKey)
+		if err != nil {
+			return S3ErrorMsg(err)
+		}
+		return S3VersionsMsg(versions)
+	}
+}
+
+func (m S3Model) downloadVersion(versionID, localPath string) tea.Cmd {
+	return armed(func() tea.Msg {
+		client, err := aws.NewS3Client(context.Background(), m.profile)
+		if err != nil {
+			return S3ErrorMsg(err)
+		}
+		err = client.DownloadObjectVersion(context.Background(), m.currentBucket, m.versionKey, versionID, localPath)
+		if err != nil {
+			return S3ErrorMsg(err)
+		}
+		return S3SuccessMsg("Version downloaded")
+	})
+}
+
+func (m S3Model) deleteVersion(versionID string) tea.Cmd {
+	return armed(func() tea.Msg {
+		client, err := aws.NewS3Client(context.Background(), m.profile)
+		if err != nil {
+			return S3ErrorMsg(err)
+		}
+		err = client.DeleteObjectVersion(context.Background(), m.currentBucket, m.versionKey, versionID)
+		if err != nil {
+			return S3ErrorMsg(err)
+		}
+
+		// Deleting the latest version or a delete marker changes the listing
+		m.cache.Delete(m.cacheKeys.S3Objects(m.currentBucket, m.currentPrefix))
+
+		return S3SuccessMsg("Version deleted")
+	})
+}
@@ -6,11 +6,13 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"path"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/giovannirossini/aws-tui/internal/aws"
@@ -24,6 +26,8 @@ const (
 	S3StateObjects
 	S3StateInput
 	S3StateConfirmDelete
+	S3StateBucketDetails
+	S3StateVersions
 )
 
 type S3Action int
@@ -36,6 +40,8 @@ const (
 	S3ActionDeleteObject
 	S3ActionUploadFile
 	S3ActionEditFile
+	S3ActionDownloadVersion
+	S3ActionDeleteVersion
 )
 
 type s3Item struct {
@@ -44,33 +50,57 @@ type s3Item struct {
 	isBucket    bool
 	isFolder    bool
 	key         string
+	versionID   string
+	values      []string
+	// Object metadata shown in the split layout
+	size         int64
+	modified     time.Time
+	storageClass string
+	etag         string
 }
 
 func (i s3Item) Title() string       { return i.title }
 func (i s3Item) Description() string { return i.description }
-func (i s3Item) FilterValue() string { return i.title }
+func (i s3Item) FilterValue() string { return i.title + " " + i.key }
+func (i s3Item) tagTarget() (tagTarget, bool) {
+	return tagTarget{kind: tagResourceS3, id: i.title, name: i.title}, i.isBucket
+}
 
 type S3Model struct {
 	client        *aws.S3Client
 	list          list.Model
 	input         textinput.Model
+	viewport      viewport.Model
 	styles        Styles
+	table         *tableLayout
 	state         S3State
 	action        S3Action
 	currentBucket string
 	currentPrefix string
 	selectedItem  s3Item
+	bucketDetails *aws.BucketDetails
+	deleteSummary deleteSummary
+	confirm       ConfirmDialog
 	width         int
 	height        int
 	profile       string
 	err           error
+	loaded        bool
 	cache         *cache.Cache
 	cacheKeys     *cache.KeyBuilder
+	// Versioning status of currentBucket and the object whose versions are listed
+	currentVersioning string
+	versionKey        string
+	// Split layout showing the selected object beside the table
+	split bool
+	// How the objects on screen were fetched, nil when cached
+	fetchStats *fetchStats
 }
 
 type s3ItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 	state  S3State
 }
 
@@ -92,17 +122,22 @@ func (d s3ItemDelegate) Render(w io.Writer, m list.Model, index int, listItem li
 	}
 
 	var columns []Column
-	if d.state == S3StateBuckets {
+	switch d.state {
+	case S3StateBuckets:
 		columns = s3BucketColumns
-	} else {
+	case S3StateVersions:
+		columns = s3VersionColumns
+	default:
 		columns = s3ObjectColumns
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, columns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, columns, d.table)
 	isSelected := index == m.Index()
 
 	var values []string
-	if d.state == S3StateBuckets {
+	if d.state == S3StateVersions {
+		values = i.values
+	} else if d.state == S3StateBuckets {
 		values = []string{
 			"🪣 " + i.title,
 			i.description,
@@ -132,15 +167,19 @@ func (d s3ItemDelegate) Render(w io.Writer, m list.Model, index int, listItem li
 		}
 	}
 
-	RenderTableRow(w, m, d.styles, colStyles, values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, values, isSelected)
 }
 
 func (d s3ItemDelegate) Height() int { return 1 }
 
-func NewS3Model(profile string, styles Styles, appCache *cache.Cache) S3Model {
+func (d s3ItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewS3Model(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) S3Model {
+	table := &tableLayout{display: display}
 	d := s3ItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 		state:           S3StateBuckets,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
@@ -162,7 +201,9 @@ func NewS3Model(profile string, styles Styles, appCache *cache.Cache) S3Model {
 	return S3Model{
 		list:      l,
 		input:     ti,
+		viewport:  viewport.New(0, 0),
 		styles:    styles,
+		table:     table,
 		state:     S3StateBuckets,
 		profile:   profile,
 		cache:     appCache,
@@ -171,7 +212,14 @@ func NewS3Model(profile string, styles Styles, appCache *cache.Cache) S3Model {
 }
 
 type S3BucketsMsg []aws.BucketInfo
-type S3ObjectsMsg []aws.ObjectInfo
+
+// S3ObjectsMsg carries the objects under the current prefix. Stats is nil
+// when they come from the cache.
+type S3ObjectsMsg struct {
+	Objects []aws.ObjectInfo
+	Stats   *fetchStats
+}
+
 type S3ErrorMsg error
 type S3SuccessMsg string
 
@@ -210,7 +258,7 @@ func (m S3Model) fetchObjects() tea.Cmd {
 		cacheKey := m.cacheKeys.S3Objects(m.currentBucket, m.currentPrefix)
 		if cached, ok := m.cache.Get(cacheKey); ok {
 			if objects, ok := cached.([]aws.ObjectInfo); ok {
-				return S3ObjectsMsg(objects)
+				return S3ObjectsMsg{Objects: objects}
 			}
 		}
 
@@ -218,7 +266,8 @@ func (m S3Model) fetchObjects() tea.Cmd {
 		if err != nil {
 			return S3ErrorMsg(err)
 		}
-		objects, err := client.ListObjects(context.Background(), m.currentBucket, m.currentPrefix, "/")
+		ctx, done := measureFetch()
+		objects, err := client.ListObjects(ctx, m.currentBucket, m.currentPrefix, "/")
 		if err != nil {
 			return S3ErrorMsg(err)
 		}
@@ -231,12 +280,12 @@ func (m S3Model) fetchObjects() tea.Cmd {
 		}
 		m.cache.Set(cacheKey, objects, ttl)
 
-		return S3ObjectsMsg(objects)
+		return S3ObjectsMsg{Objects: objects, Stats: done(len(objects))}
 	}
 }
 
 func (m S3Model) createBucket(name string) tea.Cmd {
-	return func() tea.Msg {
+	return armed(func() tea.Msg {
 		client, err := aws.NewS3Client(context.Background(), m.profile)
 		if err != nil {
 			return S3ErrorMsg(err)
@@ -250,11 +299,11 @@ func (m S3Model) createBucket(name string) tea.Cmd {
 		m.cache.Delete(m.cacheKeys.S3Buckets())
 
 		return S3SuccessMsg("Bucket created")
-	}
+	})
 }
 
 func (m S3Model) deleteBucket(name string) tea.Cmd {
-	return func() tea.Msg {
+	return armed(func() tea.Msg {
 		client, err := aws.NewS3Client(context.Background(), m.profile)
 		if err != nil {
 			return S3ErrorMsg(err)
@@ -269,11 +318,11 @@ func (m S3Model) deleteBucket(name string) tea.Cmd {
 		m.cache.DeletePrefix(m.cacheKeys.S3BucketPrefix(name))
 
 		return S3SuccessMsg("Bucket deleted")
-	}
+	})
 }
 
 func (m S3Model) createFolder(name string) tea.Cmd {
-	return func() tea.Msg {
+	return armed(func() tea.Msg {
 		client, err := aws.NewS3Client(context.Background(), m.profile)
 		if err != nil {
 			return S3ErrorMsg(err)
@@ -287,11 +336,11 @@ func (m S3Model) createFolder(name string) tea.Cmd {
 		m.cache.Delete(m.cacheKeys.S3Objects(m.currentBucket, m.currentPrefix))
 
 		return S3SuccessMsg("Folder created")
-	}
+	})
 }
 
 func (m S3Model) deleteObject(key string) tea.Cmd {
-	return func() tea.Msg {
+	return armed(func() tea.Msg {
 		client, err := aws.NewS3Client(context.Background(), m.profile)
 		if err != nil {
 			return S3ErrorMsg(err)
@@ -305,11 +354,11 @@ func (m S3Model) deleteObject(key string) tea.Cmd {
 		m.cache.Delete(m.cacheKeys.S3Objects(m.currentBucket, m.currentPrefix))
 
 		return S3SuccessMsg("Object deleted")
-	}
+	})
 }
 
 func (m S3Model) uploadFile(localPath string) tea.Cmd {
-	return func() tea.Msg {
+	return armed(func() tea.Msg {
 		client, err := aws.NewS3Client(context.Background(), m.profile)
 		if err != nil {
 			return S3ErrorMsg(err)
@@ -324,7 +373,7 @@ func (m S3Model) uploadFile(localPath string) tea.Cmd {
 		m.cache.Delete(m.cacheKeys.S3Objects(m.currentBucket, m.currentPrefix))
 
 		return S3SuccessMsg("File uploaded")
-	}
+	})
 }
 
 func (m S3Model) Update(msg tea.Msg) (S3Model, tea.Cmd) {
@@ -337,6 +386,7 @@ func (m S3Model) Update(msg tea.Msg) (S3Model, tea.Cmd) {
 		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
 	case S3BucketsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, b := range msg {
 			items[i] = s3Item{
@@ -345,13 +395,14 @@ func (m S3Model) Update(msg tea.Msg) (S3Model, tea.Cmd) {
 				isBucket:    true,
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.state = S3StateBuckets
 
 		// Update delegate state for tabular rendering
 		d := s3ItemDelegate{
 			DefaultDelegate: list.NewDefaultDelegate(),
 			styles:          m.styles,
+			table:           m.table,
 			state:           S3StateBuckets,
 		}
 		d.Styles.SelectedTitle = m.styles.ListSelectedTitle
@@ -359,6 +410,8 @@ func (m S3Model) Update(msg tea.Msg) (S3Model, tea.Cmd) {
 		m.list.SetDelegate(d)
 
 	case S3ObjectsMsg:
+		m.loaded = true
+		m.fetchStats = msg.Stats
 		items := make([]list.Item, 0)
 
 		// Add "back" item if not at root
@@ -366,44 +419,109 @@ func (m S3Model) Update(msg tea.Msg) (S3Model, tea.Cmd) {
 			items = append(items, s3Item{title: "..", description: "Back", isFolder: true, key: "back"})
 		}
 
-		for _, o := range msg {
+		for _, o := range msg.Objects {
 			desc := fmt.Sprintf("Size: %d bytes, Modified: %s", o.Size, o.LastModified.Format("2006-01-02 15:04"))
 			if o.IsFolder {
 				desc = "Folder"
 			}
 			items = append(items, s3Item{
-				title:       o.Key,
-				description: desc,
-				isFolder:    o.IsFolder,
-				key:         o.Key,
+				title:        o.Key,
+				description:  desc,
+				isFolder:     o.IsFolder,
+				key:          o.Key,
+				size:         o.Size,
+				modified:     o.LastModified,
+				storageClass: o.StorageClass,
+				etag:         o.ETag,
 			})
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.state = S3StateObjects
 
 		// Update delegate state for tabular rendering
 		d := s3ItemDelegate{
 			DefaultDelegate: list.NewDefaultDelegate(),
 			styles:          m.styles,
+			table:           m.table,
 			state:           S3StateObjects,
 		}
 		d.Styles.SelectedTitle = m.styles.ListSelectedTitle
 		d.Styles.SelectedDesc = m.styles.ListSelectedDesc
 		m.list.SetDelegate(d)
 
+	case S3VersioningMsg:
+		m.currentVersioning = string(msg)
+
+	case S3VersionsMsg:
+		m.loaded = true
+		items := make([]list.Item, len(msg))
+		for i, v := range msg {
+			size := fmt.Sprintf("%d bytes", v.Size)
+			if v.IsDeleteMarker {
+				size = ""
+			}
+			latest, marker := "", ""
+			if v.IsLatest {
+				latest = "✔"
+			}
+			if v.IsDeleteMarker {
+				marker = "✔"
+			}
+			items[i] = s3Item{
+				title:     v.VersionID,
+				key:       v.Key,
+				versionID: v.VersionID,
+				values:    []string{v.VersionID, size, v.LastModified.Format(absoluteTimeFormat), latest, marker},
+			}
+		}
+		m.table.setItems(&m.list, items)
+		m.list.ResetSelected()
+		m.state = S3StateVersions
+
+		d := s3ItemDelegate{
+			DefaultDelegate: list.NewDefaultDelegate(),
+			styles:          m.styles,
+			table:           m.table,
+			state:           S3StateVersions,
+		}
+		d.Styles.SelectedTitle = m.styles.ListSelectedTitle
+		d.Styles.SelectedDesc = m.styles.ListSelectedDesc
+		m.list.SetDelegate(d)
+
+	case S3BucketDetailsMsg:
+		m.bucketDetails = msg
+		m.viewport.SetContent(m.renderBucketDetails())
+		m.viewport.GotoTop()
+		m.state = S3StateBucketDetails
+
 	case S3SuccessMsg:
 		m.err = nil
-		m.state = S3StateBuckets
-		if m.currentBucket != "" {
-			m.state = S3StateObjects
+		m.state = m.baseState()
+		if m.state == S3StateVersions {
+			return m, m.fetchVersions()
 		}
 		if m.state == S3StateBuckets || m.action == S3ActionCreateBucket || m.action == S3ActionDeleteBucket {
 			return m, m.fetchBuckets()
 		}
 		return m, m.fetchObjects()
 
+	case s3ShellExitedMsg:
+		// The prefix may have been changed from the shell
+		if m.state == S3StateObjects {
+			m.cache.Delete(m.cacheKeys.S3Objects(m.currentBucket, m.currentPrefix))
+			return m, m.fetchObjects()
+		}
+
+	case S3DeleteSummaryMsg:
+		if m.state == S3StateConfirmDelete && m.selectedItem.title == msg.Bucket {
+			m.deleteSummary = bucketDeleteSummary(msg)
+		}
+
 	case S3ErrorMsg:
 		m.err = msg
+		if m.state == S3StateBucketDetails && m.bucketDetails == nil {
+			m.state = S3StateBuckets
+		}
 
 	case tea.KeyMsg:
 		if m.err != nil {
@@ -416,10 +534,7 @@ func (m S3Model) Update(msg tea.Msg) (S3Model, tea.Cmd) {
 			case "enter":
 				name := m.input.Value()
 				if name == "" {
-					m.state = S3StateBuckets
-					if m.currentBucket != "" {
-						m.state = S3StateObjects
-					}
+					m.state = m.baseState()
 					return m, nil
 				}
 				var actionCmd tea.Cmd
@@ -429,37 +544,44 @@ func (m S3Model) Update(msg tea.Msg) (S3Model, tea.Cmd) {
 					actionCmd = m.createFolder(name)
 				} else if m.action == S3ActionUploadFile {
 					actionCmd = m.uploadFile(name)
+				} else if m.action == S3ActionDownloadVersion {
+					actionCmd = m.downloadVersion(m.selectedItem.versionID, name)
 				}
 				m.input.Reset()
 				return m, actionCmd
 			case "esc":
-				m.state = S3StateBuckets
-				if m.currentBucket != "" {
-					m.state = S3StateObjects
-				}
+				m.input.Reset()
+				m.state = m.baseState()
 				return m, nil
 			}
 			m.input, cmd = m.input.Update(msg)
 			return m, cmd
 		}
 
-		if m.state == S3StateConfirmDelete {
+		if m.state == S3StateBucketDetails {
 			switch msg.String() {
-			case "y", "Y":
-				var actionCmd tea.Cmd
-				if m.action == S3ActionDeleteBucket {
-					actionCmd = m.deleteBucket(m.selectedItem.title)
-				} else if m.action == S3ActionDeleteObject {
-					actionCmd = m.deleteObject(m.selectedItem.key)
+			case "r":
+				if m.bucketDetails != nil {
+					m.cache.Delete(m.cacheKeys.S3BucketDetails(m.bucketDetails.Name))
+					return m, m.fetchBucketDetails(m.bucketDetails.Name)
 				}
-				return m, actionCmd
-			default:
+			case "esc", "backspace":
 				m.state = S3StateBuckets
-				if m.currentBucket != "" {
-					m.state = S3StateObjects
-				}
-				return m, nil
+				m.bucketDetails = nil
+				// The bucket list is empty when the details were reopened from the history
+				return m, m.fetchBuckets()
+			default:
+				m.viewport, cmd = m.viewport.Update(msg)
 			}
+			return m, cmd
+		}
+
+		if m.state == S3StateConfirmDelete {
+			result, cmd := m.confirm.Update(msg)
+			if result == confirmCancelled {
+				m.state = m.baseState()
+			}
+			return m, cmd
 		}
 
 		switch msg.String() {
@@ -470,10 +592,38 @@ func (m S3Model) Update(msg tea.Msg) (S3Model, tea.Cmd) {
 			} else if m.state == S3StateObjects {
 				m.cache.Delete(m.cacheKeys.S3Objects(m.currentBucket, m.currentPrefix))
 				return m, m.fetchObjects()
+			} else if m.state == S3StateVersions {
+				return m, m.fetchVersions()
+			}
+		case "|":
+			if m.state == S3StateObjects {
+				m.split = !m.split
+				return m, nil
 			}
 		case "e":
 			// Handled in main model to use tea.ExecProcess
 			return m, nil
+		case "v":
+			if item, ok := m.list.SelectedItem().(s3Item); ok && m.state == S3StateObjects && !item.isFolder && m.versioningEnabled() {
+				m.versionKey = item.key
+				return m, m.fetchVersions()
+			}
+		case "s":
+			if item, ok := m.list.SelectedItem().(s3Item); ok && m.state == S3StateVersions {
+				m.selectedItem = item
+				m.state = S3StateInput
+				m.action = S3ActionDownloadVersion
+				m.input.Placeholder = "Local file path"
+				m.input.SetValue(path.Base(item.key))
+				m.input.Focus()
+				return m, nil
+			}
+		case "i":
+			if item, ok := m.list.SelectedItem().(s3Item); ok && m.state == S3StateBuckets {
+				m.state = S3StateBucketDetails
+				m.bucketDetails = nil
+				return m, m.fetchBucketDetails(item.title)
+			}
 		case "n":
 			if m.state == S3StateBuckets {
 				m.state = S3StateInput
@@ -503,10 +653,20 @@ func (m S3Model) Update(msg tea.Msg) (S3Model, tea.Cmd) {
 				}
 				m.selectedItem = item
 				m.state = S3StateConfirmDelete
+				m.deleteSummary = deleteSummary{}
+				m.confirm = NewConfirmDialog("Confirm Deletion", "Are you sure you want to delete "+
+					lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render(item.title), true, nil)
 				if item.isBucket {
 					m.action = S3ActionDeleteBucket
+					m.confirm.OnConfirm = func() tea.Cmd { return m.deleteBucket(item.title) }
+					m.deleteSummary.loading = true
+					return m, m.fetchBucketDeleteSummary(item.title)
+				} else if item.versionID != "" {
+					m.action = S3ActionDeleteVersion
+					m.confirm.OnConfirm = func() tea.Cmd { return m.deleteVersion(item.versionID) }
 				} else {
 					m.action = S3ActionDeleteObject
+					m.confirm.OnConfirm = func() tea.Cmd { return m.deleteObject(item.key) }
 				}
 				return m, nil
 			}
@@ -515,8 +675,9 @@ func (m S3Model) Update(msg tea.Msg) (S3Model, tea.Cmd) {
 				if item.isBucket {
 					m.currentBucket = item.title
 					m.currentPrefix = ""
+					m.currentVersioning = ""
 					m.state = S3StateObjects
-					return m, m.fetchObjects()
+					return m, tea.Batch(m.fetchObjects(), m.fetchVersioning(item.title))
 				}
 				if item.isFolder {
 					if item.key == "back" {
@@ -533,6 +694,11 @@ func (m S3Model) Update(msg tea.Msg) (S3Model, tea.Cmd) {
 				}
 			}
 		case "backspace", "esc":
+			if m.state == S3StateVersions {
+				m.versionKey = ""
+				m.state = S3StateObjects
+				return m, m.fetchObjects()
+			}
 			if m.state == S3StateObjects {
 				if m.currentPrefix != "" {
 					parts := strings.Split(strings.TrimSuffix(m.currentPrefix, "/"), "/")
@@ -555,12 +721,42 @@ func (m S3Model) Update(msg tea.Msg) (S3Model, tea.Cmd) {
 	return m, cmd
 }
 
+// s3ResourceNames names the resources listed in each table state, for the empty-state message
+var s3ResourceNames = map[S3State]string{
+	S3StateBuckets:  "S3 buckets",
+	S3StateObjects:  "objects",
+	S3StateVersions: "object versions",
+}
+
+// baseState is the list state to return to once an input or confirmation closes
+func (m S3Model) baseState() S3State {
+	switch {
+	case m.versionKey != "":
+		return S3StateVersions
+	case m.currentBucket != "":
+		return S3StateObjects
+	default:
+		return S3StateBuckets
+	}
+}
+
 func (m S3Model) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
+	}
+
+	if resource, ok := s3ResourceNames[m.state]; ok && m.loaded && len(m.list.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.list, resource, m.profile, m.table)
 	}
 
 	switch m.state {
+	case S3StateBucketDetails:
+		if m.bucketDetails == nil {
+			return "\n  " + lipgloss.NewStyle().Foreground(m.styles.Primary).Render("󱎯 Loading...")
+		}
+		return lipgloss.NewStyle().
+			Padding(1, 2).
+			Render(m.viewport.View())
 	case S3StateInput:
 		header := m.renderHeader()
 		return RenderOverlay(header+"\n"+m.list.View(), m.styles.Popup.Width(40).Render(fmt.Sprintf(
@@ -571,26 +767,62 @@ func (m S3Model) View() string {
 		)), m.width, m.height)
 	case S3StateConfirmDelete:
 		header := m.renderHeader()
-		return RenderOverlay(header+"\n"+m.list.View(), m.styles.Popup.Width(40).BorderForeground(ErrorColor).Render(fmt.Sprintf(
-			" %s\n\n %s %s\n\n %s",
-			m.styles.Error.Bold(true).Render("⚠ Confirm Deletion"),
-			"Are you sure you want to delete",
-			lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render(m.selectedItem.title),
-			m.styles.StatusMuted.Render("(y/n)"),
-		)), m.width, m.height)
+		confirm := m.confirm
+		confirm.Detail = renderDeleteSummary(m.styles, m.deleteSummary)
+		return RenderOverlay(header+"\n"+m.list.View(), confirm.View(m.styles), m.width, m.height)
 	default:
+		if m.state == S3StateObjects && m.split {
+			if listWidth, paneWidth := splitWidths(m.width); paneWidth > 0 {
+				m.list.SetWidth(listWidth)
+				return renderSplit(m.renderHeader()+"\n"+m.list.View(), m.renderObjectDetail(paneWidth))
+			}
+		}
 		return m.renderHeader() + "\n" + m.list.View()
 	}
 }
 
-func (m S3Model) renderHeader() string {
-	var columns []Column
-	if m.state == S3StateBuckets {
-		columns = s3BucketColumns
-	} else {
-		columns = s3ObjectColumns
+// renderObjectDetail renders the selected object for the split layout
+func (m S3Model) renderObjectDetail(width int) string {
+	height := m.list.Height() + TableColumnHeaderHeight
+	item, ok := m.list.SelectedItem().(s3Item)
+	if !ok || item.key == "back" {
+		return renderDetailPane(m.styles, "No object selected", nil, width, height)
 	}
-	_, header := RenderTableHelpers(m.list, m.styles, columns)
+	uri := fmt.Sprintf("s3://%s/%s", m.currentBucket, item.key)
+	if item.isFolder {
+		return renderDetailPane(m.styles, item.title, []detailField{
+			{"Type", "Folder"},
+			{"URI", uri},
+		}, width, height)
+	}
+	modified := ""
+	if !item.modified.IsZero() {
+		modified = item.modified.Local().Format(absoluteTimeFormat) + " (" + humanizeTime(item.modified) + ")"
+	}
+	return renderDetailPane(m.styles, path.Base(item.key), []detailField{
+		{"Key", item.key},
+		{"URI", uri},
+		{"Size", fmt.Sprintf("%d bytes", item.size)},
+		{"Last modified", modified},
+		{"Storage class", item.storageClass},
+		{"ETag", item.etag},
+		{"Versioning", m.currentVersioning},
+	}, width, height)
+}
+
+// columns are the columns of the table on screen
+func (m S3Model) columns() []Column {
+	switch m.state {
+	case S3StateBuckets:
+		return s3BucketColumns
+	case S3StateVersions:
+		return s3VersionColumns
+	}
+	return s3ObjectColumns
+}
+
+func (m S3Model) renderHeader() string {
+	_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
 	return header
 }
 
@@ -603,13 +835,11 @@ func (m S3Model) getEditCommand(key string) *exec.Cmd {
 		return nil
 	}
 
-	// Create temp file
-	tmpFile, err := os.CreateTemp("", "aws-tui-edit-*")
+	cmd, path, err := editTempFile("aws-tui-edit-*", "")
 	if err != nil {
 		return nil
 	}
-	lastTmpPath = tmpFile.Name()
-	tmpFile.Close()
+	lastTmpPath = path
 
 	// Download
 	err = client.DownloadFile(context.Background(), m.currentBucket, key, lastTmpPath)
@@ -622,13 +852,30 @@ func (m S3Model) getEditCommand(key string) *exec.Cmd {
 		lastTmpModTime = info.ModTime()
 	}
 
-	// Open editor
+	return cmd
+}
+
+// editTempFile writes content to a new temp file named after pattern and
+// returns the command that opens it in $EDITOR, vim by default, along with
+// its path. The caller removes the file when done with it.
+func editTempFile(pattern, content string) (*exec.Cmd, string, error) {
+	tmpFile, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return nil, "", err
+	}
+	defer tmpFile.Close()
+
+	if _, err := tmpFile.WriteString(content); err != nil {
+		os.Remove(tmpFile.Name())
+		return nil, "", err
+	}
+
 	editor := os.Getenv("EDITOR")
 	if editor == "" {
 		editor = "vim"
 	}
 
-	return exec.Command(editor, lastTmpPath)
+	return exec.Command(editor, tmpFile.Name()), tmpFile.Name(), nil
 }
 
 func (m S3Model) uploadEditedFile(key string) tea.Msg {
@@ -661,4 +908,6 @@ func (m *S3Model) SetSize(width, height int) {
 	m.width = width
 	m.height = height
 	m.list.SetSize(GetInnerListSize(width, height))
+	m.viewport.Width = width - InnerContentWidthOffset
+	m.viewport.Height = height - AppInternalFooterHeight - 4
 }
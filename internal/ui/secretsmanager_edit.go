@@ -0,0 +1,165 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+// SMSecretCreatedMsg carries the name of a newly created secret
+type SMSecretCreatedMsg string
+
+// SMSecretValuePutMsg carries the name of a secret given a new version
+type SMSecretValuePutMsg string
+
+// smValueEditedMsg carries the edited value of an existing secret, which is
+// only written once the overwrite is confirmed
+type smValueEditedMsg struct {
+	name  string
+	value string
+}
+
+// smValueUnchangedMsg reports that the editor was closed without a new value
+type smValueUnchangedMsg struct{}
+
+// secretEdit is what a value edit started from; original is empty for a new
+// secret
+type secretEdit struct {
+	name     string
+	create   bool
+	original string
+}
+
+// openNewSecret shows the name prompt for a new secret; its value is typed
+// in $EDITOR once the name is entered
+func (m *SMModel) openNewSecret() {
+	m.nameInput = textinput.New()
+	m.nameInput.Placeholder = "prod/app/database"
+	m.nameInput.CharLimit = 512
+	m.nameInput.Width = 50
+	m.nameInput.Focus()
+	m.nameErr = ""
+	m.state = SMStateNameInput
+}
+
+// newSecretName is the entered name, once it is one Secrets Manager accepts
+func (m SMModel) newSecretName() (string, error) {
+	name := strings.TrimSpace(m.nameInput.Value())
+	if name == "" {
+		return "", fmt.Errorf("enter a name for the secret")
+	}
+	if strings.ContainsFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("/_+=.@-", r))
+	}) {
+		return "", fmt.Errorf("names can only contain letters, digits and /_+=.@-")
+	}
+	return name, nil
+}
+
+func (m SMModel) updateNameInput(msg tea.KeyMsg) (SMModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.state = SMStateSecrets
+		return m, nil
+	case "enter":
+		// A valid name is taken by the router, which opens the editor
+		if _, err := m.newSecretName(); err != nil {
+			m.nameErr = err.Error()
+		}
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.nameInput, cmd = m.nameInput.Update(msg)
+	return m, cmd
+}
+
+// getValueEditCommand writes the value to edit, empty for a new secret, to a
+// temp file and opens it in $EDITOR. The file is only readable by the user
+// and the caller removes it once it is read back.
+func (m SMModel) getValueEditCommand(edit secretEdit) (c *exec.Cmd, path string, err error) {
+	if edit.original == aws.BinarySecretValue {
+		return nil, "", fmt.Errorf("binary secret values cannot be edited here")
+	}
+
+	return editTempFile("aws-tui-secret-*", edit.original)
+}
+
+// readEditedValue reads the value back from the editor. A new secret is
+// created right away; a new value for an existing one waits for a confirm.
+func (m SMModel) readEditedValue(path string, edit secretEdit) tea.Msg {
+	data, err := os.ReadFile(path)
+	os.Remove(path)
+	if err != nil {
+		return SMErrorMsg(err)
+	}
+
+	value := string(data)
+	// Editors end the file with a newline the value did not have
+	if !strings.HasSuffix(edit.original, "\n") {
+		value = strings.TrimSuffix(value, "\n")
+	}
+	if value == edit.original || strings.TrimSpace(value) == "" {
+		return smValueUnchangedMsg{}
+	}
+
+	if !edit.create {
+		return smValueEditedMsg{name: edit.name, value: value}
+	}
+
+	client, err := aws.NewSecretsManagerClient(context.Background(), m.profile)
+	if err != nil {
+		return SMErrorMsg(err)
+	}
+	if _, err := client.CreateSecret(context.Background(), edit.name, value); err != nil {
+		return SMErrorMsg(err)
+	}
+	m.cache.Delete(m.cacheKeys.SMResources("secrets"))
+	return SMSecretCreatedMsg(edit.name)
+}
+
+// openConfirmPut asks before replacing the current value of a secret. Only
+// the size of the new value is shown, never the value itself.
+func (m *SMModel) openConfirmPut(msg smValueEditedMsg) {
+	m.confirm = NewConfirmDialog("Put Secret Value", "Overwrite the value of "+
+		lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render(msg.name)+"?",
+		true, func() tea.Cmd { return m.putSecretValue(msg.name, msg.value) })
+	m.confirm.Width = 64
+	m.confirm.Detail = " " + m.styles.StatusMuted.Render(fmt.Sprintf("New value of %d characters; the current one stays", len(msg.value))) +
+		"\n " + m.styles.StatusMuted.Render("readable as the AWSPREVIOUS version")
+	m.state = SMStateConfirmPut
+}
+
+func (m SMModel) putSecretValue(name, value string) tea.Cmd {
+	return armed(func() tea.Msg {
+		client, err := aws.NewSecretsManagerClient(context.Background(), m.profile)
+		if err != nil {
+			return SMErrorMsg(err)
+		}
+		if _, err := client.PutSecretValue(context.Background(), name, value); err != nil {
+			return SMErrorMsg(err)
+		}
+		m.cache.Delete(m.cacheKeys.SMResources("secrets"))
+		return SMSecretValuePutMsg(name)
+	})
+}
+
+func (m SMModel) renderNameInput(base string) string {
+	content := fmt.Sprintf(
+		" %s\n %s\n\n %s\n",
+		lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render("Create a secret"),
+		m.styles.StatusMuted.Render("Name of the secret; its value is entered in $EDITOR next"),
+		m.nameInput.View(),
+	)
+	if m.nameErr != "" {
+		content += "\n " + m.styles.Error.Render("✘ "+m.nameErr) + "\n"
+	}
+	content += "\n " + m.styles.StatusMuted.Render("(enter to continue, esc to cancel)")
+	return RenderOverlay(base, m.styles.Popup.Width(64).Render(content), m.width, m.height)
+}
@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+// SMRotationMsg carries the rotation settings of the secret being viewed
+type SMRotationMsg struct {
+	Name     string
+	Rotation *aws.SecretRotation
+}
+
+// SMSecretRotatedMsg carries the name of a secret whose rotation was started
+type SMSecretRotatedMsg string
+
+func (m SMModel) fetchRotation(secretID string) tea.Cmd {
+	return func() tea.Msg {
+		client, err := aws.NewSecretsManagerClient(context.Background(), m.profile)
+		if err != nil {
+			return SMErrorMsg(err)
+		}
+		rotation, err := client.DescribeSecret(context.Background(), secretID)
+		if err != nil {
+			return SMErrorMsg(err)
+		}
+		return SMRotationMsg{Name: secretID, Rotation: rotation}
+	}
+}
+
+func (m SMModel) rotateSecret(secretID string) tea.Cmd {
+	return armed(func() tea.Msg {
+		client, err := aws.NewSecretsManagerClient(context.Background(), m.profile)
+		if err != nil {
+			return SMErrorMsg(err)
+		}
+		if _, err := client.RotateSecret(context.Background(), secretID); err != nil {
+			return SMErrorMsg(err)
+		}
+		m.cache.Delete(m.cacheKeys.SMResources("secrets"))
+		return SMSecretRotatedMsg(secretID)
+	})
+}
+
+// openConfirmRotate asks before rotating the secret being viewed. Secrets
+// without a rotation function cannot be rotated, so none is asked for.
+func (m *SMModel) openConfirmRotate() {
+	if m.rotation == nil {
+		return
+	}
+	if m.rotation.LambdaARN == "" {
+		m.err = fmt.Errorf("rotation is not configured for %s; set up a rotation function first", m.selectedSecret)
+		return
+	}
+	m.confirm = NewConfirmDialog("Rotate Secret", "Rotate "+
+		lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render(m.selectedSecret)+" now?",
+		true, func() tea.Cmd { return m.rotateSecret(m.selectedSecret) })
+	m.confirm.Width = 64
+	m.confirm.Detail = " " + m.styles.StatusMuted.Render("The rotation function sets a new value; clients still") +
+		"\n " + m.styles.StatusMuted.Render("using the current one must fetch it again")
+	m.state = SMStateConfirmRotate
+}
+
+// renderRotation is the rotation line shown above a secret's value
+func (m SMModel) renderRotation() string {
+	if m.rotation == nil {
+		return m.styles.StatusMuted.Render("Loading rotation...")
+	}
+	r := m.rotation
+	label := func(s string) string { return m.styles.StatusMuted.Render(s + ": ") }
+
+	parts := []string{label("Rotation") + m.styles.StatusMuted.Render("off")}
+	if r.Enabled {
+		parts = []string{label("Rotation") + m.styles.Success.Render("on")}
+	}
+	if r.Schedule != "" {
+		schedule := r.Schedule
+		if r.Window != "" {
+			schedule += " (" + r.Window + " window)"
+		}
+		parts = append(parts, label("Schedule")+schedule)
+	}
+	if r.LambdaARN != "" {
+		parts = append(parts, label("Function")+formatARN(r.LambdaARN, m.table.display.fullARNs))
+	}
+	if r.LastRotated != nil {
+		parts = append(parts, label("Last rotated")+humanizeTime(*r.LastRotated))
+	}
+	if r.Enabled && r.NextRotation != nil {
+		parts = append(parts, label("Next")+r.NextRotation.Format("2006-01-02 15:04"))
+	}
+	return strings.Join(parts, m.styles.StatusMuted.Render(" • "))
+}
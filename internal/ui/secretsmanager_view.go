@@ -11,6 +11,7 @@ import (
 	"github.com/alecthomas/chroma/v2/lexers"
 	"github.com/alecthomas/chroma/v2/styles"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/giovannirossini/aws-tui/internal/aws"
@@ -22,6 +23,9 @@ type SMState int
 const (
 	SMStateSecrets SMState = iota
 	SMStateValue
+	SMStateNameInput
+	SMStateConfirmPut
+	SMStateConfirmRotate
 )
 
 type smItem struct {
@@ -34,25 +38,37 @@ type smItem struct {
 func (i smItem) Title() string       { return i.title }
 func (i smItem) Description() string { return i.description }
 func (i smItem) FilterValue() string { return i.title + " " + i.description + " " + i.arn }
+func (i smItem) rowID() string       { return i.arn }
+func (i smItem) rowValues() []string { return i.values }
 
 type SMModel struct {
 	client         *aws.SecretsManagerClient
 	list           list.Model
 	styles         Styles
+	table          *tableLayout
 	state          SMState
 	width          int
 	height         int
 	profile        string
 	err            error
+	loaded         bool
 	cache          *cache.Cache
 	cacheKeys      *cache.KeyBuilder
 	selectedValue  string
 	selectedSecret string
+
+	// nameInput is the prompt of SMStateNameInput for a new secret's name
+	nameInput textinput.Model
+	nameErr   string
+	confirm   ConfirmDialog
+	// rotation is how the secret being viewed is rotated, nil until loaded
+	rotation *aws.SecretRotation
 }
 
 type smItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 	state  SMState
 }
 
@@ -74,20 +90,24 @@ func (d smItemDelegate) Render(w io.Writer, m list.Model, index int, listItem li
 		columns = smSecretColumns
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, columns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, columns, d.table)
 	isSelected := index == m.Index()
 
-	RenderTableRow(w, m, d.styles, colStyles, i.values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, i.values, isSelected)
 }
 
 func (d smItemDelegate) Height() int {
 	return 1
 }
 
-func NewSMModel(profile string, styles Styles, appCache *cache.Cache) SMModel {
+func (d smItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewSMModel(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) SMModel {
+	table := &tableLayout{display: display}
 	d := smItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 		state:           SMStateSecrets,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
@@ -102,6 +122,7 @@ func NewSMModel(profile string, styles Styles, appCache *cache.Cache) SMModel {
 	return SMModel{
 		list:      l,
 		styles:    styles,
+		table:     table,
 		state:     SMStateSecrets,
 		profile:   profile,
 		cache:     appCache,
@@ -162,6 +183,7 @@ func (m SMModel) Update(msg tea.Msg) (SMModel, tea.Cmd) {
 		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
 	case SMSecretsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			lastChanged := "-"
@@ -175,7 +197,7 @@ func (m SMModel) Update(msg tea.Msg) (SMModel, tea.Cmd) {
 				values:      []string{v.Name, lastChanged, v.Description},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = SMStateSecrets
 
@@ -183,6 +205,24 @@ func (m SMModel) Update(msg tea.Msg) (SMModel, tea.Cmd) {
 		m.selectedValue = string(msg)
 		m.state = SMStateValue
 
+	case SMSecretCreatedMsg:
+		return m, m.fetchSecrets()
+
+	case smValueEditedMsg:
+		m.openConfirmPut(msg)
+		return m, nil
+
+	case SMSecretValuePutMsg:
+		return m, m.fetchSecretValue(string(msg))
+
+	case SMRotationMsg:
+		if msg.Name == m.selectedSecret {
+			m.rotation = msg.Rotation
+		}
+
+	case SMSecretRotatedMsg:
+		return m, m.fetchRotation(string(msg))
+
 	case SMErrorMsg:
 		m.err = msg
 
@@ -192,19 +232,41 @@ func (m SMModel) Update(msg tea.Msg) (SMModel, tea.Cmd) {
 			return m, nil
 		}
 
+		switch m.state {
+		case SMStateNameInput:
+			return m.updateNameInput(msg)
+		case SMStateConfirmPut, SMStateConfirmRotate:
+			result, cmd := m.confirm.Update(msg)
+			if result != confirmPending {
+				m.state = SMStateValue
+			}
+			return m, cmd
+		}
+
 		switch msg.String() {
+		case "n":
+			if m.state == SMStateSecrets && !m.list.SettingFilter() {
+				m.openNewSecret()
+				return m, textinput.Blink
+			}
 		case "r":
 			if m.state == SMStateSecrets {
 				m.cache.Delete(m.cacheKeys.SMResources("secrets"))
 				return m, m.fetchSecrets()
 			} else if m.state == SMStateValue {
-				return m, m.fetchSecretValue(m.selectedSecret)
+				return m, tea.Batch(m.fetchSecretValue(m.selectedSecret), m.fetchRotation(m.selectedSecret))
+			}
+		case "o":
+			if m.state == SMStateValue {
+				m.openConfirmRotate()
+				return m, nil
 			}
 		case "enter":
 			if m.state == SMStateSecrets {
 				if item, ok := m.list.SelectedItem().(smItem); ok {
 					m.selectedSecret = item.title
-					return m, m.fetchSecretValue(m.selectedSecret)
+					m.rotation = nil
+					return m, tea.Batch(m.fetchSecretValue(m.selectedSecret), m.fetchRotation(m.selectedSecret))
 				}
 			}
 		case "backspace", "esc":
@@ -234,12 +296,15 @@ func (m SMModel) highlightSecret(content string) string {
 		}
 	}
 
-	style := styles.Get("monokai")
+	style := styles.Get(m.styles.ChromaStyle)
 	if style == nil {
 		style = styles.Fallback
 	}
 
 	formatter := formatters.Get("terminal256")
+	if m.styles.NoColor {
+		formatter = formatters.NoOp
+	}
 	if formatter == nil {
 		formatter = formatters.Fallback
 	}
@@ -269,26 +334,37 @@ func (m SMModel) highlightSecret(content string) string {
 	return strings.Join(numberedLines, "\n")
 }
 
+// columns are the columns of the table on screen
+func (m SMModel) columns() []Column {
+	return smSecretColumns
+}
+
 func (m SMModel) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
 	}
 
-	if m.state == SMStateValue {
-		displayValue := m.highlightSecret(m.selectedValue)
+	if m.state == SMStateValue || m.state == SMStateConfirmPut || m.state == SMStateConfirmRotate {
+		displayValue := m.renderRotation() + "\n\n" + m.highlightSecret(m.selectedValue)
 
-		return lipgloss.NewStyle().
+		view := lipgloss.NewStyle().
 			Width(m.width-InnerContentWidthOffset).
 			Padding(1, 2).
 			Render(displayValue)
+		if m.state != SMStateValue {
+			return RenderOverlay(view, m.confirm.View(m.styles), m.width, m.height)
+		}
+		return view
 	}
 
-	var columns []Column
-	switch m.state {
-	case SMStateSecrets:
-		columns = smSecretColumns
+	if m.loaded && len(m.list.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.list, "secrets", m.profile, m.table)
+	}
+
+	_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
+	if m.state == SMStateNameInput {
+		return m.renderNameInput(header + "\n" + m.list.View())
 	}
-	_, header := RenderTableHelpers(m.list, m.styles, columns)
 	return header + "\n" + m.list.View()
 }
 
@@ -19,14 +19,18 @@ type securityHubItem struct {
 func (i securityHubItem) Title() string       { return i.finding.Title }
 func (i securityHubItem) Description() string { return i.finding.Description }
 func (i securityHubItem) FilterValue() string { return i.finding.Title + " " + i.finding.ResourceID }
+func (i securityHubItem) rowID() string       { return i.finding.ID }
+func (i securityHubItem) source() any         { return i.finding }
 
 type SecurityHubModel struct {
 	list      list.Model
 	styles    Styles
+	table     *tableLayout
 	profile   string
 	width     int
 	height    int
 	err       error
+	loaded    bool
 	cache     *cache.Cache
 	cacheKeys *cache.KeyBuilder
 }
@@ -34,6 +38,7 @@ type SecurityHubModel struct {
 type securityHubItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 }
 
 var securityHubColumns = []Column{
@@ -49,19 +54,19 @@ func (d securityHubItemDelegate) Render(w io.Writer, m list.Model, index int, li
 		return
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, securityHubColumns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, securityHubColumns, d.table)
 	isSelected := index == m.Index()
 
 	severityStyle := lipgloss.NewStyle()
 	switch i.finding.Severity {
 	case "CRITICAL":
-		severityStyle = severityStyle.Foreground(lipgloss.Color("#FF0000")).Bold(true)
+		severityStyle = severityStyle.Foreground(d.styles.ErrorColor).Bold(true)
 	case "HIGH":
-		severityStyle = severityStyle.Foreground(lipgloss.Color("#FF4500"))
+		severityStyle = severityStyle.Foreground(d.styles.ErrorColor)
 	case "MEDIUM":
-		severityStyle = severityStyle.Foreground(lipgloss.Color("#FFA500"))
+		severityStyle = severityStyle.Foreground(d.styles.WarningColor)
 	case "LOW":
-		severityStyle = severityStyle.Foreground(lipgloss.Color("#FFFF00"))
+		severityStyle = severityStyle.Foreground(d.styles.InfoColor)
 	}
 
 	values := []string{
@@ -71,15 +76,19 @@ func (d securityHubItemDelegate) Render(w io.Writer, m list.Model, index int, li
 		i.finding.Compliance,
 	}
 
-	RenderTableRow(w, m, d.styles, colStyles, values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, values, isSelected)
 }
 
 func (d securityHubItemDelegate) Height() int { return 1 }
 
-func NewSecurityHubModel(profile string, styles Styles, appCache *cache.Cache) SecurityHubModel {
+func (d securityHubItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewSecurityHubModel(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) SecurityHubModel {
+	table := &tableLayout{display: display}
 	d := securityHubItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
 	d.Styles.SelectedDesc = styles.ListSelectedDesc
@@ -96,6 +105,7 @@ func NewSecurityHubModel(profile string, styles Styles, appCache *cache.Cache) S
 	return SecurityHubModel{
 		list:      l,
 		styles:    styles,
+		table:     table,
 		profile:   profile,
 		cache:     appCache,
 		cacheKeys: cache.NewKeyBuilder(profile),
@@ -141,11 +151,12 @@ func (m SecurityHubModel) Update(msg tea.Msg) (SecurityHubModel, tea.Cmd) {
 		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
 	case SecurityHubMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, f := range msg {
 			items[i] = securityHubItem{finding: f}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 
 	case SecurityHubErrorMsg:
 		m.err = msg
@@ -167,12 +178,21 @@ func (m SecurityHubModel) Update(msg tea.Msg) (SecurityHubModel, tea.Cmd) {
 	return m, cmd
 }
 
+// columns are the columns of the table on screen
+func (m SecurityHubModel) columns() []Column {
+	return securityHubColumns
+}
+
 func (m SecurityHubModel) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
+	}
+
+	if m.loaded && len(m.list.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.list, "Security Hub findings", m.profile, m.table)
 	}
 
-	_, header := RenderTableHelpers(m.list, m.styles, securityHubColumns)
+	_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
 	return header + "\n" + m.list.View()
 }
 
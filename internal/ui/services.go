@@ -7,7 +7,7 @@ import (
 // featureIcons is the single source of truth for all service names and their icons
 var featureIcons = map[string]string{
 	"Simple Storage Service (S3)":        "󱐖 ",
-	"IAM Users":                          " ",
+	"Identity & Access (IAM)":            " ",
 	"Virtual Private Cloud (VPC)":        "󰛳 ",
 	"Lambda Functions":                   "󰘧 ",
 	"Elastic Compute Cloud (EC2)":        " ",
@@ -21,7 +21,7 @@ var featureIcons = map[string]string{
 	"Route 53":                           "󰇧 ",
 	"Certificate Manager (ACM)":          "󰔕 ",
 	"Simple Notification Service (SNS)":  "󰰓 ",
-	"Key Management Service (KMS)":       "󰌆 ",
+	"KMS Keys":                           "󰌆 ",
 	"Data Migration Service (DMS)":       "󰆼 ",
 	"Elastic Container Service (ECS)":    "󰙨 ",
 	"Billing & Costs":                    "󰠶 ",
@@ -43,167 +43,163 @@ func getServiceHandlers() map[string]serviceHandler {
 	return map[string]serviceHandler{
 		"Simple Storage Service (S3)": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewS3
-			m.s3Model = NewS3Model(m.selectedProfile, m.styles, m.cache)
+			m.s3Model = NewS3Model(m.selectedProfile, m.styles, m.cache, m.display)
 			m.s3Model.SetSize(m.width, m.height)
 			return *m, m.s3Model.Init()
 		},
-		"IAM Users": func(m *Model) (tea.Model, tea.Cmd) {
+		"Identity & Access (IAM)": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewIAM
-			m.iamModel = NewIAMModel(m.selectedProfile, m.styles, m.cache)
+			m.iamModel = NewIAMModel(m.selectedProfile, m.styles, m.cache, m.display)
 			m.iamModel.SetSize(m.width, m.height)
 			return *m, m.iamModel.Init()
 		},
 		"Virtual Private Cloud (VPC)": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewVPC
-			m.vpcModel = NewVPCModel(m.selectedProfile, m.styles, m.cache)
+			m.vpcModel = NewVPCModel(m.selectedProfile, m.styles, m.cache, m.display)
 			m.vpcModel.SetSize(m.width, m.height)
 			return *m, m.vpcModel.Init()
 		},
 		"Lambda Functions": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewLambda
-			m.lambdaModel = NewLambdaModel(m.selectedProfile, m.styles, m.cache)
+			m.lambdaModel = NewLambdaModel(m.selectedProfile, m.styles, m.cache, m.display)
 			m.lambdaModel.SetSize(m.width, m.height)
 			return *m, m.lambdaModel.Init()
 		},
 		"Elastic Compute Cloud (EC2)": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewEC2
-			m.ec2Model = NewEC2Model(m.selectedProfile, m.styles, m.cache)
+			m.ec2Model = NewEC2Model(m.selectedProfile, m.styles, m.cache, m.display)
 			m.ec2Model.SetSize(m.width, m.height)
 			return *m, m.ec2Model.Init()
 		},
 		"Relational Database Service (RDS)": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewRDS
-			m.rdsModel = NewRDSModel(m.selectedProfile, m.styles, m.cache)
+			m.rdsModel = NewRDSModel(m.selectedProfile, m.styles, m.cache, m.display)
 			m.rdsModel.SetSize(m.width, m.height)
 			return *m, m.rdsModel.Init()
 		},
 		"CloudWatch": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewCW
-			m.cwModel = NewCWModel(m.selectedProfile, m.styles, m.cache)
+			m.cwModel = NewCWModel(m.selectedProfile, m.styles, m.cache, m.display)
 			m.cwModel.SetSize(m.width, m.height)
 			return *m, m.cwModel.Init()
 		},
 		"CloudFront": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewCF
-			m.cfModel = NewCFModel(m.selectedProfile, m.styles, m.cache)
+			m.cfModel = NewCFModel(m.selectedProfile, m.styles, m.cache, m.display)
 			m.cfModel.SetSize(m.width, m.height)
 			return *m, m.cfModel.Init()
 		},
 		"ElastiCache (Redis)": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewElastiCache
-			m.elasticacheModel = NewElastiCacheModel(m.selectedProfile, m.styles, m.cache)
+			m.elasticacheModel = NewElastiCacheModel(m.selectedProfile, m.styles, m.cache, m.display)
 			m.elasticacheModel.SetSize(m.width, m.height)
 			return *m, m.elasticacheModel.Init()
 		},
 		"Managed Streaming for Kakfa (MSK)": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewMSK
-			m.mskModel = NewMSKModel(m.selectedProfile, m.styles, m.cache)
+			m.mskModel = NewMSKModel(m.selectedProfile, m.styles, m.cache, m.display)
 			m.mskModel.SetSize(m.width, m.height)
 			return *m, m.mskModel.Init()
 		},
 		"Simple Queue Service (SQS)": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewSQS
-			m.sqsModel = NewSQSModel(m.selectedProfile, m.styles, m.cache)
+			m.sqsModel = NewSQSModel(m.selectedProfile, m.styles, m.cache, m.display)
 			m.sqsModel.SetSize(m.width, m.height)
 			return *m, m.sqsModel.Init()
 		},
 		"Secrets Manager": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewSM
-			m.smModel = NewSMModel(m.selectedProfile, m.styles, m.cache)
+			m.smModel = NewSMModel(m.selectedProfile, m.styles, m.cache, m.display)
 			m.smModel.SetSize(m.width, m.height)
 			return *m, m.smModel.Init()
 		},
 		"Route 53": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewRoute53
-			m.route53Model = NewRoute53Model(m.selectedProfile, m.styles, m.cache)
+			m.route53Model = NewRoute53Model(m.selectedProfile, m.styles, m.cache, m.display)
 			m.route53Model.SetSize(m.width, m.height)
 			return *m, m.route53Model.Init()
 		},
 		"Certificate Manager (ACM)": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewACM
-			m.acmModel = NewACMModel(m.selectedProfile, m.styles, m.cache)
+			m.acmModel = NewACMModel(m.selectedProfile, m.styles, m.cache, m.display)
 			m.acmModel.SetSize(m.width, m.height)
 			return *m, m.acmModel.Init()
 		},
 		"Simple Notification Service (SNS)": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewSNS
-			m.snsModel = NewSNSModel(m.selectedProfile, m.styles, m.cache)
+			m.snsModel = NewSNSModel(m.selectedProfile, m.styles, m.cache, m.display)
 			m.snsModel.SetSize(m.width, m.height)
 			return *m, m.snsModel.Init()
 		},
 		"KMS Keys": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewKMS
-			m.kmsModel = NewKMSModel(m.selectedProfile, m.styles, m.cache)
+			m.kmsModel = NewKMSModel(m.selectedProfile, m.styles, m.cache, m.display)
 			m.kmsModel.SetSize(m.width, m.height)
 			return *m, m.kmsModel.Init()
 		},
 		"Data Migration Service (DMS)": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewDMS
-			m.dmsModel = NewDMSModel(m.selectedProfile, m.styles, m.cache)
+			m.dmsModel = NewDMSModel(m.selectedProfile, m.styles, m.cache, m.display)
 			m.dmsModel.SetSize(m.width, m.height)
 			return *m, m.dmsModel.Init()
 		},
 		"Elastic Container Service (ECS)": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewECS
-			m.ecsModel = NewECSModel(m.selectedProfile, m.styles, m.cache)
+			m.ecsModel = NewECSModel(m.selectedProfile, m.styles, m.cache, m.display)
 			m.ecsModel.SetSize(m.width, m.height)
 			return *m, m.ecsModel.Init()
 		},
 		"Billing & Costs": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewBilling
-			m.billingModel = NewBillingModel(m.selectedProfile, m.styles, m.cache)
+			m.billingModel = NewBillingModel(m.selectedProfile, m.styles, m.cache, m.display)
 			m.billingModel.SetSize(m.width, m.height)
 			return *m, m.billingModel.Init()
 		},
 		"Security Hub": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewSecurityHub
-			m.securityhubModel = NewSecurityHubModel(m.selectedProfile, m.styles, m.cache)
+			m.securityhubModel = NewSecurityHubModel(m.selectedProfile, m.styles, m.cache, m.display)
 			m.securityhubModel.SetSize(m.width, m.height)
 			return *m, m.securityhubModel.Init()
 		},
 		"Web Application Firewall (WAFv2)": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewWAF
-			region := "us-east-1"
-			if m.identity != nil {
-				region = m.identity.Region
-			}
-			m.wafModel = NewWAFModel(m.selectedProfile, m.styles, m.cache, region)
+			m.wafModel = NewWAFModel(m.selectedProfile, m.styles, m.cache, m.region(), m.display)
 			m.wafModel.SetSize(m.width, m.height)
 			return *m, m.wafModel.Init()
 		},
 		"Elastic Container Repository (ECR)": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewECR
-			m.ecrModel = NewECRModel(m.selectedProfile, m.styles, m.cache)
+			m.ecrModel = NewECRModel(m.selectedProfile, m.styles, m.cache, m.display)
 			m.ecrModel.SetSize(m.width, m.height)
 			return *m, m.ecrModel.Init()
 		},
 		"Elastic File System (EFS)": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewEFS
-			m.efsModel = NewEFSModel(m.selectedProfile, m.styles, m.cache)
+			m.efsModel = NewEFSModel(m.selectedProfile, m.styles, m.cache, m.display)
 			m.efsModel.SetSize(m.width, m.height)
 			return *m, m.efsModel.Init()
 		},
 		"AWS Backup": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewBackup
-			m.backupModel = NewBackupModel(m.selectedProfile, m.styles, m.cache)
+			m.backupModel = NewBackupModel(m.selectedProfile, m.styles, m.cache, m.display)
 			m.backupModel.SetSize(m.width, m.height)
 			return *m, m.backupModel.Init()
 		},
 		"DynamoDB": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewDynamoDB
-			m.dynamodbModel = NewDynamoDBModel(m.selectedProfile, m.styles, m.cache)
+			m.dynamodbModel = NewDynamoDBModel(m.selectedProfile, m.styles, m.cache, m.display)
 			m.dynamodbModel.SetSize(m.width, m.height)
 			return *m, m.dynamodbModel.Init()
 		},
 		"AWS Transfer": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewTransfer
-			m.transferModel = NewTransferModel(m.selectedProfile, m.styles, m.cache)
+			m.transferModel = NewTransferModel(m.selectedProfile, m.styles, m.cache, m.display)
 			m.transferModel.SetSize(m.width, m.height)
 			return *m, m.transferModel.Init()
 		},
 		"API Gateway": func(m *Model) (tea.Model, tea.Cmd) {
 			m.view = viewAPIGateway
-			m.apiGatewayModel = NewAPIGatewayModel(m.selectedProfile, m.styles, m.cache)
+			m.apiGatewayModel = NewAPIGatewayModel(m.selectedProfile, m.styles, m.cache, m.display)
 			m.apiGatewayModel.SetSize(m.width, m.height)
 			return *m, m.apiGatewayModel.Init()
 		},
@@ -251,7 +247,7 @@ func getServiceCategories() []ServiceCategory {
 		{
 			Name: "Security, Identity & Compliance",
 			Services: []string{
-				"IAM Users",
+				"Identity & Access (IAM)",
 				"Secrets Manager",
 				"Certificate Manager (ACM)",
 				"KMS Keys",
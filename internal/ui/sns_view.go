@@ -22,21 +22,27 @@ type snsItem struct {
 	description string
 	arn         string
 	values      []string
+	info        aws.TopicInfo
 }
 
 func (i snsItem) Title() string       { return i.title }
 func (i snsItem) Description() string { return i.description }
 func (i snsItem) FilterValue() string { return i.title + " " + i.description + " " + i.arn }
+func (i snsItem) rowID() string       { return i.arn }
+func (i snsItem) rowValues() []string { return i.values }
+func (i snsItem) source() any         { return i.info }
 
 type SNSModel struct {
 	client    *aws.SNSClient
 	list      list.Model
 	styles    Styles
+	table     *tableLayout
 	state     SNSState
 	width     int
 	height    int
 	profile   string
 	err       error
+	loaded    bool
 	cache     *cache.Cache
 	cacheKeys *cache.KeyBuilder
 }
@@ -44,6 +50,7 @@ type SNSModel struct {
 type snsItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 	state  SNSState
 }
 
@@ -66,20 +73,24 @@ func (d snsItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 		columns = snsTopicColumns
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, columns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, columns, d.table)
 	isSelected := index == m.Index()
 
-	RenderTableRow(w, m, d.styles, colStyles, i.values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, i.values, isSelected)
 }
 
 func (d snsItemDelegate) Height() int {
 	return 1
 }
 
-func NewSNSModel(profile string, styles Styles, appCache *cache.Cache) SNSModel {
+func (d snsItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewSNSModel(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) SNSModel {
+	table := &tableLayout{display: display}
 	d := snsItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 		state:           SNSStateTopics,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
@@ -94,6 +105,7 @@ func NewSNSModel(profile string, styles Styles, appCache *cache.Cache) SNSModel
 	return SNSModel{
 		list:      l,
 		styles:    styles,
+		table:     table,
 		state:     SNSStateTopics,
 		profile:   profile,
 		cache:     appCache,
@@ -139,16 +151,18 @@ func (m SNSModel) Update(msg tea.Msg) (SNSModel, tea.Cmd) {
 		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
 	case SNSTopicsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			items[i] = snsItem{
+				info:        v,
 				title:       v.Name,
 				description: v.ARN,
 				arn:         v.ARN,
 				values:      []string{v.Name, v.Type, v.SubscriptionsConfirmed, v.SubscriptionsPending},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = SNSStateTopics
 
@@ -172,17 +186,25 @@ func (m SNSModel) Update(msg tea.Msg) (SNSModel, tea.Cmd) {
 	return m, cmd
 }
 
+// columns are the columns of the table on screen
+func (m SNSModel) columns() []Column {
+	switch m.state {
+	case SNSStateTopics:
+		return snsTopicColumns
+	}
+	return nil
+}
+
 func (m SNSModel) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
 	}
 
-	var columns []Column
-	switch m.state {
-	case SNSStateTopics:
-		columns = snsTopicColumns
+	if m.loaded && len(m.list.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.list, "SNS topics", m.profile, m.table)
 	}
-	_, header := RenderTableHelpers(m.list, m.styles, columns)
+
+	_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
 	return header + "\n" + m.list.View()
 }
 
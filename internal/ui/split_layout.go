@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// splitMinWidth is the narrowest terminal that fits the detail pane beside a table
+	splitMinWidth = 160
+	// splitDetailRatio is the share of the inner width given to the detail pane
+	splitDetailRatio = 0.4
+)
+
+// detailField is one labelled row of a detail pane
+type detailField struct {
+	label string
+	value string
+}
+
+// splitWidths divides the inner width between the list and the detail pane.
+// The pane width is zero when the terminal is too narrow to split.
+func splitWidths(width int) (int, int) {
+	inner, _ := GetInnerListSize(width, 0)
+	if width < splitMinWidth {
+		return inner, 0
+	}
+	pane := int(float64(inner) * splitDetailRatio)
+	return inner - pane, pane
+}
+
+// renderSplit joins a table and its detail pane side by side
+func renderSplit(table, pane string) string {
+	return lipgloss.JoinHorizontal(lipgloss.Top, table, pane)
+}
+
+// renderDetailPane renders fields under a title in a pane separated from the
+// table by a vertical rule. Empty values are skipped.
+func renderDetailPane(styles Styles, title string, fields []detailField, width, height int) string {
+	labelWidth := 0
+	for _, f := range fields {
+		labelWidth = max(labelWidth, lipgloss.Width(f.label))
+	}
+	labelStyle := lipgloss.NewStyle().Foreground(styles.Primary).Width(labelWidth + 2)
+	valueStyle := lipgloss.NewStyle().Width(max(width-labelWidth-5, 1))
+
+	var s strings.Builder
+	s.WriteString(lipgloss.NewStyle().Foreground(styles.Primary).Bold(true).Render(title) + "\n\n")
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		s.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render(f.label), valueStyle.Render(f.value)) + "\n")
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder(), false, false, false, true).
+		BorderForeground(styles.Muted).
+		PaddingLeft(1).
+		Width(width - 1).
+		Height(height).
+		MaxHeight(height).
+		Render(strings.TrimSuffix(s.String(), "\n"))
+}
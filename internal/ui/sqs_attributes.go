@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+// sqsAttributeFields are the editable queue attributes, in form order, with
+// the ranges SQS accepts for them
+var sqsAttributeFields = []struct {
+	label    string
+	unit     string
+	min, max int
+}{
+	{"Visibility timeout", "seconds", 0, 43200},
+	{"Message retention period", "seconds", 60, 1209600},
+	{"Maximum message size", "bytes", 1024, 1048576},
+	{"Delivery delay", "seconds", 0, 900},
+}
+
+type SQSQueueAttributesMsg aws.QueueAttributes
+
+// SQSAttributesUpdatedMsg carries the name of the queue that was updated
+type SQSAttributesUpdatedMsg string
+
+func (m SQSModel) fetchQueueAttributes(queueURL string) tea.Cmd {
+	return func() tea.Msg {
+		client, err := aws.NewSQSClient(context.Background(), m.profile)
+		if err != nil {
+			return SQSErrorMsg(err)
+		}
+		attrs, err := client.GetQueueAttributes(context.Background(), queueURL)
+		if err != nil {
+			return SQSErrorMsg(err)
+		}
+		return SQSQueueAttributesMsg(attrs)
+	}
+}
+
+func (m *SQSModel) openAttributeForm(attrs aws.QueueAttributes) {
+	values := []int{attrs.VisibilityTimeout, attrs.MessageRetentionPeriod, attrs.MaximumMessageSize, attrs.DelaySeconds}
+	m.attrInputs = make([]textinput.Model, len(sqsAttributeFields))
+	for i := range sqsAttributeFields {
+		ti := textinput.New()
+		ti.SetValue(strconv.Itoa(values[i]))
+		ti.CharLimit = 8
+		ti.Width = 20
+		m.attrInputs[i] = ti
+	}
+	m.attrInputs[0].Focus()
+	m.attrFocus = 0
+	m.attrErr = ""
+	m.state = SQSStateEditAttributes
+}
+
+func (m SQSModel) updateAttributeForm(msg tea.KeyMsg) (SQSModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.state = SQSStateQueues
+		return m, nil
+	case "tab", "down":
+		m.attrInputs[m.attrFocus].Blur()
+		m.attrFocus = (m.attrFocus + 1) % len(m.attrInputs)
+		return m, m.attrInputs[m.attrFocus].Focus()
+	case "shift+tab", "up":
+		m.attrInputs[m.attrFocus].Blur()
+		m.attrFocus = (m.attrFocus - 1 + len(m.attrInputs)) % len(m.attrInputs)
+		return m, m.attrInputs[m.attrFocus].Focus()
+	case "enter":
+		values := make([]int, len(sqsAttributeFields))
+		for i, f := range sqsAttributeFields {
+			n, err := strconv.Atoi(strings.TrimSpace(m.attrInputs[i].Value()))
+			if err != nil || n < f.min || n > f.max {
+				m.attrErr = fmt.Sprintf("%s must be between %d and %d %s", f.label, f.min, f.max, f.unit)
+				return m, nil
+			}
+			values[i] = n
+		}
+		m.state = SQSStateQueues
+		return m, m.setQueueAttributes(aws.QueueAttributes{
+			VisibilityTimeout:      values[0],
+			MessageRetentionPeriod: values[1],
+			MaximumMessageSize:     values[2],
+			DelaySeconds:           values[3],
+		})
+	}
+
+	var cmd tea.Cmd
+	m.attrInputs[m.attrFocus], cmd = m.attrInputs[m.attrFocus].Update(msg)
+	return m, cmd
+}
+
+func (m SQSModel) setQueueAttributes(attrs aws.QueueAttributes) tea.Cmd {
+	queue := m.selectedQueue
+	return armed(func() tea.Msg {
+		client, err := aws.NewSQSClient(context.Background(), m.profile)
+		if err != nil {
+			return SQSErrorMsg(err)
+		}
+		if err := client.SetQueueAttributes(context.Background(), queue.url, attrs); err != nil {
+			return SQSErrorMsg(err)
+		}
+		return SQSAttributesUpdatedMsg(queue.title)
+	})
+}
+
+func (m SQSModel) renderAttributeForm() string {
+	var s strings.Builder
+	s.WriteString(" " + lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render("Edit "+m.selectedQueue.title) + "\n\n")
+	for i, f := range sqsAttributeFields {
+		label := m.styles.StatusMuted.Render(f.label)
+		if i == m.attrFocus {
+			label = lipgloss.NewStyle().Foreground(m.styles.Primary).Render(f.label)
+		}
+		limits := m.styles.StatusMuted.Render(fmt.Sprintf("%d-%d %s", f.min, f.max, f.unit))
+		s.WriteString(" " + label + "\n " + m.attrInputs[i].View() + " " + limits + "\n")
+	}
+	if m.attrErr != "" {
+		s.WriteString("\n " + m.styles.Error.Render("✘ "+m.attrErr) + "\n")
+	}
+	s.WriteString("\n " + m.styles.StatusMuted.Render("(tab to move, enter to apply, esc to cancel)"))
+
+	w, h := GetMainContainerSize(m.width, m.height)
+	popup := m.styles.Popup.Width(min(w-4, 64)).Render(s.String())
+	return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, popup)
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/giovannirossini/aws-tui/internal/aws"
 	"github.com/giovannirossini/aws-tui/internal/cache"
@@ -15,45 +16,61 @@ type SQSState int
 
 const (
 	SQSStateQueues SQSState = iota
+	SQSStateEditAttributes
 )
 
 type sqsItem struct {
 	title       string
 	description string
 	url         string
+	arn         string
+	dlqARN      string
 	values      []string
+	info        aws.QueueInfo
 }
 
 func (i sqsItem) Title() string       { return i.title }
 func (i sqsItem) Description() string { return i.description }
 func (i sqsItem) FilterValue() string { return i.title + " " + i.description + " " + i.url }
+func (i sqsItem) rowID() string       { return i.arn }
+func (i sqsItem) rowValues() []string { return i.values }
+func (i sqsItem) source() any         { return i.info }
 
 type SQSModel struct {
 	client    *aws.SQSClient
 	list      list.Model
 	styles    Styles
+	table     *tableLayout
 	state     SQSState
 	width     int
 	height    int
 	profile   string
 	err       error
+	loaded    bool
 	cache     *cache.Cache
 	cacheKeys *cache.KeyBuilder
+	// Attribute editor of the selected queue
+	selectedQueue sqsItem
+	attrInputs    []textinput.Model
+	attrFocus     int
+	attrErr       string
 }
 
 type sqsItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 	state  SQSState
 }
 
 var sqsQueueColumns = []Column{
-	{Title: "Queue Name", Width: 0.5},
+	{Title: "Queue Name", Width: 0.35},
 	{Title: "Type", Width: 0.1},
 	{Title: "Available", Width: 0.1},
 	{Title: "Delayed", Width: 0.1},
 	{Title: "Not Visible", Width: 0.1},
 	{Title: "Timeout (s)", Width: 0.1},
+	{Title: "DLQ", Width: 0.15},
 }
 
 func (d sqsItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
@@ -68,20 +85,24 @@ func (d sqsItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 		columns = sqsQueueColumns
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, columns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, columns, d.table)
 	isSelected := index == m.Index()
 
-	RenderTableRow(w, m, d.styles, colStyles, i.values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, i.values, isSelected)
 }
 
 func (d sqsItemDelegate) Height() int {
 	return 1
 }
 
-func NewSQSModel(profile string, styles Styles, appCache *cache.Cache) SQSModel {
+func (d sqsItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewSQSModel(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) SQSModel {
+	table := &tableLayout{display: display}
 	d := sqsItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 		state:           SQSStateQueues,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
@@ -96,6 +117,7 @@ func NewSQSModel(profile string, styles Styles, appCache *cache.Cache) SQSModel
 	return SQSModel{
 		list:      l,
 		styles:    styles,
+		table:     table,
 		state:     SQSStateQueues,
 		profile:   profile,
 		cache:     appCache,
@@ -141,19 +163,31 @@ func (m SQSModel) Update(msg tea.Msg) (SQSModel, tea.Cmd) {
 		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
 	case SQSQueuesMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			items[i] = sqsItem{
+				info:        v,
 				title:       v.Name,
 				description: v.URL,
 				url:         v.URL,
-				values:      []string{v.Name, v.Type, v.MessagesAvailable, v.MessagesDelayed, v.MessagesNotVisible, v.VisibilityTimeout},
+				arn:         v.ARN,
+				dlqARN:      v.DLQARN,
+				values:      []string{v.Name, v.Type, v.MessagesAvailable, v.MessagesDelayed, v.MessagesNotVisible, v.VisibilityTimeout, v.DLQName},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = SQSStateQueues
 
+	case SQSQueueAttributesMsg:
+		m.openAttributeForm(aws.QueueAttributes(msg))
+		return m, nil
+
+	case SQSAttributesUpdatedMsg:
+		m.cache.Delete(m.cacheKeys.SQSResources("queues"))
+		return m, m.fetchQueues()
+
 	case SQSErrorMsg:
 		m.err = msg
 
@@ -163,10 +197,19 @@ func (m SQSModel) Update(msg tea.Msg) (SQSModel, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.state == SQSStateEditAttributes {
+			return m.updateAttributeForm(msg)
+		}
+
 		switch msg.String() {
 		case "r":
 			m.cache.Delete(m.cacheKeys.SQSResources("queues"))
 			return m, m.fetchQueues()
+		case "e":
+			if item, ok := m.list.SelectedItem().(sqsItem); ok && !m.list.SettingFilter() {
+				m.selectedQueue = item
+				return m, m.fetchQueueAttributes(item.url)
+			}
 		}
 	}
 
@@ -174,17 +217,44 @@ func (m SQSModel) Update(msg tea.Msg) (SQSModel, tea.Cmd) {
 	return m, cmd
 }
 
+// selectDLQ moves the selection to the dead-letter queue of the selected queue.
+// It returns false when the queue has no DLQ or the DLQ is not in the list,
+// e.g. because it lives in another account or region.
+func (m *SQSModel) selectDLQ() bool {
+	item, ok := m.list.SelectedItem().(sqsItem)
+	if !ok || item.dlqARN == "" {
+		return false
+	}
+
+	m.list.ResetFilter()
+	for i, listItem := range m.list.Items() {
+		if q, ok := listItem.(sqsItem); ok && q.arn == item.dlqARN {
+			m.list.Select(i)
+			return true
+		}
+	}
+	return false
+}
+
+// columns are the columns of the table on screen
+func (m SQSModel) columns() []Column {
+	return sqsQueueColumns
+}
+
 func (m SQSModel) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
 	}
 
-	var columns []Column
-	switch m.state {
-	case SQSStateQueues:
-		columns = sqsQueueColumns
+	if m.state == SQSStateEditAttributes {
+		return m.renderAttributeForm()
 	}
-	_, header := RenderTableHelpers(m.list, m.styles, columns)
+
+	if m.loaded && len(m.list.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.list, "SQS queues", m.profile, m.table)
+	}
+
+	_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
 	return header + "\n" + m.list.View()
 }
 
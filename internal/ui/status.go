@@ -0,0 +1,115 @@
+package ui
+
+import "strings"
+
+type statusLevel int
+
+const (
+	statusUnknown statusLevel = iota
+	statusHealthy
+	statusPending
+	statusFailed
+)
+
+// statusLevels classifies the resource states AWS returns, normalized to
+// lowercase with dashes. States not listed here fall back to statusLevelOf's
+// pattern matching.
+var statusLevels = map[string]statusLevel{
+	"active":           statusHealthy,
+	"available":        statusHealthy,
+	"running":          statusHealthy,
+	"healthy":          statusHealthy,
+	"in-use":           statusHealthy,
+	"deployed":         statusHealthy,
+	"issued":           statusHealthy,
+	"enabled":          statusHealthy,
+	"online":           statusHealthy,
+	"completed":        statusHealthy,
+	"succeeded":        statusHealthy,
+	"attached":         statusHealthy,
+	"ready":            statusHealthy,
+	"load-complete":    statusHealthy,
+	"pending":          statusPending,
+	"creating":         statusPending,
+	"modifying":        statusPending,
+	"updating":         statusPending,
+	"starting":         statusPending,
+	"stopping":         statusPending,
+	"rebooting":        statusPending,
+	"deleting":         statusPending,
+	"backing-up":       statusPending,
+	"in-progress":      statusPending,
+	"inprogress":       statusPending,
+	"provisioning":     statusPending,
+	"maintenance":      statusPending,
+	"shutting-down":    statusPending,
+	"pending-deletion": statusPending,
+	"pendingdeletion":  statusPending,
+	"offline":          statusFailed,
+	"failed":           statusFailed,
+	"stopped":          statusFailed,
+	"error":            statusFailed,
+	"deleted":          statusFailed,
+	"terminated":       statusFailed,
+	"unhealthy":        statusFailed,
+	"inactive":         statusFailed,
+	"disabled":         statusFailed,
+	"expired":          statusFailed,
+	"revoked":          statusFailed,
+	"aborted":          statusFailed,
+	"impaired":         statusFailed,
+
+	// CloudWatch alarm states
+	"ok":                statusHealthy,
+	"insufficient-data": statusPending,
+	"alarm":             statusFailed,
+
+	// Route 53 DNSSEC signing states
+	"signing":       statusHealthy,
+	"not-signing":   statusUnknown,
+	"action-needed": statusFailed,
+}
+
+func statusLevelOf(value string) statusLevel {
+	key := strings.ToLower(strings.NewReplacer("_", "-", " ", "-").Replace(strings.TrimSpace(value)))
+	if level, ok := statusLevels[key]; ok {
+		return level
+	}
+
+	switch {
+	case strings.Contains(key, "fail"), strings.Contains(key, "error"),
+		strings.HasPrefix(key, "incompatible"), strings.HasPrefix(key, "inaccessible"):
+		return statusFailed
+	case strings.HasPrefix(key, "pending"), strings.HasSuffix(key, "ing"):
+		return statusPending
+	}
+	return statusUnknown
+}
+
+// statusSymbols mark each level for themes that must not rely on color alone
+var statusSymbols = map[statusLevel]string{
+	statusHealthy: "✔ ",
+	statusPending: "… ",
+	statusFailed:  "✘ ",
+}
+
+// renderStatus colors a resource state consistently across views: green for
+// healthy states, the warning color for transitions and red for failures.
+// Unrecognized states are returned unchanged.
+func renderStatus(styles Styles, value string) string {
+	level := statusLevelOf(value)
+	if styles.Symbols {
+		value = statusSymbols[level] + value
+	}
+
+	switch level {
+	case statusHealthy:
+		return styles.Success.Render(value)
+	case statusPending:
+		return styles.Warning.Render(value)
+	case statusFailed:
+		return styles.Error.Render(value)
+	default:
+		return value
+	}
+}
@@ -1,6 +1,12 @@
 package ui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 // Colors
 var (
@@ -20,6 +26,115 @@ var (
 	InfoColor    = lipgloss.Color("#0073BB")
 )
 
+// DefaultTheme is used when no theme is selected
+const DefaultTheme = "dark"
+
+// ThemeEnvVar selects the theme when the --theme flag is not given
+const ThemeEnvVar = "AWS_TUI_THEME"
+
+// Theme is the palette Styles are built from. An empty color renders without
+// any ANSI color.
+type Theme struct {
+	Primary   lipgloss.Color
+	Secondary lipgloss.Color
+	Accent    lipgloss.Color
+	Muted     lipgloss.Color
+	White     lipgloss.Color
+	Snow      lipgloss.Color
+	DarkGray  lipgloss.Color
+	Squid     lipgloss.Color
+	Success   lipgloss.Color
+	Error     lipgloss.Color
+	Warning   lipgloss.Color
+	Info      lipgloss.Color
+	// Symbols prefixes statuses with a marker so they do not rely on color alone
+	Symbols bool
+	// NoColor marks the selection with reverse video instead of color
+	NoColor bool
+	// ChromaStyle is the syntax highlighting style for logs and JSON
+	ChromaStyle string
+}
+
+var themes = map[string]Theme{
+	"dark": {
+		Primary:     AWSAmber,
+		Secondary:   AWSSquid,
+		Accent:      AWSSky,
+		Muted:       AWSGray,
+		White:       AWSWhite,
+		Snow:        AWSSnow,
+		DarkGray:    AWSDarkGray,
+		Squid:       AWSSquid,
+		Success:     SuccessColor,
+		Error:       ErrorColor,
+		Warning:     WarningColor,
+		Info:        InfoColor,
+		ChromaStyle: "monokai",
+	},
+	"light": {
+		Primary:     lipgloss.Color("#C45500"),
+		Secondary:   lipgloss.Color("#EAEDED"),
+		Accent:      lipgloss.Color("#0073BB"),
+		Muted:       lipgloss.Color("#687078"),
+		White:       AWSWhite,
+		Snow:        AWSDarkGray,
+		DarkGray:    lipgloss.Color("#EAEDED"),
+		Squid:       lipgloss.Color("#EAEDED"),
+		Success:     lipgloss.Color("#1D8102"),
+		Error:       ErrorColor,
+		Warning:     lipgloss.Color("#C45500"),
+		Info:        lipgloss.Color("#0073BB"),
+		ChromaStyle: "github",
+	},
+	"high-contrast": {
+		Primary:     lipgloss.Color("#FFFF00"),
+		Secondary:   lipgloss.Color("#000000"),
+		Accent:      lipgloss.Color("#00FFFF"),
+		Muted:       lipgloss.Color("#D0D0D0"),
+		White:       lipgloss.Color("#000000"),
+		Snow:        AWSWhite,
+		DarkGray:    lipgloss.Color("#000000"),
+		Squid:       lipgloss.Color("#000000"),
+		Success:     lipgloss.Color("#00FF00"),
+		Error:       lipgloss.Color("#FF5F5F"),
+		Warning:     lipgloss.Color("#FFAF00"),
+		Info:        lipgloss.Color("#00FFFF"),
+		Symbols:     true,
+		ChromaStyle: "native",
+	},
+	"mono": {
+		Symbols:     true,
+		NoColor:     true,
+		ChromaStyle: "bw",
+	},
+}
+
+// ThemeNames lists the selectable themes
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ThemeStyles builds the styles for the named theme; an empty name selects
+// DefaultTheme. Setting NO_COLOR (https://no-color.org) forces the mono theme.
+func ThemeStyles(name string) (Styles, error) {
+	if name == "" {
+		name = DefaultTheme
+	}
+	theme, ok := themes[name]
+	if !ok {
+		return Styles{}, fmt.Errorf("unknown theme %q (available: %v)", name, ThemeNames())
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		theme = themes["mono"]
+	}
+	return NewStyles(theme), nil
+}
+
 type Styles struct {
 	// Colors
 	Primary   lipgloss.Color
@@ -31,6 +146,16 @@ type Styles struct {
 	DarkGray  lipgloss.Color
 	Squid     lipgloss.Color
 
+	SuccessColor lipgloss.Color
+	ErrorColor   lipgloss.Color
+	WarningColor lipgloss.Color
+	InfoColor    lipgloss.Color
+
+	// Symbols, NoColor and ChromaStyle are carried over from the Theme
+	Symbols     bool
+	NoColor     bool
+	ChromaStyle string
+
 	AppTitle         lipgloss.Style
 	Header           lipgloss.Style
 	Profile          lipgloss.Style
@@ -59,15 +184,27 @@ type Styles struct {
 }
 
 func DefaultStyles() Styles {
+	return NewStyles(themes[DefaultTheme])
+}
+
+// NewStyles builds every style from the theme's palette
+func NewStyles(t Theme) Styles {
 	s := Styles{
-		Primary:   AWSAmber,
-		Secondary: AWSSquid,
-		Accent:    AWSSky,
-		Muted:     AWSGray,
-		White:     AWSWhite,
-		Snow:      AWSSnow,
-		DarkGray:  AWSDarkGray,
-		Squid:     AWSSquid,
+		Primary:      t.Primary,
+		Secondary:    t.Secondary,
+		Accent:       t.Accent,
+		Muted:        t.Muted,
+		White:        t.White,
+		Snow:         t.Snow,
+		DarkGray:     t.DarkGray,
+		Squid:        t.Squid,
+		SuccessColor: t.Success,
+		ErrorColor:   t.Error,
+		WarningColor: t.Warning,
+		InfoColor:    t.Info,
+		Symbols:      t.Symbols,
+		NoColor:      t.NoColor,
+		ChromaStyle:  t.ChromaStyle,
 	}
 
 	s.ViewTitle = lipgloss.NewStyle().
@@ -91,7 +228,7 @@ func DefaultStyles() Styles {
 
 	s.SelectedProfile = lipgloss.NewStyle().
 		Foreground(s.White).
-		Background(InfoColor).
+		Background(s.InfoColor).
 		Padding(0, 1)
 
 	s.MainContainer = lipgloss.NewStyle().
@@ -143,10 +280,10 @@ func DefaultStyles() Styles {
 		Foreground(s.Muted).
 		Italic(true)
 
-	s.Error = lipgloss.NewStyle().Foreground(ErrorColor)
-	s.Success = lipgloss.NewStyle().Foreground(SuccessColor)
-	s.Warning = lipgloss.NewStyle().Foreground(WarningColor)
-	s.Info = lipgloss.NewStyle().Foreground(InfoColor)
+	s.Error = lipgloss.NewStyle().Foreground(s.ErrorColor)
+	s.Success = lipgloss.NewStyle().Foreground(s.SuccessColor)
+	s.Warning = lipgloss.NewStyle().Foreground(s.WarningColor)
+	s.Info = lipgloss.NewStyle().Foreground(s.InfoColor)
 
 	s.ListSelectedTitle = lipgloss.NewStyle().
 		Foreground(s.Primary).
@@ -156,5 +293,15 @@ func DefaultStyles() Styles {
 		Foreground(s.Muted).
 		Bold(false)
 
+	if t.NoColor {
+		// Without color the selection and emphasis have to come from attributes
+		s.SelectedMenuItem = s.SelectedMenuItem.Underline(true)
+		s.ListSelectedTitle = s.ListSelectedTitle.Reverse(true)
+		s.SelectedProfile = s.SelectedProfile.Reverse(true)
+		s.AppTitle = s.AppTitle.Reverse(true)
+		s.Error = s.Error.Bold(true)
+		s.Warning = s.Warning.Underline(true)
+	}
+
 	return s
 }
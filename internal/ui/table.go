@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -14,20 +15,148 @@ type Column struct {
 	Width float64 // Percentage of total width (0.0 to 1.0)
 }
 
-func RenderTableHelpers(m list.Model, styles Styles, columns []Column) ([]lipgloss.Style, string) {
+// tableRow is implemented by items that keep their cell values, so auto-fit
+// can measure them. Tables whose delegates build the values while rendering
+// keep the fixed fractions.
+type tableRow interface {
+	rowValues() []string
+}
+
+// tableDisplay holds the display settings every table follows. The model owns
+// it and each view's tableLayout points to it, so a toggle reaches the
+// delegates of every view.
+type tableDisplay struct {
+	fullARNs bool // ARNs in cells shown in full rather than by resource name
+	// fitColumns sizes columns to the content of the loaded rows instead of
+	// the fixed fractions of their definitions
+	fitColumns bool
+	// compact drops the blank line between table rows and the descriptions
+	// of menu entries, so about twice as many rows fit on screen
+	compact bool
+}
+
+// tableLayout is what a view keeps about its tables between renders. The
+// view model and its delegates share it by pointer, as the delegates render
+// without access to the model.
+type tableLayout struct {
+	hidden     map[string]bool      // Column titles hidden with the column picker
+	pinned     map[string]bool      // rowKeys pinned in the view
+	changes    map[string]rowChange // rowKeys changed by the last refresh
+	region     string               // Region the view lists, empty for global services
+	refreshKey string               // Key bound to refresh, for the empty state
+	scrolled   *Column              // Columns the scroll offset applies to
+	offset     int
+	rows       int // Bumped by setItems, so auto-fit measures the new rows
+	fit        columnFit
+	display    *tableDisplay
+}
+
+// columnFit holds the fitted widths and what they were measured for, so the
+// rows are measured once per setItems rather than for every rendered row
+type columnFit struct {
+	rows     int
+	columns  *Column
+	width    int
+	fullARNs bool
+	widths   []int
+}
+
+// setItems replaces the rows of one of the view's tables
+func (t *tableLayout) setItems(l *list.Model, items []list.Item) tea.Cmd {
+	t.rows++
+	return l.SetItems(items)
+}
+
+// fitColumns returns column widths that fit the longest value of each column,
+// clamped between the title's width and half the table. Spare space goes to
+// the last column; when the content does not fit, the widths shrink in
+// proportion. It returns nil when the items cannot be measured.
+func fitColumns(t *tableLayout, items []list.Item, columns []Column, tableWidth int) []int {
+	if len(items) == 0 || len(columns) == 0 {
+		return nil
+	}
+	key := columnFit{rows: t.rows, columns: &columns[0], width: tableWidth, fullARNs: t.display.fullARNs}
+	if fit := t.fit; fit.rows == key.rows && fit.columns == key.columns && fit.width == key.width && fit.fullARNs == key.fullARNs {
+		return fit.widths
+	}
+
+	const padding = 2
+	widths := make([]int, len(columns))
+	for i, col := range columns {
+		widths[i] = lipgloss.Width(col.Title) + padding
+	}
+	for _, item := range items {
+		row, ok := item.(tableRow)
+		if !ok {
+			return nil
+		}
+		for i, v := range row.rowValues() {
+			if i < len(widths) {
+				widths[i] = max(widths[i], lipgloss.Width(formatARN(v, t.display.fullARNs))+padding)
+			}
+		}
+	}
+
+	total := 0
+	for i := range widths {
+		widths[i] = min(widths[i], max(tableWidth/2, lipgloss.Width(columns[i].Title)+padding))
+		total += widths[i]
+	}
+	if total > tableWidth {
+		for i := range widths {
+			widths[i] = widths[i] * tableWidth / total
+		}
+	}
+
+	key.widths = widths
+	t.fit = key
+	return widths
+}
+
+// tableStyles are the styles of the columns a table shows and the indexes of
+// those columns, for RenderTableRow to pick the values to render, along with
+// the rows pinned in the view and those changed by the last refresh
+type tableStyles struct {
+	columns  []lipgloss.Style
+	visible  []int
+	pinned   map[string]bool
+	changes  map[string]rowChange
+	fullARNs bool
+}
+
+func RenderTableHelpers(m list.Model, styles Styles, columns []Column, t *tableLayout) (tableStyles, string) {
 	fullWidth := m.Width()
 	tableContentWidth := fullWidth - 4 // 2 left + 2 right padding
 	if tableContentWidth < 0 {
 		tableContentWidth = 0
 	}
 
-	columnStyles := make([]lipgloss.Style, len(columns))
-	headerStrings := make([]string, len(columns))
+	// Columns hidden or scrolled out of view give their share to the rest
+	visible := t.visibleColumns(columns)
+	fractions := 0.0
+	for _, i := range visible {
+		fractions += columns[i].Width
+	}
+	if fractions <= 0 {
+		fractions = 1
+	}
+
+	columnStyles := make([]lipgloss.Style, len(visible))
+	headerStrings := make([]string, len(visible))
+
+	var fitted []int
+	if t.display.fitColumns {
+		fitted = fitColumns(t, m.Items(), columns, tableContentWidth)
+	}
 
 	totalWidthUsed := 0
-	for i, col := range columns {
-		colWidth := int(float64(tableContentWidth) * col.Width)
-		if i == len(columns)-1 {
+	for i, c := range visible {
+		col := columns[c]
+		colWidth := int(float64(tableContentWidth) * col.Width / fractions)
+		if fitted != nil {
+			colWidth = fitted[c]
+		}
+		if i == len(visible)-1 {
 			// Last column takes the remaining space
 			colWidth = tableContentWidth - totalWidthUsed
 		}
@@ -50,10 +179,15 @@ func RenderTableHelpers(m list.Model, styles Styles, columns []Column) ([]lipglo
 			columnStyles[i] = columnStyles[i].PaddingRight(padding)
 		}
 
+		title := strings.ToUpper(col.Title)
+		if scrolled := t.scrollOffset(columns); i == 1 && scrolled > 0 {
+			// Mark where the scrolled out columns were
+			title = fmt.Sprintf("‹%d %s", scrolled, title)
+		}
 		headerStrings[i] = columnStyles[i].Copy().
 			Foreground(styles.Muted).
 			Bold(true).
-			Render(strings.ToUpper(col.Title))
+			Render(title)
 	}
 
 	header := lipgloss.JoinHorizontal(lipgloss.Top, headerStrings...)
@@ -62,37 +196,64 @@ func RenderTableHelpers(m list.Model, styles Styles, columns []Column) ([]lipglo
 		PaddingRight(2).
 		Width(fullWidth).
 		Render(header)
-	return columnStyles, header
+	return tableStyles{columns: columnStyles, visible: visible, pinned: t.pinned, changes: t.changes, fullARNs: t.display.fullARNs}, header
 }
 
-func RenderTableRow(w io.Writer, m list.Model, styles Styles, columnStyles []lipgloss.Style, values []string, isSelected bool) {
-	numCols := len(columnStyles)
+// RenderTableRow renders one row of a table. Rows added or changed by the last
+// refresh are tinted and marked in the left padding for a moment, pinned rows
+// are marked with a pin otherwise, and ARN
+// values go through formatARN. The columnStyles come from RenderTableHelpers
+// for the same table, so the values hidden or scrolled out of view are
+// dropped here.
+func RenderTableRow(w io.Writer, m list.Model, item list.Item, styles Styles, columnStyles tableStyles, values []string, isSelected bool) {
+	shown := make([]string, 0, len(columnStyles.visible))
+	for _, i := range columnStyles.visible {
+		if i < len(values) {
+			shown = append(shown, values[i])
+		}
+	}
+	values = shown
+	numCols := len(columnStyles.columns)
 	if len(values) < numCols {
 		numCols = len(values)
 	}
 	rowValues := make([]string, numCols)
 
 	contentColor := styles.Snow
+	marker := "  "
+	switch columnStyles.changes[rowKey(item)] {
+	case rowAdded:
+		contentColor = styles.SuccessColor
+		marker = lipgloss.NewStyle().Foreground(styles.SuccessColor).Render("+ ")
+	case rowChanged:
+		contentColor = styles.WarningColor
+		marker = lipgloss.NewStyle().Foreground(styles.WarningColor).Render("~ ")
+	}
+	if marker == "  " && columnStyles.pinned[rowKey(item)] {
+		marker = "📌"
+	}
 	if isSelected {
 		contentColor = styles.Primary
 	}
 
 	for i := 0; i < numCols; i++ {
-		style := columnStyles[i].Copy().Foreground(contentColor)
+		style := columnStyles.columns[i].Copy().Foreground(contentColor)
 		if isSelected {
 			style = style.Bold(true)
+			if styles.NoColor {
+				style = style.Reverse(true)
+			}
 		}
-		rowValues[i] = style.Render(values[i])
+		rowValues[i] = style.Render(formatARN(values[i], columnStyles.fullARNs))
 	}
 
 	row := lipgloss.JoinHorizontal(lipgloss.Top, rowValues...)
 
 	fullWidth := m.Width()
 	itemStyle := lipgloss.NewStyle().
-		PaddingLeft(2).
 		PaddingRight(2).
 		Width(fullWidth)
-	fmt.Fprintf(w, "%s", itemStyle.Render(row))
+	fmt.Fprintf(w, "%s", itemStyle.Render(marker+row))
 }
 
 // RenderOverlay places the overlay text on top of the base text, centered.
@@ -0,0 +1,55 @@
+package ui
+
+// narrowTableWidth is the terminal width below which the footer offers
+// column scrolling
+const narrowTableWidth = 120
+
+// shownColumns returns the indexes of the columns of a table that the column
+// picker leaves shown, after the first one, which names the row
+func (t *tableLayout) shownColumns(columns []Column) []int {
+	var shown []int
+	for i := 1; i < len(columns); i++ {
+		if !t.hidden[columns[i].Title] {
+			shown = append(shown, i)
+		}
+	}
+	return shown
+}
+
+// scrollOffset is the number of columns scrolled out of a table with the
+// given columns. The offset belongs to one column set, so the view's other
+// tables start unscrolled.
+func (t *tableLayout) scrollOffset(columns []Column) int {
+	if len(columns) == 0 || t.scrolled != &columns[0] {
+		return 0
+	}
+	return min(t.offset, max(len(t.shownColumns(columns))-1, 0))
+}
+
+// scroll hides one more (delta 1) or one fewer (delta -1) column of a table
+// with the given columns, after the pinned first one. It reports false when
+// the table cannot scroll further.
+func (t *tableLayout) scroll(columns []Column, delta int) bool {
+	if len(columns) == 0 {
+		return false
+	}
+	// Keep the first column and at least one other in view
+	current := t.scrollOffset(columns)
+	offset := min(max(current+delta, 0), max(len(t.shownColumns(columns))-1, 0))
+	if offset == current {
+		return false
+	}
+	t.scrolled, t.offset = &columns[0], offset
+	return true
+}
+
+// visibleColumns returns the indexes of the columns shown for a table with
+// the given columns. The first column stays pinned as the name of the row;
+// hidden columns are skipped before the scroll offset applies.
+func (t *tableLayout) visibleColumns(columns []Column) []int {
+	if len(columns) == 0 {
+		return nil
+	}
+	shown := t.shownColumns(columns)
+	return append([]int{0}, shown[t.scrollOffset(columns):]...)
+}
@@ -0,0 +1,357 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+type tagResourceKind int
+
+const (
+	tagResourceEC2 tagResourceKind = iota
+	tagResourceRDS
+	tagResourceS3
+)
+
+// tagTarget is the resource whose tags are edited
+type tagTarget struct {
+	kind tagResourceKind
+	id   string // Instance or volume ID, RDS ARN or bucket name
+	name string
+}
+
+// taggedItem is implemented by items of resources whose tags can be edited
+type taggedItem interface {
+	tagTarget() (tagTarget, bool)
+}
+
+// TagsMsg carries the tags of a resource
+type TagsMsg struct {
+	target tagTarget
+	tags   []aws.Tag
+	err    error
+}
+
+// TagsSavedMsg reports the result of changing the tags of a resource
+type TagsSavedMsg struct {
+	target tagTarget
+	action string
+	err    error
+}
+
+type tagEditorMode int
+
+const (
+	tagEditorBrowse tagEditorMode = iota
+	tagEditorKey
+	tagEditorValue
+	tagEditorConfirmRemove
+)
+
+// tagEditor shows the tags of a resource and adds, edits and removes them
+type tagEditor struct {
+	target tagTarget
+	tags   []aws.Tag
+	loaded bool
+	cursor int
+	mode   tagEditorMode
+	input  textinput.Model
+	// Key of the tag being added or edited
+	key     string
+	confirm ConfirmDialog
+	saving  bool
+	err     error
+}
+
+// typing reports whether keys go to the key or value input
+func (e tagEditor) typing() bool {
+	return e.mode == tagEditorKey || e.mode == tagEditorValue
+}
+
+func (e tagEditor) selected() (aws.Tag, bool) {
+	if e.cursor < 0 || e.cursor >= len(e.tags) {
+		return aws.Tag{}, false
+	}
+	return e.tags[e.cursor], true
+}
+
+// selectedTagTarget returns the resource on screen when its tags can be
+// edited: the bucket of the detail screen or the resource of the selected row
+func (m *Model) selectedTagTarget() (tagTarget, bool) {
+	if m.view == viewS3 && m.s3Model.state == S3StateBucketDetails && m.s3Model.bucketDetails != nil {
+		name := m.s3Model.bucketDetails.Name
+		return tagTarget{kind: tagResourceS3, id: name, name: name}, true
+	}
+	l := m.activeList()
+	if m.view == viewHome || l == nil || l.FilterState() == list.Filtering {
+		return tagTarget{}, false
+	}
+	if item, ok := l.SelectedItem().(taggedItem); ok {
+		return item.tagTarget()
+	}
+	return tagTarget{}, false
+}
+
+// newTagger returns the client that tags resources of the target's service
+func newTagger(ctx context.Context, profile string, kind tagResourceKind) (aws.Tagger, error) {
+	switch kind {
+	case tagResourceEC2:
+		return aws.NewEC2ResourcesClient(ctx, profile)
+	case tagResourceRDS:
+		return aws.NewRDSClient(ctx, profile)
+	default:
+		return aws.NewS3Client(ctx, profile)
+	}
+}
+
+func (m *Model) openTagEditor(target tagTarget) tea.Cmd {
+	m.tagEditor = tagEditor{target: target, input: textinput.New()}
+	m.tagEditor.input.Width = 50
+	m.tagEditorActive = true
+	return m.fetchTags(target)
+}
+
+func (m Model) fetchTags(target tagTarget) tea.Cmd {
+	profile := m.selectedProfile
+	return func() tea.Msg {
+		ctx := context.Background()
+		client, err := newTagger(ctx, profile, target.kind)
+		if err != nil {
+			return TagsMsg{target: target, err: err}
+		}
+		tags, err := client.GetTags(ctx, target.id)
+		return TagsMsg{target: target, tags: tags, err: err}
+	}
+}
+
+func (m Model) setTag(target tagTarget, tag aws.Tag) tea.Cmd {
+	profile := m.selectedProfile
+	return func() tea.Msg {
+		ctx := context.Background()
+		saved := TagsSavedMsg{target: target, action: fmt.Sprintf("Tagged %s with %s=%s", target.name, tag.Key, tag.Value)}
+		client, err := newTagger(ctx, profile, target.kind)
+		if err == nil {
+			err = client.SetTags(ctx, target.id, []aws.Tag{tag})
+		}
+		saved.err = err
+		return saved
+	}
+}
+
+func (m Model) removeTag(target tagTarget, key string) tea.Cmd {
+	profile := m.selectedProfile
+	return func() tea.Msg {
+		ctx := context.Background()
+		saved := TagsSavedMsg{target: target, action: fmt.Sprintf("Removed tag %s from %s", key, target.name)}
+		client, err := newTagger(ctx, profile, target.kind)
+		if err == nil {
+			err = client.RemoveTags(ctx, target.id, []string{key})
+		}
+		saved.err = err
+		return saved
+	}
+}
+
+func (m *Model) handleTags(msg TagsMsg) (tea.Model, tea.Cmd) {
+	// Tags of a resource whose editor has since been closed
+	if !m.tagEditorActive || msg.target != m.tagEditor.target {
+		return *m, nil
+	}
+	m.tagEditor.loaded = true
+	m.tagEditor.err = msg.err
+	m.tagEditor.tags = msg.tags
+	m.tagEditor.cursor = min(m.tagEditor.cursor, max(len(msg.tags)-1, 0))
+	return *m, nil
+}
+
+func (m *Model) handleTagsSaved(msg TagsSavedMsg) (tea.Model, tea.Cmd) {
+	if m.tagEditorActive && msg.target == m.tagEditor.target {
+		m.tagEditor.saving = false
+		if msg.err != nil {
+			m.tagEditor.err = msg.err
+			return *m, nil
+		}
+		toastCmd := m.showToast("✔ " + msg.action)
+		return *m, tea.Batch(m.fetchTags(msg.target), toastCmd)
+	}
+	if msg.err != nil {
+		return *m, m.showToast("✘ " + msg.err.Error())
+	}
+	return *m, m.showToast("✔ " + msg.action)
+}
+
+func (m *Model) handleTagEditorKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	e := &m.tagEditor
+	if msg.String() == "ctrl+c" {
+		return *m, tea.Quit
+	}
+
+	switch e.mode {
+	case tagEditorKey, tagEditorValue:
+		switch msg.String() {
+		case "esc":
+			e.mode = tagEditorBrowse
+			e.err = nil
+			return *m, nil
+		case "enter":
+			return m.submitTagInput()
+		}
+		var cmd tea.Cmd
+		e.input, cmd = e.input.Update(msg)
+		return *m, cmd
+
+	case tagEditorConfirmRemove:
+		result, cmd := e.confirm.Update(msg)
+		if result != confirmPending {
+			e.mode = tagEditorBrowse
+		}
+		if result == confirmAccepted {
+			e.saving = true
+			m.armOperation()
+		}
+		return *m, cmd
+	}
+
+	// A save in flight keeps the editor open but takes no new changes
+	mutating := msg.String() == "a" || msg.String() == "e" || msg.String() == "enter" || msg.String() == "d"
+	if mutating && m.readOnly {
+		return *m, m.showToast(readOnlyToast)
+	}
+	if mutating && (e.saving || !e.loaded) {
+		return *m, nil
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		m.tagEditorActive = false
+	case "up", "k":
+		e.cursor = max(e.cursor-1, 0)
+	case "down", "j":
+		e.cursor = min(e.cursor+1, max(len(e.tags)-1, 0))
+	case "r":
+		e.err = nil
+		return *m, m.fetchTags(e.target)
+	case "a":
+		e.err = nil
+		e.key = ""
+		e.mode = tagEditorKey
+		e.input.Placeholder = "Key"
+		e.input.SetValue("")
+		e.input.Focus()
+		return *m, textinput.Blink
+	case "e", "enter":
+		if tag, ok := e.selected(); ok {
+			e.err = nil
+			e.key = tag.Key
+			e.mode = tagEditorValue
+			e.input.Placeholder = "Value"
+			e.input.SetValue(tag.Value)
+			e.input.Focus()
+			return *m, textinput.Blink
+		}
+	case "d":
+		if tag, ok := e.selected(); ok {
+			target := e.target
+			e.err = nil
+			e.mode = tagEditorConfirmRemove
+			e.confirm = NewConfirmDialog("Remove Tag", fmt.Sprintf("Remove tag %s from %s?",
+				lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render(tag.Key), target.name,
+			), true, func() tea.Cmd { return m.removeTag(target, tag.Key) })
+		}
+	}
+	return *m, nil
+}
+
+// submitTagInput moves from the key to the value, and saves the tag once the
+// value is given. Limits are checked here so the request is not sent in vain.
+func (m *Model) submitTagInput() (tea.Model, tea.Cmd) {
+	e := &m.tagEditor
+	if e.mode == tagEditorKey {
+		key := strings.TrimSpace(e.input.Value())
+		if err := aws.ValidateTag(key, ""); err != nil {
+			e.err = err
+			return *m, nil
+		}
+		e.err = nil
+		e.key = key
+		e.mode = tagEditorValue
+		e.input.Placeholder = "Value"
+		e.input.SetValue("")
+		return *m, nil
+	}
+
+	value := e.input.Value()
+	if err := aws.ValidateTag(e.key, value); err != nil {
+		e.err = err
+		return *m, nil
+	}
+	e.err = nil
+	e.mode = tagEditorBrowse
+	e.saving = true
+	m.armOperation()
+	return *m, m.setTag(e.target, aws.Tag{Key: e.key, Value: value})
+}
+
+func (m Model) renderTagEditor() string {
+	e := m.tagEditor
+	const width = 72
+	keyStyle := lipgloss.NewStyle().Foreground(m.styles.Primary).Width(28).MaxWidth(28)
+	valueStyle := lipgloss.NewStyle().MaxWidth(width - 36)
+
+	var s strings.Builder
+	s.WriteString(" " + lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render("Tags: "+e.target.name) + "\n\n")
+
+	switch {
+	case !e.loaded:
+		s.WriteString(" " + m.styles.StatusMuted.Render("loading...") + "\n")
+	case len(e.tags) == 0:
+		s.WriteString(" " + m.styles.StatusMuted.Render("No tags") + "\n")
+	default:
+		for i, t := range e.tags {
+			row := keyStyle.Render(t.Key) + valueStyle.Render(t.Value)
+			if i == e.cursor && !e.typing() {
+				s.WriteString(m.styles.SelectedMenuItem.Render("➜ ") + row + "\n")
+			} else {
+				s.WriteString("  " + row + "\n")
+			}
+		}
+	}
+
+	switch e.mode {
+	case tagEditorKey:
+		s.WriteString(fmt.Sprintf("\n %s\n %s\n", m.styles.StatusMuted.Render(fmt.Sprintf("New tag key (up to %d characters)", aws.MaxTagKeyLength)), e.input.View()))
+	case tagEditorValue:
+		s.WriteString(fmt.Sprintf("\n %s\n %s\n", m.styles.StatusMuted.Render(fmt.Sprintf("Value of %s (up to %d characters)", e.key, aws.MaxTagValueLength)), e.input.View()))
+	}
+
+	if e.saving {
+		s.WriteString("\n " + m.styles.StatusMuted.Render("saving...") + "\n")
+	}
+	if e.err != nil {
+		s.WriteString("\n " + lipgloss.NewStyle().MaxWidth(width-4).Render(m.styles.Error.Render("✘ "+e.err.Error())) + "\n")
+	}
+
+	help := fmt.Sprintf("(%s add, %s edit, %s remove, r reload, esc close)", m.keys.key("add"), m.keys.key("edit"), m.keys.key("delete"))
+	switch {
+	case e.typing():
+		help = "(enter to confirm, esc to cancel)"
+	case m.readOnly:
+		help = "(r reload, esc close)"
+	}
+	s.WriteString("\n " + m.styles.StatusMuted.Render(help))
+
+	popup := m.styles.Popup.Width(width).Render(s.String())
+	w, h := GetMainContainerSize(m.width, m.height)
+	placed := lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, popup)
+	if e.mode == tagEditorConfirmRemove {
+		return RenderOverlay(placed, e.confirm.View(m.styles), w, h-AppInternalFooterHeight-2)
+	}
+	return placed
+}
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+// openTagFilter shows the prompt for the global tag filter
+func (m *Model) openTagFilter() tea.Cmd {
+	m.tagFilterInput = textinput.New()
+	m.tagFilterInput.Placeholder = "Environment=prod"
+	m.tagFilterInput.CharLimit = 256
+	m.tagFilterInput.Width = 40
+	m.tagFilterInput.SetValue(m.tagFilter.String())
+	m.tagFilterInput.Focus()
+	m.tagFilterActive = true
+	return textinput.Blink
+}
+
+func (m *Model) handleTagFilterKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return *m, tea.Quit
+	case "esc":
+		m.tagFilterActive = false
+		return *m, nil
+	case "enter":
+		m.tagFilterActive = false
+		filter := aws.ParseTagFilter(m.tagFilterInput.Value())
+		if filter == m.tagFilter {
+			return *m, nil
+		}
+		m.tagFilter = filter
+		aws.SetTagFilter(filter)
+		// Cached listings were fetched with the old filter
+		m.cache.Clear()
+		text := "✔ Tag filter cleared"
+		if !filter.IsZero() {
+			text = fmt.Sprintf("✔ Tag filter set to %s", filter)
+		}
+		toastCmd := m.showToast(text)
+		_, cmd := m.handleProfileChange(m.selectedProfile)
+		return *m, tea.Batch(cmd, toastCmd)
+	}
+
+	var cmd tea.Cmd
+	m.tagFilterInput, cmd = m.tagFilterInput.Update(msg)
+	return *m, cmd
+}
+
+func (m Model) renderTagFilter() string {
+	content := fmt.Sprintf(
+		" %s\n %s\n\n %s\n\n %s",
+		lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render("Tag Filter"),
+		m.styles.StatusMuted.Render("Applies to EC2 instances, volumes and security groups\n and to RDS instances, clusters and snapshots"),
+		m.tagFilterInput.View(),
+		m.styles.StatusMuted.Render("(Key=Value or Key, empty to clear, esc to cancel)"),
+	)
+	popup := m.styles.Popup.Width(60).Render(content)
+	w, h := GetMainContainerSize(m.width, m.height)
+	return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, popup)
+}
+
+// renderTagFilterInfo shows the active tag filter in the header
+func (m Model) renderTagFilterInfo() string {
+	if m.tagFilter.IsZero() {
+		return ""
+	}
+	return m.styles.StatusMuted.Render(" | ") + m.styles.StatusKey.Render("tag: ") + m.styles.Warning.Render(m.tagFilter.String())
+}
@@ -0,0 +1,273 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+)
+
+type testEventKind int
+
+const (
+	testEventSNS testEventKind = iota
+	testEventSQS
+	testEventLambda
+)
+
+// testEventTarget is the topic, queue or function a test event is sent to
+type testEventTarget struct {
+	kind testEventKind
+	id   string // Topic ARN, queue URL or function name
+	name string
+}
+
+// eventTemplate is a sample payload offered in the test event popup.
+// {{time}} and {{name}} in the body are filled in when sending.
+type eventTemplate struct {
+	name        string
+	description string
+	kinds       []testEventKind
+	body        string
+}
+
+func (t eventTemplate) Title() string       { return t.name }
+func (t eventTemplate) Description() string { return t.description }
+func (t eventTemplate) FilterValue() string { return t.name }
+
+var eventTemplates = []eventTemplate{
+	{
+		name:        "Plain text",
+		description: "A one-line text message",
+		kinds:       []testEventKind{testEventSNS, testEventSQS},
+		body:        "Test message from aws-tui at {{time}}",
+	},
+	{
+		name:        "JSON message",
+		description: "A small JSON document",
+		kinds:       []testEventKind{testEventSNS, testEventSQS, testEventLambda},
+		body: `{
+  "source": "aws-tui",
+  "detail": "test event for {{name}}",
+  "timestamp": "{{time}}"
+}`,
+	},
+	{
+		name:        "S3 object created",
+		description: "S3 event notification for a new object",
+		kinds:       []testEventKind{testEventSNS, testEventSQS, testEventLambda},
+		body: `{
+  "Records": [
+    {
+      "eventVersion": "2.1",
+      "eventSource": "aws:s3",
+      "awsRegion": "us-east-1",
+      "eventTime": "{{time}}",
+      "eventName": "ObjectCreated:Put",
+      "s3": {
+        "s3SchemaVersion": "1.0",
+        "bucket": {"name": "example-bucket", "arn": "arn:aws:s3:::example-bucket"},
+        "object": {"key": "test/aws-tui.json", "size": 1024, "eTag": "0123456789abcdef0123456789abcdef"}
+      }
+    }
+  ]
+}`,
+	},
+	{
+		name:        "SQS message",
+		description: "SQS batch with one message",
+		kinds:       []testEventKind{testEventLambda},
+		body: `{
+  "Records": [
+    {
+      "messageId": "00000000-0000-0000-0000-000000000000",
+      "receiptHandle": "test-receipt-handle",
+      "body": "Test message from aws-tui",
+      "attributes": {"ApproximateReceiveCount": "1", "SentTimestamp": "0"},
+      "messageAttributes": {},
+      "eventSource": "aws:sqs",
+      "eventSourceARN": "arn:aws:sqs:us-east-1:000000000000:example-queue",
+      "awsRegion": "us-east-1"
+    }
+  ]
+}`,
+	},
+	{
+		name:        "SNS notification",
+		description: "SNS delivery with one message",
+		kinds:       []testEventKind{testEventLambda},
+		body: `{
+  "Records": [
+    {
+      "EventSource": "aws:sns",
+      "EventVersion": "1.0",
+      "EventSubscriptionArn": "arn:aws:sns:us-east-1:000000000000:example-topic:subscription",
+      "Sns": {
+        "Type": "Notification",
+        "MessageId": "00000000-0000-0000-0000-000000000000",
+        "TopicArn": "arn:aws:sns:us-east-1:000000000000:example-topic",
+        "Subject": "Test",
+        "Message": "Test message from aws-tui",
+        "Timestamp": "{{time}}"
+      }
+    }
+  ]
+}`,
+	},
+	{
+		name:        "Scheduled event",
+		description: "EventBridge schedule trigger",
+		kinds:       []testEventKind{testEventLambda},
+		body: `{
+  "version": "0",
+  "id": "00000000-0000-0000-0000-000000000000",
+  "detail-type": "Scheduled Event",
+  "source": "aws.events",
+  "time": "{{time}}",
+  "region": "us-east-1",
+  "resources": ["arn:aws:events:us-east-1:000000000000:rule/aws-tui-test"],
+  "detail": {}
+}`,
+	},
+}
+
+// render fills in the placeholders of the template body
+func (t eventTemplate) render(target testEventTarget) string {
+	return strings.NewReplacer(
+		"{{time}}", time.Now().UTC().Format(time.RFC3339),
+		"{{name}}", target.name,
+	).Replace(t.body)
+}
+
+// TestEventSentMsg reports the result of sending a test event
+type TestEventSentMsg struct {
+	target   testEventTarget
+	template string
+	result   string
+	failed   bool
+	err      error
+}
+
+// openTestEvent shows the templates that suit the target
+func (m *Model) openTestEvent(target testEventTarget) {
+	var items []list.Item
+	for _, t := range eventTemplates {
+		if slices.Contains(t.kinds, target.kind) {
+			items = append(items, t)
+		}
+	}
+
+	d := list.NewDefaultDelegate()
+	d.Styles.SelectedTitle = m.styles.ListSelectedTitle
+	d.Styles.SelectedDesc = m.styles.ListSelectedDesc
+
+	m.testEventList = list.New(items, d, 44, len(items)*3+2)
+	m.testEventList.Title = "Send test event to " + target.name
+	m.testEventList.SetShowStatusBar(false)
+	m.testEventList.SetShowHelp(false)
+	m.testEventList.SetFilteringEnabled(false)
+	m.testEventTarget = target
+	m.testEventActive = true
+}
+
+func (m *Model) handleTestEventKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return *m, tea.Quit
+	case "esc", "q":
+		m.testEventActive = false
+		return *m, nil
+	case "enter":
+		m.testEventActive = false
+		if t, ok := m.testEventList.SelectedItem().(eventTemplate); ok {
+			m.armOperation()
+			return *m, m.sendTestEvent(m.testEventTarget, t)
+		}
+		return *m, nil
+	}
+
+	var cmd tea.Cmd
+	m.testEventList, cmd = m.testEventList.Update(msg)
+	return *m, cmd
+}
+
+func (m Model) sendTestEvent(target testEventTarget, t eventTemplate) tea.Cmd {
+	profile := m.selectedProfile
+	body := t.render(target)
+	return func() tea.Msg {
+		ctx := context.Background()
+		sent := TestEventSentMsg{target: target, template: t.name}
+
+		switch target.kind {
+		case testEventSNS:
+			client, err := aws.NewSNSClient(ctx, profile)
+			if err == nil {
+				sent.result, err = client.Publish(ctx, target.id, body)
+			}
+			sent.err = err
+		case testEventSQS:
+			client, err := aws.NewSQSClient(ctx, profile)
+			if err == nil {
+				sent.result, err = client.SendMessage(ctx, target.id, body)
+			}
+			sent.err = err
+		case testEventLambda:
+			client, err := aws.NewLambdaClient(ctx, profile)
+			if err != nil {
+				sent.err = err
+				break
+			}
+			out, err := client.Invoke(ctx, target.id, []byte(body))
+			if err != nil {
+				sent.err = err
+				break
+			}
+			payload := strings.Join(strings.Fields(out.Payload), " ")
+			sent.failed = out.FunctionError != ""
+			sent.result = fmt.Sprintf("%d %s", out.StatusCode, payload)
+			if sent.failed {
+				sent.result = out.FunctionError + ": " + payload
+			}
+		}
+		return sent
+	}
+}
+
+// handleTestEventSent reports the outcome in a toast. Lambda responses are
+// cut short to fit the footer.
+func (m *Model) handleTestEventSent(msg TestEventSentMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		if action, denied := aws.AccessDenied(msg.err); denied {
+			return *m, m.showMutedToast(accessDeniedText(action))
+		}
+		return *m, m.showToast("✘ " + msg.err.Error())
+	}
+
+	var text string
+	switch msg.target.kind {
+	case testEventSNS:
+		text = fmt.Sprintf("✔ Published %q to %s", msg.template, msg.target.name)
+	case testEventSQS:
+		text = fmt.Sprintf("✔ Sent %q to %s", msg.template, msg.target.name)
+	case testEventLambda:
+		text = fmt.Sprintf("✔ %s returned %s", msg.target.name, msg.result)
+		if msg.failed {
+			text = fmt.Sprintf("✘ %s failed with %s", msg.target.name, msg.result)
+		}
+	}
+	return *m, m.showToast(lipgloss.NewStyle().MaxWidth(max(m.width-40, 40)).Render(text))
+}
+
+func (m Model) renderTestEvent() string {
+	popup := m.styles.Popup.Width(48).Render(
+		m.testEventList.View() + "\n" + m.styles.StatusMuted.Render(" (enter to send, esc to cancel)"),
+	)
+	w, h := GetMainContainerSize(m.width, m.height)
+	return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, popup)
+}
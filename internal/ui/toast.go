@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// toastDuration is how long a toast stays visible in the footer
+const toastDuration = 3 * time.Second
+
+type clearToastMsg int
+
+// showToast displays a transient message in the footer and schedules its removal
+func (m *Model) showToast(text string) tea.Cmd {
+	m.toastID++
+	m.toast = text
+	m.toastMuted = false
+	id := m.toastID
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return clearToastMsg(id)
+	})
+}
+
+// showMutedToast displays a toast for notices that need no attention, like
+// an action the profile is not allowed to perform
+func (m *Model) showMutedToast(text string) tea.Cmd {
+	cmd := m.showToast(text)
+	m.toastMuted = true
+	return cmd
+}
+
+// renderToast renders the current toast, if any
+func (m Model) renderToast() string {
+	if m.toast == "" {
+		return ""
+	}
+	if m.toastMuted {
+		return m.styles.StatusMuted.Render(m.toast)
+	}
+	return m.styles.Warning.Bold(true).Render(m.toast)
+}
@@ -18,36 +18,55 @@ type TransferState int
 const (
 	TransferStateServers TransferState = iota
 	TransferStateUsers
+	TransferStateServerActions
+	TransferStateConfirmStop
 )
 
 type transferItem struct {
 	title       string
 	description string
 	serverId    string
+	serverState string
 	isUser      bool
+	info        any
 }
 
 func (i transferItem) Title() string       { return i.title }
+func (i transferItem) source() any         { return i.info }
 func (i transferItem) Description() string { return i.description }
-func (i transferItem) FilterValue() string { return i.title }
+func (i transferItem) FilterValue() string { return i.title + " " + i.description }
+
+func (i transferItem) rowID() string {
+	if i.isUser {
+		return ""
+	}
+	return i.serverId
+}
 
 type TransferModel struct {
 	client        *aws.TransferClient
 	list          list.Model
 	styles        Styles
+	table         *tableLayout
 	state         TransferState
 	currentServer string
 	width         int
 	height        int
 	profile       string
 	err           error
+	loaded        bool
 	cache         *cache.Cache
 	cacheKeys     *cache.KeyBuilder
+	// Start/stop actions
+	actionList     list.Model
+	selectedServer string
+	confirm        ConfirmDialog
 }
 
 type transferItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 	state  TransferState
 }
 
@@ -79,7 +98,7 @@ func (d transferItemDelegate) Render(w io.Writer, m list.Model, index int, listI
 		columns = transferUserColumns
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, columns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, columns, d.table)
 	isSelected := index == m.Index()
 
 	var values []string
@@ -97,7 +116,7 @@ func (d transferItemDelegate) Render(w io.Writer, m list.Model, index int, listI
 		}
 		values = []string{
 			"🖥️ " + i.title,
-			state,
+			renderStatus(d.styles, state),
 			endpoint,
 			idp,
 			users,
@@ -124,15 +143,19 @@ func (d transferItemDelegate) Render(w io.Writer, m list.Model, index int, listI
 		}
 	}
 
-	RenderTableRow(w, m, d.styles, colStyles, values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, values, isSelected)
 }
 
 func (d transferItemDelegate) Height() int { return 1 }
 
-func NewTransferModel(profile string, styles Styles, appCache *cache.Cache) TransferModel {
+func (d transferItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewTransferModel(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) TransferModel {
+	table := &tableLayout{display: display}
 	d := transferItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 		state:           TransferStateServers,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
@@ -150,6 +173,7 @@ func NewTransferModel(profile string, styles Styles, appCache *cache.Cache) Tran
 	return TransferModel{
 		list:      l,
 		styles:    styles,
+		table:     table,
 		state:     TransferStateServers,
 		profile:   profile,
 		cache:     appCache,
@@ -161,6 +185,12 @@ type TransferServersMsg []aws.TransferServerInfo
 type TransferUsersMsg []aws.TransferUserInfo
 type TransferErrorMsg error
 
+// TransferServerActionMsg reports that a server was asked to start or stop
+type TransferServerActionMsg struct {
+	ServerId string
+	Action   string
+}
+
 func (m TransferModel) Init() tea.Cmd {
 	return m.fetchServers()
 }
@@ -211,6 +241,46 @@ func (m TransferModel) fetchUsers() tea.Cmd {
 	}
 }
 
+// loadActionMenu offers whichever of start and stop applies to the server's state
+func (m *TransferModel) loadActionMenu(state string) {
+	d := list.NewDefaultDelegate()
+	d.Styles.SelectedTitle = m.styles.ListSelectedTitle
+	d.Styles.SelectedDesc = m.styles.ListSelectedDesc
+
+	var items []list.Item
+	if state != "ONLINE" && state != "STARTING" {
+		items = append(items, transferItem{title: "Start", description: "Bring the server online"})
+	}
+	if state != "OFFLINE" && state != "STOPPING" {
+		items = append(items, transferItem{title: "Stop", description: "Take the server offline"})
+	}
+
+	m.actionList = list.New(items, d, 30, 8)
+	m.actionList.Title = "Server Actions"
+	m.actionList.SetShowStatusBar(false)
+	m.actionList.SetShowHelp(false)
+	m.actionList.SetShowTitle(true)
+}
+
+func (m TransferModel) serverAction(serverId, action string) tea.Cmd {
+	return armed(func() tea.Msg {
+		client, err := aws.NewTransferClient(context.Background(), m.profile)
+		if err != nil {
+			return TransferErrorMsg(err)
+		}
+		if action == "Start" {
+			err = client.StartServer(context.Background(), serverId)
+		} else {
+			err = client.StopServer(context.Background(), serverId)
+		}
+		if err != nil {
+			return TransferErrorMsg(err)
+		}
+		m.cache.Delete(m.cacheKeys.TransferResources("servers"))
+		return TransferServerActionMsg{ServerId: serverId, Action: action}
+	})
+}
+
 func (m TransferModel) Update(msg tea.Msg) (TransferModel, tea.Cmd) {
 	var cmd tea.Cmd
 
@@ -221,21 +291,24 @@ func (m TransferModel) Update(msg tea.Msg) (TransferModel, tea.Cmd) {
 		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
 	case TransferServersMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, s := range msg {
 			items[i] = transferItem{
 				title:       s.ServerId,
 				description: fmt.Sprintf("State: %s | Endpoint: %s | IDP: %s | Users: %d", s.State, s.EndpointType, s.IdentityProviderType, s.UserCount),
 				serverId:    s.ServerId,
+				serverState: s.State,
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.state = TransferStateServers
 		m.list.Title = "AWS Transfer Servers"
 
 		d := transferItemDelegate{
 			DefaultDelegate: list.NewDefaultDelegate(),
 			styles:          m.styles,
+			table:           m.table,
 			state:           TransferStateServers,
 		}
 		d.Styles.SelectedTitle = m.styles.ListSelectedTitle
@@ -243,29 +316,36 @@ func (m TransferModel) Update(msg tea.Msg) (TransferModel, tea.Cmd) {
 		m.list.SetDelegate(d)
 
 	case TransferUsersMsg:
+		m.loaded = true
 		items := make([]list.Item, 0)
 		items = append(items, transferItem{title: "..", description: "Back"})
 
 		for _, u := range msg {
 			items = append(items, transferItem{
+				info:        u,
 				title:       u.UserName,
 				description: fmt.Sprintf("Role: %s | Home: %s | SSH Keys: %d", u.Role, u.HomeDirectory, u.SshPublicKeyCount),
 				isUser:      true,
 			})
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.state = TransferStateUsers
 		m.list.Title = fmt.Sprintf("Transfer Users: %s", m.currentServer)
 
 		d := transferItemDelegate{
 			DefaultDelegate: list.NewDefaultDelegate(),
 			styles:          m.styles,
+			table:           m.table,
 			state:           TransferStateUsers,
 		}
 		d.Styles.SelectedTitle = m.styles.ListSelectedTitle
 		d.Styles.SelectedDesc = m.styles.ListSelectedDesc
 		m.list.SetDelegate(d)
 
+	case TransferServerActionMsg:
+		// The list shows STARTING/STOPPING until the next refresh
+		return m, m.fetchServers()
+
 	case TransferErrorMsg:
 		m.err = msg
 
@@ -275,7 +355,50 @@ func (m TransferModel) Update(msg tea.Msg) (TransferModel, tea.Cmd) {
 			return m, nil
 		}
 
+		switch m.state {
+		case TransferStateServerActions:
+			switch msg.String() {
+			case "esc", "q":
+				m.state = TransferStateServers
+				return m, nil
+			case "enter":
+				if item, ok := m.actionList.SelectedItem().(transferItem); ok {
+					if item.title == "Stop" {
+						server := m.selectedServer
+						m.state = TransferStateConfirmStop
+						m.confirm = NewConfirmDialog("Confirm Stop", "Are you sure you want to stop "+
+							lipgloss.NewStyle().Foreground(m.styles.Primary).Bold(true).Render(server),
+							true, func() tea.Cmd { return m.serverAction(server, "Stop") })
+						m.confirm.Width = 60
+						m.confirm.Detail = " " + m.styles.StatusMuted.Render("Users cannot connect until it is started again.")
+						return m, nil
+					}
+					m.state = TransferStateServers
+					return m, m.serverAction(m.selectedServer, item.title)
+				}
+			}
+			m.actionList, cmd = m.actionList.Update(msg)
+			return m, cmd
+		case TransferStateConfirmStop:
+			result, cmd := m.confirm.Update(msg)
+			if result != confirmPending {
+				m.state = TransferStateServers
+			}
+			return m, cmd
+		}
+
 		switch msg.String() {
+		case "o":
+			if m.state == TransferStateServers {
+				if item, ok := m.list.SelectedItem().(transferItem); ok {
+					m.selectedServer = item.serverId
+					m.loadActionMenu(item.serverState)
+					if len(m.actionList.Items()) > 0 {
+						m.state = TransferStateServerActions
+					}
+					return m, nil
+				}
+			}
 		case "r":
 			if m.state == TransferStateServers {
 				m.cache.Delete(m.cacheKeys.TransferResources("servers"))
@@ -308,22 +431,46 @@ func (m TransferModel) Update(msg tea.Msg) (TransferModel, tea.Cmd) {
 	return m, cmd
 }
 
+// transferResourceNames names the resources listed in each table state, for the empty-state message
+var transferResourceNames = map[TransferState]string{
+	TransferStateServers: "Transfer servers",
+	TransferStateUsers:   "Transfer users",
+}
+
+// columns are the columns of the table on screen
+func (m TransferModel) columns() []Column {
+	if m.state == TransferStateServers {
+		return transferServerColumns
+	}
+	return transferUserColumns
+}
+
 func (m TransferModel) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
+	}
+
+	switch m.state {
+	case TransferStateServerActions:
+		return m.renderPopup(m.styles.Popup.Width(38).Render(m.actionList.View()))
+	case TransferStateConfirmStop:
+		return m.renderPopup(m.confirm.View(m.styles))
+	}
+
+	if resource, ok := transferResourceNames[m.state]; ok && m.loaded && len(m.list.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.list, resource, m.profile, m.table)
 	}
 
 	return m.renderHeader() + "\n" + m.list.View()
 }
 
+func (m TransferModel) renderPopup(popup string) string {
+	w, h := GetMainContainerSize(m.width, m.height)
+	return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, popup)
+}
+
 func (m TransferModel) renderHeader() string {
-	var columns []Column
-	if m.state == TransferStateServers {
-		columns = transferServerColumns
-	} else {
-		columns = transferUserColumns
-	}
-	_, header := RenderTableHelpers(m.list, m.styles, columns)
+	_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
 	return header
 }
 
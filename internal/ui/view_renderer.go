@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"fmt"
+	"path"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -15,7 +17,7 @@ func (m Model) renderHeader() string {
 	// Profile Section
 	profileLabel := m.styles.StatusKey.Render("profile: ")
 	profileValue := m.selectedProfile
-	profileText := profileLabel + m.styles.Profile.Render(profileValue)
+	profileText := profileLabel + m.styles.Profile.Underline(m.mouse).Render(profileValue)
 
 	// Session Info (Account & Region)
 	var sessionInfo string
@@ -29,7 +31,7 @@ func (m Model) renderHeader() string {
 		if region == "" {
 			region = "unknown"
 		}
-		regionInfo := lipgloss.NewStyle().Foreground(m.styles.Snow).Render(region)
+		regionInfo := lipgloss.NewStyle().Foreground(m.styles.Snow).Underline(m.mouse).Render(region)
 
 		sessionInfo = lipgloss.JoinHorizontal(lipgloss.Center,
 			m.styles.StatusMuted.Render(" | "),
@@ -37,8 +39,14 @@ func (m Model) renderHeader() string {
 			m.styles.StatusMuted.Render(" | "),
 			m.styles.StatusKey.Render("region: "), regionInfo,
 		)
+	} else if m.preflightErr != nil {
+		sessionInfo = m.styles.StatusMuted.Render(" | ") + m.styles.Error.Render("no session")
 	} else {
 		sessionInfo = m.styles.StatusMuted.Render(" | ") + m.styles.StatusMuted.Render("loading session...")
+		if m.profileRegion != "" {
+			sessionInfo += m.styles.StatusMuted.Render(" | ") + m.styles.StatusKey.Render("region: ") +
+				lipgloss.NewStyle().Foreground(m.styles.Snow).Render(m.profileRegion)
+		}
 	}
 
 	headerContent := lipgloss.JoinHorizontal(lipgloss.Center,
@@ -46,6 +54,8 @@ func (m Model) renderHeader() string {
 		m.styles.StatusMuted.Render(" | "),
 		profileText,
 		sessionInfo,
+		m.renderTagFilterInfo(),
+		m.renderInFlight(),
 	)
 
 	// Center the content inside the header box
@@ -63,19 +73,44 @@ func (m Model) getViewTitle() string {
 	switch m.view {
 	case viewS3:
 		titleParts := []string{"S3"}
-		if m.s3Model.currentBucket != "" {
+		if m.s3Model.state == S3StateBucketDetails && m.s3Model.bucketDetails != nil {
+			titleParts = append(titleParts, "Buckets", m.s3Model.bucketDetails.Name, "Details")
+		} else if m.s3Model.currentBucket != "" {
 			titleParts = append(titleParts, "Buckets", m.s3Model.currentBucket)
 			if m.s3Model.currentPrefix != "" {
 				titleParts = append(titleParts, strings.TrimSuffix(m.s3Model.currentPrefix, "/"))
 			}
+			if m.s3Model.versionKey != "" {
+				titleParts = append(titleParts, path.Base(m.s3Model.versionKey), "Versions")
+			}
 		} else {
 			titleParts = append(titleParts, "Buckets")
 		}
 		return strings.Join(titleParts, " / ")
 	case viewIAM:
 		titleParts := []string{"IAM", "Users"}
-		if m.iamModel.state == IAMStateActions || m.iamModel.state == IAMStateConfirmDelete || m.iamModel.state == IAMStateConfirmConsoleToggle {
+		switch m.iamModel.state {
+		case IAMStateMenu:
+			titleParts = []string{"IAM", "Resources"}
+		case IAMStateSummary:
+			titleParts = []string{"IAM", "Security Summary"}
+		case IAMStateRoles:
+			titleParts = []string{"IAM", "Roles"}
+		case IAMStateRoleDetail:
+			titleParts = []string{"IAM", "Roles", m.iamModel.selectedRole.RoleName}
+		case IAMStateGroups:
+			titleParts = []string{"IAM", "Groups"}
+		case IAMStateGroupMembers, IAMStateConfirmRemoveMember:
+			titleParts = []string{"IAM", "Groups", m.iamModel.selectedGroup.GroupName}
+		case IAMStateActions, IAMStateConfirmDelete, IAMStateConfirmConsoleToggle, IAMStateNewPassword:
 			titleParts = append(titleParts, m.iamModel.selectedUser.userName)
+		case IAMStatePolicies, IAMStateConfirmDetach:
+			titleParts = append(titleParts, m.iamModel.selectedUser.userName, "Policies")
+		case IAMStateAccessKeys, IAMStateConfirmKeyAction, IAMStateNewAccessKey:
+			titleParts = append(titleParts, m.iamModel.selectedUser.userName, "Access Keys")
+		}
+		if m.iamModel.loadingMore {
+			return strings.Join(titleParts, " / ") + " (loading more…)"
 		}
 		return strings.Join(titleParts, " / ")
 	case viewVPC:
@@ -93,9 +128,14 @@ func (m Model) getViewTitle() string {
 			titleParts = append(titleParts, "Route Tables")
 		case VPCStateVpnGateways:
 			titleParts = append(titleParts, "VPN Gateways")
+		case VPCStateVPCDetail:
+			titleParts = append(titleParts, "VPCs", m.vpcModel.selectedVPC.ID)
 		}
 		return strings.Join(titleParts, " / ")
 	case viewLambda:
+		if m.lambdaModel.state != LambdaStateFunctions {
+			return "Lambda / Functions / " + m.lambdaModel.selectedFunction + " / Concurrency"
+		}
 		return "Lambda / Functions"
 	case viewEC2:
 		titleParts := []string{"EC2"}
@@ -110,6 +150,8 @@ func (m Model) getViewTitle() string {
 			titleParts = append(titleParts, "Volumes")
 		case EC2StateTargetGroups:
 			titleParts = append(titleParts, "Target Groups")
+		case EC2StateElasticIPs:
+			titleParts = append(titleParts, "Elastic IPs")
 		}
 		return strings.Join(titleParts, " / ")
 	case viewRDS:
@@ -117,7 +159,7 @@ func (m Model) getViewTitle() string {
 		switch m.rdsModel.state {
 		case RDSStateMenu:
 			titleParts = append(titleParts, "Databases")
-		case RDSStateInstances:
+		case RDSStateInstances, RDSStateConnectInput:
 			titleParts = append(titleParts, "Databases")
 		case RDSStateClusters:
 			titleParts = append(titleParts, "Clusters")
@@ -131,15 +173,29 @@ func (m Model) getViewTitle() string {
 		titleParts := []string{"CloudWatch"}
 		switch m.cwModel.state {
 		case CWStateMenu:
-			titleParts = append(titleParts, "Logs")
+			titleParts = append(titleParts, "Resources")
 		case CWStateLogGroups:
 			titleParts = append(titleParts, "Log Groups")
+			if m.cwModel.groupPrefix != "" {
+				titleParts = append(titleParts, m.cwModel.groupPrefix+"*")
+			}
+			if m.cwModel.loadingMoreGroups {
+				titleParts = append(titleParts, "loading more...")
+			}
 		case CWStateLogStreams:
-			titleParts = append(titleParts, "Log Groups", m.cwModel.selectedGroup)
+			order := "Most recent first"
+			if m.cwModel.streamsByName {
+				order = "By name"
+			}
+			titleParts = append(titleParts, "Log Groups", m.cwModel.selectedGroup, order)
 		case CWStateLogEvents:
 			titleParts = append(titleParts, "Log Groups", m.cwModel.selectedGroup, m.cwModel.selectedStream)
 		case CWStateLogDetail:
 			titleParts = append(titleParts, "Log Groups", m.cwModel.selectedGroup, m.cwModel.selectedStream, "Detail")
+		case CWStateAlarms:
+			titleParts = append(titleParts, "Alarms")
+		case CWStateAlarmDetail:
+			titleParts = append(titleParts, "Alarms", m.cwModel.selectedAlarm.Name)
 		}
 		return strings.Join(titleParts, " / ")
 	case viewCF:
@@ -161,6 +217,8 @@ func (m Model) getViewTitle() string {
 			titleParts = append(titleParts, "Policies")
 		case CFStateFunctions:
 			titleParts = append(titleParts, "Functions")
+		case CFStateFunctionDetail, CFStateConfirmPublish:
+			titleParts = append(titleParts, "Functions", m.cfModel.function.Name+" ("+m.cfModel.function.Stage+")")
 		}
 		return strings.Join(titleParts, " / ")
 	case viewElastiCache:
@@ -175,15 +233,27 @@ func (m Model) getViewTitle() string {
 		}
 		return strings.Join(titleParts, " / ")
 	case viewMSK:
-		return "MSK / Clusters"
+		titleParts := []string{"MSK"}
+		switch m.mskModel.state {
+		case MSKStateMenu:
+			titleParts = append(titleParts, "Resources")
+		case MSKStateClusters:
+			titleParts = append(titleParts, "Clusters")
+		case MSKStateConfigurations:
+			titleParts = append(titleParts, "Configurations")
+		}
+		return strings.Join(titleParts, " / ")
 	case viewSQS:
+		if m.sqsModel.state == SQSStateEditAttributes {
+			return "SQS / Queues / " + m.sqsModel.selectedQueue.title + " / Attributes"
+		}
 		return "SQS / Queues"
 	case viewSM:
 		titleParts := []string{"Secrets Manager"}
 		switch m.smModel.state {
-		case SMStateSecrets:
+		case SMStateSecrets, SMStateNameInput:
 			titleParts = append(titleParts, "Secrets")
-		case SMStateValue:
+		case SMStateValue, SMStateConfirmPut, SMStateConfirmRotate:
 			titleParts = append(titleParts, "Secrets", m.smModel.selectedSecret)
 		}
 		return strings.Join(titleParts, " / ")
@@ -217,16 +287,28 @@ func (m Model) getViewTitle() string {
 		return strings.Join(titleParts, " / ")
 	case viewECS:
 		titleParts := []string{"ECS"}
-		if m.ecsModel.selectedCluster != "" {
+		if m.ecsModel.state == ECSStateRunTask {
+			titleParts = append(titleParts, "Run Task")
+		} else if m.ecsModel.selectedCluster != "" {
 			titleParts = append(titleParts, m.ecsModel.selectedCluster)
 			if m.ecsModel.selectedService != "" {
 				titleParts = append(titleParts, m.ecsModel.selectedService)
 				switch m.ecsModel.state {
 				case ECSStateTasks:
-					titleParts = append(titleParts, "Tasks")
+					if m.ecsModel.showStopped {
+						titleParts = append(titleParts, "Stopped Tasks")
+					} else {
+						titleParts = append(titleParts, "Tasks")
+					}
+				case ECSStateTaskDetail:
+					titleParts = append(titleParts, "Tasks", path.Base(m.ecsModel.selectedTask))
 				case ECSStateEvents:
 					titleParts = append(titleParts, "Events")
+				case ECSStateDeployment:
+					titleParts = append(titleParts, "Deployment")
 				}
+			} else if m.ecsModel.state == ECSStateContainerInstances {
+				titleParts = append(titleParts, "Container Instances")
 			} else {
 				titleParts = append(titleParts, "Services")
 			}
@@ -239,6 +321,9 @@ func (m Model) getViewTitle() string {
 		}
 		return strings.Join(titleParts, " / ")
 	case viewBilling:
+		if m.billingModel.byAccount {
+			return "Billing / Costs by Account"
+		}
 		return "Billing / Costs"
 	case viewSecurityHub:
 		return "Security Hub / Findings"
@@ -282,9 +367,24 @@ func (m Model) getViewTitle() string {
 			titleParts = append(titleParts, "Plans")
 		case BackupStateJobs:
 			titleParts = append(titleParts, "Jobs")
+		case BackupStateJobDetail:
+			titleParts = append(titleParts, "Jobs", m.backupModel.selectedJob.BackupJobId)
+		case BackupStateRestoreInput:
+			titleParts = append(titleParts, "Jobs", m.backupModel.selectedJob.BackupJobId, "Restore")
 		}
 		return strings.Join(titleParts, " / ")
 	case viewDynamoDB:
+		switch m.dynamodbModel.state {
+		case DynamoDBStateItems, DynamoDBStateConfirmDeleteItem:
+			title := "DynamoDB / Tables / " + m.dynamodbModel.currentTable + " / Items"
+			if m.dynamodbModel.itemsMore {
+				title += fmt.Sprintf(" (first %d)", dynamoItemsLimit)
+			}
+			return title
+		}
+		if m.dynamodbModel.currentTable != "" {
+			return "DynamoDB / Tables / " + m.dynamodbModel.currentTable + " / Backups"
+		}
 		return "DynamoDB / Tables"
 	case viewTransfer:
 		titleParts := []string{"AWS Transfer"}
@@ -315,49 +415,243 @@ func (m Model) getViewTitle() string {
 
 // renderFooter generates footer hints based on current view and context
 func (m Model) renderFooter() string {
+	filterLabel := "Filter"
+	if m.view == viewCW && m.cwModel.state == CWStateLogGroups {
+		filterLabel = "Prefix search"
+	}
 	footerHints := []string{
 		m.styles.StatusKey.Render("↑↓←→") + " " + m.styles.StatusMuted.Render("Navigate"),
-		m.styles.StatusKey.Render("/") + " " + m.styles.StatusMuted.Render("Filter"),
+		m.styles.StatusKey.Render("/") + " " + m.styles.StatusMuted.Render(filterLabel),
+		m.styles.StatusKey.Render(m.keys.key("command")) + " " + m.styles.StatusMuted.Render("Command"),
 		m.styles.StatusKey.Render("Enter") + " " + m.styles.StatusMuted.Render("Select"),
 	}
 
 	if m.view != viewHome {
 		footerHints = append(footerHints, m.styles.StatusKey.Render("esc")+" "+m.styles.StatusMuted.Render("Back"))
+	} else if m.config.Attention {
+		footerHints = append(footerHints, m.styles.StatusKey.Render(fmt.Sprintf("1-%d", len(attentionChecks)))+" "+m.styles.StatusMuted.Render("Attention"))
 	}
 
 	footerHints = append(footerHints,
-		m.styles.StatusKey.Render("p")+" "+m.styles.StatusMuted.Render("Profile"),
-		m.styles.StatusKey.Render("r")+" "+m.styles.StatusMuted.Render("Refresh"),
+		m.styles.StatusKey.Render(m.keys.key("profile"))+" "+m.styles.StatusMuted.Render("Profile"),
+		m.styles.StatusKey.Render(m.keys.key("refresh"))+" "+m.styles.StatusMuted.Render("Refresh"),
+		m.styles.StatusKey.Render(m.keys.key("clear_cache"))+" "+m.styles.StatusMuted.Render("Clear Cache"),
+		m.styles.StatusKey.Render(m.keys.key("tag_filter"))+" "+m.styles.StatusMuted.Render("Tag Filter"),
 	)
+	if len(m.recent) > 0 {
+		footerHints = append(footerHints, m.styles.StatusKey.Render(m.keys.key("recent"))+" "+m.styles.StatusMuted.Render("Recent"))
+	}
+	if len(m.opsLog) > 0 {
+		footerHints = append(footerHints, m.styles.StatusKey.Render(m.keys.key("ops_log"))+" "+m.styles.StatusMuted.Render("Ops Log"))
+	}
+	if m.view != viewHome && m.activeList() != nil {
+		footerHints = append(footerHints, m.styles.StatusKey.Render(m.keys.key("pin"))+" "+m.styles.StatusMuted.Render("Pin"))
+	}
+	if _, ok := m.describeSource(); ok {
+		footerHints = append(footerHints, m.styles.StatusKey.Render("Enter")+" "+m.styles.StatusMuted.Render("Describe"))
+	}
+	if m.hasRelated() {
+		footerHints = append(footerHints, m.styles.StatusKey.Render(m.keys.key("jump"))+" "+m.styles.StatusMuted.Render("Related"))
+	}
+	if _, ok := m.selectedTagTarget(); ok {
+		footerHints = append(footerHints, m.styles.StatusKey.Render("t")+" "+m.styles.StatusMuted.Render("Tags"))
+	}
 
-	// Context-specific hints
-	m.addContextSpecificHints(&footerHints)
+	m.addNavigationHints(&footerHints)
 
-	footerHints = append(footerHints, m.styles.StatusKey.Render("q")+" "+m.styles.StatusMuted.Render("Quit"))
+	// Context-specific hints (mutating actions are hidden in read-only mode)
+	if !m.readOnly {
+		m.addContextSpecificHints(&footerHints)
+	}
+
+	footerHints = append(footerHints, m.styles.StatusKey.Render(m.keys.key("quit"))+" "+m.styles.StatusMuted.Render("Quit"))
+
+	if note := m.fetchStatsNote(); note != "" {
+		footerHints = append([]string{m.styles.StatusMuted.Render(note)}, footerHints...)
+	}
+	if m.readOnly {
+		footerHints = append([]string{m.styles.Warning.Render("🔒 Read-only")}, footerHints...)
+	}
+	if toast := m.renderToast(); toast != "" {
+		footerHints = append([]string{toast}, footerHints...)
+	}
 	return strings.Join(footerHints, m.styles.StatusMuted.Render(" • "))
 }
 
+// addNavigationHints adds hints for view-specific keys that only read, so they
+// are shown in read-only mode too
+func (m Model) addNavigationHints(footerHints *[]string) {
+	switch m.view {
+	case viewS3:
+		switch m.s3Model.state {
+		case S3StateBuckets:
+			*footerHints = append(*footerHints, m.styles.StatusKey.Render("i")+" "+m.styles.StatusMuted.Render("Details"))
+		case S3StateObjects:
+			if m.s3Model.versioningEnabled() {
+				*footerHints = append(*footerHints, m.styles.StatusKey.Render("v")+" "+m.styles.StatusMuted.Render("Versions"))
+			}
+			*footerHints = append(*footerHints, m.styles.StatusKey.Render("!")+" "+m.styles.StatusMuted.Render("Shell"))
+			m.addSplitHint(footerHints, m.s3Model.width, m.s3Model.split)
+		case S3StateVersions:
+			*footerHints = append(*footerHints, m.styles.StatusKey.Render("s")+" "+m.styles.StatusMuted.Render("Download"))
+		}
+	case viewEC2:
+		if m.ec2Model.state == EC2StateInstances {
+			m.addSplitHint(footerHints, m.ec2Model.width, m.ec2Model.split)
+		}
+	case viewBilling:
+		if m.billingModel.hasLinkedAccounts() {
+			label := "By Account"
+			if m.billingModel.byAccount {
+				label = "By Service"
+			}
+			*footerHints = append(*footerHints, m.styles.StatusKey.Render("g")+" "+m.styles.StatusMuted.Render(label))
+		}
+	case viewKMS:
+		if m.kmsModel.state == KMSStateKeys {
+			*footerHints = append(*footerHints,
+				m.styles.StatusKey.Render("e")+" "+m.styles.StatusMuted.Render("Encrypt"),
+				m.styles.StatusKey.Render("d")+" "+m.styles.StatusMuted.Render("Decrypt"),
+			)
+		}
+	case viewDynamoDB:
+		if m.dynamodbModel.state == DynamoDBStateTables {
+			*footerHints = append(*footerHints,
+				m.styles.StatusKey.Render("enter")+" "+m.styles.StatusMuted.Render("Backups"),
+				m.styles.StatusKey.Render("i")+" "+m.styles.StatusMuted.Render("Items"),
+			)
+			m.addSplitHint(footerHints, m.dynamodbModel.width, m.dynamodbModel.split)
+		}
+	case viewSQS:
+		if m.sqsModel.state == SQSStateQueues {
+			*footerHints = append(*footerHints, m.styles.StatusKey.Render("D")+" "+m.styles.StatusMuted.Render("Go to DLQ"))
+		}
+	case viewLambda:
+		if m.lambdaModel.state == LambdaStateFunctions {
+			*footerHints = append(*footerHints,
+				m.styles.StatusKey.Render("c")+" "+m.styles.StatusMuted.Render("Concurrency"),
+				m.styles.StatusKey.Render("D")+" "+m.styles.StatusMuted.Render("Compare Profiles"),
+			)
+		}
+	case viewRDS:
+		if m.rdsModel.state == RDSStateInstances {
+			*footerHints = append(*footerHints, m.styles.StatusKey.Render("c")+" "+m.styles.StatusMuted.Render("Connect via bastion"))
+		}
+		if m.rdsModel.state == RDSStateInstances || m.rdsModel.state == RDSStateClusters {
+			*footerHints = append(*footerHints, m.styles.StatusKey.Render("y")+" "+m.styles.StatusMuted.Render("Copy connection string"))
+		}
+	case viewElastiCache:
+		if m.elasticacheModel.state != ElastiCacheStateMenu {
+			*footerHints = append(*footerHints, m.styles.StatusKey.Render("y")+" "+m.styles.StatusMuted.Render("Copy connection string"))
+		}
+	case viewMSK:
+		if m.mskModel.state == MSKStateClusters {
+			*footerHints = append(*footerHints, m.styles.StatusKey.Render("y")+" "+m.styles.StatusMuted.Render("Copy bootstrap brokers"))
+		}
+	case viewECS:
+		if m.ecsModel.state == ECSStateTasks {
+			label := "Show stopped"
+			if m.ecsModel.showStopped {
+				label = "Show running"
+			}
+			*footerHints = append(*footerHints, m.styles.StatusKey.Render("f")+" "+m.styles.StatusMuted.Render(label))
+		}
+		if m.ecsModel.state == ECSStateServices {
+			*footerHints = append(*footerHints, m.styles.StatusKey.Render("i")+" "+m.styles.StatusMuted.Render("Container Instances"))
+		}
+	}
+
+	if m.view == viewCF && m.cfModel.state == CFStateFunctionDetail {
+		*footerHints = append(*footerHints, m.styles.StatusKey.Render("pgup/pgdn")+" "+m.styles.StatusMuted.Render("Page"))
+	}
+	if (m.view == viewECS && m.ecsModel.state == ECSStateTaskDefJSON) || (m.view == viewCW && m.cwModel.state == CWStateLogDetail) {
+		*footerHints = append(*footerHints, m.styles.StatusKey.Render("pgup/pgdn")+" "+m.styles.StatusMuted.Render("Page"),
+			m.styles.StatusKey.Render("home/end")+" "+m.styles.StatusMuted.Render("Top/Bottom"),
+			m.styles.StatusKey.Render("n/N")+" "+m.styles.StatusMuted.Render("Next/Prev match"))
+	}
+	if t, columns := m.activeTable(); t != nil && len(columns) > 1 && (t.scrollOffset(columns) > 0 || m.width < narrowTableWidth) {
+		*footerHints = append(*footerHints, m.styles.StatusKey.Render(m.keys.key("scroll_left")+" "+m.keys.key("scroll_right"))+" "+m.styles.StatusMuted.Render("Scroll columns"))
+	}
+	if t, columns := m.activeTable(); t != nil && len(columns) > 1 {
+		*footerHints = append(*footerHints, m.styles.StatusKey.Render(m.keys.key("columns"))+" "+m.styles.StatusMuted.Render("Columns"))
+	}
+	if m.view == viewRoute53 && m.route53Model.state == Route53StateRecords {
+		*footerHints = append(*footerHints, m.styles.StatusKey.Render("y")+" "+m.styles.StatusMuted.Render("Copy name servers"))
+	}
+	if m.view == viewCW && m.cwModel.state == CWStateLogStreams {
+		label := "Sort by name"
+		if m.cwModel.streamsByName {
+			label = "Sort by last event"
+		}
+		*footerHints = append(*footerHints, m.styles.StatusKey.Render("s")+" "+m.styles.StatusMuted.Render(label))
+	}
+	if m.view == viewCW && m.cwModel.state == CWStateLogDetail {
+		label := "No wrap"
+		if m.cwModel.noWrap {
+			label = "Wrap"
+		}
+		*footerHints = append(*footerHints, m.styles.StatusKey.Render("w")+" "+m.styles.StatusMuted.Render(label),
+			m.styles.StatusKey.Render("y")+" "+m.styles.StatusMuted.Render("Copy"))
+	}
+
+	if _, ok := m.autoRefreshState(); ok {
+		label := "Auto-refresh"
+		if m.autoRefreshActive() {
+			label = fmt.Sprintf("Auto-refresh (%s)", m.autoRefresh)
+		}
+		*footerHints = append(*footerHints, m.styles.StatusKey.Render(m.keys.key("auto_refresh"))+" "+m.styles.StatusMuted.Render(label))
+	}
+}
+
+// addSplitHint offers the detail pane toggle when the terminal is wide enough to split
+func (m Model) addSplitHint(footerHints *[]string, width int, split bool) {
+	if _, pane := splitWidths(width); pane == 0 {
+		return
+	}
+	label := "Detail pane"
+	if split {
+		label = "Hide detail"
+	}
+	*footerHints = append(*footerHints, m.styles.StatusKey.Render("|")+" "+m.styles.StatusMuted.Render(label))
+}
+
 // addContextSpecificHints adds view-specific footer hints
 func (m Model) addContextSpecificHints(footerHints *[]string) {
 	switch m.view {
 	case viewS3:
 		if m.s3Model.state == S3StateBuckets {
-			*footerHints = append(*footerHints, m.styles.StatusKey.Render("n")+" "+m.styles.StatusMuted.Render("New Bucket"))
+			*footerHints = append(*footerHints, m.styles.StatusKey.Render(m.keys.key("new"))+" "+m.styles.StatusMuted.Render("New Bucket"))
 		} else if m.s3Model.state == S3StateObjects {
 			*footerHints = append(*footerHints,
-				m.styles.StatusKey.Render("n")+" "+m.styles.StatusMuted.Render("New Folder"),
+				m.styles.StatusKey.Render(m.keys.key("new"))+" "+m.styles.StatusMuted.Render("New Folder"),
 				m.styles.StatusKey.Render("u")+" "+m.styles.StatusMuted.Render("Upload"),
-				m.styles.StatusKey.Render("e")+" "+m.styles.StatusMuted.Render("Edit"),
+				m.styles.StatusKey.Render(m.keys.key("edit"))+" "+m.styles.StatusMuted.Render("Edit"),
 			)
 		}
-		if m.s3Model.state == S3StateBuckets || m.s3Model.state == S3StateObjects {
-			*footerHints = append(*footerHints, m.styles.StatusKey.Render("d")+" "+m.styles.StatusMuted.Render("Delete"))
+		if m.s3Model.state == S3StateBuckets || m.s3Model.state == S3StateObjects || m.s3Model.state == S3StateVersions {
+			*footerHints = append(*footerHints, m.styles.StatusKey.Render(m.keys.key("delete"))+" "+m.styles.StatusMuted.Render("Delete"))
 		}
 	case viewIAM:
 		if m.iamModel.state == IAMStateUsers {
 			*footerHints = append(*footerHints,
-				m.styles.StatusKey.Render("n")+" "+m.styles.StatusMuted.Render("New User"),
-				m.styles.StatusKey.Render("d")+" "+m.styles.StatusMuted.Render("Delete"),
+				m.styles.StatusKey.Render(m.keys.key("new"))+" "+m.styles.StatusMuted.Render("New User"),
+				m.styles.StatusKey.Render(m.keys.key("delete"))+" "+m.styles.StatusMuted.Render("Delete"),
+			)
+		} else if m.iamModel.state == IAMStatePolicies {
+			*footerHints = append(*footerHints,
+				m.styles.StatusKey.Render(m.keys.key("add"))+" "+m.styles.StatusMuted.Render("Attach"),
+				m.styles.StatusKey.Render(m.keys.key("delete"))+" "+m.styles.StatusMuted.Render("Detach"),
+			)
+		} else if m.iamModel.state == IAMStateGroupMembers {
+			*footerHints = append(*footerHints,
+				m.styles.StatusKey.Render(m.keys.key("add"))+" "+m.styles.StatusMuted.Render("Add User"),
+				m.styles.StatusKey.Render(m.keys.key("delete"))+" "+m.styles.StatusMuted.Render("Remove"),
+			)
+		} else if m.iamModel.state == IAMStateAccessKeys {
+			*footerHints = append(*footerHints,
+				m.styles.StatusKey.Render(m.keys.key("new"))+" "+m.styles.StatusMuted.Render("New Key"),
+				m.styles.StatusKey.Render("t")+" "+m.styles.StatusMuted.Render("Activate/Deactivate"),
+				m.styles.StatusKey.Render(m.keys.key("delete"))+" "+m.styles.StatusMuted.Render("Delete"),
 			)
 		}
 	case viewDMS:
@@ -368,14 +662,81 @@ func (m Model) addContextSpecificHints(footerHints *[]string) {
 		if m.ecsModel.state == ECSStateTasks || m.ecsModel.state == ECSStateServices {
 			*footerHints = append(*footerHints, m.styles.StatusKey.Render("o")+" "+m.styles.StatusMuted.Render("Options"))
 		}
+		if m.ecsModel.state == ECSStateTaskDefJSON {
+			*footerHints = append(*footerHints, m.styles.StatusKey.Render(m.keys.key("edit"))+" "+m.styles.StatusMuted.Render("Edit & Register"))
+		}
+		switch m.ecsModel.state {
+		case ECSStateClusters, ECSStateTaskDefFamilies, ECSStateTaskDefRevisions:
+			*footerHints = append(*footerHints, m.styles.StatusKey.Render(m.keys.key("new"))+" "+m.styles.StatusMuted.Render("Run Task"))
+		}
 	case viewEC2:
 		if m.ec2Model.state == EC2StateInstances {
 			*footerHints = append(*footerHints, m.styles.StatusKey.Render("o")+" "+m.styles.StatusMuted.Render("Options"))
 		}
+	case viewACM:
+		if m.acmModel.state == ACMStateList {
+			*footerHints = append(*footerHints,
+				m.styles.StatusKey.Render(m.keys.key("new"))+" "+m.styles.StatusMuted.Render("Request Certificate"),
+				m.styles.StatusKey.Render(m.keys.key("delete"))+" "+m.styles.StatusMuted.Render("Delete"),
+			)
+		}
+	case viewSM:
+		switch m.smModel.state {
+		case SMStateSecrets:
+			*footerHints = append(*footerHints, m.styles.StatusKey.Render(m.keys.key("new"))+" "+m.styles.StatusMuted.Render("New Secret"))
+		case SMStateValue:
+			*footerHints = append(*footerHints,
+				m.styles.StatusKey.Render(m.keys.key("edit"))+" "+m.styles.StatusMuted.Render("Put New Value"),
+				m.styles.StatusKey.Render("o")+" "+m.styles.StatusMuted.Render("Rotate Now"),
+			)
+		}
 	case viewWAF:
 		if m.wafModel.state != WAFStateMenu {
 			*footerHints = append(*footerHints, m.styles.StatusKey.Render("backspace")+" "+m.styles.StatusMuted.Render("Back to Menu"))
 		}
+	case viewSQS:
+		if m.sqsModel.state == SQSStateQueues {
+			*footerHints = append(*footerHints,
+				m.styles.StatusKey.Render("t")+" "+m.styles.StatusMuted.Render("Test Event"),
+				m.styles.StatusKey.Render(m.keys.key("edit"))+" "+m.styles.StatusMuted.Render("Edit Attributes"),
+			)
+		}
+	case viewLambda:
+		if m.lambdaModel.state == LambdaStateConcurrency {
+			*footerHints = append(*footerHints,
+				m.styles.StatusKey.Render(m.keys.key("edit"))+" "+m.styles.StatusMuted.Render("Set Reserved"),
+				m.styles.StatusKey.Render(m.keys.key("delete"))+" "+m.styles.StatusMuted.Render("Clear Reserved"),
+			)
+		} else if m.lambdaModel.state == LambdaStateFunctions {
+			*footerHints = append(*footerHints, m.styles.StatusKey.Render("t")+" "+m.styles.StatusMuted.Render("Test Event"))
+		}
+	case viewSNS:
+		*footerHints = append(*footerHints, m.styles.StatusKey.Render("t")+" "+m.styles.StatusMuted.Render("Test Event"))
+	case viewBackup:
+		if m.backupModel.state == BackupStateJobs || m.backupModel.state == BackupStateJobDetail {
+			*footerHints = append(*footerHints, m.styles.StatusKey.Render("s")+" "+m.styles.StatusMuted.Render("Restore"))
+		}
+	case viewTransfer:
+		if m.transferModel.state == TransferStateServers {
+			*footerHints = append(*footerHints, m.styles.StatusKey.Render("o")+" "+m.styles.StatusMuted.Render("Start/Stop"))
+		}
+	case viewCF:
+		if m.cfModel.state == CFStateFunctionDetail {
+			*footerHints = append(*footerHints, m.styles.StatusKey.Render("t")+" "+m.styles.StatusMuted.Render("Test"))
+			if m.cfModel.function.Stage == "DEVELOPMENT" {
+				*footerHints = append(*footerHints, m.styles.StatusKey.Render("u")+" "+m.styles.StatusMuted.Render("Publish"))
+			}
+		}
+	case viewDynamoDB:
+		if m.dynamodbModel.state == DynamoDBStateBackups {
+			*footerHints = append(*footerHints, m.styles.StatusKey.Render(m.keys.key("new"))+" "+m.styles.StatusMuted.Render("New Backup"))
+		} else if m.dynamodbModel.state == DynamoDBStateItems {
+			*footerHints = append(*footerHints,
+				m.styles.StatusKey.Render("enter")+" "+m.styles.StatusMuted.Render("Edit"),
+				m.styles.StatusKey.Render(m.keys.key("new"))+" "+m.styles.StatusMuted.Render("New Item"),
+				m.styles.StatusKey.Render(m.keys.key("delete"))+" "+m.styles.StatusMuted.Render("Delete"),
+			)
+		}
 	}
 }
 
@@ -383,12 +744,71 @@ func (m Model) addContextSpecificHints(footerHints *[]string) {
 func (m Model) renderMainContent() string {
 	if m.profileSelector.active {
 		popup := m.styles.Popup.Width(38).Render(
-			m.profileSelector.View(),
+			m.profileSelector.View() + "\n" + m.styles.StatusMuted.Render(" (i to check all profiles)"),
 		)
 		w, h := GetMainContainerSize(m.width, m.height)
 		return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, popup)
 	}
 
+	if m.profileCheckActive {
+		return m.renderProfileCheck()
+	}
+
+	if m.preflightBlocking() {
+		return m.renderPreflight()
+	}
+
+	if m.locked {
+		return m.renderLocked()
+	}
+
+	if m.paletteActive {
+		w, h := GetMainContainerSize(m.width, m.height)
+		return lipgloss.Place(w, h-AppInternalFooterHeight-2, lipgloss.Center, lipgloss.Center, m.renderCommandPalette())
+	}
+
+	if m.testEventActive {
+		return m.renderTestEvent()
+	}
+
+	if m.recentActive {
+		return m.renderRecent()
+	}
+
+	if m.opsLogActive {
+		return m.renderOpsLog()
+	}
+
+	if m.columnPickerActive {
+		return m.renderColumnPicker()
+	}
+
+	if m.describeActive {
+		return m.renderDescribe()
+	}
+
+	if m.relatedActive {
+		return m.renderRelated()
+	}
+
+	if m.compareActive {
+		return m.renderCompare()
+	}
+
+	if m.tagEditorActive {
+		return m.renderTagEditor()
+	}
+
+	if m.tagFilterActive {
+		return m.renderTagFilter()
+	}
+
+	if m.errorExpanded {
+		if err := m.viewError(); err != nil {
+			return m.renderErrorDetail(err)
+		}
+	}
+
 	switch m.view {
 	case viewS3:
 		return m.s3Model.View()
@@ -473,6 +893,9 @@ func (m Model) renderHomeView() string {
 		menuBox = m.renderSearchMenu()
 	} else {
 		menuBox = m.renderServiceCategories()
+		if m.config.Attention {
+			menuBox = lipgloss.JoinVertical(lipgloss.Left, menuBox, m.renderAttention(m.homeColumnWidth()))
+		}
 	}
 
 	homeView := lipgloss.JoinVertical(lipgloss.Center,
@@ -491,11 +914,9 @@ func (m Model) renderSearchMenu() string {
 	var sb strings.Builder
 	sb.WriteString(m.searchInput.View() + "\n")
 
-	// Show max 10 items
-	displayItems := m.filteredServices
-	if len(displayItems) > 10 {
-		displayItems = displayItems[:10]
-	}
+	// Show one page of results around the selection
+	end := min(m.searchOffset+searchPageSize, len(m.filteredServices))
+	displayItems := m.filteredServices[m.searchOffset:end]
 
 	if len(displayItems) == 0 {
 		sb.WriteString(m.styles.StatusMuted.Render("No services found.") + "\n")
@@ -505,23 +926,36 @@ func (m Model) renderSearchMenu() string {
 			if icon == "" {
 				icon = "• "
 			}
-			if i == m.selectedFiltered {
+			if m.searchOffset+i == m.selectedFiltered {
 				sb.WriteString(m.styles.SelectedMenuItem.Render("➜ "+icon+service) + "\n")
 			} else {
 				sb.WriteString(m.styles.MenuItem.Render("  "+icon+service) + "\n")
 			}
 		}
 	}
+	if len(m.filteredServices) > searchPageSize {
+		sb.WriteString(m.styles.StatusMuted.Render(fmt.Sprintf("showing %d-%d of %d (pgup/pgdn to page)",
+			m.searchOffset+1, end, len(m.filteredServices))))
+	}
 
-	// Fixed size box: width 60, height 14 (1 line input + 1 blank + 10 items + 2 border)
+	// Fixed size box: width 60, height 15 (1 line input + 10 items + count + padding)
 	return m.styles.MenuContainer.Copy().
 		Border(lipgloss.RoundedBorder()).
 		Padding(1, 2).
 		Width(60).
-		Height(14).
+		Height(15).
 		Render(sb.String())
 }
 
+// homeColumnWidth is the width of each of the home screen's three columns
+func (m Model) homeColumnWidth() int {
+	// Leave room for the count badges after the longest names
+	if m.resourceCountsEnabled {
+		return 47
+	}
+	return 40
+}
+
 // renderServiceCategories renders the service categories in columns
 func (m Model) renderServiceCategories() string {
 	renderCategory := func(catIdx int) string {
@@ -541,9 +975,9 @@ func (m Model) renderServiceCategories() string {
 			}
 
 			if catIdx == m.selectedCategory && i == m.selectedService && m.focus == focusContent {
-				sb.WriteString(m.styles.SelectedMenuItem.Render("➜ "+icon+service) + "\n")
+				sb.WriteString(m.styles.SelectedMenuItem.Render("➜ "+icon+service) + m.resourceCountBadge(service) + "\n")
 			} else {
-				sb.WriteString(m.styles.MenuItem.Render("  "+icon+service) + "\n")
+				sb.WriteString(m.styles.MenuItem.Render("  "+icon+service) + m.resourceCountBadge(service) + "\n")
 			}
 		}
 		return sb.String()
@@ -567,10 +1001,11 @@ func (m Model) renderServiceCategories() string {
 		renderCategory(6),
 	)
 
+	colWidth := m.homeColumnWidth()
 	columns := lipgloss.JoinHorizontal(lipgloss.Top,
-		lipgloss.NewStyle().Width(40).Render(col0),
-		lipgloss.NewStyle().Width(40).Render(col1),
-		lipgloss.NewStyle().Width(40).Render(col2),
+		lipgloss.NewStyle().Width(colWidth).Render(col0),
+		lipgloss.NewStyle().Width(colWidth).Render(col1),
+		lipgloss.NewStyle().Width(colWidth).Render(col2),
 	)
 
 	return m.styles.MenuContainer.Copy().
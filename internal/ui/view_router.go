@@ -1,10 +1,14 @@
 package ui
 
 import (
-	"os/exec"
+	"context"
+	"fmt"
+	"os"
 
+	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/giovannirossini/aws-tui/internal/aws"
 	"github.com/giovannirossini/aws-tui/internal/cache"
 )
 
@@ -134,24 +138,121 @@ func (m *Model) handleWindowSize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 
 // handleKeyPress routes key presses to appropriate handlers
 func (m *Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Keys wait until the operation in the progress overlay is done
+	if m.progress != nil {
+		if msg.String() == "ctrl+c" {
+			return *m, tea.Quit
+		}
+		return *m, nil
+	}
+
 	if m.profileSelector.active {
+		if msg.String() == "i" && m.profileSelector.list.FilterState() != list.Filtering {
+			m.profileSelector.active = false
+			return *m, m.openProfileCheck()
+		}
 		var cmd tea.Cmd
 		m.profileSelector, cmd = m.profileSelector.Update(msg)
 		return *m, cmd
 	}
 
-	// Handle global keys that should work in all views (unless in input state)
-	if !m.isInputFocused() {
-		switch msg.String() {
+	// Reachable from the preflight screen, to find a profile that works
+	if m.profileCheckActive {
+		return m.handleProfileCheckKeyPress(msg)
+	}
+
+	if m.preflightBlocking() {
+		return m.handlePreflightKeyPress(msg)
+	}
+
+	if m.paletteActive {
+		if msg.String() == "ctrl+c" {
+			return *m, tea.Quit
+		}
+		return m.handlePaletteInput(msg)
+	}
+
+	if m.testEventActive {
+		return m.handleTestEventKeyPress(msg)
+	}
+
+	if m.recentActive {
+		return m.handleRecentKeyPress(msg)
+	}
+
+	if m.opsLogActive {
+		return m.handleOpsLogKeyPress(msg)
+	}
+
+	if m.columnPickerActive {
+		return m.handleColumnPickerKeyPress(msg)
+	}
+
+	if m.describeActive {
+		return m.handleDescribeKeyPress(msg)
+	}
+
+	if m.relatedActive {
+		return m.handleRelatedKeyPress(msg)
+	}
+
+	if m.compareActive {
+		return m.handleCompareKeyPress(msg)
+	}
+
+	if m.tagEditorActive {
+		if m.keySkips&viewHandlers != 0 {
+			return *m, nil
+		}
+		return m.handleTagEditorKeyPress(msg)
+	}
+
+	if m.tagFilterActive {
+		return m.handleTagFilterKeyPress(msg)
+	}
+
+	if handled, cmd := m.handleErrorKeyPress(msg); handled {
+		return *m, cmd
+	}
+
+	// Handle global keys that should work in all views, unless typing into an
+	// input or a list filter
+	if !m.typing() {
+		switch m.globalKey(msg) {
+		case ":":
+			return *m, m.openPalette()
 		case "/":
 			if m.view == viewHome {
 				m.searching = true
 				m.selectedFiltered = 0
+				m.searchOffset = 0
 				m.searchInput.Focus()
 				m.updateFilter()
 				return *m, textinput.Blink
 			}
-		case "p", "P":
+		case "R":
+			return m.handleClearCache()
+		case "H":
+			return *m, m.openRecent()
+		case "L":
+			return *m, m.openOpsLog()
+		case "C":
+			return *m, m.openColumnPicker()
+		case "T":
+			return *m, m.openTagFilter()
+		case "A":
+			m.display.fullARNs = !m.display.fullARNs
+			if m.display.fullARNs {
+				return *m, m.showMutedToast("Showing full ARNs")
+			}
+			return *m, m.showMutedToast("Showing shortened ARNs")
+		case "P":
+			return *m, m.togglePin()
+		case "J":
+			return *m, m.openRelated()
+		case "z":
+			return *m, m.toggleCompact()
+		case "p":
 			m.profileSelector.active = true
 			m.profileSelector.list.FilterInput.Focus()
 			return *m, nil
@@ -169,6 +270,52 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	// The freed default key of a remapped view action is left to the global
+	// keys above
+	if m.keySkips&viewHandlers != 0 {
+		return *m, nil
+	}
+
+	// Block mutating actions in read-only mode. Keys typed into an input or a
+	// list filter are text, not actions.
+	if m.readOnly && !m.typing() && m.isMutatingKey(msg) {
+		return *m, m.showToast(readOnlyToast)
+	}
+
+	if m.globalKey(msg) == "a" && !m.isInputFocused() {
+		if _, ok := m.autoRefreshState(); ok {
+			return *m, m.cycleAutoRefresh()
+		}
+	}
+
+	// Scroll the columns of the table on screen
+	if key := m.globalKey(msg); (key == "[" || key == "]") && m.view != viewHome && !m.isInputFocused() {
+		delta := 1
+		if msg.String() == "[" {
+			delta = -1
+		}
+		if t, columns := m.activeTable(); t != nil && t.scroll(columns, delta) {
+			return *m, nil
+		}
+	}
+
+	// Remember the rows so the refreshed list can highlight what changed
+	if msg.String() == "r" && m.view != viewHome && !m.isInputFocused() {
+		m.snapshotRows()
+	}
+
+	if msg.String() == "enter" && !m.isInputFocused() {
+		if v, ok := m.describeSource(); ok {
+			return *m, m.openDescribe(v)
+		}
+	}
+
+	if msg.String() == "t" && !m.isInputFocused() {
+		if target, ok := m.selectedTagTarget(); ok {
+			return *m, m.openTagEditor(target)
+		}
+	}
+
 	// Route to view-specific handlers
 	if cmd := m.handleViewKeyPress(msg); cmd != nil {
 		return *m, cmd
@@ -206,6 +353,10 @@ func (m *Model) handleSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.selectedFiltered < len(m.filteredServices)-1 {
 			m.selectedFiltered++
 		}
+	case "pgup":
+		m.selectedFiltered = max(m.selectedFiltered-searchPageSize, 0)
+	case "pgdown":
+		m.selectedFiltered = max(min(m.selectedFiltered+searchPageSize, len(m.filteredServices)-1), 0)
 	}
 
 	var tiCmd tea.Cmd
@@ -281,9 +432,14 @@ func (m *Model) handleS3KeyPress(msg tea.KeyMsg) tea.Cmd {
 		m.view = viewHome
 		return nil
 	}
+	// The shell for the AWS CLI suspends the program like edits
+	if msg.String() == "!" && m.s3Model.state == S3StateObjects && !m.s3Model.list.SettingFilter() {
+		return tea.ExecProcess(m.s3Model.getShellCommand(m.region()), shellExited)
+	}
 	// Special handling for edit which requires suspension
 	if msg.String() == "e" && m.s3Model.state == S3StateObjects {
 		if item, ok := m.s3Model.list.SelectedItem().(s3Item); ok && !item.isFolder && !item.isBucket {
+			m.armOperation()
 			return tea.ExecProcess(m.s3Model.getEditCommand(item.key), func(err error) tea.Msg {
 				if err != nil {
 					return S3ErrorMsg(err)
@@ -298,7 +454,7 @@ func (m *Model) handleS3KeyPress(msg tea.KeyMsg) tea.Cmd {
 }
 
 func (m *Model) handleIAMKeyPress(msg tea.KeyMsg) tea.Cmd {
-	if msg.String() == "esc" && m.iamModel.state == IAMStateUsers {
+	if msg.String() == "esc" && m.iamModel.state == IAMStateMenu {
 		m.view = viewHome
 		return nil
 	}
@@ -318,9 +474,24 @@ func (m *Model) handleVPCKeyPress(msg tea.KeyMsg) tea.Cmd {
 }
 
 func (m *Model) handleLambdaKeyPress(msg tea.KeyMsg) tea.Cmd {
-	if msg.String() == "esc" {
+	if m.lambdaModel.state != LambdaStateFunctions {
+		var cmd tea.Cmd
+		m.lambdaModel, cmd = m.lambdaModel.Update(msg)
+		return cmd
+	}
+	switch msg.String() {
+	case "esc":
 		m.view = viewHome
 		return nil
+	case "t":
+		if item, ok := m.lambdaModel.list.SelectedItem().(lambdaItem); ok && m.lambdaModel.err == nil && !m.lambdaModel.list.SettingFilter() {
+			m.openTestEvent(testEventTarget{kind: testEventLambda, id: item.title, name: item.title})
+			return nil
+		}
+	case "D":
+		if item, ok := m.lambdaModel.list.SelectedItem().(lambdaItem); ok && m.lambdaModel.err == nil && !m.lambdaModel.list.SettingFilter() {
+			return m.openCompare(item.title)
+		}
 	}
 	var cmd tea.Cmd
 	m.lambdaModel, cmd = m.lambdaModel.Update(msg)
@@ -334,6 +505,7 @@ func (m *Model) handleEC2KeyPress(msg tea.KeyMsg) tea.Cmd {
 	}
 	var cmd tea.Cmd
 	m.ec2Model, cmd = m.ec2Model.Update(msg)
+	m.trackRecentInstance()
 	return cmd
 }
 
@@ -382,7 +554,7 @@ func (m *Model) handleElastiCacheKeyPress(msg tea.KeyMsg) tea.Cmd {
 }
 
 func (m *Model) handleMSKKeyPress(msg tea.KeyMsg) tea.Cmd {
-	if msg.String() == "esc" {
+	if msg.String() == "esc" && m.mskModel.state == MSKStateMenu {
 		m.view = viewHome
 		return nil
 	}
@@ -392,9 +564,25 @@ func (m *Model) handleMSKKeyPress(msg tea.KeyMsg) tea.Cmd {
 }
 
 func (m *Model) handleSQSKeyPress(msg tea.KeyMsg) tea.Cmd {
-	if msg.String() == "esc" {
+	if m.sqsModel.state != SQSStateQueues {
+		var cmd tea.Cmd
+		m.sqsModel, cmd = m.sqsModel.Update(msg)
+		return cmd
+	}
+	switch msg.String() {
+	case "esc":
 		m.view = viewHome
 		return nil
+	case "D":
+		if m.sqsModel.err == nil && !m.sqsModel.list.SettingFilter() && !m.sqsModel.selectDLQ() {
+			return m.showToast("✘ No dead-letter queue in this account and region")
+		}
+		return nil
+	case "t":
+		if item, ok := m.sqsModel.list.SelectedItem().(sqsItem); ok && m.sqsModel.err == nil && !m.sqsModel.list.SettingFilter() {
+			m.openTestEvent(testEventTarget{kind: testEventSQS, id: item.url, name: item.title})
+			return nil
+		}
 	}
 	var cmd tea.Cmd
 	m.sqsModel, cmd = m.sqsModel.Update(msg)
@@ -406,6 +594,37 @@ func (m *Model) handleSMKeyPress(msg tea.KeyMsg) tea.Cmd {
 		m.view = viewHome
 		return nil
 	}
+	// Values are typed in the editor, which suspends the program like S3 edits
+	var edit secretEdit
+	switch {
+	case msg.String() == "enter" && m.smModel.state == SMStateNameInput:
+		name, err := m.smModel.newSecretName()
+		if err != nil {
+			break
+		}
+		m.smModel.state = SMStateSecrets
+		edit = secretEdit{name: name, create: true}
+	case msg.String() == "e" && m.smModel.state == SMStateValue:
+		edit = secretEdit{name: m.smModel.selectedSecret, original: m.smModel.selectedValue}
+	}
+	if edit.name != "" {
+		c, path, err := m.smModel.getValueEditCommand(edit)
+		if err != nil {
+			return func() tea.Msg { return SMErrorMsg(err) }
+		}
+		// A new secret is created when the editor closes; a new value is
+		// confirmed first
+		if edit.create {
+			m.armOperation()
+		}
+		return tea.ExecProcess(c, func(err error) tea.Msg {
+			if err != nil {
+				os.Remove(path)
+				return SMErrorMsg(err)
+			}
+			return m.smModel.readEditedValue(path, edit)
+		})
+	}
 	var cmd tea.Cmd
 	m.smModel, cmd = m.smModel.Update(msg)
 	return cmd
@@ -422,7 +641,7 @@ func (m *Model) handleRoute53KeyPress(msg tea.KeyMsg) tea.Cmd {
 }
 
 func (m *Model) handleACMKeyPress(msg tea.KeyMsg) tea.Cmd {
-	if msg.String() == "esc" {
+	if msg.String() == "esc" && m.acmModel.state == ACMStateList {
 		m.view = viewHome
 		return nil
 	}
@@ -432,9 +651,15 @@ func (m *Model) handleACMKeyPress(msg tea.KeyMsg) tea.Cmd {
 }
 
 func (m *Model) handleSNSKeyPress(msg tea.KeyMsg) tea.Cmd {
-	if msg.String() == "esc" {
+	switch msg.String() {
+	case "esc":
 		m.view = viewHome
 		return nil
+	case "t":
+		if item, ok := m.snsModel.list.SelectedItem().(snsItem); ok && m.snsModel.err == nil && !m.snsModel.list.SettingFilter() {
+			m.openTestEvent(testEventTarget{kind: testEventSNS, id: item.arn, name: item.title})
+			return nil
+		}
 	}
 	var cmd tea.Cmd
 	m.snsModel, cmd = m.snsModel.Update(msg)
@@ -442,7 +667,7 @@ func (m *Model) handleSNSKeyPress(msg tea.KeyMsg) tea.Cmd {
 }
 
 func (m *Model) handleKMSKeyPress(msg tea.KeyMsg) tea.Cmd {
-	if msg.String() == "esc" {
+	if msg.String() == "esc" && m.kmsModel.state == KMSStateKeys {
 		m.view = viewHome
 		return nil
 	}
@@ -466,6 +691,21 @@ func (m *Model) handleECSKeyPress(msg tea.KeyMsg) tea.Cmd {
 		m.view = viewHome
 		return nil
 	}
+	// Editing the task definition suspends the program like S3 edits
+	if msg.String() == "e" && m.ecsModel.state == ECSStateTaskDefJSON && !m.ecsModel.search.typing {
+		c, path, err := m.ecsModel.getTaskDefEditCommand()
+		if err != nil {
+			return func() tea.Msg { return ECSErrorMsg(err) }
+		}
+		m.armOperation()
+		return tea.ExecProcess(c, func(err error) tea.Msg {
+			if err != nil {
+				os.Remove(path)
+				return ECSErrorMsg(err)
+			}
+			return m.ecsModel.registerEditedTaskDef(path)
+		})
+	}
 	var cmd tea.Cmd
 	m.ecsModel, cmd = m.ecsModel.Update(msg)
 	return cmd
@@ -532,10 +772,25 @@ func (m *Model) handleBackupKeyPress(msg tea.KeyMsg) tea.Cmd {
 }
 
 func (m *Model) handleDynamoDBKeyPress(msg tea.KeyMsg) tea.Cmd {
-	if msg.String() == "esc" {
+	if msg.String() == "esc" && m.dynamodbModel.state == DynamoDBStateTables {
 		m.view = viewHome
 		return nil
 	}
+	// Editing an item suspends the program like S3 edits
+	if (msg.String() == "enter" || msg.String() == "n") && m.dynamodbModel.state == DynamoDBStateItems && !m.dynamodbModel.list.SettingFilter() {
+		c, path, edit, err := m.dynamodbModel.getItemEditCommand(msg.String() == "n")
+		if err != nil {
+			return func() tea.Msg { return DynamoErrorMsg(err) }
+		}
+		m.armOperation()
+		return tea.ExecProcess(c, func(err error) tea.Msg {
+			if err != nil {
+				os.Remove(path)
+				return DynamoErrorMsg(err)
+			}
+			return m.dynamodbModel.saveEditedItem(path, edit)
+		})
+	}
 	var cmd tea.Cmd
 	m.dynamodbModel, cmd = m.dynamodbModel.Update(msg)
 	return cmd
@@ -569,7 +824,11 @@ func (m *Model) handleHomeNavigation(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "r": // Manual refresh
 		m.cache.Delete(m.cacheKeys.Identity())
-		return *m, m.fetchIdentity()
+		return *m, tea.Batch(m.fetchIdentity(), m.refreshAttention())
+	case "1", "2", "3", "4", "5", "6":
+		if model, cmd, ok := m.openAttention(msg.String()); ok {
+			return model, cmd
+		}
 	case "tab":
 		// No-op, header is non-interactive
 	case "up":
@@ -628,148 +887,174 @@ func (m *Model) handleProfileChange(profile string) (tea.Model, tea.Cmd) {
 	m.selectedProfile = profile
 	m.profileSelector.active = false
 	m.identity = nil
+	m.profileRegion = aws.ProfileRegion(context.Background(), profile)
 	m.cacheKeys = cache.NewKeyBuilder(m.selectedProfile)
+	m.stopAutoRefresh()
 
-	// Reset current view with new profile
+	if m.preflightBlocking() {
+		return *m, m.retryPreflight()
+	}
+
+	counts, attention := m.fetchResourceCounts(), m.fetchAttention()
+	model, cmd := m.reloadView()
+	return model, tea.Batch(cmd, counts, attention)
+}
+
+// reloadView recreates the current view with the selected profile
+func (m *Model) reloadView() (tea.Model, tea.Cmd) {
 	switch m.view {
 	case viewS3:
-		m.s3Model = NewS3Model(m.selectedProfile, m.styles, m.cache)
+		m.s3Model = NewS3Model(m.selectedProfile, m.styles, m.cache, m.display)
 		m.s3Model.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.s3Model.Init(), m.fetchIdentity())
 	case viewIAM:
-		m.iamModel = NewIAMModel(m.selectedProfile, m.styles, m.cache)
+		m.iamModel = NewIAMModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.iamModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.iamModel.Init(), m.fetchIdentity())
 	case viewVPC:
-		m.vpcModel = NewVPCModel(m.selectedProfile, m.styles, m.cache)
+		m.vpcModel = NewVPCModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.vpcModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.vpcModel.Init(), m.fetchIdentity())
 	case viewLambda:
-		m.lambdaModel = NewLambdaModel(m.selectedProfile, m.styles, m.cache)
+		m.lambdaModel = NewLambdaModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.lambdaModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.lambdaModel.Init(), m.fetchIdentity())
 	case viewEC2:
-		m.ec2Model = NewEC2Model(m.selectedProfile, m.styles, m.cache)
+		m.ec2Model = NewEC2Model(m.selectedProfile, m.styles, m.cache, m.display)
 		m.ec2Model.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.ec2Model.Init(), m.fetchIdentity())
 	case viewRDS:
-		m.rdsModel = NewRDSModel(m.selectedProfile, m.styles, m.cache)
+		m.rdsModel = NewRDSModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.rdsModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.rdsModel.Init(), m.fetchIdentity())
 	case viewCW:
-		m.cwModel = NewCWModel(m.selectedProfile, m.styles, m.cache)
+		m.cwModel = NewCWModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.cwModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.cwModel.Init(), m.fetchIdentity())
 	case viewCF:
-		m.cfModel = NewCFModel(m.selectedProfile, m.styles, m.cache)
+		m.cfModel = NewCFModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.cfModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.cfModel.Init(), m.fetchIdentity())
 	case viewElastiCache:
-		m.elasticacheModel = NewElastiCacheModel(m.selectedProfile, m.styles, m.cache)
+		m.elasticacheModel = NewElastiCacheModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.elasticacheModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.elasticacheModel.Init(), m.fetchIdentity())
 	case viewMSK:
-		m.mskModel = NewMSKModel(m.selectedProfile, m.styles, m.cache)
+		m.mskModel = NewMSKModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.mskModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.mskModel.Init(), m.fetchIdentity())
 	case viewSQS:
-		m.sqsModel = NewSQSModel(m.selectedProfile, m.styles, m.cache)
+		m.sqsModel = NewSQSModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.sqsModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.sqsModel.Init(), m.fetchIdentity())
 	case viewSM:
-		m.smModel = NewSMModel(m.selectedProfile, m.styles, m.cache)
+		m.smModel = NewSMModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.smModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.smModel.Init(), m.fetchIdentity())
 	case viewRoute53:
-		m.route53Model = NewRoute53Model(m.selectedProfile, m.styles, m.cache)
+		m.route53Model = NewRoute53Model(m.selectedProfile, m.styles, m.cache, m.display)
 		m.route53Model.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.route53Model.Init(), m.fetchIdentity())
 	case viewACM:
-		m.acmModel = NewACMModel(m.selectedProfile, m.styles, m.cache)
+		m.acmModel = NewACMModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.acmModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.acmModel.Init(), m.fetchIdentity())
 	case viewSNS:
-		m.snsModel = NewSNSModel(m.selectedProfile, m.styles, m.cache)
+		m.snsModel = NewSNSModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.snsModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.snsModel.Init(), m.fetchIdentity())
 	case viewKMS:
-		m.kmsModel = NewKMSModel(m.selectedProfile, m.styles, m.cache)
+		m.kmsModel = NewKMSModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.kmsModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.kmsModel.Init(), m.fetchIdentity())
 	case viewDMS:
-		m.dmsModel = NewDMSModel(m.selectedProfile, m.styles, m.cache)
+		m.dmsModel = NewDMSModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.dmsModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.dmsModel.Init(), m.fetchIdentity())
 	case viewECS:
-		m.ecsModel = NewECSModel(m.selectedProfile, m.styles, m.cache)
+		m.ecsModel = NewECSModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.ecsModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.ecsModel.Init(), m.fetchIdentity())
 	case viewBilling:
-		m.billingModel = NewBillingModel(m.selectedProfile, m.styles, m.cache)
+		m.billingModel = NewBillingModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.billingModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.billingModel.Init(), m.fetchIdentity())
 	case viewSecurityHub:
-		m.securityhubModel = NewSecurityHubModel(m.selectedProfile, m.styles, m.cache)
+		m.securityhubModel = NewSecurityHubModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.securityhubModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.securityhubModel.Init(), m.fetchIdentity())
 	case viewWAF:
-		region := "us-east-1"
-		if m.identity != nil {
-			region = m.identity.Region
-		}
-		m.wafModel = NewWAFModel(m.selectedProfile, m.styles, m.cache, region)
+		m.wafModel = NewWAFModel(m.selectedProfile, m.styles, m.cache, m.region(), m.display)
 		m.wafModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.wafModel.Init(), m.fetchIdentity())
 	case viewECR:
-		m.ecrModel = NewECRModel(m.selectedProfile, m.styles, m.cache)
+		m.ecrModel = NewECRModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.ecrModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.ecrModel.Init(), m.fetchIdentity())
 	case viewEFS:
-		m.efsModel = NewEFSModel(m.selectedProfile, m.styles, m.cache)
+		m.efsModel = NewEFSModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.efsModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.efsModel.Init(), m.fetchIdentity())
 	case viewBackup:
-		m.backupModel = NewBackupModel(m.selectedProfile, m.styles, m.cache)
+		m.backupModel = NewBackupModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.backupModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.backupModel.Init(), m.fetchIdentity())
 	case viewDynamoDB:
-		m.dynamodbModel = NewDynamoDBModel(m.selectedProfile, m.styles, m.cache)
+		m.dynamodbModel = NewDynamoDBModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.dynamodbModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.dynamodbModel.Init(), m.fetchIdentity())
 	case viewTransfer:
-		m.transferModel = NewTransferModel(m.selectedProfile, m.styles, m.cache)
+		m.transferModel = NewTransferModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.transferModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.transferModel.Init(), m.fetchIdentity())
 	case viewAPIGateway:
-		m.apiGatewayModel = NewAPIGatewayModel(m.selectedProfile, m.styles, m.cache)
+		m.apiGatewayModel = NewAPIGatewayModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.apiGatewayModel.SetSize(m.width, m.height)
 		return *m, tea.Batch(m.apiGatewayModel.Init(), m.fetchIdentity())
 	}
 	return *m, m.fetchIdentity()
 }
 
+// handleClearCache drops every cached entry and re-fetches the current view and identity
+func (m *Model) handleClearCache() (tea.Model, tea.Cmd) {
+	m.cache.Clear()
+	toastCmd := m.showToast("✔ Cache cleared")
+	_, cmd := m.handleProfileChange(m.selectedProfile)
+	return *m, tea.Batch(cmd, toastCmd)
+}
+
 // handleViewMessages delegates service-specific messages to their models
 func (m *Model) handleViewMessages(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
+	m.trackOperation(msg)
+	if toast, ok := m.handleAccessDenied(msg); ok {
+		return *m, toast
+	}
+	m.trackRecent(msg)
+
 	switch msg := msg.(type) {
-	case S3BucketsMsg, S3ObjectsMsg, S3ErrorMsg, S3SuccessMsg:
+	case S3BucketsMsg, S3ObjectsMsg, S3BucketDetailsMsg, S3VersionsMsg, S3VersioningMsg, S3DeleteSummaryMsg, S3ErrorMsg, S3SuccessMsg, s3ShellExitedMsg:
 		m.s3Model, cmd = m.s3Model.Update(msg)
 		return *m, cmd
 
-	case IAMUsersMsg, IAMErrorMsg, IAMSuccessMsg:
+	case IAMUsersMsg, IAMUsersPageMsg, IAMUserDetailsMsg, IAMRolesMsg, IAMGroupsMsg, IAMGroupMembersMsg, IAMUserPoliciesMsg, IAMAccessKeyCreatedMsg, IAMPasswordSetMsg, IAMSummaryMsg, IAMDeleteSummaryMsg, IAMErrorMsg, IAMSuccessMsg:
 		m.iamModel, cmd = m.iamModel.Update(msg)
 		return *m, cmd
 
-	case VPCsMsg, SubnetsMsg, NatGatewaysMsg, RouteTablesMsg, VpnGatewaysMsg, VPCErrorMsg, VPCMenuMsg:
+	case VPCsMsg, SubnetsMsg, NatGatewaysMsg, RouteTablesMsg, VpnGatewaysMsg, VPCFlowLogsMsg, VPCErrorMsg, VPCMenuMsg:
 		m.vpcModel, cmd = m.vpcModel.Update(msg)
 		return *m, cmd
 
-	case LambdaFunctionsMsg, LambdaErrorMsg:
+	case LambdaFunctionsMsg, LambdaConcurrencyMsg, LambdaErrorMsg:
 		m.lambdaModel, cmd = m.lambdaModel.Update(msg)
 		return *m, cmd
 
-	case InstancesMsg, SecurityGroupsMsg, VolumesMsg, TargetGroupsMsg, EC2ErrorMsg, EC2MenuMsg:
+	case LambdaSuccessMsg:
+		m.lambdaModel, cmd = m.lambdaModel.Update(msg)
+		return *m, tea.Batch(cmd, m.showToast("✔ "+string(msg)))
+
+	case InstancesMsg, SecurityGroupsMsg, VolumesMsg, TargetGroupsMsg, ElasticIPsMsg, EC2ErrorMsg, EC2MenuMsg:
 		m.ec2Model, cmd = m.ec2Model.Update(msg)
 		return *m, cmd
 
@@ -777,35 +1062,83 @@ func (m *Model) handleViewMessages(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.rdsModel, cmd = m.rdsModel.Update(msg)
 		return *m, cmd
 
-	case CWLogGroupsMsg, CWLogStreamsMsg, CWLogEventsMsg, CWErrorMsg, CWMenuMsg:
+	case CWLogGroupsMsg, CWLogStreamsMsg, CWLogEventsMsg, CWAlarmsMsg, CWAlarmHistoryMsg, CWErrorMsg, CWMenuMsg:
 		m.cwModel, cmd = m.cwModel.Update(msg)
 		return *m, cmd
 
-	case CFDistributionsMsg, CFOriginsMsg, CFBehaviorsMsg, CFInvalidationsMsg, CFPoliciesMsg, CFFunctionsMsg, CFErrorMsg, CFMenuMsg:
+	case CFDistributionsMsg, CFOriginsMsg, CFBehaviorsMsg, CFInvalidationsMsg, CFPoliciesMsg, CFFunctionsMsg, CFFunctionDetailMsg, CFFunctionTestMsg, CFErrorMsg, CFMenuMsg:
 		m.cfModel, cmd = m.cfModel.Update(msg)
 		return *m, cmd
 
+	case ECSTaskDefRegisteredMsg:
+		m.ecsModel, cmd = m.ecsModel.Update(msg)
+		return *m, tea.Batch(cmd, m.showToast(fmt.Sprintf("✔ Registered %s", string(msg))))
+
+	case ECSTaskLaunchedMsg:
+		m.ecsModel, cmd = m.ecsModel.Update(msg)
+		return *m, tea.Batch(cmd, m.showToast("✔ Started task "+shortID(msg.TaskArn)))
+
+	case ecsTaskDefUnchangedMsg:
+		return *m, m.showMutedToast("No changes made, nothing registered")
+
+	case DynamoItemSavedMsg:
+		m.dynamodbModel, cmd = m.dynamodbModel.Update(msg)
+		if msg.Created {
+			return *m, tea.Batch(cmd, m.showToast("✔ Added item "+msg.Key))
+		}
+		return *m, tea.Batch(cmd, m.showToast("✔ Saved item "+msg.Key))
+
+	case DynamoItemDeletedMsg:
+		m.dynamodbModel, cmd = m.dynamodbModel.Update(msg)
+		return *m, tea.Batch(cmd, m.showToast("✔ Deleted item "+string(msg)))
+
+	case dynamoItemUnchangedMsg:
+		return *m, m.showMutedToast("No changes made, nothing written")
+
+	case SMSecretCreatedMsg:
+		m.smModel, cmd = m.smModel.Update(msg)
+		return *m, tea.Batch(cmd, m.showToast("✔ Created secret "+string(msg)))
+
+	case SMSecretValuePutMsg:
+		m.smModel, cmd = m.smModel.Update(msg)
+		return *m, tea.Batch(cmd, m.showToast("✔ Put a new version of "+string(msg)))
+
+	case SMSecretRotatedMsg:
+		m.smModel, cmd = m.smModel.Update(msg)
+		return *m, tea.Batch(cmd, m.showToast("✔ Started rotating "+string(msg)))
+
+	case smValueUnchangedMsg:
+		return *m, m.showMutedToast("No new value entered, nothing written")
+
+	case CFFunctionPublishedMsg:
+		m.cfModel, cmd = m.cfModel.Update(msg)
+		return *m, tea.Batch(cmd, m.showToast(fmt.Sprintf("✔ Published %s to LIVE", string(msg))))
+
 	case ReplicationGroupsMsg, CacheClustersMsg, ElastiCacheErrorMsg, ElastiCacheMenuMsg:
 		m.elasticacheModel, cmd = m.elasticacheModel.Update(msg)
 		return *m, cmd
 
-	case MSKClustersMsg, MSKErrorMsg:
+	case MSKClustersMsg, MSKConfigurationsMsg, MSKBootstrapMsg, MSKErrorMsg, MSKMenuMsg:
 		m.mskModel, cmd = m.mskModel.Update(msg)
 		return *m, cmd
 
-	case SQSQueuesMsg, SQSErrorMsg:
+	case SQSQueuesMsg, SQSQueueAttributesMsg, SQSErrorMsg:
 		m.sqsModel, cmd = m.sqsModel.Update(msg)
 		return *m, cmd
 
-	case SMSecretsMsg, SMSecretValueMsg, SMErrorMsg:
+	case SQSAttributesUpdatedMsg:
+		m.sqsModel, cmd = m.sqsModel.Update(msg)
+		return *m, tea.Batch(cmd, m.showToast(fmt.Sprintf("✔ Updated attributes of %s", string(msg))))
+
+	case SMSecretsMsg, SMSecretValueMsg, SMRotationMsg, smValueEditedMsg, SMErrorMsg:
 		m.smModel, cmd = m.smModel.Update(msg)
 		return *m, cmd
 
-	case HostedZonesMsg, RecordSetsMsg, Route53ErrorMsg:
+	case HostedZonesMsg, RecordSetsMsg, Route53ZoneDetailsMsg, Route53ErrorMsg:
 		m.route53Model, cmd = m.route53Model.Update(msg)
 		return *m, cmd
 
-	case CertificatesMsg, ACMErrorMsg:
+	case CertificatesMsg, ACMRequestedMsg, ACMValidationMsg, ACMRecordsCreatedMsg, ACMCertificateDetailsMsg, ACMDeletedMsg, ACMErrorMsg:
 		m.acmModel, cmd = m.acmModel.Update(msg)
 		return *m, cmd
 
@@ -813,7 +1146,7 @@ func (m *Model) handleViewMessages(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.snsModel, cmd = m.snsModel.Update(msg)
 		return *m, cmd
 
-	case KMSKeysMsg, KMSErrorMsg:
+	case KMSKeysMsg, KMSCryptoMsg, KMSErrorMsg:
 		m.kmsModel, cmd = m.kmsModel.Update(msg)
 		return *m, cmd
 
@@ -821,7 +1154,7 @@ func (m *Model) handleViewMessages(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.dmsModel, cmd = m.dmsModel.Update(msg)
 		return *m, cmd
 
-	case ECSClustersMsg, ECSServicesMsg, ECSTasksMsg, ECSEventsMsg, ECSTaskDefsMsg, ECSTaskDefFamiliesMsg, ECSTaskDefJSONMsg, ECSErrorMsg, ECSSuccessMsg:
+	case ECSClustersMsg, ECSServicesMsg, ECSClusterSummaryMsg, ECSClusterSummaryErrorMsg, ECSContainerInstancesMsg, ECSTasksMsg, ECSTaskDetailMsg, ECSEventsMsg, ECSTaskDefsMsg, ECSTaskDefFamiliesMsg, ECSTaskDefJSONMsg, ECSDeploymentMsg, ecsDeploymentTickMsg, ecsRunTaskOptionsMsg, ECSErrorMsg, ECSSuccessMsg:
 		m.ecsModel, cmd = m.ecsModel.Update(msg)
 		return *m, cmd
 
@@ -851,19 +1184,25 @@ func (m *Model) handleViewMessages(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return *m, cmd
 		}
 
-	case BackupPlansMsg, BackupJobsMsg, BackupErrorMsg:
+	case BackupPlansMsg, BackupJobsMsg, BackupRecoveryPointMsg, BackupErrorMsg:
 		if m.view == viewBackup {
 			m.backupModel, cmd = m.backupModel.Update(msg)
 			return *m, cmd
 		}
 
-	case DynamoTablesMsg, DynamoErrorMsg:
+	case BackupRestoreStartedMsg:
+		if m.view == viewBackup {
+			m.backupModel, cmd = m.backupModel.Update(msg)
+		}
+		return *m, tea.Batch(cmd, m.showToast(fmt.Sprintf("✔ Restore job %s started", string(msg))))
+
+	case DynamoTablesMsg, DynamoBackupsMsg, DynamoContinuousBackupsMsg, DynamoBackupCreatedMsg, DynamoItemsMsg, DynamoErrorMsg:
 		if m.view == viewDynamoDB {
 			m.dynamodbModel, cmd = m.dynamodbModel.Update(msg)
 			return *m, cmd
 		}
 
-	case TransferServersMsg, TransferUsersMsg, TransferErrorMsg:
+	case TransferServersMsg, TransferUsersMsg, TransferServerActionMsg, TransferErrorMsg:
 		if m.view == viewTransfer {
 			m.transferModel, cmd = m.transferModel.Update(msg)
 			return *m, cmd
@@ -877,7 +1216,7 @@ func (m *Model) handleViewMessages(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case ECSLogGroupMsg:
 		m.view = viewCW
-		m.cwModel = NewCWModel(m.selectedProfile, m.styles, m.cache)
+		m.cwModel = NewCWModel(m.selectedProfile, m.styles, m.cache, m.display)
 		m.cwModel.SetSize(m.width, m.height)
 		m.cwModel.selectedGroup = string(msg)
 		m.cwModel.state = CWStateLogStreams
@@ -885,7 +1224,7 @@ func (m *Model) handleViewMessages(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return *m, m.cwModel.fetchLogStreams(string(msg))
 
 	case SSMStartedMsg:
-		c := exec.Command("aws", "ssm", "start-session", "--target", string(msg), "--profile", m.selectedProfile)
+		c := awsCLI(m.selectedProfile, m.region(), "ssm", "start-session", "--target", string(msg))
 		return *m, tea.ExecProcess(c, func(err error) tea.Msg {
 			if err != nil {
 				return EC2ErrorMsg(err)
@@ -893,7 +1232,32 @@ func (m *Model) handleViewMessages(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return nil
 		})
 
+	case ClipboardMsg:
+		return *m, m.handleClipboard(msg)
+
+	case TestEventSentMsg:
+		return m.handleTestEventSent(msg)
+
+	case TagsMsg:
+		return m.handleTags(msg)
+
+	case TagsSavedMsg:
+		return m.handleTagsSaved(msg)
+
+	case SSMPortForwardMsg:
+		c := &bannerExecCommand{cmd: msg.command(m.selectedProfile, m.region()), banner: msg.banner(m.region())}
+		return *m, tea.Exec(c, func(err error) tea.Msg {
+			if err != nil {
+				return EC2ErrorMsg(err)
+			}
+			return nil
+		})
+
 	case IdentityMsg:
+		// An identity fetched before a profile switch belongs to the old profile
+		if msg != nil && msg.Profile != "" && msg.Profile != m.selectedProfile {
+			return *m, nil
+		}
 		m.identity = msg
 		return *m, nil
 	}
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// scrollViewport applies the paging keys shared by the long-text viewports:
+// pgup/pgdown, home/end and ctrl+u/ctrl+d for half pages. It reports whether
+// the key was handled; other keys fall through to the viewport's own keymap.
+func scrollViewport(vp *viewport.Model, key string) bool {
+	switch key {
+	case "pgup":
+		vp.PageUp()
+	case "pgdown":
+		vp.PageDown()
+	case "ctrl+u":
+		vp.HalfPageUp()
+	case "ctrl+d":
+		vp.HalfPageDown()
+	case "home":
+		vp.GotoTop()
+	case "end":
+		vp.GotoBottom()
+	default:
+		return false
+	}
+	return true
+}
+
+// renderScrollIndicator shows how far a viewport is scrolled, right-aligned
+// under it next to the left-hand status. Content that fits on one screen
+// shows no percentage.
+func renderScrollIndicator(styles Styles, vp viewport.Model, left string) string {
+	if vp.TotalLineCount() <= vp.Height {
+		return left
+	}
+	right := styles.StatusMuted.Render(fmt.Sprintf("%3.0f%%", vp.ScrollPercent()*100))
+	return left + lipgloss.NewStyle().Width(max(vp.Width-lipgloss.Width(left), 0)).Align(lipgloss.Right).Render(right)
+}
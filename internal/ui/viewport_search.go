@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sgrPattern matches the colour sequences chroma and lipgloss emit
+var sgrPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// viewportSearch finds text in a viewport's rendered content and steps
+// through the matching lines with n/N
+type viewportSearch struct {
+	input   textinput.Model
+	typing  bool
+	query   string
+	content string
+	matches []int
+	current int
+}
+
+func newViewportSearch() viewportSearch {
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.Placeholder = "search"
+	ti.CharLimit = 100
+	ti.Width = 30
+	return viewportSearch{input: ti}
+}
+
+// findLineMatches returns the lines of content whose plain text contains
+// query, ignoring case
+func findLineMatches(content, query string) []int {
+	if query == "" {
+		return nil
+	}
+	var matches []int
+	query = strings.ToLower(query)
+	for i, line := range strings.Split(content, "\n") {
+		if strings.Contains(strings.ToLower(sgrPattern.ReplaceAllString(line, "")), query) {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// setContent replaces the searched content and shows it in vp, keeping the
+// current query
+func (s *viewportSearch) setContent(vp *viewport.Model, styles Styles, content string) {
+	s.content = content
+	s.matches = findLineMatches(content, s.query)
+	s.current = 0
+	s.render(vp, styles)
+}
+
+// reset drops the query, e.g. when a different document is opened
+func (s *viewportSearch) reset() {
+	s.typing = false
+	s.input.Blur()
+	s.query = ""
+	s.matches = nil
+	s.current = 0
+}
+
+// render highlights the matches in the content. Matching lines lose their
+// syntax colours so the highlight stays readable.
+func (s viewportSearch) render(vp *viewport.Model, styles Styles) {
+	if len(s.matches) == 0 {
+		vp.SetContent(s.content)
+		return
+	}
+
+	pattern := regexp.MustCompile("(?i)" + regexp.QuoteMeta(s.query))
+	matchStyle := lipgloss.NewStyle().Background(styles.Muted).Foreground(styles.White)
+	currentStyle := lipgloss.NewStyle().Background(styles.Accent).Foreground(styles.DarkGray).Bold(true)
+
+	lines := strings.Split(s.content, "\n")
+	for i, line := range s.matches {
+		style := matchStyle
+		if i == s.current {
+			style = currentStyle
+		}
+		lines[line] = pattern.ReplaceAllStringFunc(sgrPattern.ReplaceAllString(lines[line], ""), func(match string) string {
+			return style.Render(match)
+		})
+	}
+	vp.SetContent(strings.Join(lines, "\n"))
+}
+
+// jump scrolls the current match to the middle of the viewport
+func (s viewportSearch) jump(vp *viewport.Model) {
+	if len(s.matches) > 0 {
+		vp.SetYOffset(s.matches[s.current] - vp.Height/2)
+	}
+}
+
+// update handles the search keys. It reports whether the key was consumed.
+func (s *viewportSearch) update(vp *viewport.Model, styles Styles, msg tea.KeyMsg) (bool, tea.Cmd) {
+	if s.typing {
+		switch msg.String() {
+		case "enter":
+			s.typing = false
+			s.input.Blur()
+			s.query = s.input.Value()
+			s.setContent(vp, styles, s.content)
+			s.jump(vp)
+			return true, nil
+		case "esc":
+			s.typing = false
+			s.input.Blur()
+			return true, nil
+		}
+		var cmd tea.Cmd
+		s.input, cmd = s.input.Update(msg)
+		return true, cmd
+	}
+
+	switch msg.String() {
+	case "/":
+		s.typing = true
+		s.input.SetValue(s.query)
+		s.input.CursorEnd()
+		return true, s.input.Focus()
+	case "n", "N":
+		if s.query == "" {
+			return false, nil
+		}
+		if len(s.matches) > 0 {
+			step := 1
+			if msg.String() == "N" {
+				step = len(s.matches) - 1
+			}
+			s.current = (s.current + step) % len(s.matches)
+			s.render(vp, styles)
+			s.jump(vp)
+		}
+		return true, nil
+	case "esc":
+		// The first esc clears the search, the next one leaves the view
+		if s.query != "" {
+			s.reset()
+			s.render(vp, styles)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// view shows the search prompt while typing, otherwise the match position
+func (s viewportSearch) view(styles Styles) string {
+	switch {
+	case s.typing:
+		return s.input.View()
+	case s.query == "":
+		return ""
+	case len(s.matches) == 0:
+		return styles.Error.Render(fmt.Sprintf("No matches for %q", s.query))
+	default:
+		return styles.StatusMuted.Render(fmt.Sprintf("Match %d/%d for %q", s.current+1, len(s.matches), s.query))
+	}
+}
@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/giovannirossini/aws-tui/internal/aws"
+	"github.com/giovannirossini/aws-tui/internal/cache"
+)
+
+// VPCFlowLogsMsg carries the flow logs of a VPC; an empty list means flow logs
+// are disabled
+type VPCFlowLogsMsg struct {
+	vpcID    string
+	flowLogs []aws.FlowLogInfo
+}
+
+func (m VPCModel) fetchFlowLogs(vpcID string) tea.Cmd {
+	return func() tea.Msg {
+		key := m.cacheKeys.VPCResources("flow-logs:" + vpcID)
+		if cached, ok := m.cache.Get(key); ok {
+			if flowLogs, ok := cached.([]aws.FlowLogInfo); ok {
+				return VPCFlowLogsMsg{vpcID: vpcID, flowLogs: flowLogs}
+			}
+		}
+
+		client, err := aws.NewEC2Client(context.Background(), m.profile)
+		if err != nil {
+			return VPCErrorMsg(err)
+		}
+		flowLogs, err := client.GetFlowLogsForVpc(context.Background(), vpcID)
+		if err != nil {
+			return VPCErrorMsg(err)
+		}
+		// An empty slice rather than nil, so the detail screen can tell
+		// "disabled" from "loading"
+		if flowLogs == nil {
+			flowLogs = []aws.FlowLogInfo{}
+		}
+		m.cache.Set(key, flowLogs, cache.TTLVPCResources)
+		return VPCFlowLogsMsg{vpcID: vpcID, flowLogs: flowLogs}
+	}
+}
+
+// flowLogDestinationLabels names the delivery targets of flow logs
+var flowLogDestinationLabels = map[string]string{
+	"cloud-watch-logs":      "CloudWatch Logs",
+	"s3":                    "S3",
+	"kinesis-data-firehose": "Data Firehose",
+}
+
+// renderVPCDetail shows the selected VPC and whether its traffic is logged
+func (m VPCModel) renderVPCDetail() string {
+	v := m.selectedVPC
+	w, h := GetMainContainerSize(m.width, m.height)
+
+	def := "No"
+	if v.IsDefault {
+		def = "Yes"
+	}
+	fields := []detailField{
+		{"VPC ID", v.ID},
+		{"Name", v.Name},
+		{"CIDR block", v.CidrBlock},
+		{"State", renderStatus(m.styles, v.State)},
+		{"Default", def},
+	}
+
+	switch {
+	case m.flowLogs == nil:
+		fields = append(fields, detailField{"Flow logs", m.styles.StatusMuted.Render("loading...")})
+	case len(m.flowLogs) == 0:
+		fields = append(fields, detailField{"Flow logs", m.styles.Error.Render("✘ Disabled")})
+	default:
+		for _, f := range m.flowLogs {
+			destination := flowLogDestinationLabels[f.DestinationType]
+			if destination == "" {
+				destination = f.DestinationType
+			}
+			status := renderStatus(m.styles, f.Status)
+			if f.DeliverStatus == "FAILED" {
+				status += " " + m.styles.Error.Render("delivery failed: "+f.DeliverError)
+			}
+			fields = append(fields,
+				detailField{"Flow log", f.ID + " (" + strings.ToLower(f.TrafficType) + " traffic)"},
+				detailField{"  Status", status},
+				detailField{"  Destination", fmt.Sprintf("%s: %s", destination, f.Destination)},
+			)
+		}
+	}
+
+	labelStyle := lipgloss.NewStyle().Foreground(m.styles.Primary).Width(16)
+	valueStyle := lipgloss.NewStyle().Width(max(w-24, 20))
+
+	var s strings.Builder
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		s.WriteString(" " + lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render(f.label), valueStyle.Render(f.value)) + "\n")
+	}
+	s.WriteString("\n " + m.styles.StatusMuted.Render("(r to refresh, esc to go back)"))
+
+	return lipgloss.NewStyle().Height(h - AppInternalFooterHeight - 2).MaxHeight(h - AppInternalFooterHeight - 2).Render(s.String())
+}
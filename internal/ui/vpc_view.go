@@ -20,6 +20,7 @@ const (
 	VPCStateNatGateways
 	VPCStateRouteTables
 	VPCStateVpnGateways
+	VPCStateVPCDetail
 )
 
 type vpcItem struct {
@@ -28,29 +29,47 @@ type vpcItem struct {
 	id          string
 	category    string
 	values      []string // Added for tabular rendering
+	// keywords holds identifiers the filter matches besides the visible ones
+	keywords string
+	vpc      aws.VPCInfo
+	links    []relatedLink
+	info     any
 }
 
 func (i vpcItem) Title() string       { return i.title }
+func (i vpcItem) source() any         { return i.info }
 func (i vpcItem) Description() string { return i.description }
-func (i vpcItem) FilterValue() string { return i.title + " " + i.description + " " + i.id }
+func (i vpcItem) FilterValue() string {
+	return i.title + " " + i.description + " " + i.id + " " + i.keywords
+}
+func (i vpcItem) rowID() string               { return i.id }
+func (i vpcItem) rowValues() []string         { return i.values }
+func (i vpcItem) relatedLinks() []relatedLink { return i.links }
 
 type VPCModel struct {
 	client    *aws.EC2Client
 	list      list.Model
 	styles    Styles
+	table     *tableLayout
 	state     VPCState
 	width     int
 	height    int
 	profile   string
 	err       error
+	loaded    bool
 	cache     *cache.Cache
 	cacheKeys *cache.KeyBuilder
 	vpcNames  map[string]string // ID -> Name lookup
+	// selectedVPC is shown in the detail screen with its flow logs, which
+	// are nil until they load
+	selectedVPC aws.VPCInfo
+	flowLogs    []aws.FlowLogInfo
 }
 
 type vpcItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 	state  VPCState
 }
 
@@ -98,7 +117,7 @@ func (d vpcItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 	}
 
 	if d.state == VPCStateMenu {
-		d.DefaultDelegate.Render(w, m, index, listItem)
+		d.table.renderMenuItem(d.DefaultDelegate, w, m, index, listItem)
 		return
 	}
 
@@ -116,23 +135,27 @@ func (d vpcItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 		columns = vpnColumns
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, columns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, columns, d.table)
 	isSelected := index == m.Index()
 
-	RenderTableRow(w, m, d.styles, colStyles, i.values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, i.values, isSelected)
 }
 
 func (d vpcItemDelegate) Height() int {
 	if d.state == VPCStateMenu {
-		return 2
+		return d.table.menuItemHeight()
 	}
 	return 1
 }
 
-func NewVPCModel(profile string, styles Styles, appCache *cache.Cache) VPCModel {
+func (d vpcItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewVPCModel(profile string, styles Styles, appCache *cache.Cache, display *tableDisplay) VPCModel {
+	table := &tableLayout{display: display}
 	d := vpcItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 		state:           VPCStateMenu,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
@@ -147,6 +170,7 @@ func NewVPCModel(profile string, styles Styles, appCache *cache.Cache) VPCModel
 	return VPCModel{
 		list:      l,
 		styles:    styles,
+		table:     table,
 		state:     VPCStateMenu,
 		profile:   profile,
 		cache:     appCache,
@@ -318,12 +342,13 @@ func (m VPCModel) Update(msg tea.Msg) (VPCModel, tea.Cmd) {
 		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
 	case VPCMenuMsg:
-		m.list.SetItems(msg)
+		m.table.setItems(&m.list, msg)
 		m.list.ResetSelected()
 		m.state = VPCStateMenu
 		m.updateDelegate()
 
 	case VPCsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			m.vpcNames[v.ID] = v.Name
@@ -336,81 +361,101 @@ func (m VPCModel) Update(msg tea.Msg) (VPCModel, tea.Cmd) {
 				description: v.CidrBlock,
 				id:          v.ID,
 				category:    "vpc",
-				values:      []string{v.Name, v.ID, v.CidrBlock, v.State, def},
+				vpc:         v,
+				values:      []string{v.Name, v.ID, v.CidrBlock, renderStatus(m.styles, v.State), def},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = VPCStateVPCs
 		m.updateDelegate()
 
 	case SubnetsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, s := range msg {
 			vpcDisplay := m.getVPCDisplayName(s.VpcID)
 			items[i] = vpcItem{
+				info:        s,
 				title:       s.Name,
 				description: s.VpcID,
 				id:          s.ID,
 				category:    "subnet",
+				links:       linksTo("vpc", s.VpcID),
+				keywords:    s.CidrBlock + " " + s.AvailabilityZone,
 				values:      []string{s.Name, s.ID, vpcDisplay, s.CidrBlock, s.AvailabilityZone},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = VPCStateSubnets
 		m.updateDelegate()
 
 	case NatGatewaysMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, n := range msg {
 			vpcDisplay := m.getVPCDisplayName(n.VpcID)
 			items[i] = vpcItem{
+				info:        n,
 				title:       n.Name,
 				description: n.VpcID,
 				id:          n.ID,
 				category:    "nat",
-				values:      []string{n.Name, n.ID, vpcDisplay, n.PublicIP, n.State},
+				links:       linksTo("vpc", n.VpcID),
+				keywords:    n.PublicIP,
+				values:      []string{n.Name, n.ID, vpcDisplay, n.PublicIP, renderStatus(m.styles, n.State)},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = VPCStateNatGateways
 		m.updateDelegate()
 
 	case RouteTablesMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, r := range msg {
 			vpcDisplay := m.getVPCDisplayName(r.VpcID)
 			items[i] = vpcItem{
+				info:        r,
 				title:       r.Name,
 				description: r.VpcID,
 				id:          r.ID,
 				category:    "rt",
+				links:       linksTo("vpc", r.VpcID),
 				values:      []string{r.Name, r.ID, vpcDisplay},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = VPCStateRouteTables
 		m.updateDelegate()
 
 	case VpnGatewaysMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, v := range msg {
 			items[i] = vpcItem{
+				info:        v,
 				title:       v.Name,
 				description: v.State,
 				id:          v.ID,
 				category:    "vpn",
-				values:      []string{v.Name, v.ID, v.State, v.Type},
+				keywords:    v.Type,
+				values:      []string{v.Name, v.ID, renderStatus(m.styles, v.State), v.Type},
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.list.ResetSelected()
 		m.state = VPCStateVpnGateways
 		m.updateDelegate()
 
+	case VPCFlowLogsMsg:
+		if msg.vpcID == m.selectedVPC.ID {
+			m.flowLogs = msg.flowLogs
+		}
+
 	case VPCErrorMsg:
 		m.err = msg
 
@@ -420,6 +465,19 @@ func (m VPCModel) Update(msg tea.Msg) (VPCModel, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.state == VPCStateVPCDetail {
+			switch msg.String() {
+			case "backspace", "esc", "q":
+				m.state = VPCStateVPCs
+				m.updateDelegate()
+			case "r":
+				m.cache.Delete(m.cacheKeys.VPCResources("flow-logs:" + m.selectedVPC.ID))
+				m.flowLogs = nil
+				return m, m.fetchFlowLogs(m.selectedVPC.ID)
+			}
+			return m, nil
+		}
+
 		switch msg.String() {
 		case "r":
 			if m.state == VPCStateVPCs {
@@ -440,6 +498,12 @@ func (m VPCModel) Update(msg tea.Msg) (VPCModel, tea.Cmd) {
 			}
 		case "enter":
 			if item, ok := m.list.SelectedItem().(vpcItem); ok {
+				if m.state == VPCStateVPCs {
+					m.selectedVPC = item.vpc
+					m.flowLogs = nil
+					m.state = VPCStateVPCDetail
+					return m, m.fetchFlowLogs(item.vpc.ID)
+				}
 				if m.state == VPCStateMenu {
 					switch item.title {
 					case "VPCs":
@@ -470,6 +534,7 @@ func (m *VPCModel) updateDelegate() {
 	d := vpcItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          m.styles,
+		table:           m.table,
 		state:           m.state,
 	}
 	d.Styles.SelectedTitle = m.styles.ListSelectedTitle
@@ -477,27 +542,48 @@ func (m *VPCModel) updateDelegate() {
 	m.list.SetDelegate(d)
 }
 
+// vpcResourceNames names the resources listed in each table state, for the empty-state message
+var vpcResourceNames = map[VPCState]string{
+	VPCStateVPCs:        "VPCs",
+	VPCStateSubnets:     "subnets",
+	VPCStateNatGateways: "NAT gateways",
+	VPCStateRouteTables: "route tables",
+	VPCStateVpnGateways: "VPN gateways",
+}
+
+// columns are the columns of the table on screen
+func (m VPCModel) columns() []Column {
+	switch m.state {
+	case VPCStateVPCs:
+		return vpcColumns
+	case VPCStateSubnets:
+		return subnetColumns
+	case VPCStateNatGateways:
+		return natColumns
+	case VPCStateRouteTables:
+		return rtColumns
+	case VPCStateVpnGateways:
+		return vpnColumns
+	}
+	return nil
+}
+
 func (m VPCModel) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
+	}
+
+	if m.state == VPCStateVPCDetail {
+		return m.renderVPCDetail()
+	}
+
+	if resource, ok := vpcResourceNames[m.state]; ok && m.loaded && len(m.list.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.list, resource, m.profile, m.table)
 	}
 
 	header := ""
 	if m.state != VPCStateMenu {
-		var columns []Column
-		switch m.state {
-		case VPCStateVPCs:
-			columns = vpcColumns
-		case VPCStateSubnets:
-			columns = subnetColumns
-		case VPCStateNatGateways:
-			columns = natColumns
-		case VPCStateRouteTables:
-			columns = rtColumns
-		case VPCStateVpnGateways:
-			columns = vpnColumns
-		}
-		_, header = RenderTableHelpers(m.list, m.styles, columns)
+		_, header = RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
 		return header + "\n" + m.list.View()
 	}
 
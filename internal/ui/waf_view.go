@@ -26,15 +26,22 @@ type wafItem struct {
 	description string
 	id          string
 	arn         string
+	info        any
 }
 
 func (i wafItem) Title() string       { return i.title }
+func (i wafItem) source() any         { return i.info }
 func (i wafItem) Description() string { return i.description }
-func (i wafItem) FilterValue() string { return i.title }
+func (i wafItem) FilterValue() string {
+	return i.title + " " + i.description + " " + i.id + " " + i.arn
+}
+
+func (i wafItem) rowID() string { return i.id }
 
 type WAFModel struct {
 	list      list.Model
 	styles    Styles
+	table     *tableLayout
 	state     WAFState
 	scope     types.Scope
 	profile   string
@@ -42,6 +49,7 @@ type WAFModel struct {
 	width     int
 	height    int
 	err       error
+	loaded    bool
 	cache     *cache.Cache
 	cacheKeys *cache.KeyBuilder
 }
@@ -49,6 +57,7 @@ type WAFModel struct {
 type wafItemDelegate struct {
 	list.DefaultDelegate
 	styles Styles
+	table  *tableLayout
 }
 
 var wafWebACLColumns = []Column{
@@ -63,7 +72,7 @@ func (d wafItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 		return
 	}
 
-	colStyles, _ := RenderTableHelpers(m, d.styles, wafWebACLColumns)
+	colStyles, _ := RenderTableHelpers(m, d.styles, wafWebACLColumns, d.table)
 	isSelected := index == m.Index()
 
 	values := []string{
@@ -72,15 +81,19 @@ func (d wafItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 		i.description,
 	}
 
-	RenderTableRow(w, m, d.styles, colStyles, values, isSelected)
+	RenderTableRow(w, m, listItem, d.styles, colStyles, values, isSelected)
 }
 
 func (d wafItemDelegate) Height() int { return 1 }
 
-func NewWAFModel(profile string, styles Styles, appCache *cache.Cache, region string) WAFModel {
+func (d wafItemDelegate) Spacing() int { return d.table.rowSpacing() }
+
+func NewWAFModel(profile string, styles Styles, appCache *cache.Cache, region string, display *tableDisplay) WAFModel {
+	table := &tableLayout{display: display}
 	d := wafItemDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
 		styles:          styles,
+		table:           table,
 	}
 	d.Styles.SelectedTitle = styles.ListSelectedTitle
 	d.Styles.SelectedDesc = styles.ListSelectedDesc
@@ -97,6 +110,7 @@ func NewWAFModel(profile string, styles Styles, appCache *cache.Cache, region st
 	return WAFModel{
 		list:      l,
 		styles:    styles,
+		table:     table,
 		state:     WAFStateMenu,
 		profile:   profile,
 		region:    region,
@@ -192,33 +206,37 @@ func (m WAFModel) Update(msg tea.Msg) (WAFModel, tea.Cmd) {
 		m.list.SetSize(GetInnerListSize(msg.Width, msg.Height))
 
 	case WAFMenuMsg:
-		m.list.SetItems(msg)
+		m.table.setItems(&m.list, msg)
 		m.state = WAFStateMenu
 
 	case WAFWebACLsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, acl := range msg {
 			items[i] = wafItem{
+				info:        acl,
 				title:       acl.Name,
 				description: acl.Description,
 				id:          acl.ID,
 				arn:         acl.ARN,
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.state = WAFStateWebACLs
 
 	case WAFIPSetsMsg:
+		m.loaded = true
 		items := make([]list.Item, len(msg))
 		for i, ipSet := range msg {
 			items[i] = wafItem{
+				info:        ipSet,
 				title:       ipSet.Name,
 				description: ipSet.Description,
 				id:          ipSet.ID,
 				arn:         ipSet.ARN,
 			}
 		}
-		m.list.SetItems(items)
+		m.table.setItems(&m.list, items)
 		m.state = WAFStateIPSets
 
 	case WAFErrorMsg:
@@ -268,16 +286,31 @@ func (m WAFModel) Update(msg tea.Msg) (WAFModel, tea.Cmd) {
 	return m, cmd
 }
 
+// wafResourceNames names the resources listed in each table state, for the empty-state message
+var wafResourceNames = map[WAFState]string{
+	WAFStateWebACLs: "web ACLs",
+	WAFStateIPSets:  "IP sets",
+}
+
+// columns are the columns of the table on screen
+func (m WAFModel) columns() []Column {
+	return wafWebACLColumns
+}
+
 func (m WAFModel) View() string {
 	if m.err != nil {
-		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress any key to continue...", m.err))
+		return m.styles.Error.Render(fmt.Sprintf("✘ Error: %v\n\nPress e for details, any other key to continue...", m.err))
 	}
 
 	if m.state == WAFStateMenu {
 		return m.list.View()
 	}
 
-	_, header := RenderTableHelpers(m.list, m.styles, wafWebACLColumns)
+	if resource, ok := wafResourceNames[m.state]; ok && m.loaded && len(m.list.Items()) == 0 {
+		return RenderEmptyState(m.styles, m.list, resource, m.profile, m.table)
+	}
+
+	_, header := RenderTableHelpers(m.list, m.styles, m.columns(), m.table)
 	return header + "\n" + m.list.View()
 }
 